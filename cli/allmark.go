@@ -5,24 +5,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
 	"github.com/andreaskoch/allmark/common/logger/console"
 	"github.com/andreaskoch/allmark/common/logger/loglevel"
 	"github.com/andreaskoch/allmark/common/shutdown"
 	"github.com/andreaskoch/allmark/common/util/fsutil"
+	"github.com/andreaskoch/allmark/dataaccess"
 	"github.com/andreaskoch/allmark/dataaccess/filesystem"
+	"github.com/andreaskoch/allmark/dataaccess/git"
+	"github.com/andreaskoch/allmark/services/checker"
 	"github.com/andreaskoch/allmark/services/initialization"
 	"github.com/andreaskoch/allmark/services/parser"
 	"github.com/andreaskoch/allmark/services/thumbnail"
+	"github.com/andreaskoch/allmark/web/export"
 	"github.com/andreaskoch/allmark/web/server"
 	// "github.com/davecheney/profile"
+	"encoding/json"
 	"flag"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 )
 
 const (
@@ -33,6 +41,12 @@ const (
 	// CommandNameServe contains the name of the serve action
 	CommandNameServe = "serve"
 
+	// CommandNameBuild contains the name of the build action
+	CommandNameBuild = "build"
+
+	// CommandNameCheck contains the name of the check action
+	CommandNameCheck = "check"
+
 	// CommandNameVersion contains the name of the version action
 	CommandNameVersion = "version"
 )
@@ -45,37 +59,42 @@ var (
 	logLevelOverride = serveFlags.String("loglevel", "", "Log level")
 	reindex          = serveFlags.Bool("reindex", false, "Enable reindexing")
 	livereload       = serveFlags.Bool("livereload", false, "Enable live-reload")
+	outputFolder     = serveFlags.String("output", "", "Target folder for static builds")
+	siteURL          = serveFlags.String("url", "", "Public URL of static builds (e.g. https://example.com)")
+	gitRef           = serveFlags.String("ref", "", "Serve the given branch, tag or commit of a git repository")
+	jsonOutput       = serveFlags.Bool("json", false, "Print the results of the check as JSON")
 )
 
 func main() {
 
 	// defer profile.Start(profile.CPUProfile).Stop()
 
-	// Handle CTRL-C
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
-	go func() {
-		select {
-		case _ = <-c:
-			{
-				fmt.Println("Stopping")
-
-				// Execute shutdown handlers
-				shutdown.Shutdown()
+	parseCommandLineArguments(os.Args, func(commandName, repositoryPath string) (commandWasFound bool) {
 
-				os.Exit(0)
-			}
-		}
-	}()
+		// the server stops gracefully, the other commands are interrupted
+		handleSignals(strings.ToLower(commandName) != CommandNameServe)
 
-	parseCommandLineArguments(os.Args, func(commandName, repositoryPath string) (commandWasFound bool) {
 		switch strings.ToLower(commandName) {
 		case CommandNameInit:
 			initialize(repositoryPath)
 			return true
 
 		case CommandNameServe:
-			serve(repositoryPath)
+			if !serve(repositoryPath) {
+				os.Exit(1)
+			}
+			return true
+
+		case CommandNameBuild:
+			if !build(repositoryPath) {
+				os.Exit(1)
+			}
+			return true
+
+		case CommandNameCheck:
+			if !check(repositoryPath) {
+				os.Exit(1)
+			}
 			return true
 
 		case CommandNameVersion:
@@ -88,6 +107,36 @@ func main() {
 	})
 }
 
+// handleSignals executes the shutdown handlers on CTRL-C and SIGTERM. If exitAfterShutdown is
+// false the caller waits for the shutdown (see shutdown.Wait) and determines the exit status.
+// A second signal stops the application immediately.
+func handleSignals(exitAfterShutdown bool) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-signals
+		fmt.Println("Stopping")
+
+		go func() {
+			err := shutdown.Shutdown()
+			if !exitAfterShutdown {
+				return
+			}
+
+			if err != nil {
+				fmt.Println(err.Error())
+			}
+
+			os.Exit(1)
+		}()
+
+		<-signals
+		fmt.Println("Stopping immediately")
+		os.Exit(1)
+	}()
+}
+
 func parseCommandLineArguments(args []string, commandHandler func(commandName, repositoryPath string) (commandWasFound bool)) {
 
 	remainingArguments := args
@@ -148,6 +197,8 @@ func printUsageInformation(args []string) {
 	fmt.Fprintf(os.Stderr, "\nAvailable commands:\n")
 	fmt.Fprintf(os.Stderr, "  %7s  %s\n", CommandNameInit, "Initialize the configuration")
 	fmt.Fprintf(os.Stderr, "  %7s  %s\n", CommandNameServe, "Start serving the supplied repository via HTTP and HTTPs")
+	fmt.Fprintf(os.Stderr, "  %7s  %s\n", CommandNameBuild, "Render the supplied repository into a folder of static files")
+	fmt.Fprintf(os.Stderr, "  %7s  %s\n", CommandNameCheck, "Report unresolved references, missing files and dead links")
 	fmt.Fprintf(os.Stderr, "\n")
 	fmt.Fprintf(os.Stderr, "Fork me on GitHub %q\n", "https://github.com/andreaskoch/allmark")
 
@@ -183,13 +234,14 @@ func serve(repositoryPath string) bool {
 	}
 
 	// data access
-	repository, err := filesystem.NewRepository(logger, repositoryPath, *configuration)
+	repository, err := newRepository(logger, repositoryPath, configuration)
 	if err != nil {
 		logger.Fatal("Unable to create a repository. Error: %s", err)
 	}
 
 	// thumbnail index
 	thumbnailIndex := thumbnail.EmptyIndex()
+	var thumbnailService *thumbnail.ConversionService
 	if configuration.Conversion.Thumbnails.Enabled {
 
 		thumbnailIndexFilePath := configuration.ThumbnailIndexFilePath()
@@ -202,7 +254,7 @@ func serve(repositoryPath string) bool {
 		thumbnailIndex = thumbnail.NewIndex(logger, thumbnailIndexFilePath, thumbnailFolder)
 
 		// thumbnail conversion service
-		thumbnail.NewConversionService(logger, repository, thumbnailIndex)
+		thumbnailService = thumbnail.NewConversionService(logger, repository, thumbnailIndex)
 
 	}
 
@@ -213,20 +265,179 @@ func serve(repositoryPath string) bool {
 	}
 
 	// server
-	server, err := server.New(logger, *configuration, repository, itemParser, thumbnailIndex)
+	server, err := server.New(logger, *configuration, repository, itemParser, thumbnailIndex, thumbnailService)
 	if err != nil {
 		logger.Error("Unable to instantiate a server. Error: %s", err.Error())
 		return false
 	}
 
+	// stop the server gracefully before the other shutdown handlers are executed
+	shutdown.Register(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), configuration.Server.Timeouts.Shutdown())
+		defer cancel()
+
+		return server.Shutdown(ctx)
+	})
+
 	if result := <-server.Start(); result != nil {
 		logger.Error("%s", result)
 		return false
 	}
 
+	// the server has been stopped; wait for the remaining shutdown handlers
+	if err := shutdown.Wait(); err != nil {
+		logger.Error("The shutdown failed. Error: %s", err)
+		return false
+	}
+
+	return true
+}
+
+func build(repositoryPath string) bool {
+
+	// get the configuration
+	configuration := config.Get(repositoryPath)
+
+	// static files can neither be reindexed, converted nor edited on request
+	configuration.Indexing.Enabled = false
+	configuration.LiveReload.Enabled = false
+	configuration.Conversion.DOCX.Enabled = false
+	configuration.Server.Editing.Enabled = false
+
+	// create a logger
+	logger := console.New(loglevel.FromString(configuration.LogLevel))
+	if *logLevelOverride != "" {
+		logger = console.New(loglevel.FromString(*logLevelOverride))
+	}
+
+	// data access
+	repository, err := newRepository(logger, repositoryPath, configuration)
+	if err != nil {
+		logger.Fatal("Unable to create a repository. Error: %s", err)
+	}
+
+	// thumbnails
+	thumbnailIndex := thumbnail.EmptyIndex()
+	if configuration.Conversion.Thumbnails.Enabled {
+
+		thumbnailIndexFilePath := configuration.ThumbnailIndexFilePath()
+		thumbnailFolder := configuration.ThumbnailFolder()
+
+		if !fsutil.CreateDirectory(thumbnailFolder) {
+			logger.Fatal("Could not create the thumbnail folder %q", thumbnailFolder)
+		}
+
+		thumbnailIndex = thumbnail.NewIndex(logger, thumbnailIndexFilePath, thumbnailFolder)
+
+		// all thumbnails must exist before the items are rendered
+		thumbnail.CreateAll(logger, repository, thumbnailIndex)
+
+	}
+
+	// parser
+	itemParser, err := parser.New(logger)
+	if err != nil {
+		logger.Fatal("Unable to instantiate a parser. Error: %s", err)
+	}
+
+	// server
+	server, err := server.New(logger, *configuration, repository, itemParser, thumbnailIndex, nil)
+	if err != nil {
+		logger.Error("Unable to instantiate a server. Error: %s", err.Error())
+		return false
+	}
+
+	// export
+	targetFolder := configuration.BuildFolder()
+	if *outputFolder != "" {
+		targetFolder = *outputFolder
+	}
+
+	exporter, err := export.New(logger, *configuration, repository, server.Handler(), *siteURL, targetFolder)
+	if err != nil {
+		logger.Error("Unable to instantiate an exporter. Error: %s", err.Error())
+		return false
+	}
+
+	if err := exporter.Export(); err != nil {
+		logger.Error("%s", err)
+		return false
+	}
+
 	return true
 }
 
+// check reports the unresolved references, missing files and dead links of the
+// items in the supplied repository. Returns false if problems were found.
+func check(repositoryPath string) bool {
+
+	// get the configuration
+	configuration := config.Get(repositoryPath)
+
+	// the repository is only read once
+	configuration.Indexing.Enabled = false
+	configuration.LiveReload.Enabled = false
+
+	// create a logger
+	logger := console.New(loglevel.FromString(configuration.LogLevel))
+	if *logLevelOverride != "" {
+		logger = console.New(loglevel.FromString(*logLevelOverride))
+	}
+
+	// data access
+	repository, err := newRepository(logger, repositoryPath, configuration)
+	if err != nil {
+		logger.Fatal("Unable to create a repository. Error: %s", err)
+	}
+
+	// parser
+	itemParser, err := parser.New(logger)
+	if err != nil {
+		logger.Fatal("Unable to instantiate a parser. Error: %s", err)
+	}
+
+	problems := checker.New(logger, *configuration, repository, itemParser).Check()
+
+	if *jsonOutput {
+		bytes, err := json.MarshalIndent(problems, "", "\t")
+		if err != nil {
+			logger.Fatal("Unable to serialize the problems. Error: %s", err)
+		}
+
+		fmt.Println(string(bytes))
+		return len(problems) == 0
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem.String())
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("No problems found.")
+	} else {
+		fmt.Printf("%d problem(s) found.\n", len(problems))
+	}
+
+	return len(problems) == 0
+}
+
+// newRepository creates a repository for the given path. If a git ref has been
+// supplied the items are read from that ref instead of the working directory.
+// The caches of the given configuration are moved to the folder of the ref.
+func newRepository(logger logger.Logger, repositoryPath string, configuration *config.Config) (dataaccess.Repository, error) {
+	if *gitRef != "" {
+		repository, err := git.NewRepository(logger, repositoryPath, *gitRef, *configuration)
+		if err != nil {
+			return nil, err
+		}
+
+		configuration.UseRef(repository.Ref())
+		return repository, nil
+	}
+
+	return filesystem.NewRepository(logger, repositoryPath, *configuration)
+}
+
 func initialize(repositoryPath string) bool {
 
 	config := config.Get(repositoryPath)
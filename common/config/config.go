@@ -14,11 +14,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/andreaskoch/allmark/common/certificates"
 	"github.com/andreaskoch/allmark/common/logger/loglevel"
 	"github.com/andreaskoch/allmark/common/ports"
 	"github.com/andreaskoch/allmark/common/util/fsutil"
+	"github.com/andreaskoch/allmark/common/util/hashutil"
 	"github.com/abbot/go-http-auth"
 	"github.com/mitchellh/go-homedir"
 )
@@ -33,6 +36,12 @@ const (
 	ThumbnailIndexFileName = "thumbnail.index"
 	ThumbnailsFolderName   = "thumbnails"
 	SSLCertsFolderName     = "certs"
+	BuildFolderName        = "build"
+	SearchIndexFolderName  = "search"
+	DiagramsFolderName     = "diagrams"
+	CommandsFolderName     = "commands"
+	ACMEFolderName         = "acme"
+	RefsFolderName         = "refs"
 )
 
 // Global default values.
@@ -51,6 +60,23 @@ const (
 	DefaultConversionDocxEnabled     = true
 	DefaultAuthenticationEnabled     = false
 	DefaultUserStoreFileName         = "users.htpasswd"
+	DefaultOIDCUsernameClaim         = "preferred_username"
+	DefaultSessionLifetimeInMinutes  = 480
+	DefaultSessionKeyFileName        = "session.key"
+	DefaultEditingEnabled            = false
+	DefaultCommandTimeoutInSeconds   = 10
+	DefaultReadTimeoutInSeconds      = 60
+	DefaultWriteTimeoutInSeconds     = 120
+	DefaultIdleTimeoutInSeconds      = 120
+	DefaultShutdownTimeoutInSeconds  = 15
+	DefaultACMEEnabled               = false
+	DefaultACMEDirectoryURL          = "https://acme-v02.api.letsencrypt.org/directory"
+)
+
+// The output formats of command extensions.
+const (
+	CommandOutputMarkdown = "markdown"
+	CommandOutputHTML     = "html"
 )
 
 // homeDirectory returns the current users home directory path.
@@ -170,6 +196,9 @@ func Default(baseFolder string) *Config {
 	config.Server.HTTPS.CertFileName = DefaultHTTPSCertName
 	config.Server.HTTPS.KeyFileName = DefaultHTTPSKeyName
 	config.Server.HTTPS.Force = DefaultForceHTTPS
+	config.Server.HTTPS.ACME.Enabled = DefaultACMEEnabled
+	config.Server.HTTPS.ACME.DirectoryURL = DefaultACMEDirectoryURL
+	config.Server.HTTPS.ACME.Domains = []string{}
 	config.Server.HTTPS.Bindings = []*TCPBinding{
 		&TCPBinding{
 			Network: "tcp4",
@@ -188,6 +217,26 @@ func Default(baseFolder string) *Config {
 	// Authentication
 	config.Server.Authentication.Enabled = DefaultAuthenticationEnabled
 	config.Server.Authentication.UserStoreFileName = DefaultUserStoreFileName
+	config.Server.Authentication.OIDC.Scopes = []string{"profile", "email"}
+	config.Server.Authentication.OIDC.UsernameClaim = DefaultOIDCUsernameClaim
+	config.Server.Authentication.OIDC.SessionLifetimeInMinutes = DefaultSessionLifetimeInMinutes
+	config.Server.Authentication.OIDC.SessionKeyFileName = DefaultSessionKeyFileName
+
+	// Proxy
+	config.Server.Proxy.TrustedCIDRs = []string{}
+
+	// Access
+	config.Server.Access.Roles = make(map[string][]string)
+	config.Server.Access.Rules = make([]AccessRule, 0)
+
+	// Editing
+	config.Server.Editing.Enabled = DefaultEditingEnabled
+
+	// Timeouts
+	config.Server.Timeouts.ReadInSeconds = DefaultReadTimeoutInSeconds
+	config.Server.Timeouts.WriteInSeconds = DefaultWriteTimeoutInSeconds
+	config.Server.Timeouts.IdleInSeconds = DefaultIdleTimeoutInSeconds
+	config.Server.Timeouts.ShutdownInSeconds = DefaultShutdownTimeoutInSeconds
 
 	config.Web.DefaultLanguage = DefaultLanguage
 
@@ -206,6 +255,11 @@ func Default(baseFolder string) *Config {
 	// DOCX Conversion
 	config.Conversion.DOCX.Enabled = DefaultConversionDocxEnabled
 
+	// Markdown extensions
+	config.Conversion.Extensions.Order = []string{}
+	config.Conversion.Extensions.Disabled = []string{}
+	config.Conversion.Extensions.Commands = []CommandExtension{}
+
 	// Logging
 	config.LogLevel = DefaultLogLevel.String()
 
@@ -269,6 +323,8 @@ type HTTPS struct {
 	KeyFileName  string
 
 	Force bool
+
+	ACME ACME
 }
 
 // HTTPSIsForced indicates whether HTTPS is forced or not.
@@ -280,6 +336,52 @@ func (https *HTTPS) HTTPSIsForced() bool {
 	return https.Force
 }
 
+// ACME contains the settings for obtaining the HTTPS certificates automatically from an
+// ACME certificate authority (e.g. Let's Encrypt) using the HTTP-01 or TLS-ALPN-01 challenge.
+type ACME struct {
+	// Enabled is a flag indicating whether the certificates are obtained via ACME instead of being read from CertFileName and KeyFileName.
+	Enabled bool
+
+	// DirectoryURL is the URL of the ACME directory of the certificate authority (default: Let's Encrypt).
+	DirectoryURL string
+
+	// AcceptTermsOfService must be set to true in order to agree to the terms of service of the certificate authority.
+	AcceptTermsOfService bool
+
+	// Email is the optional contact address of the ACME account.
+	Email string
+
+	// Domains contains the domain names certificates are requested for (default: the domain name of the server).
+	Domains []string
+
+	// CACertFileName is the optional name of a PEM file in the certificates folder with additional root certificates
+	// which are trusted when connecting to the ACME directory (e.g. the certificate of a local test CA such as Pebble).
+	CACertFileName string
+
+	// RenewBeforeInDays optionally defines how many days before their expiry the certificates are renewed
+	// (default: after two thirds of their validity period, at most 30 days before their expiry).
+	RenewBeforeInDays int
+}
+
+// Directory returns the URL of the ACME directory.
+func (acme ACME) Directory() string {
+	if strings.TrimSpace(acme.DirectoryURL) == "" {
+		return DefaultACMEDirectoryURL
+	}
+
+	return acme.DirectoryURL
+}
+
+// RenewBefore returns how long before their expiry the certificates are renewed
+// or zero if the default shall be used.
+func (acme ACME) RenewBefore() time.Duration {
+	if acme.RenewBeforeInDays <= 0 {
+		return 0
+	}
+
+	return time.Duration(acme.RenewBeforeInDays) * 24 * time.Hour
+}
+
 // Authentication contains authentication settings.
 type Authentication struct {
 	// Enabled is flag indicating whether authentication is enabled.
@@ -287,6 +389,184 @@ type Authentication struct {
 
 	// UserStoreFileName defines the file name for the authentication user-store file (e.g. "users.htpasswd").
 	UserStoreFileName string
+
+	// OIDC contains the settings for logging in with an OpenID Connect provider instead of the user-store.
+	OIDC OIDC
+}
+
+// OIDC contains the settings for logging in with an OpenID Connect provider using the authorization code flow.
+// After logging in, the users are identified by a signed session cookie.
+type OIDC struct {
+	// Enabled is a flag indicating whether users log in with the OpenID Connect provider.
+	// Like basic-authentication the login is only available over HTTPS.
+	Enabled bool
+
+	// IssuerURL is the URL of the provider which serves the discovery document (e.g. "https://sso.example.com/realms/company").
+	IssuerURL string
+
+	// ClientID and ClientSecret are the credentials of the client which has been registered at the provider.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL optionally defines the callback URL which has been registered at the provider
+	// (default: "/login/callback" on the host of the request, e.g. "https://docs.example.com/login/callback").
+	RedirectURL string
+
+	// Scopes contains the scopes which are requested in addition to "openid" (e.g. "profile", "email", "groups").
+	Scopes []string
+
+	// UsernameClaim is the name of the claim which contains the username (default: "preferred_username").
+	// If the claim is missing the verified "email" or the "sub" claim is used. The roles of the access
+	// settings are assigned to the "sub" claim because the username might match the name of another user.
+	UsernameClaim string
+
+	// RolesClaim optionally defines the name of the claim which contains the roles of the user (e.g. "groups" or
+	// "realm_access.roles" for nested claims). The roles are added to the roles of the user in the access settings.
+	RolesClaim string
+
+	// SessionLifetimeInMinutes defines how long users stay logged in (default: 480).
+	SessionLifetimeInMinutes int
+
+	// SessionKeyFileName is the name of the file in the meta data folder which contains the key for
+	// signing the session cookies (default: "session.key"). The file is created if it does not exist.
+	SessionKeyFileName string
+}
+
+// Username returns the name of the claim which contains the username.
+func (oidc OIDC) Username() string {
+	if strings.TrimSpace(oidc.UsernameClaim) == "" {
+		return DefaultOIDCUsernameClaim
+	}
+
+	return oidc.UsernameClaim
+}
+
+// SessionLifetime returns how long users stay logged in.
+func (oidc OIDC) SessionLifetime() time.Duration {
+	if oidc.SessionLifetimeInMinutes <= 0 {
+		return DefaultSessionLifetimeInMinutes * time.Minute
+	}
+
+	return time.Duration(oidc.SessionLifetimeInMinutes) * time.Minute
+}
+
+// Proxy contains the settings for serving the repository behind reverse proxies (e.g. nginx or Traefik) which terminate TLS.
+type Proxy struct {
+	// TrustedCIDRs contains the IP addresses or networks of the proxies (e.g. "127.0.0.1" or "10.0.0.0/8").
+	// The X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-For headers are only honored for requests from these proxies.
+	TrustedCIDRs []string
+}
+
+// IsEnabled indicates whether there are trusted proxies.
+func (proxy Proxy) IsEnabled() bool {
+	return len(proxy.TrustedCIDRs) > 0
+}
+
+// TrustedNetworks returns the networks of the trusted proxies. Single IP addresses are returned as networks with one address.
+func (proxy Proxy) TrustedNetworks() ([]*net.IPNet, error) {
+
+	networks := make([]*net.IPNet, 0, len(proxy.TrustedCIDRs))
+	for _, cidr := range proxy.TrustedCIDRs {
+		cidr = strings.TrimSpace(cidr)
+
+		if ip := net.ParseIP(cidr); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("The trusted proxy %q is neither an IP address nor a CIDR network.", cidr)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// Timeouts contains the timeouts of the web server. Values which are not set fall back to the defaults.
+type Timeouts struct {
+	// ReadInSeconds is the maximum duration for reading a request (including the body).
+	ReadInSeconds int
+
+	// WriteInSeconds is the maximum duration for writing a response (e.g. a DOCX conversion).
+	WriteInSeconds int
+
+	// IdleInSeconds is the maximum duration a keep-alive connection may be idle.
+	IdleInSeconds int
+
+	// ShutdownInSeconds is the maximum duration for completing the open requests on shutdown.
+	ShutdownInSeconds int
+}
+
+// Read returns the maximum duration for reading a request.
+func (timeouts Timeouts) Read() time.Duration {
+	return getDuration(timeouts.ReadInSeconds, DefaultReadTimeoutInSeconds)
+}
+
+// Write returns the maximum duration for writing a response.
+func (timeouts Timeouts) Write() time.Duration {
+	return getDuration(timeouts.WriteInSeconds, DefaultWriteTimeoutInSeconds)
+}
+
+// Idle returns the maximum duration a keep-alive connection may be idle.
+func (timeouts Timeouts) Idle() time.Duration {
+	return getDuration(timeouts.IdleInSeconds, DefaultIdleTimeoutInSeconds)
+}
+
+// Shutdown returns the maximum duration for completing the open requests on shutdown.
+func (timeouts Timeouts) Shutdown() time.Duration {
+	return getDuration(timeouts.ShutdownInSeconds, DefaultShutdownTimeoutInSeconds)
+}
+
+// getDuration returns the given number of seconds or, if it is not positive, the default number of seconds as a duration.
+func getDuration(seconds, defaultSeconds int) time.Duration {
+	if seconds <= 0 {
+		seconds = defaultSeconds
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// Access contains the roles of the users and the rules which restrict the items they can see.
+type Access struct {
+	// Roles contains the roles of the users in the authentication user-store (e.g. "alice": ["eng"])
+	// or of the users of the OpenID Connect provider by their subject (e.g. "248289761001": ["eng"]).
+	Roles map[string][]string
+
+	// Rules restricts the items which match a rule to the users with one of the roles of the rule.
+	// Items must pass all matching rules and the rules of their parent items.
+	Rules []AccessRule
+}
+
+// AccessRule restricts the items below a route or the items with the given meta data.
+type AccessRule struct {
+	// Route matches the item with the given route and all items below it (e.g. "/internal").
+	Route string
+
+	// MetaDataKey matches the items which have a value for the given (custom) meta data key (e.g. "visibility").
+	MetaDataKey string
+
+	// MetaDataValue limits the MetaDataKey to items with the given value (e.g. "internal").
+	MetaDataValue string
+
+	// Roles contains the roles which can see the matching items. Without roles the matching items
+	// are visible to all authenticated users or, if the rule has a meta data key but no value,
+	// to the users with one of the roles listed in the meta data of the item (e.g. "groups: eng, ops").
+	Roles []string
+}
+
+// Editing contains the settings of the web editor.
+type Editing struct {
+	// Enabled is flag indicating whether items can be edited in the browser.
+	// The editor is only available over HTTPS and requires the authentication user-store file.
+	Enabled bool
 }
 
 // Web contains all web-site related properties such as the language, authors and publisher information.
@@ -315,6 +595,10 @@ type Server struct {
 	HTTP            HTTP
 	HTTPS           HTTPS
 	Authentication  Authentication
+	Proxy           Proxy
+	Access          Access
+	Editing         Editing
+	Timeouts        Timeouts
 }
 
 // Indexing defines the reindexing parameters of the repository.
@@ -332,6 +616,7 @@ type LiveReload struct {
 type Conversion struct {
 	DOCX       DOCXConversion
 	Thumbnails ThumbnailConversion
+	Extensions MarkdownExtensions
 }
 
 // EndpointBinding returns the TCPBinding of the conversion endpoint
@@ -363,6 +648,37 @@ type ThumbnailConversion struct {
 	FolderName    string
 }
 
+// MarkdownExtensions defines the order in which the markdown extensions
+// (e.g. "imagegallery", "csv") are applied and which of them are disabled.
+// Extensions which are not listed in the order are applied afterwards in their default order.
+type MarkdownExtensions struct {
+	Order    []string
+	Disabled []string
+	Commands []CommandExtension
+}
+
+// CommandExtension defines an external command which renders the markdown extension
+// "exec:<Name> [*description*](*file or parameter*)". The content of the referenced
+// file (or the parameter itself) is piped to the command and its output is inserted
+// as markdown or HTML. The extension can be ordered and disabled as "exec:<Name>".
+type CommandExtension struct {
+	Name             string
+	Command          string
+	Arguments        []string
+	TimeoutInSeconds int
+	Output           string
+}
+
+// Timeout returns the maximum duration of a single execution of the command.
+func (command CommandExtension) Timeout() time.Duration {
+	return getDuration(command.TimeoutInSeconds, DefaultCommandTimeoutInSeconds)
+}
+
+// OutputIsHTML returns true if the output of the command is HTML and not markdown.
+func (command CommandExtension) OutputIsHTML() bool {
+	return strings.ToLower(command.Output) == CommandOutputHTML
+}
+
 // Analytics defines the web-analytics parameters of the web-server.
 type Analytics struct {
 	Enabled         bool
@@ -389,6 +705,7 @@ type Config struct {
 	metaDataFolder  string
 	themeFolderBase string
 	templatesFolder string
+	cacheFolder     string
 }
 
 // CertificateDirectory returns the path of the SSL certificates directory in the meta-data folder.
@@ -396,6 +713,39 @@ func (config *Config) CertificateDirectory() string {
 	return filepath.Join(config.MetaDataFolder(), SSLCertsFolderName)
 }
 
+// ACMECertificateDirectory returns the path of the folder in which the ACME account key
+// and the certificates obtained via ACME are cached.
+func (config *Config) ACMECertificateDirectory() string {
+	return filepath.Join(config.CertificateDirectory(), ACMEFolderName)
+}
+
+// ACMECACertFilePath returns the path of the file with the additional root certificates
+// for the ACME directory or an empty string if none is configured.
+func (config *Config) ACMECACertFilePath() string {
+	if config.Server.HTTPS.ACME.CACertFileName == "" {
+		return ""
+	}
+
+	return filepath.Join(config.CertificateDirectory(), config.Server.HTTPS.ACME.CACertFileName)
+}
+
+// ACMEDomains returns the domain names certificates are requested for via ACME.
+// If no domains are configured the domain name of the server is used.
+func (config *Config) ACMEDomains() []string {
+	var domains []string
+	for _, domain := range config.Server.HTTPS.ACME.Domains {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	if len(domains) == 0 && config.Server.DomainName != "" {
+		domains = append(domains, config.Server.DomainName)
+	}
+
+	return domains
+}
+
 // CertificateFilePaths returns the SSL certificate and key file paths.
 // If none are configured or the configured ones don't exist it will create new
 // ones and return the paths of the newly generates certificate/key pair.
@@ -471,6 +821,22 @@ func (config *Config) MetaDataFolder() string {
 	return config.metaDataFolder
 }
 
+// CacheFolder returns the path of the folder for the indices and caches which are
+// derived from the content of the repository (e.g. search index, thumbnails).
+func (config *Config) CacheFolder() string {
+	if config.cacheFolder != "" {
+		return config.cacheFolder
+	}
+
+	return config.MetaDataFolder()
+}
+
+// UseRef moves the cache folder into a subfolder of the meta-data folder which belongs to the given git ref,
+// so that processes which serve different refs of the same repository don't overwrite each other's caches.
+func (config *Config) UseRef(ref string) {
+	config.cacheFolder = filepath.Join(config.MetaDataFolder(), RefsFolderName, hashutil.FromString(ref))
+}
+
 // TemplatesFolder returns the path of the templates folder.
 func (config *Config) TemplatesFolder() string {
 	return config.templatesFolder
@@ -498,7 +864,7 @@ func (config *Config) ThumbnailIndexFilePath() string {
 		filename = config.Conversion.Thumbnails.IndexFileName
 	}
 
-	return filepath.Join(config.MetaDataFolder(), filename)
+	return filepath.Join(config.CacheFolder(), filename)
 }
 
 // ThumbnailFolder returns the path of the thumbnail folder.
@@ -508,7 +874,27 @@ func (config *Config) ThumbnailFolder() string {
 		folderName = config.Conversion.Thumbnails.FolderName
 	}
 
-	return filepath.Join(config.MetaDataFolder(), folderName)
+	return filepath.Join(config.CacheFolder(), folderName)
+}
+
+// BuildFolder returns the default path of the folder for static builds.
+func (config *Config) BuildFolder() string {
+	return filepath.Join(config.MetaDataFolder(), BuildFolderName)
+}
+
+// SearchIndexFolder returns the path of the folder for the full-text search index.
+func (config *Config) SearchIndexFolder() string {
+	return filepath.Join(config.CacheFolder(), SearchIndexFolderName)
+}
+
+// DiagramFolder returns the path of the folder for the rendered diagrams.
+func (config *Config) DiagramFolder() string {
+	return filepath.Join(config.CacheFolder(), DiagramsFolderName)
+}
+
+// CommandFolder returns the path of the folder for the cached output of the command extensions.
+func (config *Config) CommandFolder() string {
+	return filepath.Join(config.CacheFolder(), CommandsFolderName)
 }
 
 // Load reads the configuration-model from disk.
@@ -592,19 +978,88 @@ func (config *Config) apply(newConfig *Config) (*Config, error) {
 }
 
 // AuthenticationIsEnabled get a flag indicating if authentication is enabled.
+// Like logging in, basic-authentication is only available over secure connections.
 func (config *Config) AuthenticationIsEnabled() bool {
 
 	if !config.Server.Authentication.Enabled {
 		return false
 	}
 
-	// we will only allow basic authentication over https
-	if config.Server.HTTP.Enabled && config.Server.HTTPS.HTTPSIsForced() == false {
-		fmt.Println("Basic-Authentication over HTTP is not available. Please disable HTTP or force HTTPS in order to use basic-authentication.")
-		os.Exit(1)
+	return config.secureConnections()
+}
+
+// EditingIsEnabled get a flag indicating if items can be edited in the browser.
+// Like basic-authentication the editor is only available over HTTPS and
+// it requires the authentication user-store file.
+func (config *Config) EditingIsEnabled() bool {
+
+	if !config.Server.Editing.Enabled {
+		return false
+	}
+
+	return config.LoginIsAvailable()
+}
+
+// LoginIsAvailable get a flag indicating if users can log in with the credentials
+// of the authentication user-store. Credentials are only accepted over secure connections.
+func (config *Config) LoginIsAvailable() bool {
+
+	if !config.secureConnections() {
+		return false
+	}
+
+	return fsutil.FileExists(config.AuthenticationFilePath())
+}
+
+// OIDCIsEnabled get a flag indicating if users log in with an OpenID Connect provider.
+// Like the credentials of the user-store, the session cookies are only accepted over secure connections.
+func (config *Config) OIDCIsEnabled() bool {
+
+	if !config.Server.Authentication.OIDC.Enabled {
+		return false
+	}
+
+	return config.secureConnections()
+}
+
+// secureConnections get a flag indicating if the repository is only served over secure connections: either
+// it is only served over HTTPS or the HTTP requests come from trusted proxies which terminate TLS. Requests
+// which did not reach the proxies over HTTPS are redirected to HTTPS.
+func (config *Config) secureConnections() bool {
+	if config.Server.HTTPS.Enabled && (!config.Server.HTTP.Enabled || config.Server.HTTPS.HTTPSIsForced()) {
+		return true
+	}
+
+	return config.Server.HTTP.Enabled && config.Server.Proxy.IsEnabled()
+}
+
+// SessionKeyFilePath returns the path of the file with the key for signing the session cookies.
+func (config *Config) SessionKeyFilePath() string {
+
+	fileName := config.Server.Authentication.OIDC.SessionKeyFileName
+	if strings.TrimSpace(fileName) == "" {
+		fileName = DefaultSessionKeyFileName
+	}
+
+	return filepath.Join(config.MetaDataFolder(), fileName)
+}
+
+// GetLoginUserStore returns the user-store for logging in or nil if logging in is not available.
+func (config *Config) GetLoginUserStore() auth.SecretProvider {
+	if !config.LoginIsAvailable() {
+		return nil
+	}
+
+	return auth.HtpasswdFileProvider(config.AuthenticationFilePath())
+}
+
+// GetEditorUserStore returns a secret provider for the users which are allowed to edit items.
+func (config *Config) GetEditorUserStore() auth.SecretProvider {
+	if !config.EditingIsEnabled() {
+		return nil
 	}
 
-	return true
+	return auth.HtpasswdFileProvider(config.AuthenticationFilePath())
 }
 
 // AuthenticationFilePath returns the path of the authentication file.
@@ -0,0 +1,146 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Timeouts_ValuesNotSet_DefaultsAreReturned(t *testing.T) {
+	// arrange
+	timeouts := Timeouts{ReadInSeconds: -1}
+
+	// act
+	read, shutdown := timeouts.Read(), timeouts.Shutdown()
+
+	// assert
+	if read != DefaultReadTimeoutInSeconds*time.Second || shutdown != DefaultShutdownTimeoutInSeconds*time.Second {
+		t.Errorf("The timeouts should be the defaults but were %s and %s", read, shutdown)
+	}
+}
+
+func Test_Timeouts_ValueSet_ValueIsReturned(t *testing.T) {
+	// arrange
+	timeouts := Timeouts{WriteInSeconds: 300}
+
+	// act
+	write := timeouts.Write()
+
+	// assert
+	if write != 300*time.Second {
+		t.Errorf("The write timeout should be %s but was %s", 300*time.Second, write)
+	}
+}
+
+func Test_ACMEDomains_NoDomainsConfigured_DomainNameOfTheServerIsReturned(t *testing.T) {
+	// arrange
+	config := Default("/tmp")
+	config.Server.DomainName = "example.com"
+	config.Server.HTTPS.ACME.Domains = []string{" "}
+
+	// act
+	domains := config.ACMEDomains()
+
+	// assert
+	if len(domains) != 1 || domains[0] != "example.com" {
+		t.Errorf("The domains should be %v but were %v", []string{"example.com"}, domains)
+	}
+}
+
+func Test_LoginIsAvailable_HTTPNotForcedToHTTPS_LoginIsNotAvailable(t *testing.T) {
+	// arrange
+	config := Default("/tmp")
+	config.Server.HTTP.Enabled = true
+	config.Server.HTTPS.Enabled = true
+	config.Server.HTTPS.Force = false
+
+	// act
+	isAvailable := config.LoginIsAvailable()
+
+	// assert
+	if isAvailable {
+		t.Errorf("Logging in should not be available if HTTP is not forced to HTTPS")
+	}
+}
+
+func Test_OIDCIsEnabled_HTTPNotForcedToHTTPS_OIDCIsDisabled(t *testing.T) {
+	// arrange
+	config := Default("/tmp")
+	config.Server.HTTP.Enabled = true
+	config.Server.HTTPS.Enabled = true
+	config.Server.HTTPS.Force = false
+	config.Server.Authentication.OIDC.Enabled = true
+
+	// act
+	isEnabled := config.OIDCIsEnabled()
+
+	// assert
+	if isEnabled {
+		t.Errorf("Logging in with an OpenID Connect provider should not be available if HTTP is not forced to HTTPS")
+	}
+}
+
+func Test_AuthenticationIsEnabled_HTTPBehindTrustedProxy_AuthenticationIsEnabled(t *testing.T) {
+	// arrange
+	config := Default("/tmp")
+	config.Server.HTTP.Enabled = true
+	config.Server.HTTPS.Enabled = false
+	config.Server.Authentication.Enabled = true
+	config.Server.Proxy.TrustedCIDRs = []string{"10.0.0.0/8"}
+
+	// act
+	isEnabled := config.AuthenticationIsEnabled()
+
+	// assert
+	if !isEnabled {
+		t.Errorf("Authentication should be enabled over HTTP if there are trusted proxies")
+	}
+}
+
+func Test_TrustedNetworks_IPAddresses_NetworksWithOneAddressAreReturned(t *testing.T) {
+	// arrange
+	proxy := Proxy{TrustedCIDRs: []string{"127.0.0.1", "::1", "10.0.0.0/8"}}
+
+	// act
+	networks, err := proxy.TrustedNetworks()
+
+	// assert
+	if err != nil || len(networks) != 3 || networks[0].String() != "127.0.0.1/32" || networks[1].String() != "::1/128" {
+		t.Errorf("The networks should be [127.0.0.1/32 ::1/128 10.0.0.0/8] but were %v (error: %v)", networks, err)
+	}
+}
+
+func Test_TrustedNetworks_InvalidValue_ErrorIsReturned(t *testing.T) {
+	// arrange
+	proxy := Proxy{TrustedCIDRs: []string{"proxy.example.com"}}
+
+	// act
+	_, err := proxy.TrustedNetworks()
+
+	// assert
+	if err == nil {
+		t.Errorf("TrustedNetworks should return an error for a host name")
+	}
+}
+
+func Test_UseRef_DifferentRefs_CachesAreSeparated(t *testing.T) {
+	// arrange
+	main, release := Default("/tmp"), Default("/tmp")
+
+	// act
+	main.UseRef("refs/heads/main")
+	release.UseRef("refs/tags/v1")
+
+	// assert
+	if main.SearchIndexFolder() == release.SearchIndexFolder() || main.ThumbnailFolder() == release.ThumbnailFolder() {
+		t.Errorf("The refs should use separate caches but both use %q.", main.SearchIndexFolder())
+	}
+
+	if !strings.HasPrefix(main.SearchIndexFolder(), main.MetaDataFolder()) {
+		t.Errorf("The cache of the ref should be located in the meta-data folder %q but was %q.", main.MetaDataFolder(), main.SearchIndexFolder())
+	}
+}
@@ -0,0 +1,727 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dot
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	pointsPerInch = 72.0
+
+	defaultFontSize       = 14.0
+	defaultNodeSeparation = 0.25 // inches
+	defaultRankSeparation = 0.5  // inches
+
+	// the space for the loops of edges from a node to itself
+	selfLoopSize = 30.0
+
+	// the number of iterations of the ordering and positioning heuristics
+	orderingIterations    = 24
+	positioningIterations = 8
+)
+
+// vertex is a node or a virtual node of a long edge in the layout.
+// The breadth is the extent along a rank, the depth the extent across the ranks.
+type vertex struct {
+	node  *Node
+	route *route
+
+	rank     int
+	order    int
+	cluster  *Cluster
+	hasLoops bool
+
+	breadth  float64
+	depth    float64
+	position float64
+}
+
+// route is the path of an edge through the ranks.
+type route struct {
+	edge *Edge
+
+	// the vertices from the lower to the higher rank
+	vertices []*vertex
+
+	// true if the edge points from a higher to a lower rank
+	reversed bool
+
+	// the virtual node which holds the label of the edge (or nil)
+	labelVertex *vertex
+}
+
+// layout assigns ranks and positions to the nodes of a graph (Sugiyama-style):
+// nodes are placed on ranks along the direction of the edges, edges spanning multiple
+// ranks get virtual nodes, the nodes are ordered to reduce crossings and positioned
+// close to their neighbors.
+type layout struct {
+	graph     *Graph
+	direction string
+
+	nodeSeparation float64
+	rankSeparation float64
+
+	vertices     []*vertex
+	nodeVertices map[*Node]*vertex
+	routes       []*route
+	layers       [][]*vertex
+
+	// the neighbors of the vertices on the previous and the next rank
+	upper map[*vertex][]*vertex
+	lower map[*vertex][]*vertex
+
+	// the center of every rank across the ranks
+	rankCenters []float64
+}
+
+// newLayout creates the layout for the given graph.
+func newLayout(graph *Graph) *layout {
+
+	l := &layout{
+		graph:          graph,
+		direction:      strings.ToUpper(graph.Attributes["rankdir"]),
+		nodeSeparation: getInches(graph.Attributes["nodesep"], defaultNodeSeparation),
+		rankSeparation: getInches(graph.Attributes["ranksep"], defaultRankSeparation),
+		nodeVertices:   make(map[*Node]*vertex),
+		upper:          make(map[*vertex][]*vertex),
+		lower:          make(map[*vertex][]*vertex),
+	}
+
+	for _, node := range graph.Nodes {
+		width, height := getNodeSize(graph, node)
+		v := &vertex{node: node, cluster: getOutermostCluster(graph, node)}
+		v.breadth, v.depth = l.orient(width, height)
+
+		l.vertices = append(l.vertices, v)
+		l.nodeVertices[node] = v
+	}
+
+	l.assignRanks()
+	l.createRoutes()
+	l.orderVertices()
+	l.assignPositions()
+
+	return l
+}
+
+// isHorizontal indicates whether the ranks are laid out from left to right (or right to left).
+func (l *layout) isHorizontal() bool {
+	return l.direction == "LR" || l.direction == "RL"
+}
+
+// orient returns the breadth and depth for the given width and height.
+func (l *layout) orient(width, height float64) (breadth, depth float64) {
+	if l.isHorizontal() {
+		return height, width
+	}
+
+	return width, height
+}
+
+// assignRanks places every node on a rank so that most edges point to a higher rank.
+func (l *layout) assignRanks() {
+
+	count := len(l.graph.Nodes)
+	index := make(map[*Node]int, count)
+	for i, node := range l.graph.Nodes {
+		index[node] = i
+	}
+
+	// nodes with a "same" rank constraint are ranked as one node
+	groups := make([]int, count)
+	for i := range groups {
+		groups[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if groups[i] != i {
+			groups[i] = find(groups[i])
+		}
+		return groups[i]
+	}
+
+	for _, rankGroup := range l.graph.RankGroups {
+		for _, node := range rankGroup.Nodes[1:] {
+			groups[find(index[node])] = find(index[rankGroup.Nodes[0]])
+		}
+	}
+
+	// the edges between the groups
+	successors := make([][]int, count)
+	for _, edge := range l.graph.Edges {
+		from, to := find(index[edge.From]), find(index[edge.To])
+		if from != to {
+			successors[from] = append(successors[from], to)
+		}
+	}
+
+	// break cycles by reversing the edges which point back to a node on the current path
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, count)
+	acyclicSuccessors := make([][]int, count)
+
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		for _, successor := range successors[i] {
+			switch state[successor] {
+			case visiting:
+				acyclicSuccessors[successor] = append(acyclicSuccessors[successor], i)
+			case unvisited:
+				acyclicSuccessors[i] = append(acyclicSuccessors[i], successor)
+				visit(successor)
+			default:
+				acyclicSuccessors[i] = append(acyclicSuccessors[i], successor)
+			}
+		}
+		state[i] = visited
+	}
+
+	for i := range l.graph.Nodes {
+		if find(i) == i && state[i] == unvisited {
+			visit(i)
+		}
+	}
+
+	// longest path ranking (in topological order)
+	predecessorCount := make([]int, count)
+	for _, targets := range acyclicSuccessors {
+		for _, target := range targets {
+			predecessorCount[target]++
+		}
+	}
+
+	ranks := make([]int, count)
+	var queue []int
+	for i := range l.graph.Nodes {
+		if find(i) == i && predecessorCount[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, successor := range acyclicSuccessors[current] {
+			if ranks[current]+1 > ranks[successor] {
+				ranks[successor] = ranks[current] + 1
+			}
+
+			predecessorCount[successor]--
+			if predecessorCount[successor] == 0 {
+				queue = append(queue, successor)
+			}
+		}
+	}
+
+	maximumRank := 0
+	for i, v := range l.vertices {
+		v.rank = ranks[find(i)]
+		if v.rank > maximumRank {
+			maximumRank = v.rank
+		}
+	}
+
+	// rank constraints
+	for _, rankGroup := range l.graph.RankGroups {
+		for _, node := range rankGroup.Nodes {
+			switch rankGroup.Rank {
+			case "min", "source":
+				l.nodeVertices[node].rank = 0
+			case "max", "sink":
+				l.nodeVertices[node].rank = maximumRank
+			}
+		}
+	}
+
+	// edge labels are placed on the ranks between the nodes
+	if l.hasEdgeLabels() {
+		for _, v := range l.vertices {
+			v.rank *= 2
+		}
+		l.rankSeparation /= 2
+	}
+}
+
+// hasEdgeLabels indicates whether any of the edges between different nodes has a label.
+func (l *layout) hasEdgeLabels() bool {
+	for _, edge := range l.graph.Edges {
+		if edge.From != edge.To && edge.Attributes["label"] != "" && l.nodeVertices[edge.From].rank != l.nodeVertices[edge.To].rank {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createRoutes creates the routes of all edges with virtual nodes on the ranks between their nodes.
+func (l *layout) createRoutes() {
+
+	for _, edge := range l.graph.Edges {
+		from, to := l.nodeVertices[edge.From], l.nodeVertices[edge.To]
+		r := &route{edge: edge}
+		l.routes = append(l.routes, r)
+
+		if from == to {
+			from.hasLoops = true
+			continue
+		}
+
+		if from.rank > to.rank {
+			from, to = to, from
+			r.reversed = true
+		}
+
+		r.vertices = []*vertex{from}
+
+		labelRank := -1
+		if label := getLabel(edge.Attributes["label"], "", l.graph); label != "" && to.rank-from.rank > 1 {
+			labelRank = (from.rank + to.rank) / 2
+		}
+
+		cluster := from.cluster
+		if to.cluster != cluster {
+			cluster = nil
+		}
+
+		for rank := from.rank + 1; rank < to.rank; rank++ {
+			dummy := &vertex{route: r, rank: rank, cluster: cluster}
+			if rank == labelRank {
+				width, height := getLabelSize(getLabel(edge.Attributes["label"], "", l.graph), getFontSize(edge.Attributes))
+				dummy.breadth, dummy.depth = l.orient(width+8, height)
+				r.labelVertex = dummy
+			}
+
+			l.vertices = append(l.vertices, dummy)
+			r.vertices = append(r.vertices, dummy)
+		}
+
+		r.vertices = append(r.vertices, to)
+
+		if from.rank == to.rank {
+			continue
+		}
+
+		for index := 1; index < len(r.vertices); index++ {
+			upper, lower := r.vertices[index-1], r.vertices[index]
+			l.lower[upper] = append(l.lower[upper], lower)
+			l.upper[lower] = append(l.upper[lower], upper)
+		}
+	}
+
+	// layers
+	for _, v := range l.vertices {
+		for len(l.layers) <= v.rank {
+			l.layers = append(l.layers, nil)
+		}
+
+		l.layers[v.rank] = append(l.layers[v.rank], v)
+	}
+
+	l.updateOrder()
+}
+
+// orderVertices orders the vertices on every rank to reduce the number of edge crossings.
+func (l *layout) orderVertices() {
+
+	best := l.copyLayers()
+	bestCrossings := l.crossings()
+
+	for iteration := 0; iteration < orderingIterations && bestCrossings > 0; iteration++ {
+		if iteration%2 == 0 {
+			for rank := 1; rank < len(l.layers); rank++ {
+				l.sortLayer(rank, l.upper)
+			}
+		} else {
+			for rank := len(l.layers) - 2; rank >= 0; rank-- {
+				l.sortLayer(rank, l.lower)
+			}
+		}
+
+		l.transpose()
+
+		if crossings := l.crossings(); crossings < bestCrossings {
+			best, bestCrossings = l.copyLayers(), crossings
+		}
+	}
+
+	l.layers = best
+	l.updateOrder()
+}
+
+// sortLayer sorts the vertices of the given rank by the average order of their neighbors.
+// The vertices of a cluster are kept together.
+func (l *layout) sortLayer(rank int, neighbors map[*vertex][]*vertex) {
+
+	layer := l.layers[rank]
+	barycenters := make(map[*vertex]float64, len(layer))
+	clusterBarycenters := make(map[*Cluster][]float64)
+
+	for _, v := range layer {
+		barycenter := float64(v.order)
+		if len(neighbors[v]) > 0 {
+			sum := 0.0
+			for _, neighbor := range neighbors[v] {
+				sum += float64(neighbor.order)
+			}
+			barycenter = sum / float64(len(neighbors[v]))
+		}
+
+		barycenters[v] = barycenter
+		if v.cluster != nil {
+			clusterBarycenters[v.cluster] = append(clusterBarycenters[v.cluster], barycenter)
+		}
+	}
+
+	groupKey := func(v *vertex) float64 {
+		if v.cluster == nil {
+			return barycenters[v]
+		}
+
+		return average(clusterBarycenters[v.cluster])
+	}
+
+	sort.SliceStable(layer, func(i, j int) bool {
+		keyI, keyJ := groupKey(layer[i]), groupKey(layer[j])
+		if keyI != keyJ {
+			return keyI < keyJ
+		}
+
+		if layer[i].cluster != layer[j].cluster {
+			return false
+		}
+
+		return barycenters[layer[i]] < barycenters[layer[j]]
+	})
+
+	l.updateOrder()
+}
+
+// transpose swaps neighboring vertices if this reduces the number of crossings.
+func (l *layout) transpose() {
+	for improved, pass := true, 0; improved && pass < 4; pass++ {
+		improved = false
+
+		for rank, layer := range l.layers {
+			for index := 0; index+1 < len(layer); index++ {
+				if layer[index].cluster != layer[index+1].cluster {
+					continue
+				}
+
+				before := l.rankCrossings(rank)
+				layer[index], layer[index+1] = layer[index+1], layer[index]
+				layer[index].order, layer[index+1].order = index, index+1
+
+				if l.rankCrossings(rank) < before {
+					improved = true
+					continue
+				}
+
+				layer[index], layer[index+1] = layer[index+1], layer[index]
+				layer[index].order, layer[index+1].order = index, index+1
+			}
+		}
+	}
+}
+
+// crossings returns the number of edge crossings between all ranks.
+func (l *layout) crossings() int {
+	crossings := 0
+	for rank := 0; rank+1 < len(l.layers); rank++ {
+		crossings += l.crossingsBetween(rank)
+	}
+
+	return crossings
+}
+
+// rankCrossings returns the number of edge crossings above and below the given rank.
+func (l *layout) rankCrossings(rank int) int {
+	crossings := l.crossingsBetween(rank)
+	if rank > 0 {
+		crossings += l.crossingsBetween(rank - 1)
+	}
+
+	return crossings
+}
+
+// crossingsBetween returns the number of edge crossings between the given and the next rank.
+func (l *layout) crossingsBetween(rank int) int {
+	if rank+1 >= len(l.layers) {
+		return 0
+	}
+
+	type segment struct{ upper, lower int }
+	var segments []segment
+	for _, v := range l.layers[rank] {
+		for _, lower := range l.lower[v] {
+			segments = append(segments, segment{v.order, lower.order})
+		}
+	}
+
+	crossings := 0
+	for i := range segments {
+		for j := i + 1; j < len(segments); j++ {
+			if (segments[i].upper-segments[j].upper)*(segments[i].lower-segments[j].lower) < 0 {
+				crossings++
+			}
+		}
+	}
+
+	return crossings
+}
+
+// assignPositions positions the vertices of every rank close to their neighbors without overlaps.
+func (l *layout) assignPositions() {
+
+	for _, layer := range l.layers {
+		position := 0.0
+		for index, v := range layer {
+			if index > 0 {
+				position += l.gap(layer[index-1], v)
+			}
+
+			v.position = position
+		}
+	}
+
+	for iteration := 0; iteration < positioningIterations; iteration++ {
+		for rank := 1; rank < len(l.layers); rank++ {
+			l.alignLayer(rank, l.upper)
+		}
+
+		for rank := len(l.layers) - 2; rank >= 0; rank-- {
+			l.alignLayer(rank, l.lower)
+		}
+	}
+
+	l.separateClusters()
+
+	// the centers of the ranks
+	center := 0.0
+	previousDepth := 0.0
+	l.rankCenters = make([]float64, len(l.layers))
+	for rank, layer := range l.layers {
+		depth := 0.0
+		for _, v := range layer {
+			depth = math.Max(depth, v.depth)
+		}
+
+		if rank > 0 {
+			center += previousDepth/2 + l.rankSeparation + depth/2
+		} else {
+			center = depth / 2
+		}
+
+		l.rankCenters[rank] = center
+		previousDepth = depth
+	}
+}
+
+// alignLayer moves the vertices of the given rank to the median position of their neighbors. The
+// order of the vertices is kept: the average of a left-aligned and a right-aligned placement is used.
+func (l *layout) alignLayer(rank int, neighbors map[*vertex][]*vertex) {
+
+	layer := l.layers[rank]
+	count := len(layer)
+
+	desired := make([]float64, count)
+	for index, v := range layer {
+		desired[index] = v.position
+		if positions := getPositions(neighbors[v]); len(positions) > 0 {
+			desired[index] = median(positions)
+		}
+	}
+
+	left := make([]float64, count)
+	for index := range layer {
+		left[index] = desired[index]
+		if index > 0 {
+			left[index] = math.Max(left[index], left[index-1]+l.gap(layer[index-1], layer[index]))
+		}
+	}
+
+	right := make([]float64, count)
+	for index := count - 1; index >= 0; index-- {
+		right[index] = desired[index]
+		if index < count-1 {
+			right[index] = math.Min(right[index], right[index+1]-l.gap(layer[index], layer[index+1]))
+		}
+	}
+
+	for index, v := range layer {
+		v.position = (left[index] + right[index]) / 2
+	}
+}
+
+// separateClusters moves clusters which share ranks apart so their boxes do not overlap.
+func (l *layout) separateClusters() {
+
+	type extent struct {
+		cluster             *Cluster
+		left, right         float64
+		firstRank, lastRank int
+	}
+
+	var extents []*extent
+	getExtent := func(cluster *Cluster) *extent {
+		e := &extent{cluster: cluster, left: math.Inf(1), right: math.Inf(-1), firstRank: len(l.layers), lastRank: -1}
+		for _, v := range l.vertices {
+			if v.cluster != cluster {
+				continue
+			}
+
+			e.left, e.right = math.Min(e.left, v.position-v.breadth/2), math.Max(e.right, v.position+v.breadth/2)
+			e.firstRank, e.lastRank = minInt(e.firstRank, v.rank), maxInt(e.lastRank, v.rank)
+		}
+
+		return e
+	}
+
+	for _, cluster := range l.graph.Clusters {
+		if e := getExtent(cluster); e.lastRank >= 0 {
+			extents = append(extents, e)
+		}
+	}
+
+	sort.SliceStable(extents, func(i, j int) bool {
+		return extents[i].left+extents[i].right < extents[j].left+extents[j].right
+	})
+
+	placed := make(map[*Cluster]bool)
+	for index, e := range extents {
+		placed[e.cluster] = true
+
+		required := math.Inf(-1)
+		for _, previous := range extents[:index] {
+			if previous.firstRank <= e.lastRank && e.firstRank <= previous.lastRank {
+				required = math.Max(required, previous.right+2*clusterMargin+l.nodeSeparation)
+			}
+		}
+
+		shift := required - e.left
+		if shift <= 0 {
+			continue
+		}
+
+		// move the cluster and everything to its right
+		for _, layer := range l.layers {
+			for _, v := range layer {
+				if v.cluster == e.cluster || v.position >= e.left && (v.cluster == nil || !placed[v.cluster]) {
+					v.position += shift
+				}
+			}
+		}
+
+		// restore the gaps within the ranks
+		for _, layer := range l.layers {
+			for position := 1; position < len(layer); position++ {
+				layer[position].position = math.Max(layer[position].position, layer[position-1].position+l.gap(layer[position-1], layer[position]))
+			}
+		}
+
+		for _, other := range extents[index:] {
+			*other = *getExtent(other.cluster)
+		}
+	}
+}
+
+// gap returns the minimum distance between the centers of the given neighboring vertices.
+func (l *layout) gap(left, right *vertex) float64 {
+	separation := l.nodeSeparation
+	if left.node == nil || right.node == nil {
+		separation /= 2
+	}
+
+	if left.hasLoops && !l.isHorizontal() {
+		separation += selfLoopSize
+	}
+
+	if left.cluster != right.cluster {
+		separation += 2 * clusterMargin
+	}
+
+	return left.breadth/2 + separation + right.breadth/2
+}
+
+// updateOrder stores the index of every vertex in its rank.
+func (l *layout) updateOrder() {
+	for _, layer := range l.layers {
+		for index, v := range layer {
+			v.order = index
+		}
+	}
+}
+
+func (l *layout) copyLayers() [][]*vertex {
+	layers := make([][]*vertex, len(l.layers))
+	for rank, layer := range l.layers {
+		layers[rank] = append([]*vertex(nil), layer...)
+	}
+
+	return layers
+}
+
+// getOutermostCluster returns the first cluster the given node belongs to (or nil).
+func getOutermostCluster(graph *Graph, node *Node) *Cluster {
+	for _, cluster := range graph.Clusters {
+		for _, member := range cluster.Nodes {
+			if member == node {
+				return cluster
+			}
+		}
+	}
+
+	return nil
+}
+
+func getPositions(vertices []*vertex) []float64 {
+	positions := make([]float64, 0, len(vertices))
+	for _, v := range vertices {
+		positions = append(positions, v.position)
+	}
+
+	return positions
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+
+	return (sorted[middle-1] + sorted[middle]) / 2
+}
+
+func average(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+// getInches returns the given length in inches as points or the default value if the length is invalid.
+func getInches(value string, defaultValue float64) float64 {
+	if inches, err := strconv.ParseFloat(strings.Fields(value + " x")[0], 64); err == nil && inches >= 0 {
+		return inches * pointsPerInch
+	}
+
+	return defaultValue * pointsPerInch
+}
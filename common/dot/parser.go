@@ -0,0 +1,611 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dot parses graphs in the Graphviz dot language and renders them to SVG.
+package dot
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// A Graph is a directed or undirected graph with its nodes, edges and clusters.
+type Graph struct {
+	Name       string
+	Directed   bool
+	Attributes map[string]string
+
+	// all nodes and edges in the order of their appearance
+	Nodes []*Node
+	Edges []*Edge
+
+	// subgraphs whose names start with "cluster"
+	Clusters []*Cluster
+
+	// subgraphs with a rank attribute (e.g. {rank=same; a; b})
+	RankGroups []*RankGroup
+
+	nodesByID map[string]*Node
+
+	// the values which were HTML strings (e.g. <<b>bold</b>>)
+	htmlStrings map[string]bool
+}
+
+// A Node is a node of a graph.
+type Node struct {
+	ID         string
+	Attributes map[string]string
+}
+
+// An Edge connects two nodes of a graph.
+type Edge struct {
+	From       *Node
+	To         *Node
+	Attributes map[string]string
+}
+
+// A Cluster is a group of nodes which is drawn with a box around it.
+type Cluster struct {
+	ID         string
+	Attributes map[string]string
+	Nodes      []*Node
+}
+
+// A RankGroup is a set of nodes with a rank constraint ("same", "min", "source", "max" or "sink").
+type RankGroup struct {
+	Rank  string
+	Nodes []*Node
+}
+
+// Parse parses the given dot code (e.g. "digraph { a -> b }").
+func Parse(code string) (*Graph, error) {
+	tokens, err := tokenize(code)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &parser{
+		tokens: tokens,
+	}
+
+	return parser.parseGraph()
+}
+
+type tokenKind int
+
+const (
+	tokenEOF          tokenKind = iota
+	tokenID                     // identifiers, numerals, quoted strings and HTML strings
+	tokenPunctuation            // { } [ ] = ; , :
+	tokenEdgeOperator           // -> --
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	quoted bool
+	html   bool
+	line   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of input"
+	}
+
+	return fmt.Sprintf("%q in line %d", t.value, t.line)
+}
+
+// tokenize splits the given dot code into tokens. Comments are removed.
+func tokenize(code string) ([]token, error) {
+
+	runes := []rune(code)
+	var tokens []token
+	line := 1
+
+	for index := 0; index < len(runes); {
+		character := runes[index]
+		next := rune(0)
+		if index+1 < len(runes) {
+			next = runes[index+1]
+		}
+
+		switch {
+		case character == '\n':
+			line++
+			index++
+
+		case unicode.IsSpace(character):
+			index++
+
+		// comments and preprocessor output
+		case character == '/' && next == '/', character == '#' && isLineStart(runes, index):
+			for index < len(runes) && runes[index] != '\n' {
+				index++
+			}
+
+		case character == '/' && next == '*':
+			index += 2
+			for index < len(runes) && !(runes[index] == '*' && index+1 < len(runes) && runes[index+1] == '/') {
+				if runes[index] == '\n' {
+					line++
+				}
+				index++
+			}
+			index += 2
+
+		// quoted strings
+		case character == '"':
+			start := line
+			var value []rune
+			for index++; index < len(runes) && runes[index] != '"'; index++ {
+				if runes[index] == '\\' && index+1 < len(runes) && (runes[index+1] == '"' || runes[index+1] == '\n') {
+					index++
+					if runes[index] == '\n' {
+						line++
+						continue
+					}
+				} else if runes[index] == '\n' {
+					line++
+				}
+
+				value = append(value, runes[index])
+			}
+
+			if index >= len(runes) {
+				return nil, fmt.Errorf("The string starting in line %d is not terminated.", start)
+			}
+
+			tokens = append(tokens, token{tokenID, string(value), true, false, start})
+			index++
+
+		// HTML strings
+		case character == '<':
+			start, depth, end := line, 0, index
+			for ; end < len(runes); end++ {
+				if runes[end] == '<' {
+					depth++
+				} else if runes[end] == '>' {
+					depth--
+				} else if runes[end] == '\n' {
+					line++
+				}
+
+				if depth == 0 {
+					break
+				}
+			}
+
+			if end >= len(runes) {
+				return nil, fmt.Errorf("The HTML string starting in line %d is not terminated.", start)
+			}
+
+			tokens = append(tokens, token{tokenID, string(runes[index : end+1]), true, true, start})
+			index = end + 1
+
+		case character == '-' && (next == '>' || next == '-'):
+			tokens = append(tokens, token{tokenEdgeOperator, string(runes[index : index+2]), false, false, line})
+			index += 2
+
+		case strings.ContainsRune("{}[]=;,:", character):
+			tokens = append(tokens, token{tokenPunctuation, string(character), false, false, line})
+			index++
+
+		case isIdentifierCharacter(character) || character == '-' || character == '.':
+			end := index + 1
+			for end < len(runes) && (isIdentifierCharacter(runes[end]) || runes[end] == '.') {
+				end++
+			}
+
+			tokens = append(tokens, token{tokenID, string(runes[index:end]), false, false, line})
+			index = end
+
+		default:
+			return nil, fmt.Errorf("Unexpected character %q in line %d.", character, line)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, line: line}), nil
+}
+
+// parser creates a graph from dot tokens.
+type parser struct {
+	tokens   []token
+	position int
+	graph    *Graph
+}
+
+// scope contains the defaults and the nodes of the graph or of a subgraph.
+type scope struct {
+	parent *scope
+
+	attributes     map[string]string
+	nodeAttributes map[string]string
+	edgeAttributes map[string]string
+
+	cluster *Cluster
+
+	nodes     []*Node
+	nodeIsSet map[*Node]bool
+}
+
+func newScope(parent *scope, attributes map[string]string) *scope {
+	child := &scope{
+		parent:         parent,
+		attributes:     attributes,
+		nodeAttributes: make(map[string]string),
+		edgeAttributes: make(map[string]string),
+		nodeIsSet:      make(map[*Node]bool),
+	}
+
+	if parent != nil {
+		child.nodeAttributes = copyAttributes(parent.nodeAttributes)
+		child.edgeAttributes = copyAttributes(parent.edgeAttributes)
+	}
+
+	return child
+}
+
+func (parser *parser) peek() token {
+	return parser.tokens[parser.position]
+}
+
+func (parser *parser) next() token {
+	current := parser.tokens[parser.position]
+	if current.kind != tokenEOF {
+		parser.position++
+	}
+
+	return current
+}
+
+// isPunctuation indicates whether the next token is the given punctuation character.
+func (parser *parser) isPunctuation(value string) bool {
+	next := parser.peek()
+	return next.kind == tokenPunctuation && next.value == value
+}
+
+// isKeyword indicates whether the next token is the given keyword.
+func (parser *parser) isKeyword(keyword string) bool {
+	next := parser.peek()
+	return next.kind == tokenID && !next.quoted && strings.EqualFold(next.value, keyword)
+}
+
+func (parser *parser) expectPunctuation(value string) error {
+	if !parser.isPunctuation(value) {
+		return fmt.Errorf("Expected %q but found %s.", value, parser.peek())
+	}
+
+	parser.next()
+	return nil
+}
+
+func (parser *parser) expectID() (string, error) {
+	if parser.peek().kind != tokenID {
+		return "", fmt.Errorf("Expected an identifier but found %s.", parser.peek())
+	}
+
+	id := parser.next()
+	if id.html {
+		parser.graph.htmlStrings[id.value] = true
+	}
+
+	return id.value, nil
+}
+
+// parseGraph parses: [strict] (graph | digraph) [ID] '{' statements '}'
+func (parser *parser) parseGraph() (*Graph, error) {
+
+	if parser.isKeyword("strict") {
+		parser.next()
+	}
+
+	graph := &Graph{
+		Attributes:  make(map[string]string),
+		nodesByID:   make(map[string]*Node),
+		htmlStrings: make(map[string]bool),
+	}
+
+	switch {
+	case parser.isKeyword("digraph"):
+		graph.Directed = true
+	case parser.isKeyword("graph"):
+		graph.Directed = false
+	default:
+		return nil, fmt.Errorf("Expected \"graph\" or \"digraph\" but found %s.", parser.peek())
+	}
+
+	parser.next()
+	if parser.peek().kind == tokenID {
+		graph.Name = parser.next().value
+	}
+
+	if err := parser.expectPunctuation("{"); err != nil {
+		return nil, err
+	}
+
+	parser.graph = graph
+	if err := parser.parseStatements(newScope(nil, graph.Attributes)); err != nil {
+		return nil, err
+	}
+
+	if err := parser.expectPunctuation("}"); err != nil {
+		return nil, err
+	}
+
+	if parser.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("Unexpected %s after the end of the graph.", parser.peek())
+	}
+
+	return graph, nil
+}
+
+// parseStatements parses statements until the end of the current block.
+func (parser *parser) parseStatements(current *scope) error {
+	for {
+		if parser.isPunctuation(";") || parser.isPunctuation(",") {
+			parser.next()
+			continue
+		}
+
+		if parser.isPunctuation("}") || parser.peek().kind == tokenEOF {
+			return nil
+		}
+
+		if err := parser.parseStatement(current); err != nil {
+			return err
+		}
+	}
+}
+
+// parseStatement parses a subgraph, an attribute, a node or an edge statement.
+func (parser *parser) parseStatement(current *scope) error {
+
+	// subgraphs (which can be the start of an edge statement)
+	if parser.isPunctuation("{") || parser.isKeyword("subgraph") {
+		nodes, err := parser.parseSubgraph(current)
+		if err != nil {
+			return err
+		}
+
+		if parser.peek().kind == tokenEdgeOperator {
+			return parser.parseEdges(current, nodes)
+		}
+
+		return nil
+	}
+
+	// attribute statements: graph [...], node [...], edge [...]
+	for keyword, attributes := range map[string]map[string]string{"graph": current.attributes, "node": current.nodeAttributes, "edge": current.edgeAttributes} {
+		if parser.isKeyword(keyword) && parser.tokens[parser.position+1].kind == tokenPunctuation && parser.tokens[parser.position+1].value == "[" {
+			parser.next()
+			newAttributes, err := parser.parseAttributeList()
+			if err != nil {
+				return err
+			}
+
+			mergeAttributes(attributes, newAttributes)
+			return nil
+		}
+	}
+
+	id, err := parser.expectID()
+	if err != nil {
+		return err
+	}
+
+	// ID = ID
+	if parser.isPunctuation("=") {
+		parser.next()
+		value, err := parser.expectID()
+		if err != nil {
+			return err
+		}
+
+		current.attributes[id] = value
+		return nil
+	}
+
+	parser.skipPort()
+	node := parser.getNode(current, id)
+
+	if parser.peek().kind == tokenEdgeOperator {
+		return parser.parseEdges(current, []*Node{node})
+	}
+
+	attributes, err := parser.parseAttributeList()
+	if err != nil {
+		return err
+	}
+
+	mergeAttributes(node.Attributes, attributes)
+	return nil
+}
+
+// parseEdges parses the rest of an edge statement (e.g. "-> b -> {c d} [label=x]").
+func (parser *parser) parseEdges(current *scope, tails []*Node) error {
+
+	endpoints := [][]*Node{tails}
+	for parser.peek().kind == tokenEdgeOperator {
+		parser.next()
+
+		if parser.isPunctuation("{") || parser.isKeyword("subgraph") {
+			nodes, err := parser.parseSubgraph(current)
+			if err != nil {
+				return err
+			}
+
+			endpoints = append(endpoints, nodes)
+			continue
+		}
+
+		id, err := parser.expectID()
+		if err != nil {
+			return err
+		}
+
+		parser.skipPort()
+		endpoints = append(endpoints, []*Node{parser.getNode(current, id)})
+	}
+
+	attributes, err := parser.parseAttributeList()
+	if err != nil {
+		return err
+	}
+
+	for index := 1; index < len(endpoints); index++ {
+		for _, from := range endpoints[index-1] {
+			for _, to := range endpoints[index] {
+				edgeAttributes := copyAttributes(current.edgeAttributes)
+				mergeAttributes(edgeAttributes, attributes)
+				parser.graph.Edges = append(parser.graph.Edges, &Edge{from, to, edgeAttributes})
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseSubgraph parses: [subgraph [ID]] '{' statements '}' and returns the nodes of the subgraph.
+func (parser *parser) parseSubgraph(current *scope) ([]*Node, error) {
+
+	name := ""
+	if parser.isKeyword("subgraph") {
+		parser.next()
+		if parser.peek().kind == tokenID {
+			name = parser.next().value
+		}
+	}
+
+	if err := parser.expectPunctuation("{"); err != nil {
+		return nil, err
+	}
+
+	subgraph := newScope(current, make(map[string]string))
+	if strings.HasPrefix(name, "cluster") {
+		subgraph.cluster = &Cluster{
+			ID:         name,
+			Attributes: subgraph.attributes,
+		}
+
+		parser.graph.Clusters = append(parser.graph.Clusters, subgraph.cluster)
+	}
+
+	if err := parser.parseStatements(subgraph); err != nil {
+		return nil, err
+	}
+
+	if err := parser.expectPunctuation("}"); err != nil {
+		return nil, err
+	}
+
+	if rank := subgraph.attributes["rank"]; rank != "" && len(subgraph.nodes) > 0 {
+		parser.graph.RankGroups = append(parser.graph.RankGroups, &RankGroup{rank, subgraph.nodes})
+	}
+
+	return subgraph.nodes, nil
+}
+
+// parseAttributeList parses: ('[' [ID ['=' ID] [';' | ',']]* ']')*
+func (parser *parser) parseAttributeList() (map[string]string, error) {
+
+	attributes := make(map[string]string)
+	for parser.isPunctuation("[") {
+		parser.next()
+
+		for !parser.isPunctuation("]") {
+			key, err := parser.expectID()
+			if err != nil {
+				return nil, err
+			}
+
+			value := "true"
+			if parser.isPunctuation("=") {
+				parser.next()
+				if value, err = parser.expectID(); err != nil {
+					return nil, err
+				}
+			}
+
+			attributes[key] = value
+
+			if parser.isPunctuation(",") || parser.isPunctuation(";") {
+				parser.next()
+			}
+		}
+
+		parser.next()
+	}
+
+	return attributes, nil
+}
+
+// skipPort skips the port of a node (e.g. ":f0:n").
+func (parser *parser) skipPort() {
+	for parser.isPunctuation(":") {
+		parser.next()
+		if parser.peek().kind == tokenID {
+			parser.next()
+		}
+	}
+}
+
+// getNode returns the node with the given id and creates it with the defaults
+// of the current scope if it doesn't exist yet. The node is added to the current
+// scope and its parents.
+func (parser *parser) getNode(current *scope, id string) *Node {
+
+	node, exists := parser.graph.nodesByID[id]
+	if !exists {
+		node = &Node{
+			ID:         id,
+			Attributes: copyAttributes(current.nodeAttributes),
+		}
+
+		parser.graph.nodesByID[id] = node
+		parser.graph.Nodes = append(parser.graph.Nodes, node)
+	}
+
+	for parent := current; parent != nil; parent = parent.parent {
+		if parent.nodeIsSet[node] {
+			continue
+		}
+
+		parent.nodeIsSet[node] = true
+		parent.nodes = append(parent.nodes, node)
+
+		if parent.cluster != nil {
+			parent.cluster.Nodes = append(parent.cluster.Nodes, node)
+		}
+	}
+
+	return node
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+	result := make(map[string]string, len(attributes))
+	mergeAttributes(result, attributes)
+	return result
+}
+
+func mergeAttributes(target, attributes map[string]string) {
+	for key, value := range attributes {
+		target[key] = value
+	}
+}
+
+func isIdentifierCharacter(character rune) bool {
+	return character == '_' || unicode.IsLetter(character) || unicode.IsDigit(character) || character > unicode.MaxASCII && !unicode.IsSpace(character)
+}
+
+func isLineStart(runes []rune, index int) bool {
+	for index--; index >= 0 && runes[index] != '\n'; index-- {
+		if !unicode.IsSpace(runes[index]) {
+			return false
+		}
+	}
+
+	return true
+}
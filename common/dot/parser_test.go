@@ -0,0 +1,88 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dot
+
+import (
+	"testing"
+)
+
+func Test_Parse_EdgeChainWithAttributes_AllEdgesAreCreated(t *testing.T) {
+	// arrange
+	code := `digraph G { node [shape=box]; a -> b -> "c d" [label="x"]; }`
+
+	// act
+	graph, err := Parse(code)
+
+	// assert
+	if err != nil {
+		t.Fatalf("Parse(%q) returned an error: %s", code, err)
+	}
+
+	if !graph.Directed || graph.Name != "G" {
+		t.Errorf("The graph should be a directed graph named %q but was %#v", "G", graph)
+	}
+
+	if len(graph.Nodes) != 3 || graph.Nodes[2].ID != "c d" || graph.Nodes[2].Attributes["shape"] != "box" {
+		t.Errorf("The graph should have three boxes but had %d nodes", len(graph.Nodes))
+	}
+
+	if len(graph.Edges) != 2 || graph.Edges[1].Attributes["label"] != "x" {
+		t.Errorf("The graph should have two labeled edges but had %d edges", len(graph.Edges))
+	}
+}
+
+func Test_Parse_SubgraphsAndComments_ClustersAndRankGroupsAreCreated(t *testing.T) {
+	// arrange
+	code := `graph {
+		// a comment
+		subgraph cluster_a { label="A"; a1 -- a2 }
+		/* another
+		   comment */
+		{ rank=same; b1; b2 }
+		a2 -- { b1 b2 }
+	}`
+
+	// act
+	graph, err := Parse(code)
+
+	// assert
+	if err != nil {
+		t.Fatalf("Parse returned an error: %s", err)
+	}
+
+	if len(graph.Clusters) != 1 || graph.Clusters[0].Attributes["label"] != "A" || len(graph.Clusters[0].Nodes) != 2 {
+		t.Errorf("The graph should have one cluster with two nodes but had %#v", graph.Clusters)
+	}
+
+	if len(graph.RankGroups) != 1 || graph.RankGroups[0].Rank != "same" || len(graph.RankGroups[0].Nodes) != 2 {
+		t.Errorf("The graph should have one rank group with two nodes but had %#v", graph.RankGroups)
+	}
+
+	if len(graph.Edges) != 3 {
+		t.Errorf("The graph should have three edges but had %d", len(graph.Edges))
+	}
+}
+
+func Test_Parse_InvalidCode_ErrorIsReturned(t *testing.T) {
+	// arrange
+	inputs := []string{
+		"",
+		"digraph {",
+		"digraph { a -> }",
+		"digraph { a [label=] }",
+		"flowchart { a }",
+	}
+
+	for _, input := range inputs {
+
+		// act
+		_, err := Parse(input)
+
+		// assert
+		if err == nil {
+			t.Errorf("Parse(%q) should return an error", input)
+		}
+	}
+}
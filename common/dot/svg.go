@@ -0,0 +1,769 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dot
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	margin        = 8.0
+	lineHeight    = 1.2 // relative to the font size
+	arrowLength   = 10.0
+	arrowWidth    = 7.0
+	clusterMargin = 8.0
+)
+
+var (
+	// <br/>, <BR ALIGN="LEFT"/>
+	htmlLineBreakPattern = regexp.MustCompile(`(?i)<br[^>]*>`)
+
+	// any HTML tag
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+	// the ports of record fields (e.g. "<f0>")
+	recordPortPattern = regexp.MustCompile(`<[^>]*>`)
+)
+
+// RenderSVG parses the given dot code and returns the graph as SVG.
+func RenderSVG(code string) (string, error) {
+	graph, err := Parse(code)
+	if err != nil {
+		return "", err
+	}
+
+	return Render(graph), nil
+}
+
+// Render lays out the given graph and returns it as SVG.
+func Render(graph *Graph) string {
+
+	l := newLayout(graph)
+	drawing := newDrawing(l)
+
+	svg := new(bytes.Buffer)
+	fmt.Fprintf(svg, `<svg xmlns="http://www.w3.org/2000/svg" class="graph" width="%s" height="%s" viewBox="0 0 %s %s" font-family="Helvetica, Arial, sans-serif">`,
+		number(drawing.width), number(drawing.height), number(drawing.width), number(drawing.height))
+
+	if name := graph.Name; name != "" {
+		fmt.Fprintf(svg, "<title>%s</title>", html.EscapeString(name))
+	}
+
+	if background := getColor(graph.Attributes["bgcolor"]); background != "" {
+		fmt.Fprintf(svg, `<rect width="100%%" height="100%%" fill="%s"/>`, background)
+	}
+
+	for _, cluster := range graph.Clusters {
+		drawing.renderCluster(svg, cluster)
+	}
+
+	for _, r := range l.routes {
+		drawing.renderEdge(svg, r)
+	}
+
+	for _, node := range graph.Nodes {
+		drawing.renderNode(svg, node)
+	}
+
+	if label := getLabel(graph.Attributes["label"], graph.Name, graph); label != "" {
+		fontSize := getFontSize(graph.Attributes)
+		_, height := getLabelSize(label, fontSize)
+		renderText(svg, label, drawing.width/2, drawing.height-margin-height/2, fontSize, getColor(graph.Attributes["fontcolor"]))
+	}
+
+	svg.WriteString("</svg>")
+	return svg.String()
+}
+
+type point struct {
+	x, y float64
+}
+
+// drawing converts the layout coordinates to SVG coordinates.
+type drawing struct {
+	layout *layout
+
+	width  float64
+	height float64
+
+	// the offsets of the layout coordinates
+	minimumPosition float64
+	maximumPosition float64
+	totalDepth      float64
+
+	// the space for the cluster labels
+	top float64
+}
+
+func newDrawing(l *layout) *drawing {
+
+	d := &drawing{
+		layout:          l,
+		minimumPosition: math.Inf(1),
+		maximumPosition: math.Inf(-1),
+	}
+
+	for _, v := range l.vertices {
+		d.minimumPosition = math.Min(d.minimumPosition, v.position-v.breadth/2)
+		d.maximumPosition = math.Max(d.maximumPosition, v.position+v.breadth/2)
+		if v.hasLoops {
+			d.maximumPosition = math.Max(d.maximumPosition, v.position+v.breadth/2+selfLoopSize)
+		}
+	}
+
+	if len(l.vertices) == 0 {
+		d.minimumPosition, d.maximumPosition = 0, 0
+	}
+
+	if rankCount := len(l.rankCenters); rankCount > 0 {
+		lastDepth := 0.0
+		for _, v := range l.layers[rankCount-1] {
+			lastDepth = math.Max(lastDepth, v.depth)
+		}
+		d.totalDepth = l.rankCenters[rankCount-1] + lastDepth/2
+	}
+
+	breadth := d.maximumPosition - d.minimumPosition
+	d.width, d.height = breadth, d.totalDepth
+	if l.isHorizontal() {
+		d.width, d.height = d.totalDepth, breadth
+	}
+
+	// space for the clusters and their labels
+	if len(l.graph.Clusters) > 0 {
+		d.top = clusterMargin + getFontSize(nil)*lineHeight
+		d.width += 2 * clusterMargin
+		d.height += d.top + clusterMargin
+	}
+
+	d.width += 2 * margin
+	d.height += 2 * margin
+
+	if label := getLabel(l.graph.Attributes["label"], l.graph.Name, l.graph); label != "" {
+		labelWidth, labelHeight := getLabelSize(label, getFontSize(l.graph.Attributes))
+		d.width = math.Max(d.width, labelWidth+2*margin)
+		d.height += labelHeight + margin
+	}
+
+	return d
+}
+
+// center returns the SVG coordinates of the center of the given vertex.
+func (d *drawing) center(v *vertex) point {
+	return d.toPoint(v.position, d.layout.rankCenters[v.rank])
+}
+
+// toPoint converts the given layout coordinates to SVG coordinates.
+func (d *drawing) toPoint(position, depth float64) point {
+	breadth := position - d.minimumPosition
+	offsetX, offsetY := margin, margin+d.top
+	if len(d.layout.graph.Clusters) > 0 {
+		offsetX += clusterMargin
+	}
+
+	switch d.layout.direction {
+	case "LR":
+		return point{offsetX + depth, offsetY + breadth}
+	case "RL":
+		return point{offsetX + d.totalDepth - depth, offsetY + breadth}
+	case "BT":
+		return point{offsetX + breadth, offsetY + d.totalDepth - depth}
+	}
+
+	return point{offsetX + breadth, offsetY + depth}
+}
+
+// nodeBounds returns the center and the half width and height of the given node.
+func (d *drawing) nodeBounds(node *Node) (center point, halfWidth, halfHeight float64) {
+	width, height := getNodeSize(d.layout.graph, node)
+	return d.center(d.layout.nodeVertices[node]), width / 2, height / 2
+}
+
+func (d *drawing) renderNode(svg *bytes.Buffer, node *Node) {
+
+	attributes := node.Attributes
+	style := getStyle(attributes["style"])
+	if style["invis"] {
+		return
+	}
+
+	center, halfWidth, halfHeight := d.nodeBounds(node)
+	shape := getShape(attributes)
+
+	stroke := getColor(attributes["color"])
+	if stroke == "" {
+		stroke = "black"
+	}
+
+	fill := "none"
+	if style["filled"] {
+		fill = firstNonEmpty(getColor(attributes["fillcolor"]), getColor(attributes["color"]), "lightgrey")
+	}
+
+	if shape == "point" {
+		fill = firstNonEmpty(getColor(attributes["fillcolor"]), getColor(attributes["color"]), "black")
+	}
+
+	line := fmt.Sprintf(`fill="%s" stroke="%s"%s`, fill, stroke, getLineStyle(style, attributes["penwidth"]))
+
+	fmt.Fprintf(svg, `<g class="node"><title>%s</title>`, html.EscapeString(node.ID))
+
+	switch shape {
+	case "plaintext", "plain", "none":
+
+	case "box", "rect", "rectangle", "square", "record", "mrecord", "note", "tab", "folder", "component", "cylinder", "box3d":
+		rounded := ""
+		if style["rounded"] || shape == "mrecord" {
+			rounded = ` rx="8" ry="8"`
+		}
+
+		fmt.Fprintf(svg, `<rect x="%s" y="%s" width="%s" height="%s"%s %s/>`,
+			number(center.x-halfWidth), number(center.y-halfHeight), number(2*halfWidth), number(2*halfHeight), rounded, line)
+
+	case "diamond":
+		fmt.Fprintf(svg, `<polygon points="%s" %s/>`, points(
+			point{center.x, center.y - halfHeight},
+			point{center.x + halfWidth, center.y},
+			point{center.x, center.y + halfHeight},
+			point{center.x - halfWidth, center.y},
+		), line)
+
+	case "doublecircle":
+		fmt.Fprintf(svg, `<ellipse cx="%s" cy="%s" rx="%s" ry="%s" %s/>`, number(center.x), number(center.y), number(halfWidth), number(halfHeight), line)
+		fmt.Fprintf(svg, `<ellipse cx="%s" cy="%s" rx="%s" ry="%s" fill="none" stroke="%s"/>`, number(center.x), number(center.y), number(halfWidth-4), number(halfHeight-4), stroke)
+
+	default:
+		fmt.Fprintf(svg, `<ellipse cx="%s" cy="%s" rx="%s" ry="%s" %s/>`, number(center.x), number(center.y), number(halfWidth), number(halfHeight), line)
+	}
+
+	if shape != "point" {
+		renderText(svg, getNodeLabel(d.layout.graph, node), center.x, center.y, getFontSize(attributes), getColor(attributes["fontcolor"]))
+	}
+
+	svg.WriteString("</g>")
+}
+
+func (d *drawing) renderEdge(svg *bytes.Buffer, r *route) {
+
+	edge := r.edge
+	attributes := edge.Attributes
+	style := getStyle(attributes["style"])
+	if style["invis"] {
+		return
+	}
+
+	color := firstNonEmpty(getColor(attributes["color"]), "black")
+
+	// the arrow heads
+	direction := attributes["dir"]
+	if direction == "" {
+		direction = "none"
+		if d.layout.graph.Directed {
+			direction = "forward"
+		}
+	}
+
+	hasHead := (direction == "forward" || direction == "both") && attributes["arrowhead"] != "none"
+	hasTail := (direction == "back" || direction == "both") && attributes["arrowtail"] != "none"
+
+	var path []point
+	var labelPosition point
+
+	if edge.From == edge.To {
+		path, labelPosition = d.getLoop(edge.From)
+	} else {
+		path, labelPosition = d.getPath(r)
+	}
+
+	separator := "-&gt;"
+	if !d.layout.graph.Directed {
+		separator = "--"
+	}
+
+	fmt.Fprintf(svg, `<g class="edge"><title>%s%s%s</title>`, html.EscapeString(edge.From.ID), separator, html.EscapeString(edge.To.ID))
+
+	var arrows []string
+	if hasHead {
+		var arrow string
+		path, arrow = getArrow(path)
+		arrows = append(arrows, arrow)
+	}
+
+	if hasTail {
+		reversed := reversePoints(path)
+		var arrow string
+		reversed, arrow = getArrow(reversed)
+		path = reversePoints(reversed)
+		arrows = append(arrows, arrow)
+	}
+
+	fmt.Fprintf(svg, `<path d="%s" fill="none" stroke="%s"%s/>`, getCurve(path), color, getLineStyle(style, attributes["penwidth"]))
+
+	for _, arrow := range arrows {
+		fmt.Fprintf(svg, `<polygon points="%s" fill="%s" stroke="%s"/>`, arrow, color, color)
+	}
+
+	if label := getLabel(attributes["label"], "", d.layout.graph); label != "" {
+		renderText(svg, label, labelPosition.x, labelPosition.y, getFontSize(attributes), getColor(attributes["fontcolor"]))
+	}
+
+	svg.WriteString("</g>")
+}
+
+// getPath returns the points of the given edge from the tail to the head and the position of its label.
+func (d *drawing) getPath(r *route) ([]point, point) {
+
+	vertices := r.vertices
+	if r.reversed {
+		vertices = make([]*vertex, len(r.vertices))
+		for index, v := range r.vertices {
+			vertices[len(vertices)-1-index] = v
+		}
+	}
+
+	var path []point
+	for _, v := range vertices {
+		path = append(path, d.center(v))
+	}
+
+	// edges between non-neighboring nodes on the same rank are routed around the nodes in between
+	from, to := vertices[0], vertices[len(vertices)-1]
+	if from.rank == to.rank && math.Abs(float64(from.order-to.order)) > 1 {
+		depth := 0.0
+		for _, v := range d.layout.layers[from.rank] {
+			depth = math.Max(depth, v.depth)
+		}
+
+		apex := d.toPoint((from.position+to.position)/2, d.layout.rankCenters[from.rank]-depth/2-d.layout.rankSeparation/2)
+		path = []point{path[0], apex, path[len(path)-1]}
+	}
+
+	// start and end at the border of the nodes
+	path[0] = d.clip(r.edge.From, path[1])
+	path[len(path)-1] = d.clip(r.edge.To, path[len(path)-2])
+
+	labelPosition := point{(path[0].x + path[len(path)-1].x) / 2, (path[0].y + path[len(path)-1].y) / 2}
+	if len(path) == 3 {
+		labelPosition = path[1]
+	}
+
+	if r.labelVertex != nil {
+		labelPosition = d.center(r.labelVertex)
+	} else if label := getLabel(r.edge.Attributes["label"], "", d.layout.graph); label != "" {
+
+		// move the label next to the edge
+		width, height := getLabelSize(label, getFontSize(r.edge.Attributes))
+		if d.layout.isHorizontal() {
+			labelPosition.y -= height/2 + 2
+		} else {
+			labelPosition.x += width/2 + 4
+		}
+	}
+
+	return path, labelPosition
+}
+
+// getLoop returns the points of a loop from the given node to itself and the position of its label.
+func (d *drawing) getLoop(node *Node) ([]point, point) {
+	center, halfWidth, halfHeight := d.nodeBounds(node)
+
+	start := d.clip(node, point{center.x + halfWidth, center.y - halfHeight/2})
+	end := d.clip(node, point{center.x + halfWidth, center.y + halfHeight/2})
+	outer := center.x + halfWidth + selfLoopSize*0.8
+
+	return []point{start, {outer, start.y - 4}, {outer, end.y + 4}, end}, point{outer + 4 + selfLoopSize/2, center.y}
+}
+
+// clip returns the point where the line from the center of the given node to the given point crosses the border of the node.
+func (d *drawing) clip(node *Node, target point) point {
+
+	center, halfWidth, halfHeight := d.nodeBounds(node)
+	dx, dy := target.x-center.x, target.y-center.y
+	if dx == 0 && dy == 0 {
+		return center
+	}
+
+	var scale float64
+	switch getShape(node.Attributes) {
+	case "box", "rect", "rectangle", "square", "record", "mrecord", "note", "tab", "folder", "component", "cylinder", "box3d", "plaintext", "plain", "none":
+		scale = math.Min(halfWidth/math.Abs(dx), halfHeight/math.Abs(dy))
+	case "diamond":
+		scale = 1 / (math.Abs(dx)/halfWidth + math.Abs(dy)/halfHeight)
+	default:
+		scale = 1 / math.Sqrt(dx*dx/(halfWidth*halfWidth)+dy*dy/(halfHeight*halfHeight))
+	}
+
+	if scale > 1 {
+		return target
+	}
+
+	return point{center.x + dx*scale, center.y + dy*scale}
+}
+
+func (d *drawing) renderCluster(svg *bytes.Buffer, cluster *Cluster) {
+
+	if len(cluster.Nodes) == 0 {
+		return
+	}
+
+	attributes := cluster.Attributes
+	style := getStyle(attributes["style"])
+	if style["invis"] {
+		return
+	}
+
+	left, top, right, bottom := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, node := range cluster.Nodes {
+		center, halfWidth, halfHeight := d.nodeBounds(node)
+		left, right = math.Min(left, center.x-halfWidth), math.Max(right, center.x+halfWidth)
+		top, bottom = math.Min(top, center.y-halfHeight), math.Max(bottom, center.y+halfHeight)
+	}
+
+	label := getLabel(attributes["label"], "", d.layout.graph)
+	fontSize := getFontSize(attributes)
+	_, labelHeight := getLabelSize(label, fontSize)
+
+	left, right, bottom = left-clusterMargin, right+clusterMargin, bottom+clusterMargin
+	top -= clusterMargin + labelHeight
+
+	fill := "none"
+	if style["filled"] {
+		fill = firstNonEmpty(getColor(attributes["fillcolor"]), getColor(attributes["bgcolor"]), getColor(attributes["color"]), "lightgrey")
+	} else if background := getColor(attributes["bgcolor"]); background != "" {
+		fill = background
+	}
+
+	stroke := firstNonEmpty(getColor(attributes["pencolor"]), getColor(attributes["color"]), "black")
+
+	rounded := ""
+	if style["rounded"] {
+		rounded = ` rx="8" ry="8"`
+	}
+
+	fmt.Fprintf(svg, `<g class="cluster"><title>%s</title>`, html.EscapeString(cluster.ID))
+	fmt.Fprintf(svg, `<rect x="%s" y="%s" width="%s" height="%s"%s fill="%s" stroke="%s"%s/>`,
+		number(left), number(top), number(right-left), number(bottom-top), rounded, fill, stroke, getLineStyle(style, attributes["penwidth"]))
+
+	if label != "" {
+		renderText(svg, label, (left+right)/2, top+clusterMargin/2+labelHeight/2, fontSize, getColor(attributes["fontcolor"]))
+	}
+
+	svg.WriteString("</g>")
+}
+
+// renderText renders the lines of the given text centered at the given position.
+func renderText(svg *bytes.Buffer, text string, x, y, fontSize float64, color string) {
+	lines := strings.Split(text, "\n")
+
+	fill := ""
+	if color != "" {
+		fill = fmt.Sprintf(` fill="%s"`, color)
+	}
+
+	// the baseline of the first line
+	baseline := y - float64(len(lines)-1)*fontSize*lineHeight/2 + fontSize*0.35
+
+	fmt.Fprintf(svg, `<text text-anchor="middle" font-size="%s"%s>`, number(fontSize), fill)
+	for index, line := range lines {
+		fmt.Fprintf(svg, `<tspan x="%s" y="%s">%s</tspan>`, number(x), number(baseline+float64(index)*fontSize*lineHeight), html.EscapeString(line))
+	}
+	svg.WriteString("</text>")
+}
+
+// getArrow shortens the given path by the length of an arrow head and returns the arrow head at its end.
+func getArrow(path []point) ([]point, string) {
+	tip := path[len(path)-1]
+	previous := path[len(path)-2]
+
+	dx, dy := tip.x-previous.x, tip.y-previous.y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return path, ""
+	}
+
+	dx, dy = dx/length, dy/length
+	base := point{tip.x - dx*arrowLength, tip.y - dy*arrowLength}
+
+	shortened := append([]point(nil), path...)
+	shortened[len(shortened)-1] = base
+
+	return shortened, points(
+		tip,
+		point{base.x - dy*arrowWidth/2, base.y + dx*arrowWidth/2},
+		point{base.x + dy*arrowWidth/2, base.y - dx*arrowWidth/2},
+	)
+}
+
+// getCurve returns a smooth SVG path through the given points (Catmull-Rom spline as cubic Bézier curves).
+func getCurve(path []point) string {
+	curve := new(bytes.Buffer)
+	fmt.Fprintf(curve, "M%s,%s", number(path[0].x), number(path[0].y))
+
+	if len(path) == 2 {
+		fmt.Fprintf(curve, " L%s,%s", number(path[1].x), number(path[1].y))
+		return curve.String()
+	}
+
+	for index := 0; index+1 < len(path); index++ {
+		before, start, end, after := path[maxInt(index-1, 0)], path[index], path[index+1], path[minInt(index+2, len(path)-1)]
+
+		first := point{start.x + (end.x-before.x)/6, start.y + (end.y-before.y)/6}
+		second := point{end.x - (after.x-start.x)/6, end.y - (after.y-start.y)/6}
+
+		fmt.Fprintf(curve, " C%s,%s %s,%s %s,%s", number(first.x), number(first.y), number(second.x), number(second.y), number(end.x), number(end.y))
+	}
+
+	return curve.String()
+}
+
+// getNodeSize returns the width and height of the given node.
+func getNodeSize(graph *Graph, node *Node) (width, height float64) {
+
+	attributes := node.Attributes
+	textWidth, textHeight := getLabelSize(getNodeLabel(graph, node), getFontSize(attributes))
+
+	shape := getShape(attributes)
+	switch shape {
+	case "point":
+		width, height = 8, 8
+
+	case "plaintext", "plain", "none":
+		width, height = textWidth+8, textHeight+4
+
+	case "box", "rect", "rectangle", "square", "record", "mrecord", "note", "tab", "folder", "component", "cylinder", "box3d":
+		width, height = textWidth+16, textHeight+12
+
+	case "diamond":
+		width, height = 2*textWidth+8, 2*textHeight+4
+
+	case "circle", "doublecircle":
+		width = math.Hypot(textWidth, textHeight) + 8
+		height = width
+
+	default:
+		width, height = textWidth*math.Sqrt2+12, textHeight*math.Sqrt2+6
+	}
+
+	// the minimum size (0.75 x 0.5 inches)
+	if shape != "point" && shape != "plaintext" && shape != "plain" && shape != "none" {
+		width, height = math.Max(width, 0.75*pointsPerInch), math.Max(height, 0.5*pointsPerInch)
+	}
+
+	// explicit sizes
+	fixedSize := attributes["fixedsize"] == "true"
+	if value, err := strconv.ParseFloat(attributes["width"], 64); err == nil && value > 0 {
+		if fixedSize {
+			width = value * pointsPerInch
+		} else {
+			width = math.Max(width, value*pointsPerInch)
+		}
+	}
+
+	if value, err := strconv.ParseFloat(attributes["height"], 64); err == nil && value > 0 {
+		if fixedSize {
+			height = value * pointsPerInch
+		} else {
+			height = math.Max(height, value*pointsPerInch)
+		}
+	}
+
+	if shape == "square" || shape == "circle" || shape == "doublecircle" {
+		width = math.Max(width, height)
+		height = width
+	}
+
+	return width, height
+}
+
+// getLabelSize returns the estimated width and height of the given text.
+func getLabelSize(text string, fontSize float64) (width, height float64) {
+	if text == "" {
+		return 0, 0
+	}
+
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		width = math.Max(width, getTextWidth(line, fontSize))
+	}
+
+	return width, float64(len(lines)) * fontSize * lineHeight
+}
+
+// getTextWidth returns the estimated width of the given line of text in a proportional font.
+func getTextWidth(text string, fontSize float64) float64 {
+	width := 0.0
+	for _, character := range text {
+		switch {
+		case strings.ContainsRune("ijlI!|.,:;'", character):
+			width += 0.28
+		case strings.ContainsRune("frt ()[]-", character):
+			width += 0.36
+		case strings.ContainsRune("mwMW@", character):
+			width += 0.86
+		case character >= 'A' && character <= 'Z':
+			width += 0.68
+		case character >= 0x2E80:
+			width += 1
+		default:
+			width += 0.56
+		}
+	}
+
+	return width * fontSize
+}
+
+// getNodeLabel returns the label of the given node (the id if there is no label).
+func getNodeLabel(graph *Graph, node *Node) string {
+	label, exists := node.Attributes["label"]
+	if !exists {
+		label = `\N`
+	}
+
+	label = getLabel(label, node.ID, graph)
+
+	// record fields
+	if shape := getShape(node.Attributes); shape == "record" || shape == "mrecord" {
+		label = recordPortPattern.ReplaceAllString(label, "")
+		label = strings.NewReplacer("{", "", "}", "", "|", " | ").Replace(label)
+		label = strings.TrimSpace(label)
+	}
+
+	return label
+}
+
+// getLabel returns the text of the given label with resolved escape sequences and line breaks.
+func getLabel(label, nodeID string, graph *Graph) string {
+
+	// HTML labels
+	if graph.htmlStrings[label] {
+		label = htmlLineBreakPattern.ReplaceAllString(label[1:len(label)-1], "\n")
+		return strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(label, "")))
+	}
+
+	label = strings.NewReplacer(
+		`\N`, nodeID,
+		`\G`, graph.Name,
+		`\n`, "\n",
+		`\l`, "\n",
+		`\r`, "\n",
+		`\\`, `\`,
+	).Replace(label)
+
+	return strings.TrimRight(label, "\n")
+}
+
+func getShape(attributes map[string]string) string {
+	if shape := strings.ToLower(attributes["shape"]); shape != "" {
+		return shape
+	}
+
+	return "ellipse"
+}
+
+func getFontSize(attributes map[string]string) float64 {
+	if fontSize, err := strconv.ParseFloat(attributes["fontsize"], 64); err == nil && fontSize > 0 {
+		return fontSize
+	}
+
+	return defaultFontSize
+}
+
+// getStyle returns the given comma separated styles (e.g. "filled,dashed") as a set.
+func getStyle(style string) map[string]bool {
+	styles := make(map[string]bool)
+	for _, name := range strings.Split(style, ",") {
+		styles[strings.TrimSpace(name)] = true
+	}
+
+	return styles
+}
+
+// getLineStyle returns the SVG attributes for the given styles and pen width.
+func getLineStyle(style map[string]bool, penWidth string) string {
+	attributes := ""
+
+	switch {
+	case style["dashed"]:
+		attributes += ` stroke-dasharray="5,2"`
+	case style["dotted"]:
+		attributes += ` stroke-dasharray="1,5"`
+	}
+
+	if width, err := strconv.ParseFloat(penWidth, 64); err == nil && width >= 0 {
+		attributes += fmt.Sprintf(` stroke-width="%s"`, number(width))
+	} else if style["bold"] {
+		attributes += ` stroke-width="2"`
+	}
+
+	return attributes
+}
+
+// getColor returns the first of the given colors (e.g. "red:blue") as an SVG color.
+func getColor(color string) string {
+	color = strings.TrimSpace(strings.Split(color, ":")[0])
+	if color == "" {
+		return ""
+	}
+
+	// Graphviz allows an alpha channel (#rrggbbaa) which is not supported everywhere
+	if strings.HasPrefix(color, "#") && len(color) == 9 {
+		color = color[:7]
+	}
+
+	return html.EscapeString(strings.Split(color, ";")[0])
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func points(list ...point) string {
+	values := make([]string, 0, len(list))
+	for _, p := range list {
+		values = append(values, number(p.x)+","+number(p.y))
+	}
+
+	return strings.Join(values, " ")
+}
+
+func reversePoints(path []point) []point {
+	reversed := make([]point, len(path))
+	for index, p := range path {
+		reversed[len(path)-1-index] = p
+	}
+
+	return reversed
+}
+
+// number formats the given coordinate with at most two decimal places.
+func number(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,78 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dot
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_RenderSVG_DirectedGraph_NodesEdgesAndArrowsAreRendered(t *testing.T) {
+	// arrange
+	code := `digraph { a -> b; b -> c; c -> a; a [label="<Start>"] }`
+
+	// act
+	svg, err := RenderSVG(code)
+
+	// assert
+	if err != nil {
+		t.Fatalf("RenderSVG returned an error: %s", err)
+	}
+
+	if !strings.HasPrefix(svg, "<svg ") || !strings.HasSuffix(svg, "</svg>") {
+		t.Errorf("The result should be an SVG image but was %q", svg)
+	}
+
+	if count := strings.Count(svg, `<g class="node">`); count != 3 {
+		t.Errorf("The image should contain 3 nodes but contained %d", count)
+	}
+
+	if count := strings.Count(svg, "<polygon"); count != 3 {
+		t.Errorf("The image should contain 3 arrow heads but contained %d", count)
+	}
+
+	if !strings.Contains(svg, "&lt;Start&gt;") {
+		t.Errorf("The label should be escaped: %s", svg)
+	}
+}
+
+func Test_Render_SameGraph_OutputIsDeterministic(t *testing.T) {
+	// arrange
+	code := `digraph { rankdir=LR; subgraph cluster_x { a; b } a -> b -> c; a -> c [label="skip"]; d -> d }`
+	expected, _ := RenderSVG(code)
+
+	for i := 0; i < 5; i++ {
+
+		// act
+		result, _ := RenderSVG(code)
+
+		// assert
+		if result != expected {
+			t.Fatalf("The output should be the same for the same graph")
+		}
+	}
+}
+
+func Test_newLayout_EdgesAcrossRanks_NodesOfOneRankDoNotOverlap(t *testing.T) {
+	// arrange
+	graph, _ := Parse(`digraph { a -> {b c d e}; b -> f; c -> f; d -> g; e -> g; a -> g }`)
+
+	// act
+	l := newLayout(graph)
+
+	// assert
+	for rank, layer := range l.layers {
+		for index := 1; index < len(layer); index++ {
+			left, right := layer[index-1], layer[index]
+			if right.position-left.position < left.breadth/2+right.breadth/2 {
+				t.Errorf("The vertices %d and %d of rank %d overlap", index-1, index, rank)
+			}
+		}
+	}
+
+	if l.nodeVertices[graph.Nodes[0]].rank != 0 || l.nodeVertices[graph.Nodes[6]].rank != 2 {
+		t.Errorf("The nodes should be ranked by the longest path")
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package highlight renders source code as HTML with CSS classes for syntax highlighting.
+//
+// The classes are compatible with highlight.js style sheets (e.g. "hljs-keyword").
+// Line numbers and highlighted lines can be enabled with options which are
+// usually taken from the info string of a fenced code block:
+//
+//	```go {linenos=true hl_lines=[2,"4-6"] linenostart=10}
+package highlight
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// linenos, hl_lines=[1,"3-4"], linenostart=10
+	optionPattern = regexp.MustCompile(`([A-Za-z_][\w-]*)(?:\s*=\s*("[^"]*"|'[^']*'|\[[^\]]*\]|[^\s,}]+(?:,\d[^\s,}]*)*))?`)
+)
+
+// Options define how a block of code is rendered.
+type Options struct {
+	LineNumbers      bool
+	FirstLineNumber  int
+	HighlightedLines []LineRange
+}
+
+// A LineRange is a range of line numbers (e.g. 4-6).
+type LineRange struct {
+	From int
+	To   int
+}
+
+func (lineRange LineRange) String() string {
+	if lineRange.From == lineRange.To {
+		return strconv.Itoa(lineRange.From)
+	}
+
+	return fmt.Sprintf("%d-%d", lineRange.From, lineRange.To)
+}
+
+// IsEmpty returns true if the options do not change the rendering of the code.
+func (options Options) IsEmpty() bool {
+	return !options.LineNumbers && options.FirstLineNumber <= 1 && len(options.HighlightedLines) == 0
+}
+
+// IsHighlighted returns true if the line with the given number (starting with 1) is highlighted.
+func (options Options) IsHighlighted(line int) bool {
+	for _, lineRange := range options.HighlightedLines {
+		if line >= lineRange.From && line <= lineRange.To {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the options as space-separated words without spaces
+// (e.g. "linenos linenostart=10 hl_lines=2,4-6").
+func (options Options) String() string {
+	var values []string
+	if options.LineNumbers {
+		values = append(values, "linenos")
+	}
+
+	if options.FirstLineNumber > 1 {
+		values = append(values, fmt.Sprintf("linenostart=%d", options.FirstLineNumber))
+	}
+
+	if len(options.HighlightedLines) > 0 {
+		ranges := make([]string, 0, len(options.HighlightedLines))
+		for _, lineRange := range options.HighlightedLines {
+			ranges = append(ranges, lineRange.String())
+		}
+
+		values = append(values, "hl_lines="+strings.Join(ranges, ","))
+	}
+
+	return strings.Join(values, " ")
+}
+
+// ParseInfo returns the language and the options of the given info string of a
+// fenced code block (e.g. `go {linenos=true, hl_lines=[2,"4-6"]}` or `go linenos hl_lines=2,4-6`).
+func ParseInfo(info string) (language string, options Options) {
+
+	info = strings.TrimSpace(info)
+	if !strings.HasPrefix(info, "{") {
+		fields := strings.Fields(info)
+		if len(fields) == 0 {
+			return "", options
+		}
+
+		language = fields[0]
+		info = strings.TrimSpace(info[len(language):])
+	}
+
+	for _, match := range optionPattern.FindAllStringSubmatch(strings.Trim(info, "{}"), -1) {
+		key, value := strings.ToLower(match[1]), strings.Trim(match[2], `"'`)
+
+		switch key {
+		case "linenos", "linenumbers", "numberlines":
+			options.LineNumbers = value != "false"
+
+		case "linenostart", "start":
+			if number, err := strconv.Atoi(value); err == nil && number > 0 {
+				options.FirstLineNumber = number
+			}
+
+		case "hl_lines", "highlight", "hl":
+			options.HighlightedLines = append(options.HighlightedLines, parseLineRanges(value)...)
+
+		default:
+			// the language in the braces (e.g. "{go linenos}")
+			if language == "" && value == "" {
+				language = match[1]
+			}
+		}
+	}
+
+	return language, options
+}
+
+// parseLineRanges parses line ranges separated by commas or spaces (e.g. `[2,"4-6"]` or "2 4-6").
+func parseLineRanges(value string) []LineRange {
+	var ranges []LineRange
+
+	value = strings.NewReplacer("[", "", "]", "", `"`, "", "'", "").Replace(value)
+	for _, field := range strings.FieldsFunc(value, func(character rune) bool { return character == ',' || character == ' ' }) {
+		bounds := strings.SplitN(field, "-", 2)
+
+		from, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			continue
+		}
+
+		to := from
+		if len(bounds) == 2 {
+			if to, err = strconv.Atoi(bounds[1]); err != nil || to < from {
+				continue
+			}
+		}
+
+		ranges = append(ranges, LineRange{from, to})
+	}
+
+	return ranges
+}
+
+// Highlight returns the given code as HTML (without the surrounding pre and code elements).
+// Code in unknown languages is only escaped.
+func Highlight(code, languageName string, options Options) string {
+
+	var tokens []token
+	if lang, exists := languages[GetLanguage(languageName)]; exists {
+		tokens = lang.tokenize(code)
+	} else {
+		tokens = []token{{classPlain, code}}
+	}
+
+	result := new(bytes.Buffer)
+	if options.IsEmpty() {
+		for _, t := range tokens {
+			writeToken(result, t)
+		}
+
+		return result.String()
+	}
+
+	// render every line separately so the line numbers and highlighted lines can be styled
+	lines := splitLines(tokens)
+	firstLineNumber := options.FirstLineNumber
+	if firstLineNumber < 1 {
+		firstLineNumber = 1
+	}
+
+	width := len(strconv.Itoa(firstLineNumber + len(lines) - 1))
+	for index, line := range lines {
+		class := "line"
+		if options.IsHighlighted(index + 1) {
+			class += " highlighted"
+		}
+
+		fmt.Fprintf(result, `<span class="%s">`, class)
+		if options.LineNumbers {
+			fmt.Fprintf(result, `<span class="line-number">%*d</span>`, width, firstLineNumber+index)
+		}
+
+		for _, t := range line {
+			writeToken(result, t)
+		}
+
+		result.WriteString("</span>\n")
+	}
+
+	return result.String()
+}
+
+// splitLines splits the given tokens into lines. Tokens which span several lines are split.
+// A line break at the end of the code does not start a new line.
+func splitLines(tokens []token) [][]token {
+	lines := [][]token{nil}
+	for _, t := range tokens {
+		parts := strings.Split(t.text, "\n")
+		for index, part := range parts {
+			if index > 0 {
+				lines = append(lines, nil)
+			}
+
+			if part != "" {
+				lines[len(lines)-1] = append(lines[len(lines)-1], token{t.class, part})
+			}
+		}
+	}
+
+	if len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func writeToken(writer *bytes.Buffer, t token) {
+	if t.text == "" {
+		return
+	}
+
+	if t.class == classPlain {
+		writer.WriteString(html.EscapeString(t.text))
+		return
+	}
+
+	fmt.Fprintf(writer, `<span class="hljs-%s">%s</span>`, t.class, html.EscapeString(t.text))
+}
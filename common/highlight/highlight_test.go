@@ -0,0 +1,87 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package highlight
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ParseInfo_InfoStrings_LanguageAndOptionsAreReturned(t *testing.T) {
+	// arrange
+	inputs := map[string]Options{
+		`go {linenos=true, hl_lines=[2,"4-6"], linenostart=10}`: {true, 10, []LineRange{{2, 2}, {4, 6}}},
+		`go linenos hl_lines=2,4-6`:                             {true, 0, []LineRange{{2, 2}, {4, 6}}},
+		`{go hl_lines="2 4-6" linenos=false}`:                   {false, 0, []LineRange{{2, 2}, {4, 6}}},
+		`go`:                                                    {},
+	}
+
+	for input, expected := range inputs {
+
+		// act
+		language, options := ParseInfo(input)
+
+		// assert
+		if language != "go" || !reflect.DeepEqual(options, expected) {
+			t.Errorf("ParseInfo(%q) should return %q and %#v but returned %q and %#v", input, "go", expected, language, options)
+		}
+	}
+}
+
+func Test_Highlight_GoCode_TokensAreWrappedInSpans(t *testing.T) {
+	// arrange
+	code := `func main() { return "<a>" } // done`
+	expected := `<span class="hljs-keyword">func</span> <span class="hljs-title">main</span>() { ` +
+		`<span class="hljs-keyword">return</span> <span class="hljs-string">&#34;&lt;a&gt;&#34;</span> } ` +
+		`<span class="hljs-comment">// done</span>`
+
+	// act
+	result := Highlight(code, "golang", Options{})
+
+	// assert
+	if result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
+
+func Test_Highlight_LineNumbersAndHighlightedLines_EveryLineIsWrapped(t *testing.T) {
+	// arrange
+	code := "/* a\nb */\nx\n"
+	options := Options{LineNumbers: true, FirstLineNumber: 9, HighlightedLines: []LineRange{{2, 2}}}
+	expected := `<span class="line"><span class="line-number"> 9</span><span class="hljs-comment">/* a</span></span>` + "\n" +
+		`<span class="line highlighted"><span class="line-number">10</span><span class="hljs-comment">b */</span></span>` + "\n" +
+		`<span class="line"><span class="line-number">11</span>x</span>` + "\n"
+
+	// act
+	result := Highlight(code, "c", options)
+
+	// assert
+	if result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
+
+func Test_GetLanguageFromFilename_FileNames_CanonicalLanguageIsReturned(t *testing.T) {
+	// arrange
+	inputs := map[string]string{
+		"files/main.go":     "go",
+		"files/script.PY":   "python",
+		"files/Makefile":    "makefile",
+		"files/config.yml":  "yaml",
+		"files/readme":      "",
+		"files/archive.xyz": "xyz",
+	}
+
+	for input, expected := range inputs {
+
+		// act
+		result := GetLanguageFromFilename(input)
+
+		// assert
+		if result != expected {
+			t.Errorf("GetLanguageFromFilename(%q) should return %q but returned %q", input, expected, result)
+		}
+	}
+}
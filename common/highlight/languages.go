@@ -0,0 +1,447 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package highlight
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	classID     = "id"
+	classPseudo = "pseudo"
+)
+
+// rules which are shared by many languages
+var (
+	slashComment = newRule(`//[^\n]*`, classComment)
+	blockComment = newRule(`(?s)/\*.*?(?:\*/|$)`, classComment)
+	hashComment  = newRule(`#[^\n]*`, classComment)
+
+	doubleQuotedString = newRule(`"(?:[^"\\\n]|\\.)*"?`, classString)
+	singleQuotedString = newRule(`'(?:[^'\\\n]|\\.)*'?`, classString)
+	tripleQuotedString = newRule(`(?s)""".*?(?:"""|$)`, classString)
+	backtickString     = newRule("(?s)`(?:[^`\\\\]|\\\\.)*`?", classString)
+
+	number = newRule(`0[xX][0-9a-fA-F_]+|0[bBoO][0-7_]+|(?:\d[\d_]*(?:\.\d[\d_]*)?|\.\d[\d_]*)(?:[eE][+-]?\d+)?[a-zA-Z]*`, classNumber)
+
+	annotation = newRule(`@[\pL_][\pL\pN_.]*`, classDecorator)
+)
+
+// languages contains the supported languages by their canonical name.
+var languages = map[string]*language{
+
+	"go": {
+		rules: []rule{slashComment, blockComment, doubleQuotedString, singleQuotedString, backtickString, number},
+		keywords: words(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var`),
+		literals: words(`true false nil iota`),
+		types: words(`bool byte complex64 complex128 error float32 float64 int int8 int16 int32 int64
+			rune string uint uint8 uint16 uint32 uint64 uintptr any comparable`),
+		builtIns:      words(`append cap clear close complex copy delete imag len make max min new panic print println real recover`),
+		titleKeywords: words(`func type`),
+	},
+
+	"c": {
+		rules: []rule{newLineRule(`[ \t]*#(?:[^\n\\]|\\.)*`, classMeta), slashComment, blockComment, doubleQuotedString, singleQuotedString, number},
+		keywords: words(`auto break case char const continue default do double else enum extern float for goto if
+			inline int long register restrict return short signed sizeof static struct switch typedef union
+			unsigned void volatile while _Bool _Complex`),
+		literals:      words(`true false NULL`),
+		types:         words(`size_t ssize_t ptrdiff_t int8_t int16_t int32_t int64_t uint8_t uint16_t uint32_t uint64_t bool FILE`),
+		builtIns:      words(`printf fprintf sprintf snprintf scanf malloc calloc realloc free memcpy memset strlen strcmp strcpy fopen fclose exit`),
+		titleKeywords: words(`struct enum union`),
+	},
+
+	"cpp": {
+		rules: []rule{newLineRule(`[ \t]*#(?:[^\n\\]|\\.)*`, classMeta), slashComment, blockComment, doubleQuotedString, singleQuotedString, number},
+		keywords: words(`alignas alignof and asm auto bool break case catch char char16_t char32_t class concept const
+			consteval constexpr const_cast continue co_await co_return co_yield decltype default delete do double
+			dynamic_cast else enum explicit export extern final float for friend goto if inline int long mutable
+			namespace new noexcept not operator or override private protected public register reinterpret_cast
+			requires return short signed sizeof static static_assert static_cast struct switch template this
+			thread_local throw try typedef typeid typename union unsigned using virtual void volatile wchar_t while xor`),
+		literals:      words(`true false nullptr NULL`),
+		types:         words(`size_t int8_t int16_t int32_t int64_t uint8_t uint16_t uint32_t uint64_t`),
+		builtIns:      words(`std string vector map set unordered_map unique_ptr shared_ptr make_unique make_shared cout cin cerr endl printf`),
+		titleKeywords: words(`class struct enum union namespace`),
+	},
+
+	"csharp": {
+		rules: []rule{newLineRule(`[ \t]*#[^\n]*`, classMeta), slashComment, blockComment, newRule(`@"(?:[^"]|"")*"?`, classString),
+			doubleQuotedString, singleQuotedString, number},
+		keywords: words(`abstract as base break case catch checked class const continue default delegate do else enum
+			event explicit extern finally fixed for foreach goto if implicit in interface internal is lock namespace
+			new operator out override params private protected public readonly record ref return sealed sizeof
+			stackalloc static struct switch this throw try typeof unchecked unsafe using var virtual void volatile
+			while async await get set value yield where when init`),
+		literals:      words(`true false null`),
+		types:         words(`bool byte char decimal double dynamic float int long object sbyte short string uint ulong ushort`),
+		builtIns:      words(`Console String Math List Dictionary Task Exception`),
+		titleKeywords: words(`class interface struct enum record namespace`),
+	},
+
+	"java": {
+		rules: []rule{slashComment, blockComment, annotation, tripleQuotedString, doubleQuotedString, singleQuotedString, number},
+		keywords: words(`abstract assert break case catch class const continue default do else enum extends final
+			finally for goto if implements import instanceof interface native new package permits private protected
+			public record return sealed static strictfp super switch synchronized this throw throws transient try
+			var void volatile while yield`),
+		literals:      words(`true false null`),
+		types:         words(`boolean byte char double float int long short`),
+		builtIns:      words(`System String Object Integer Long Double Boolean Math List Map Set ArrayList HashMap Exception`),
+		titleKeywords: words(`class interface enum record`),
+	},
+
+	"javascript": {
+		rules: []rule{slashComment, blockComment, doubleQuotedString, singleQuotedString, backtickString, number},
+		keywords: words(`as async await break case catch class const continue debugger default delete do else export
+			extends finally for from function get if import in instanceof let new of return set static super switch
+			this throw try typeof var void while with yield`),
+		literals: words(`true false null undefined NaN Infinity`),
+		builtIns: words(`Array Boolean Date Error JSON Map Math Number Object Promise RegExp Set String Symbol
+			console document window require module exports process setTimeout setInterval parseInt parseFloat`),
+		titleKeywords: words(`function class`),
+	},
+
+	"typescript": {
+		rules: []rule{slashComment, blockComment, annotation, doubleQuotedString, singleQuotedString, backtickString, number},
+		keywords: words(`abstract as async await break case catch class const continue debugger declare default delete
+			do else enum export extends finally for from function get if implements import in infer instanceof
+			interface is keyof let module namespace new of private protected public readonly return set static
+			super switch this throw try type typeof var void while with yield`),
+		literals: words(`true false null undefined NaN Infinity`),
+		types:    words(`any bigint boolean never number object string symbol unknown void`),
+		builtIns: words(`Array Boolean Date Error JSON Map Math Number Object Promise RegExp Set String Symbol
+			console document window require module exports process`),
+		titleKeywords: words(`function class interface type enum namespace`),
+	},
+
+	"json": {
+		rules: []rule{
+			newRule(`("(?:[^"\\\n]|\\.)*")(\s*:)`, classAttribute, classPlain),
+			doubleQuotedString,
+			number,
+		},
+		literals: words(`true false null`),
+	},
+
+	"python": {
+		rules: []rule{
+			hashComment,
+			annotation,
+			newRule(`(?s)[rRbBuUfF]{0,2}(?:""".*?(?:"""|$)|'''.*?(?:'''|$))`, classString),
+			newRule(`[rRbBuUfF]{0,2}(?:"(?:[^"\\\n]|\\.)*"?|'(?:[^'\\\n]|\\.)*'?)`, classString),
+			number,
+		},
+		keywords: words(`and as assert async await break case class continue def del elif else except finally for
+			from global if import in is lambda match nonlocal not or pass raise return try while with yield`),
+		literals: words(`True False None`),
+		builtIns: words(`abs all any bool bytes callable dict enumerate filter float format getattr hasattr input int
+			isinstance iter len list map max min next object open print range repr reversed self set setattr
+			sorted str sum super tuple type zip`),
+		titleKeywords: words(`def class`),
+	},
+
+	"ruby": {
+		rules: []rule{
+			newLineRule(`(?s)=begin.*?(?:\n=end[^\n]*|$)`, classComment),
+			hashComment,
+			doubleQuotedString,
+			singleQuotedString,
+			newRule(`:[\pL_][\pL\pN_]*[?!]?`, classSymbol),
+			newRule(`@@?[\pL_][\pL\pN_]*|\$[\pL_][\pL\pN_]*`, classVariable),
+			number,
+		},
+		identifier: regexp.MustCompile(`^[\pL_][\pL\pN_]*[?!]?`),
+		keywords: words(`alias and begin break case class def defined? do else elsif end ensure for if in module next
+			not or redo rescue retry return self super then undef unless until when while yield`),
+		literals:      words(`true false nil`),
+		builtIns:      words(`attr_accessor attr_reader attr_writer extend include lambda p print private proc protected public puts raise require require_relative`),
+		titleKeywords: words(`def class module`),
+	},
+
+	"php": {
+		rules: []rule{
+			newRule(`<\?(?:php)?|\?>`, classMeta),
+			slashComment,
+			hashComment,
+			blockComment,
+			newRule(`\$[\pL_][\pL\pN_]*`, classVariable),
+			doubleQuotedString,
+			singleQuotedString,
+			number,
+		},
+		keywords: words(`abstract and array as break callable case catch class clone const continue declare default do
+			echo else elseif empty enddeclare endfor endforeach endif endswitch endwhile enum extends final finally
+			fn for foreach function global goto if implements include include_once instanceof insteadof interface
+			isset list match namespace new or print private protected public readonly require require_once return
+			static switch throw trait try unset use var while xor yield`),
+		literals:        words(`true false null`),
+		caseInsensitive: true,
+		titleKeywords:   words(`function class interface trait enum`),
+	},
+
+	"bash": {
+		rules: []rule{
+			newLineRule(`#![^\n]*`, classShebang),
+			hashComment,
+			newRule(`\$\{[^}\n]*\}|\$[A-Za-z_]\w*|\$[0-9@*#?$!-]`, classVariable),
+			doubleQuotedString,
+			newRule(`'[^']*'?`, classString),
+			number,
+		},
+		identifier: regexp.MustCompile(`^[A-Za-z_][\w-]*`),
+		keywords: words(`if then else elif fi case esac for select while until do done in function time return break
+			continue local export readonly declare typeset unset shift exit source alias`),
+		builtIns:      words(`echo printf cd pwd read test eval exec set trap wait kill getopts ulimit umask true false`),
+		titleKeywords: words(`function`),
+	},
+
+	"sql": {
+		rules: []rule{
+			newRule(`--[^\n]*`, classComment),
+			blockComment,
+			newRule(`'(?:[^']|'')*'?`, classString),
+			doubleQuotedString,
+			number,
+		},
+		keywords: words(`add all alter and as asc begin between by cascade case check column commit constraint create
+			cross database declare default delete desc distinct drop else end exists foreign from full function grant
+			group having if in index inner insert into is join key left like limit natural not offset on or order
+			outer primary procedure references replace return returning revoke right rollback schema select set
+			table then transaction trigger truncate union unique update using values view when where with`),
+		literals: words(`null true false`),
+		types: words(`bigint blob boolean char date datetime decimal double float int integer json numeric real
+			serial smallint text time timestamp tinyint uuid varchar`),
+		builtIns:        words(`avg cast coalesce concat count length lower max min now round substring sum upper`),
+		caseInsensitive: true,
+	},
+
+	"xml": {
+		rules: []rule{
+			newRule(`(?s)<!--.*?(?:-->|$)`, classComment),
+			newRule(`(?s)<!\[CDATA\[.*?(?:\]\]>|$)`, classString),
+			newRule(`<[!?][^>]*>?`, classMeta),
+			{pattern: regexp.MustCompile(`^</?[\pL_][\w:.-]*(?:[^<>"']|"[^"]*"|'[^']*')*>?`), split: splitTag},
+		},
+		identifier: regexp.MustCompile(`^[^<\s]+`),
+	},
+
+	"css": {
+		rules: []rule{
+			blockComment,
+			doubleQuotedString,
+			singleQuotedString,
+			newRule(`@[\w-]+`, classKeyword),
+			{pattern: regexp.MustCompile(`^[\w-]+[ \t]*:[^{};\n]*;`), split: splitDeclaration},
+			newRule(`#[\w-]+`, classID),
+			newRule(`::?[\w-]+`, classPseudo),
+			number,
+		},
+		identifier: regexp.MustCompile(`^-?[\pL_][\w-]*`),
+	},
+
+	"yaml": {
+		rules: []rule{
+			newLineRule(`---|\.\.\.`, classMeta),
+			newLineRule(`([ \t]*(?:- )*)([^\s:#'"\-\[\]{}][^:#\n]*?)(\s*:)(?:[ \t]|\n|$)`, classPlain, classAttribute, classPlain),
+			hashComment,
+			doubleQuotedString,
+			newRule(`'(?:[^']|'')*'?`, classString),
+			newRule(`[&*][\w-]+`, classVariable),
+			newRule(`!!?[\w-]+`, classType),
+			number,
+		},
+		literals:        words(`true false null yes no on off`),
+		caseInsensitive: true,
+	},
+
+	"ini": {
+		rules: []rule{
+			newLineRule(`[ \t]*[;#][^\n]*`, classComment),
+			newLineRule(`[ \t]*\[[^\]\n]*\]`, classTitle),
+			newLineRule(`([ \t]*)([\w.\-"]+)(\s*[=:])`, classPlain, classAttribute, classPlain),
+			tripleQuotedString,
+			doubleQuotedString,
+			singleQuotedString,
+			number,
+		},
+		literals:        words(`true false on off yes no`),
+		caseInsensitive: true,
+	},
+
+	"diff": {
+		rules: []rule{
+			newLineRule(`(?:\+\+\+|---|diff |index )[^\n]*`, classHeader),
+			newLineRule(`@@[^\n]*`, classMeta),
+			newLineRule(`\+[^\n]*`, classAddition),
+			newLineRule(`-[^\n]*`, classDeletion),
+		},
+	},
+
+	"rust": {
+		rules: []rule{
+			slashComment,
+			blockComment,
+			newRule(`#!?\[[^\]\n]*\]`, classMeta),
+			newRule(`(?s)b?r#*".*?(?:"#*|$)`, classString),
+			newRule(`b?"(?s:(?:[^"\\]|\\.)*)"?`, classString),
+			newRule(`b?'(?:[^'\\\n]|\\[^\n][^'\n]*)'`, classString),
+			newRule(`'[\pL_][\pL\pN_]*`, classSymbol),
+			newRule(`([\pL_][\pL\pN_]*!)\s*[(\[{]`, classBuiltIn),
+			number,
+		},
+		keywords: words(`as async await break const continue crate dyn else enum extern fn for if impl in let loop
+			match mod move mut pub ref return self Self static struct super trait type unsafe use where while`),
+		literals: words(`true false`),
+		types: words(`bool char f32 f64 i8 i16 i32 i64 i128 isize str u8 u16 u32 u64 u128 usize
+			Box Option Result String Vec`),
+		builtIns:      words(`Some None Ok Err`),
+		titleKeywords: words(`fn struct enum trait mod type`),
+	},
+
+	"makefile": {
+		rules: []rule{
+			hashComment,
+			newRule(`\$[({][^)}\n]*[)}]|\$[\w@<^+*?%]`, classVariable),
+			newLineRule(`([\w.-]+)(\s*[:+?!]?=)`, classVariable, classPlain),
+			newLineRule(`([^\s:=#][^:=#\n]*)(:)(?:[ \t]|\n|$)`, classTitle, classPlain),
+			doubleQuotedString,
+			singleQuotedString,
+		},
+		identifier: regexp.MustCompile(`^[\w.-]+`),
+		keywords:   words(`define endef else endif export ifdef ifeq ifndef ifneq include override unexport .PHONY`),
+	},
+
+	"dockerfile": {
+		rules: []rule{
+			newLineRule(`[ \t]*#[^\n]*`, classComment),
+			newLineRule(`(?i)([ \t]*)(FROM|RUN|CMD|LABEL|MAINTAINER|EXPOSE|ENV|ADD|COPY|ENTRYPOINT|VOLUME|USER|WORKDIR|ARG|ONBUILD|STOPSIGNAL|HEALTHCHECK|SHELL)\b`, classPlain, classKeyword),
+			newRule(`\$\{[^}\n]*\}|\$[A-Za-z_]\w*`, classVariable),
+			doubleQuotedString,
+			singleQuotedString,
+		},
+		keywords: words(`AS as`),
+	},
+
+	"markdown": {
+		rules: []rule{
+			newLineRule(`#{1,6}[ \t][^\n]*|[^\n]+\n(?:=+|-+)[ \t]*(?:\n|$)`, classHeader),
+			newRule("`[^`\n]*`", classString),
+			newRule(`\[[^\]\n]*\]\([^)\n]*\)`, classString),
+			newRule(`\*\*[^*\n]+\*\*|__[^_\n]+__`, classKeyword),
+		},
+	},
+}
+
+// aliases maps the names and file extensions of the supported languages to their canonical names.
+var aliases = map[string]string{
+	"golang": "go",
+	"h":      "c",
+	"c++":    "cpp", "cc": "cpp", "cxx": "cpp", "hpp": "cpp", "hh": "cpp", "hxx": "cpp",
+	"cs": "csharp", "c#": "csharp",
+	"js": "javascript", "jsx": "javascript", "mjs": "javascript", "cjs": "javascript", "node": "javascript",
+	"ts": "typescript", "tsx": "typescript",
+	"py": "python", "python3": "python", "py3": "python", "pyw": "python",
+	"rb": "ruby", "gemspec": "ruby", "rake": "ruby",
+	"php3": "php", "php4": "php", "php5": "php", "phtml": "php",
+	"sh": "bash", "shell": "bash", "zsh": "bash", "ksh": "bash", "console": "bash",
+	"mysql": "sql", "pgsql": "sql", "postgresql": "sql", "sqlite": "sql", "plsql": "sql",
+	"html": "xml", "htm": "xml", "xhtml": "xml", "svg": "xml", "xsd": "xml", "xsl": "xml", "xslt": "xml",
+	"rss": "xml", "atom": "xml", "plist": "xml", "csproj": "xml",
+	"scss": "css", "less": "css",
+	"yml":  "yaml",
+	"toml": "ini", "cfg": "ini", "conf": "ini", "properties": "ini", "editorconfig": "ini", "gitconfig": "ini",
+	"patch": "diff",
+	"rs":    "rust",
+	"make":  "makefile", "mk": "makefile", "mak": "makefile", "gnumakefile": "makefile",
+	"docker": "dockerfile",
+	"md":     "markdown", "mdown": "markdown", "mkd": "markdown",
+}
+
+// GetLanguage returns the canonical name of the given language name or file extension
+// (e.g. "go" for "golang" or ".go"). Unknown languages are returned in lower case.
+func GetLanguage(name string) string {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "."))
+	if canonicalName, exists := aliases[name]; exists {
+		return canonicalName
+	}
+
+	return name
+}
+
+// GetLanguageFromFilename returns the language of the file with the given name (e.g. "go" for "main.go").
+func GetLanguageFromFilename(filename string) string {
+	base := strings.ToLower(filepath.Base(filename))
+	if _, exists := languages[GetLanguage(base)]; exists && !strings.Contains(base, ".") {
+		return GetLanguage(base)
+	}
+
+	return GetLanguage(filepath.Ext(base))
+}
+
+// IsSupported returns true if the given language (or one of its aliases) can be highlighted.
+func IsSupported(name string) bool {
+	_, exists := languages[GetLanguage(name)]
+	return exists
+}
+
+var (
+	tagStartPattern     = regexp.MustCompile(`^</?`)
+	tagNamePattern      = regexp.MustCompile(`^[\pL_][\w:.-]*`)
+	tagAttributePattern = regexp.MustCompile(`^([^\s=/>"']+)(?:(\s*=\s*)("[^"]*"?|'[^']*'?|[^\s>]*))?`)
+	tagEndPattern       = regexp.MustCompile(`^/?>`)
+)
+
+// splitTag splits an XML or HTML tag (e.g. <a href="#">) into tokens.
+func splitTag(tag string) []token {
+	start := tagStartPattern.FindString(tag)
+	name := tagNamePattern.FindString(tag[len(start):])
+	tokens := []token{{classTag, start}, {classTitle, name}}
+
+	rest := tag[len(start)+len(name):]
+	for len(rest) > 0 {
+		if end := tagEndPattern.FindString(rest); end != "" {
+			tokens = append(tokens, token{classTag, end})
+			rest = rest[len(end):]
+			continue
+		}
+
+		if match := tagAttributePattern.FindStringSubmatch(rest); match != nil && match[0] != "" {
+			tokens = append(tokens, token{classAttribute, match[1]}, token{classPlain, match[2]}, token{classString, match[3]})
+			rest = rest[len(match[0]):]
+			continue
+		}
+
+		tokens = append(tokens, token{classPlain, rest[:1]})
+		rest = rest[1:]
+	}
+
+	return tokens
+}
+
+// cssValue is used for the values of CSS declarations.
+var cssValue = &language{
+	rules: []rule{
+		blockComment,
+		doubleQuotedString,
+		singleQuotedString,
+		newRule(`#[0-9a-fA-F]{3,8}\b`, classHexColor),
+		newRule(`!important`, classKeyword),
+		newRule(`-?(?:\d+(?:\.\d+)?|\.\d+)(?:%|[a-zA-Z]+)?`, classNumber),
+	},
+	identifier: regexp.MustCompile(`^[\pL_-][\w-]*`),
+}
+
+// splitDeclaration splits a CSS declaration (e.g. "color: red;") into tokens.
+func splitDeclaration(declaration string) []token {
+	colon := strings.Index(declaration, ":")
+	property := strings.TrimRight(declaration[:colon], " \t")
+
+	tokens := []token{{classAttribute, property}, {classPlain, declaration[len(property) : colon+1]}}
+	return append(tokens, cssValue.tokenize(declaration[colon+1:])...)
+}
@@ -0,0 +1,223 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package highlight
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// The token classes. They are rendered as CSS classes with the prefix "hljs-"
+// so the highlight.js style sheets of existing themes can be used.
+const (
+	classPlain      = ""
+	classKeyword    = "keyword"
+	classLiteral    = "literal"
+	classBuiltIn    = "built_in"
+	classType       = "type"
+	classTitle      = "title"
+	classString     = "string"
+	classNumber     = "number"
+	classComment    = "comment"
+	classMeta       = "preprocessor"
+	classDecorator  = "decorator"
+	classVariable   = "variable"
+	classRegexp     = "regexp"
+	classTag        = "tag"
+	classAttribute  = "attribute"
+	classValue      = "value"
+	classAddition   = "addition"
+	classDeletion   = "deletion"
+	classHeader     = "header"
+	classShebang    = "shebang"
+	classSymbol     = "symbol"
+	classHexColor   = "hexcolor"
+	classSubstitute = "subst"
+)
+
+var defaultIdentifierPattern = regexp.MustCompile(`^[\pL_][\pL\pN_]*`)
+
+// A token is a piece of code with a class (e.g. "keyword").
+type token struct {
+	class string
+	text  string
+}
+
+// A rule assigns a class to the code matched by its pattern. If the pattern
+// has groups, the classes are assigned to the groups and the rest of the match is plain.
+type rule struct {
+	pattern   *regexp.Regexp
+	classes   []string
+	lineStart bool // the rule only applies at the beginning of a line
+
+	// an optional function which splits the match into tokens
+	split func(match string) []token
+}
+
+// newRule creates a rule for the given pattern. The pattern is anchored at the current position.
+func newRule(pattern string, classes ...string) rule {
+	return rule{pattern: regexp.MustCompile(`^(?:` + pattern + `)`), classes: classes}
+}
+
+// newLineRule creates a rule which only applies at the beginning of a line.
+func newLineRule(pattern string, classes ...string) rule {
+	r := newRule(pattern, classes...)
+	r.lineStart = true
+	return r
+}
+
+func (r rule) tokens(code string, location []int) []token {
+	match := code[location[0]:location[1]]
+	if r.split != nil {
+		return r.split(match)
+	}
+
+	if r.pattern.NumSubexp() == 0 {
+		return []token{{r.classes[0], match}}
+	}
+
+	var tokens []token
+	position := location[0]
+	for group := 1; group <= len(r.classes) && 2*group+1 < len(location); group++ {
+		start, end := location[2*group], location[2*group+1]
+		if start < 0 || start < position {
+			continue
+		}
+
+		tokens = append(tokens, token{classPlain, code[position:start]}, token{r.classes[group-1], code[start:end]})
+		position = end
+	}
+
+	return append(tokens, token{classPlain, code[position:location[1]]})
+}
+
+// A language defines the rules for the tokens of a programming language.
+type language struct {
+	rules []rule
+
+	identifier      *regexp.Regexp
+	keywords        map[string]bool
+	literals        map[string]bool
+	builtIns        map[string]bool
+	types           map[string]bool
+	caseInsensitive bool
+
+	// the keywords after which the next identifier is a title (e.g. the name of a function)
+	titleKeywords map[string]bool
+}
+
+// tokenize splits the given code into tokens.
+func (lang *language) tokenize(code string) []token {
+
+	identifierPattern := lang.identifier
+	if identifierPattern == nil {
+		identifierPattern = defaultIdentifierPattern
+	}
+
+	var tokens []token
+	plainStart := 0
+	expectTitle := false
+
+	flush := func(position int) {
+		if position > plainStart {
+			tokens = append(tokens, token{classPlain, code[plainStart:position]})
+		}
+	}
+
+	for position := 0; position < len(code); {
+		rest := code[position:]
+		isLineStart := position == 0 || code[position-1] == '\n'
+
+		matched := false
+		for _, r := range lang.rules {
+			if r.lineStart && !isLineStart {
+				continue
+			}
+
+			location := r.pattern.FindStringSubmatchIndex(rest)
+			if location == nil || location[1] == 0 {
+				continue
+			}
+
+			flush(position)
+			tokens = append(tokens, r.tokens(rest, location)...)
+			position += location[1]
+			plainStart = position
+			matched = true
+			break
+		}
+
+		if matched {
+			expectTitle = false
+			continue
+		}
+
+		if location := identifierPattern.FindStringIndex(rest); location != nil && location[1] > 0 {
+			word := rest[:location[1]]
+			class := lang.classify(word)
+			if class == classPlain && expectTitle {
+				class = classTitle
+			}
+
+			expectTitle = lang.titleKeywords[lang.normalize(word)]
+
+			if class != classPlain {
+				flush(position)
+				tokens = append(tokens, token{class, word})
+				plainStart = position + len(word)
+			}
+
+			position += len(word)
+			continue
+		}
+
+		character, size := utf8.DecodeRuneInString(rest)
+		if character != ' ' && character != '\t' {
+			expectTitle = false
+		}
+
+		position += size
+	}
+
+	flush(len(code))
+	return tokens
+}
+
+// classify returns the class of the given identifier.
+func (lang *language) classify(word string) string {
+	word = lang.normalize(word)
+
+	switch {
+	case lang.keywords[word]:
+		return classKeyword
+	case lang.literals[word]:
+		return classLiteral
+	case lang.types[word]:
+		return classType
+	case lang.builtIns[word]:
+		return classBuiltIn
+	}
+
+	return classPlain
+}
+
+func (lang *language) normalize(word string) string {
+	if lang.caseInsensitive {
+		return strings.ToLower(word)
+	}
+
+	return word
+}
+
+// words returns a set of the given space separated words.
+func words(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(list) {
+		set[word] = true
+	}
+
+	return set
+}
@@ -0,0 +1,129 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package markdown parses and renders CommonMark with the GitHub Flavored Markdown
+// extensions (tables, strikethrough, autolinks and task lists), footnotes,
+// definition lists and heading attributes (e.g. "## Title {#id}").
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// defaultMarkdown is used to parse markdown without any additional extensions.
+var defaultMarkdown = New()
+
+// New creates a markdown converter with the given additional extensions
+// (e.g. renderers for specific nodes of the AST).
+func New(extensions ...goldmark.Extender) goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(append([]goldmark.Extender{
+			extension.GFM,
+			extension.Footnote,
+			extension.DefinitionList,
+			extension.Typographer,
+		}, extensions...)...),
+		goldmark.WithParserOptions(
+			parser.WithAttribute(),
+		),
+		goldmark.WithRendererOptions(
+			html.WithUnsafe(),
+			html.WithXHTML(),
+			html.WithHardWraps(),
+		),
+	)
+}
+
+// Parse returns the AST of the given markdown.
+func Parse(source []byte) ast.Node {
+	return defaultMarkdown.Parser().Parse(text.NewReader(source))
+}
+
+// ToHTML converts the given markdown to HTML with the given converter and parse options
+// (e.g. the context of the document).
+func ToHTML(converter goldmark.Markdown, markdown string, options ...parser.ParseOption) (string, error) {
+	var buffer bytes.Buffer
+	if err := converter.Convert([]byte(markdown), &buffer, options...); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// CodeSegments returns the segments of the given markdown which contain code (the lines
+// of fenced and indented code blocks and the content of code spans) in the order of their
+// appearance. The segments do not contain line breaks.
+func CodeSegments(source []byte) []text.Segment {
+	var segments []text.Segment
+
+	ast.Walk(Parse(source), func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch node.Kind() {
+
+		case ast.KindFencedCodeBlock, ast.KindCodeBlock:
+			lines := node.Lines()
+			for index := 0; index < lines.Len(); index++ {
+				segments = appendSegment(segments, source, lines.At(index))
+			}
+
+			return ast.WalkSkipChildren, nil
+
+		case ast.KindCodeSpan:
+			for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+				if textNode, isText := child.(*ast.Text); isText {
+					segments = appendSegment(segments, source, textNode.Segment)
+				}
+			}
+
+			return ast.WalkSkipChildren, nil
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	return segments
+}
+
+// RemoveCode returns the given markdown without the code (the lines of code blocks and the
+// content of code spans), e.g. for finding links which are not part of an example.
+func RemoveCode(markdown string) string {
+	source := []byte(markdown)
+	segments := CodeSegments(source)
+	if len(segments) == 0 {
+		return markdown
+	}
+
+	var result bytes.Buffer
+	position := 0
+	for _, segment := range segments {
+		result.Write(source[position:segment.Start])
+		position = segment.Stop
+	}
+
+	result.Write(source[position:])
+	return result.String()
+}
+
+// appendSegment appends the given segment without the line break to the given segments.
+func appendSegment(segments []text.Segment, source []byte, segment text.Segment) []text.Segment {
+	for segment.Stop > segment.Start && (source[segment.Stop-1] == '\n' || source[segment.Stop-1] == '\r') {
+		segment = segment.WithStop(segment.Stop - 1)
+	}
+
+	if segment.Start >= segment.Stop {
+		return segments
+	}
+
+	return append(segments, segment)
+}
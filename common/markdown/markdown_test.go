@@ -0,0 +1,68 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ToHTML_Extensions_AreRendered(t *testing.T) {
+	// arrange
+	input := "## Title {#custom}\n\n- [x] done\n\nTerm\n: Definition\n\nText[^1]\n\n[^1]: Note\n"
+	expectedParts := []string{
+		`<h2 id="custom">Title</h2>`,
+		`<li><input checked="" disabled="" type="checkbox" /> done</li>`,
+		"<dl>\n<dt>Term</dt>\n<dd>Definition</dd>\n</dl>",
+		`<a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a>`,
+	}
+
+	// act
+	result, err := ToHTML(New(), input)
+
+	// assert
+	if err != nil {
+		t.Fatalf("ToHTML(%q) returned an error: %s", input, err)
+	}
+
+	for _, expected := range expectedParts {
+		if !strings.Contains(result, expected) {
+			t.Errorf("The result should contain %q but was %q", expected, result)
+		}
+	}
+}
+
+func Test_CodeSegments_CodeBlocksAndCodeSpans_SegmentsAreReturned(t *testing.T) {
+	// arrange
+	input := "Text `span` text\n\n```go\nline 1\nline 2\n```\n\n    indented\n\n> ```\n> quoted\n> ```\n"
+	expected := []string{"span", "line 1", "line 2", "indented", "quoted"}
+
+	// act
+	segments := CodeSegments([]byte(input))
+
+	// assert
+	var result []string
+	for _, segment := range segments {
+		result = append(result, string(segment.Value([]byte(input))))
+	}
+
+	if strings.Join(result, "|") != strings.Join(expected, "|") {
+		t.Errorf("The code segments should be %q but were %q", expected, result)
+	}
+}
+
+func Test_RemoveCode_CodeBlocksAndCodeSpans_TextIsKept(t *testing.T) {
+	// arrange
+	input := "Text `span` text\n\n```go\nline 1\n```\n"
+	expected := "Text `` text\n\n```go\n\n```\n"
+
+	// act
+	result := RemoveCode(input)
+
+	// assert
+	if result != expected {
+		t.Errorf("RemoveCode(%q) should return %q but returned %q", input, expected, result)
+	}
+}
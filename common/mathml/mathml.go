@@ -0,0 +1,691 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mathml converts LaTeX math expressions to MathML.
+package mathml
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Render returns the MathML code for the given LaTeX math expression (e.g. "\frac{a}{b}").
+// Display math is rendered as a block, all other math inline. The LaTeX code is kept as an
+// annotation. Unknown commands are rendered as errors so the rest of the expression remains readable.
+func Render(tex string, display bool) string {
+
+	parser := &parser{
+		tokens: tokenize(tex),
+	}
+
+	// the top-level expression can contain rows (\\) and alignments (&) like an aligned environment
+	rows := parser.parseTable(func(token) bool { return false })
+	content := rows[0][0]
+	if len(rows) > 1 || len(rows[0]) > 1 {
+		content = renderTable(rows, "right left", true)
+	}
+
+	displayMode := "inline"
+	if display {
+		displayMode = "block"
+	}
+
+	return fmt.Sprintf(`<math xmlns="http://www.w3.org/1998/Math/MathML" display="%s"><semantics>%s<annotation encoding="application/x-tex">%s</annotation></semantics></math>`,
+		displayMode,
+		mrow(content),
+		html.EscapeString(tex))
+}
+
+type tokenType int
+
+const (
+	tokenCommand     tokenType = iota // \alpha, \frac, \{, \\
+	tokenLetter                       // x
+	tokenNumber                       // 3.14
+	tokenSymbol                       // +, =, (
+	tokenSpace                        // whitespace (only relevant for \text)
+	tokenOpen                         // {
+	tokenClose                        // }
+	tokenSuperscript                  // ^
+	tokenSubscript                    // _
+	tokenAlignment                    // &
+	tokenPrime                        // '
+)
+
+type token struct {
+	kind  tokenType
+	value string
+}
+
+// tokenize splits the given LaTeX code into tokens. Comments are removed.
+func tokenize(tex string) []token {
+
+	runes := []rune(tex)
+	tokens := make([]token, 0, len(runes))
+
+	for index := 0; index < len(runes); {
+		character := runes[index]
+		end := index + 1
+		kind := tokenSymbol
+
+		switch {
+		case character == '\\':
+			kind = tokenCommand
+			for end < len(runes) && isASCIILetter(runes[end]) {
+				end++
+			}
+
+			// single character commands (e.g. "\{" or "\,")
+			if end == index+1 && end < len(runes) {
+				end++
+			}
+
+		case character == '%':
+			for end < len(runes) && runes[end] != '\n' {
+				end++
+			}
+
+			index = end
+			continue
+
+		case unicode.IsSpace(character):
+			kind = tokenSpace
+			for end < len(runes) && unicode.IsSpace(runes[end]) {
+				end++
+			}
+
+		case unicode.IsDigit(character):
+			kind = tokenNumber
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' && end+1 < len(runes) && unicode.IsDigit(runes[end+1])) {
+				end++
+			}
+
+		case unicode.IsLetter(character):
+			kind = tokenLetter
+
+		case character == '{':
+			kind = tokenOpen
+
+		case character == '}':
+			kind = tokenClose
+
+		case character == '^':
+			kind = tokenSuperscript
+
+		case character == '_':
+			kind = tokenSubscript
+
+		case character == '&':
+			kind = tokenAlignment
+
+		case character == '\'':
+			kind = tokenPrime
+		}
+
+		tokens = append(tokens, token{kind, string(runes[index:end])})
+		index = end
+	}
+
+	return tokens
+}
+
+type atomType int
+
+const (
+	atomOrdinary atomType = 0
+
+	// atoms which place their sub- and superscripts below and above them (e.g. \sum or \lim)
+	atomLimits atomType = 1 << iota
+
+	// atoms which are followed by a thin space (e.g. \sin)
+	atomFunction
+)
+
+// parser converts LaTeX tokens to MathML.
+type parser struct {
+	tokens   []token
+	position int
+
+	// the font of letters and numbers (e.g. "bold" for \mathbf)
+	variant string
+}
+
+// peek returns the next token which is not a space without consuming it.
+func (parser *parser) peek() (token, bool) {
+	parser.skipSpaces()
+	if parser.position >= len(parser.tokens) {
+		return token{}, false
+	}
+
+	return parser.tokens[parser.position], true
+}
+
+// next returns and consumes the next token which is not a space.
+func (parser *parser) next() (token, bool) {
+	next, exists := parser.peek()
+	if exists {
+		parser.position++
+	}
+
+	return next, exists
+}
+
+func (parser *parser) skipSpaces() {
+	for parser.position < len(parser.tokens) && parser.tokens[parser.position].kind == tokenSpace {
+		parser.position++
+	}
+}
+
+// parseTable parses rows (separated by \\) of cells (separated by &) until the given end token is reached.
+func (parser *parser) parseTable(isEnd func(token) bool) [][]string {
+
+	isCellEnd := func(next token) bool {
+		return next.kind == tokenAlignment || isRowSeparator(next) || isEnd(next)
+	}
+
+	rows := [][]string{}
+	cells := []string{}
+	for {
+		cells = append(cells, mrow(parser.parseRow(isCellEnd)...))
+
+		next, exists := parser.peek()
+		if !exists || isEnd(next) {
+			break
+		}
+
+		parser.position++
+		if isRowSeparator(next) {
+			rows = append(rows, cells)
+			cells = []string{}
+		}
+	}
+
+	// ignore a trailing row separator
+	if len(cells) > 1 || cells[0] != mrow() || len(rows) == 0 {
+		rows = append(rows, cells)
+	}
+
+	return rows
+}
+
+// parseRow parses elements until the given end token (which is not consumed) is reached.
+func (parser *parser) parseRow(isEnd func(token) bool) []string {
+	var elements []string
+	for {
+		next, exists := parser.peek()
+		if !exists || isEnd(next) {
+			return elements
+		}
+
+		if element := parser.parseElement(); element != "" {
+			elements = append(elements, element)
+		}
+	}
+}
+
+// parseElement parses an atom with its sub- and superscripts.
+func (parser *parser) parseElement() (element string) {
+
+	base, kind := mrow(), atomOrdinary
+	if next, _ := parser.peek(); next.kind != tokenSuperscript && next.kind != tokenSubscript && next.kind != tokenPrime {
+		base, kind = parser.parseAtom()
+	}
+
+	// functions (e.g. \sin) are separated from their arguments by a thin space
+	if kind&atomFunction != 0 {
+		defer func() { element += `<mspace width="0.1667em"/>` }()
+	}
+
+	var subscript, superscript, primes string
+	for {
+		next, exists := parser.peek()
+		if !exists {
+			break
+		}
+
+		if next.kind == tokenSubscript {
+			parser.position++
+			subscript = parser.parseArgument()
+		} else if next.kind == tokenSuperscript {
+			parser.position++
+			superscript = parser.parseArgument()
+		} else if next.kind == tokenPrime {
+			parser.position++
+			primes += "′"
+		} else {
+			break
+		}
+	}
+
+	if primes != "" {
+		superscript = mrow(mo(primes), superscript)
+	}
+
+	if subscript == "" && superscript == "" {
+		return base
+	}
+
+	if base == "" {
+		base = mrow()
+	}
+
+	under, over := "msub", "msup"
+	hasLimits := kind&atomLimits != 0
+	if hasLimits {
+		under, over = "munder", "mover"
+	}
+
+	switch {
+	case subscript != "" && superscript != "":
+		if hasLimits {
+			return fmt.Sprintf("<munderover>%s%s%s</munderover>", base, subscript, superscript)
+		}
+		return fmt.Sprintf("<msubsup>%s%s%s</msubsup>", base, subscript, superscript)
+
+	case subscript != "":
+		return fmt.Sprintf("<%s>%s%s</%s>", under, base, subscript, under)
+
+	default:
+		return fmt.Sprintf("<%s>%s%s</%s>", over, base, superscript, over)
+	}
+}
+
+// parseArgument parses the argument of a command or script: a group or a single atom.
+func (parser *parser) parseArgument() string {
+	next, exists := parser.peek()
+	if !exists {
+		return mrow()
+	}
+
+	// only the first digit of a number is an argument (e.g. x^23)
+	if next.kind == tokenNumber && len(next.value) > 1 {
+		parser.tokens[parser.position].value = next.value[1:]
+		return parser.mn(next.value[:1])
+	}
+
+	argument, _ := parser.parseAtom()
+	if argument == "" {
+		return mrow()
+	}
+
+	return argument
+}
+
+// parseRawArgument returns the unparsed text of a group (e.g. the name of an environment).
+func (parser *parser) parseRawArgument() string {
+	if next, exists := parser.peek(); !exists || next.kind != tokenOpen {
+		return ""
+	}
+
+	parser.position++
+
+	var text bytes.Buffer
+	for depth := 1; parser.position < len(parser.tokens); parser.position++ {
+		current := parser.tokens[parser.position]
+		if current.kind == tokenOpen {
+			depth++
+		} else if current.kind == tokenClose {
+			depth--
+		}
+
+		if depth == 0 {
+			parser.position++
+			break
+		}
+
+		text.WriteString(current.value)
+	}
+
+	return text.String()
+}
+
+// parseGroup parses the elements up to the closing brace of a group whose opening brace was consumed.
+func (parser *parser) parseGroup() string {
+	elements := parser.parseRow(func(next token) bool { return next.kind == tokenClose })
+	parser.next()
+	return mrow(elements...)
+}
+
+// parseAtom parses a single symbol, group or command.
+func (parser *parser) parseAtom() (string, atomType) {
+	current, exists := parser.next()
+	if !exists {
+		return "", atomOrdinary
+	}
+
+	switch current.kind {
+	case tokenLetter:
+		return parser.mi(current.value), atomOrdinary
+
+	case tokenNumber:
+		return parser.mn(current.value), atomOrdinary
+
+	case tokenOpen:
+		return parser.parseGroup(), atomOrdinary
+
+	case tokenSymbol:
+		return renderSymbol(current.value), atomOrdinary
+
+	case tokenCommand:
+		return parser.parseCommand(current.value)
+	}
+
+	// misplaced braces, alignments and scripts
+	return "", atomOrdinary
+}
+
+// parseCommand parses the command with the given name (e.g. "\frac") and its arguments.
+func (parser *parser) parseCommand(command string) (string, atomType) {
+
+	name := strings.TrimPrefix(command, `\`)
+
+	if letter, exists := greekLetters[name]; exists {
+		if unicode.IsUpper(letter) {
+			return fmt.Sprintf(`<mi mathvariant="normal">%c</mi>`, letter), atomOrdinary
+		}
+		return fmt.Sprintf("<mi>%c</mi>", letter), atomOrdinary
+	}
+
+	if identifier, exists := identifiers[name]; exists {
+		return fmt.Sprintf("<mi>%s</mi>", identifier), atomOrdinary
+	}
+
+	if operator, exists := operators[command]; exists {
+		return mo(operator), atomOrdinary
+	}
+
+	if operator, exists := largeOperators[name]; exists {
+		if operator.hasLimits {
+			return mo(operator.symbol), atomLimits
+		}
+		return mo(operator.symbol), atomOrdinary
+	}
+
+	if function, exists := functions[name]; exists {
+		if function.hasLimits {
+			return fmt.Sprintf("<mi>%s</mi>", function.name), atomLimits | atomFunction
+		}
+		return fmt.Sprintf("<mi>%s</mi>", function.name), atomFunction
+	}
+
+	if accent, exists := accents[name]; exists {
+		argument := parser.parseArgument()
+		if accent.below {
+			return fmt.Sprintf(`<munder accentunder="true">%s<mo stretchy="true">%s</mo></munder>`, argument, accent.symbol), atomOrdinary
+		}
+		return fmt.Sprintf(`<mover accent="true">%s<mo stretchy="%t">%s</mo></mover>`, argument, accent.stretchy, accent.symbol), atomOrdinary
+	}
+
+	if variant, exists := fontVariants[name]; exists {
+		previousVariant := parser.variant
+		parser.variant = variant
+		argument := parser.parseArgument()
+		parser.variant = previousVariant
+		return argument, atomOrdinary
+	}
+
+	if width, exists := spaces[command]; exists {
+		return fmt.Sprintf(`<mspace width="%s"/>`, width), atomOrdinary
+	}
+
+	if size, exists := delimiterSizes[name]; exists {
+		return parser.parseDelimiter(fmt.Sprintf(`minsize="%s" maxsize="%s"`, size, size)), atomOrdinary
+	}
+
+	switch name {
+	case "frac", "dfrac", "tfrac", "cfrac":
+		numerator := parser.parseArgument()
+		denominator := parser.parseArgument()
+		return fmt.Sprintf("<mfrac>%s%s</mfrac>", numerator, denominator), atomOrdinary
+
+	case "binom":
+		top := parser.parseArgument()
+		bottom := parser.parseArgument()
+		return mrow(mo("("), fmt.Sprintf(`<mfrac linethickness="0">%s%s</mfrac>`, top, bottom), mo(")")), atomOrdinary
+
+	case "sqrt":
+		if next, _ := parser.peek(); next.kind == tokenSymbol && next.value == "[" {
+			parser.position++
+			index := mrow(parser.parseRow(func(next token) bool { return next.kind == tokenSymbol && next.value == "]" })...)
+			parser.next()
+			return fmt.Sprintf("<mroot>%s%s</mroot>", parser.parseArgument(), index), atomOrdinary
+		}
+		return fmt.Sprintf("<msqrt>%s</msqrt>", parser.parseArgument()), atomOrdinary
+
+	case "overset", "stackrel":
+		over := parser.parseArgument()
+		return fmt.Sprintf("<mover>%s%s</mover>", parser.parseArgument(), over), atomOrdinary
+
+	case "underset":
+		under := parser.parseArgument()
+		return fmt.Sprintf("<munder>%s%s</munder>", parser.parseArgument(), under), atomOrdinary
+
+	case "text", "textrm", "textit", "textbf", "mbox":
+		return fmt.Sprintf("<mtext>%s</mtext>", html.EscapeString(unescapeText(parser.parseRawArgument()))), atomOrdinary
+
+	case "operatorname":
+		return fmt.Sprintf("<mi>%s</mi>", html.EscapeString(parser.parseRawArgument())), atomFunction
+
+	case "textcolor", "color":
+		color := parser.parseRawArgument()
+		return fmt.Sprintf(`<mstyle mathcolor="%s">%s</mstyle>`, html.EscapeString(color), parser.parseArgument()), atomOrdinary
+
+	case "boxed":
+		return fmt.Sprintf(`<menclose notation="box">%s</menclose>`, parser.parseArgument()), atomOrdinary
+
+	case "phantom":
+		return fmt.Sprintf("<mphantom>%s</mphantom>", parser.parseArgument()), atomOrdinary
+
+	case "not":
+		next, _ := parser.peek()
+		if operator, exists := operators[next.value]; exists {
+			parser.position++
+			return mo(operator + "\u0338"), atomOrdinary
+		}
+
+		if next.kind == tokenSymbol {
+			parser.position++
+			return mo(next.value + "\u0338"), atomOrdinary
+		}
+
+		return "", atomOrdinary
+
+	case "bmod", "mod":
+		return `<mo lspace="0.2222em" rspace="0.2222em">mod</mo>`, atomOrdinary
+
+	case "pmod":
+		return mrow(`<mspace width="1em"/>`, mo("("), "<mi>mod</mi>", `<mspace width="0.3333em"/>`, parser.parseArgument(), mo(")")), atomOrdinary
+
+	case "left":
+		return parser.parseFenced(), atomOrdinary
+
+	case "begin":
+		return parser.parseEnvironment(), atomOrdinary
+
+	case "displaystyle", "textstyle", "scriptstyle", "limits", "nolimits", "right", "middle", "end", "\\", "newline":
+		// misplaced or without effect
+		if name == "end" {
+			parser.parseRawArgument()
+		} else if name == "right" || name == "middle" {
+			parser.next()
+		}
+		return "", atomOrdinary
+	}
+
+	return fmt.Sprintf("<merror><mtext>%s</mtext></merror>", html.EscapeString(command)), atomOrdinary
+}
+
+// parseDelimiter parses a delimiter (e.g. "(", "\{" or "." for none) and renders it with the given attributes.
+func (parser *parser) parseDelimiter(attributes string) string {
+	delimiter, exists := parser.next()
+	if !exists || delimiter.value == "." {
+		return ""
+	}
+
+	symbol := delimiter.value
+	if operator, exists := operators[delimiter.value]; exists {
+		symbol = operator
+	}
+
+	return fmt.Sprintf("<mo %s>%s</mo>", attributes, html.EscapeString(symbol))
+}
+
+// parseFenced parses an expression between \left and \right (with optional \middle delimiters).
+func (parser *parser) parseFenced() string {
+
+	const stretchy = `fence="true" stretchy="true"`
+
+	elements := []string{parser.parseDelimiter(stretchy)}
+	for {
+		elements = append(elements, parser.parseRow(func(next token) bool {
+			return next.kind == tokenCommand && (next.value == `\right` || next.value == `\middle`)
+		})...)
+
+		delimiter, exists := parser.next()
+		if !exists {
+			break
+		}
+
+		elements = append(elements, parser.parseDelimiter(stretchy))
+		if delimiter.value == `\right` {
+			break
+		}
+	}
+
+	return mrow(elements...)
+}
+
+// parseEnvironment parses a \begin{name} ... \end{name} block (e.g. a matrix).
+func (parser *parser) parseEnvironment() string {
+
+	name := parser.parseRawArgument()
+
+	// ignore the column specification of arrays
+	if name == "array" {
+		parser.parseRawArgument()
+	}
+
+	rows := parser.parseTable(func(next token) bool { return next.kind == tokenCommand && next.value == `\end` })
+	parser.next()
+	parser.parseRawArgument()
+
+	switch strings.TrimSuffix(name, "*") {
+	case "matrix", "smallmatrix", "array":
+		return renderTable(rows, "", false)
+
+	case "pmatrix":
+		return mrow(mo("("), renderTable(rows, "", false), mo(")"))
+
+	case "bmatrix":
+		return mrow(mo("["), renderTable(rows, "", false), mo("]"))
+
+	case "Bmatrix":
+		return mrow(mo("{"), renderTable(rows, "", false), mo("}"))
+
+	case "vmatrix":
+		return mrow(mo("|"), renderTable(rows, "", false), mo("|"))
+
+	case "Vmatrix":
+		return mrow(mo("‖"), renderTable(rows, "", false), mo("‖"))
+
+	case "cases":
+		return mrow(mo("{"), renderTable(rows, "left left", false))
+
+	case "aligned", "align", "alignat", "split", "eqnarray":
+		return renderTable(rows, "right left", true)
+
+	case "gathered", "gather", "equation":
+		return renderTable(rows, "", true)
+	}
+
+	return fmt.Sprintf("<merror><mtext>%s</mtext></merror>", html.EscapeString(`\begin{`+name+`}`))
+}
+
+// mi renders an identifier in the current font.
+func (parser *parser) mi(identifier string) string {
+	if parser.variant == "normal" {
+		return fmt.Sprintf(`<mi mathvariant="normal">%s</mi>`, html.EscapeString(identifier))
+	}
+
+	return fmt.Sprintf("<mi>%s</mi>", html.EscapeString(styleText(identifier, parser.variant)))
+}
+
+// mn renders a number in the current font.
+func (parser *parser) mn(number string) string {
+	return fmt.Sprintf("<mn>%s</mn>", styleText(number, parser.variant))
+}
+
+// renderSymbol renders a character which is neither a letter nor a digit.
+func renderSymbol(symbol string) string {
+	switch symbol {
+	case "-":
+		return mo("−")
+	case "*":
+		return mo("∗")
+	case "~":
+		return `<mspace width="0.3333em"/>`
+	case "(", ")", "[", "]", "|", "/":
+		return fmt.Sprintf(`<mo stretchy="false">%s</mo>`, symbol)
+	}
+
+	return mo(symbol)
+}
+
+// renderTable renders the given rows as a table. The column alignments are repeated for all columns.
+func renderTable(rows [][]string, columnAlignments string, displayStyle bool) string {
+
+	var table bytes.Buffer
+	table.WriteString("<mtable")
+	if displayStyle {
+		table.WriteString(` displaystyle="true"`)
+	}
+
+	if columnAlignments != "" {
+		fmt.Fprintf(&table, ` columnalign="%s"`, columnAlignments)
+	}
+
+	table.WriteString(">")
+
+	for _, row := range rows {
+		table.WriteString("<mtr>")
+		for _, cell := range row {
+			fmt.Fprintf(&table, "<mtd>%s</mtd>", cell)
+		}
+		table.WriteString("</mtr>")
+	}
+
+	table.WriteString("</mtable>")
+	return table.String()
+}
+
+// mrow returns the given elements as a single element.
+func mrow(elements ...string) string {
+	if len(elements) == 1 && strings.HasPrefix(elements[0], "<mrow>") {
+		return elements[0]
+	}
+
+	return "<mrow>" + strings.Join(elements, "") + "</mrow>"
+}
+
+// mo renders an operator.
+func mo(operator string) string {
+	return fmt.Sprintf("<mo>%s</mo>", html.EscapeString(operator))
+}
+
+// isRowSeparator indicates whether the given token separates the rows of a table.
+func isRowSeparator(next token) bool {
+	return next.kind == tokenCommand && (next.value == `\\` || next.value == `\newline`)
+}
+
+// unescapeText removes the backslashes from escaped characters (e.g. "\&") in text.
+func unescapeText(text string) string {
+	return strings.NewReplacer(`\&`, "&", `\%`, "%", `\$`, "$", `\#`, "#", `\_`, "_", `\{`, "{", `\}`, "}").Replace(text)
+}
+
+func isASCIILetter(character rune) bool {
+	return character >= 'a' && character <= 'z' || character >= 'A' && character <= 'Z'
+}
@@ -0,0 +1,96 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mathml
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Render_InlineExpression_MathElementWithAnnotationIsReturned(t *testing.T) {
+	// arrange
+	input := `a < b`
+	expected := `<math xmlns="http://www.w3.org/1998/Math/MathML" display="inline"><semantics><mrow><mi>a</mi><mo>&lt;</mo><mi>b</mi></mrow><annotation encoding="application/x-tex">a &lt; b</annotation></semantics></math>`
+
+	// act
+	result := Render(input, false)
+
+	// assert
+	if result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
+
+func Test_Render_DisplayExpression_BlockIsReturned(t *testing.T) {
+	// act
+	result := Render(`x`, true)
+
+	// assert
+	if !strings.Contains(result, `display="block"`) {
+		t.Errorf("The result should be rendered as a block but was %q", result)
+	}
+}
+
+func Test_Render_Expressions_MathMLIsReturned(t *testing.T) {
+	// arrange
+	inputs := map[string]string{
+		`x^2_i`:                           `<msubsup><mi>x</mi><mi>i</mi><mn>2</mn></msubsup>`,
+		`x^23`:                            `<msup><mi>x</mi><mn>2</mn></msup><mn>3</mn>`,
+		`f'`:                              `<msup><mi>f</mi><mrow><mo>′</mo></mrow></msup>`,
+		`\frac12`:                         `<mfrac><mn>1</mn><mn>2</mn></mfrac>`,
+		`\sqrt[3]{x}`:                     `<mroot><mrow><mi>x</mi></mrow><mrow><mn>3</mn></mrow></mroot>`,
+		`\sum_{i=1}^n`:                    `<munderover><mo>∑</mo><mrow><mi>i</mi><mo>=</mo><mn>1</mn></mrow><mi>n</mi></munderover>`,
+		`\int_0^1`:                        `<msubsup><mo>∫</mo><mn>0</mn><mn>1</mn></msubsup>`,
+		`\sin x`:                          `<mi>sin</mi><mspace width="0.1667em"/><mi>x</mi>`,
+		`\alpha \Omega \leq \infty`:       `<mi>α</mi><mi mathvariant="normal">Ω</mi><mo>≤</mo><mi>∞</mi>`,
+		`\mathbb{R} \mathbf{v}`:           `<mrow><mi>ℝ</mi></mrow><mrow><mi>𝐯</mi></mrow>`,
+		`\text{if } x`:                    `<mtext>if </mtext><mi>x</mi>`,
+		`\left( x \right.`:                `<mrow><mo fence="true" stretchy="true">(</mo><mi>x</mi></mrow>`,
+		`\hat{x}`:                         `<mover accent="true"><mrow><mi>x</mi></mrow><mo stretchy="false">^</mo></mover>`,
+		`\begin{pmatrix}a&b\end{pmatrix}`: `<mrow><mo>(</mo><mtable><mtr><mtd><mrow><mi>a</mi></mrow></mtd><mtd><mrow><mi>b</mi></mrow></mtd></mtr></mtable><mo>)</mo></mrow>`,
+		`\unknown`:                        `<merror><mtext>\unknown</mtext></merror>`,
+	}
+
+	for input, expected := range inputs {
+
+		// act
+		result := Render(input, false)
+
+		// assert
+		if !strings.Contains(result, "<semantics><mrow>"+expected+"</mrow><annotation") && !strings.Contains(result, "<semantics>"+expected+"<annotation") {
+			t.Errorf("Render(%q) should contain %q but was %q", input, expected, result)
+		}
+	}
+}
+
+func Test_Render_RowsAndAlignments_TableIsReturned(t *testing.T) {
+	// arrange
+	input := `a &= b \\ &= c \\`
+	expected := `<mtable displaystyle="true" columnalign="right left"><mtr><mtd><mrow><mi>a</mi></mrow></mtd><mtd><mrow><mo>=</mo><mi>b</mi></mrow></mtd></mtr><mtr><mtd><mrow></mrow></mtd><mtd><mrow><mo>=</mo><mi>c</mi></mrow></mtd></mtr></mtable>`
+
+	// act
+	result := Render(input, true)
+
+	// assert
+	if !strings.Contains(result, expected) {
+		t.Errorf("The result should contain %q but was %q", expected, result)
+	}
+}
+
+func Test_Render_MalformedExpressions_NoPanic(t *testing.T) {
+	// arrange
+	inputs := []string{``, `}`, `{`, `x^`, `^_'`, `\frac`, `\left(`, `\begin{matrix}a &`, `\end{matrix}`, `\sqrt[`, `\`, `&&\\`}
+
+	for _, input := range inputs {
+
+		// act
+		result := Render(input, false)
+
+		// assert
+		if !strings.HasPrefix(result, "<math") {
+			t.Errorf("Render(%q) should return a math element but returned %q", input, result)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mathml
+
+var (
+	// \alpha, \Gamma, ...
+	greekLetters = map[string]rune{
+		"alpha": 'α', "beta": 'β', "gamma": 'γ', "delta": 'δ', "epsilon": 'ϵ', "varepsilon": 'ε',
+		"zeta": 'ζ', "eta": 'η', "theta": 'θ', "vartheta": 'ϑ', "iota": 'ι', "kappa": 'κ',
+		"lambda": 'λ', "mu": 'μ', "nu": 'ν', "xi": 'ξ', "omicron": 'ο', "pi": 'π', "varpi": 'ϖ',
+		"rho": 'ρ', "varrho": 'ϱ', "sigma": 'σ', "varsigma": 'ς', "tau": 'τ', "upsilon": 'υ',
+		"phi": 'ϕ', "varphi": 'φ', "chi": 'χ', "psi": 'ψ', "omega": 'ω',
+		"Gamma": 'Γ', "Delta": 'Δ', "Theta": 'Θ', "Lambda": 'Λ', "Xi": 'Ξ', "Pi": 'Π',
+		"Sigma": 'Σ', "Upsilon": 'Υ', "Phi": 'Φ', "Psi": 'Ψ', "Omega": 'Ω',
+	}
+
+	// symbols which are rendered as identifiers
+	identifiers = map[string]string{
+		"infty": "∞", "partial": "∂", "nabla": "∇", "emptyset": "∅", "varnothing": "∅",
+		"aleph": "ℵ", "hbar": "ℏ", "ell": "ℓ", "Re": "ℜ", "Im": "ℑ", "wp": "℘",
+		"angle": "∠", "triangle": "△", "imath": "ı", "jmath": "ȷ", "top": "⊤", "bot": "⊥",
+	}
+
+	// symbols which are rendered as operators (by command)
+	operators = map[string]string{
+		// binary operators
+		`\pm`: "±", `\mp`: "∓", `\times`: "×", `\div`: "÷", `\cdot`: "⋅", `\ast`: "∗",
+		`\star`: "⋆", `\circ`: "∘", `\bullet`: "∙", `\oplus`: "⊕", `\ominus`: "⊖",
+		`\otimes`: "⊗", `\odot`: "⊙", `\cap`: "∩", `\cup`: "∪", `\setminus`: "∖",
+		`\wedge`: "∧", `\land`: "∧", `\vee`: "∨", `\lor`: "∨", `\neg`: "¬", `\lnot`: "¬",
+
+		// relations
+		`\leq`: "≤", `\le`: "≤", `\geq`: "≥", `\ge`: "≥", `\neq`: "≠", `\ne`: "≠",
+		`\approx`: "≈", `\equiv`: "≡", `\sim`: "∼", `\simeq`: "≃", `\cong`: "≅",
+		`\propto`: "∝", `\ll`: "≪", `\gg`: "≫", `\subset`: "⊂", `\supset`: "⊃",
+		`\subseteq`: "⊆", `\supseteq`: "⊇", `\in`: "∈", `\notin`: "∉", `\ni`: "∋",
+		`\perp`: "⊥", `\parallel`: "∥", `\mid`: "∣", `\models`: "⊨", `\vdash`: "⊢",
+		`\coloneqq`: "≔", `\doteq`: "≐",
+
+		// arrows
+		`\to`: "→", `\rightarrow`: "→", `\leftarrow`: "←", `\gets`: "←",
+		`\leftrightarrow`: "↔", `\Rightarrow`: "⇒", `\Leftarrow`: "⇐",
+		`\Leftrightarrow`: "⇔", `\iff`: "⟺", `\implies`: "⟹", `\impliedby`: "⟸",
+		`\mapsto`: "↦", `\uparrow`: "↑", `\downarrow`: "↓", `\updownarrow`: "↕",
+		`\longrightarrow`: "⟶", `\longleftarrow`: "⟵", `\longmapsto`: "⟼",
+
+		// dots, quantifiers and punctuation
+		`\ldots`: "…", `\dots`: "…", `\cdots`: "⋯", `\vdots`: "⋮", `\ddots`: "⋱",
+		`\forall`: "∀", `\exists`: "∃", `\nexists`: "∄", `\colon`: ":", `\prime`: "′",
+
+		// delimiters
+		`\{`: "{", `\}`: "}", `\lbrace`: "{", `\rbrace`: "}", `\langle`: "⟨", `\rangle`: "⟩",
+		`\lfloor`: "⌊", `\rfloor`: "⌋", `\lceil`: "⌈", `\rceil`: "⌉", `\|`: "‖",
+		`\vert`: "|", `\lvert`: "|", `\rvert`: "|", `\Vert`: "‖", `\lVert`: "‖", `\rVert`: "‖",
+		`\backslash`: `\`,
+
+		// escaped characters
+		`\%`: "%", `\$`: "$", `\&`: "&", `\#`: "#", `\_`: "_",
+	}
+
+	// \sum, \int, ...
+	largeOperators = map[string]struct {
+		symbol    string
+		hasLimits bool
+	}{
+		"sum": {"∑", true}, "prod": {"∏", true}, "coprod": {"∐", true},
+		"bigcup": {"⋃", true}, "bigcap": {"⋂", true}, "bigvee": {"⋁", true}, "bigwedge": {"⋀", true},
+		"bigoplus": {"⨁", true}, "bigotimes": {"⨂", true}, "bigodot": {"⨀", true},
+		"int": {"∫", false}, "iint": {"∬", false}, "iiint": {"∭", false}, "oint": {"∮", false},
+	}
+
+	// \sin, \lim, ...
+	functions = map[string]struct {
+		name      string
+		hasLimits bool
+	}{
+		"sin": {"sin", false}, "cos": {"cos", false}, "tan": {"tan", false}, "cot": {"cot", false},
+		"sec": {"sec", false}, "csc": {"csc", false}, "arcsin": {"arcsin", false},
+		"arccos": {"arccos", false}, "arctan": {"arctan", false}, "sinh": {"sinh", false},
+		"cosh": {"cosh", false}, "tanh": {"tanh", false}, "coth": {"coth", false},
+		"log": {"log", false}, "ln": {"ln", false}, "lg": {"lg", false}, "exp": {"exp", false},
+		"det": {"det", true}, "dim": {"dim", false}, "ker": {"ker", false}, "deg": {"deg", false},
+		"gcd": {"gcd", true}, "hom": {"hom", false}, "arg": {"arg", false}, "Pr": {"Pr", true},
+		"lim": {"lim", true}, "limsup": {"lim sup", true}, "liminf": {"lim inf", true},
+		"max": {"max", true}, "min": {"min", true}, "sup": {"sup", true}, "inf": {"inf", true},
+		"argmax": {"arg max", true}, "argmin": {"arg min", true},
+	}
+
+	// \hat{x}, \overline{x}, ...
+	accents = map[string]struct {
+		symbol   string
+		stretchy bool
+		below    bool
+	}{
+		"hat": {"^", false, false}, "widehat": {"^", true, false}, "check": {"ˇ", false, false},
+		"tilde": {"~", false, false}, "widetilde": {"~", true, false}, "acute": {"´", false, false},
+		"grave": {"`", false, false}, "breve": {"˘", false, false}, "dot": {"˙", false, false},
+		"ddot": {"¨", false, false}, "bar": {"¯", false, false}, "vec": {"→", false, false},
+		"overline": {"‾", true, false}, "overrightarrow": {"→", true, false},
+		"overleftarrow": {"←", true, false}, "overbrace": {"⏞", true, false},
+		"underline": {"_", true, true}, "underbrace": {"⏟", true, true},
+	}
+
+	// \mathbf{x}, \mathbb{R}, ...
+	fontVariants = map[string]string{
+		"mathrm": "normal", "mathup": "normal", "mathit": "italic", "mathbf": "bold",
+		"boldsymbol": "bold-italic", "bm": "bold-italic", "mathbb": "double-struck",
+		"mathcal": "script", "mathscr": "script", "mathfrak": "fraktur",
+		"mathsf": "sans-serif", "mathtt": "monospace",
+	}
+
+	// \, \quad, ...
+	spaces = map[string]string{
+		`\,`: "0.1667em", `\thinspace`: "0.1667em", `\:`: "0.2222em", `\>`: "0.2222em",
+		`\medspace`: "0.2222em", `\;`: "0.2778em", `\thickspace`: "0.2778em",
+		`\!`: "-0.1667em", `\ `: "0.3333em", `\quad`: "1em", `\qquad`: "2em",
+	}
+
+	// \big(, \Bigg], ...
+	delimiterSizes = map[string]string{
+		"big": "1.2em", "bigl": "1.2em", "bigr": "1.2em", "bigm": "1.2em",
+		"Big": "1.8em", "Bigl": "1.8em", "Bigr": "1.8em", "Bigm": "1.8em",
+		"bigg": "2.4em", "biggl": "2.4em", "biggr": "2.4em", "biggm": "2.4em",
+		"Bigg": "3em", "Biggl": "3em", "Biggr": "3em", "Biggm": "3em",
+	}
+)
+
+// mathematical alphanumeric symbols: the first capital letter, small letter and digit of every font
+var alphanumericOffsets = map[string][3]rune{
+	"bold":          {0x1D400, 0x1D41A, 0x1D7CE},
+	"italic":        {0x1D434, 0x1D44E, 0},
+	"bold-italic":   {0x1D468, 0x1D482, 0x1D7CE},
+	"script":        {0x1D49C, 0x1D4B6, 0},
+	"fraktur":       {0x1D504, 0x1D51E, 0},
+	"double-struck": {0x1D538, 0x1D552, 0x1D7D8},
+	"sans-serif":    {0x1D5A0, 0x1D5BA, 0x1D7E2},
+	"monospace":     {0x1D670, 0x1D68A, 0x1D7F6},
+}
+
+// letters which are not part of the mathematical alphanumeric symbols block
+var letterlikeSymbols = map[string]map[rune]rune{
+	"italic":        {'h': 'ℎ'},
+	"script":        {'B': 'ℬ', 'E': 'ℰ', 'F': 'ℱ', 'H': 'ℋ', 'I': 'ℐ', 'L': 'ℒ', 'M': 'ℳ', 'R': 'ℛ', 'e': 'ℯ', 'g': 'ℊ', 'o': 'ℴ'},
+	"fraktur":       {'C': 'ℭ', 'H': 'ℌ', 'I': 'ℑ', 'R': 'ℜ', 'Z': 'ℨ'},
+	"double-struck": {'C': 'ℂ', 'H': 'ℍ', 'N': 'ℕ', 'P': 'ℙ', 'Q': 'ℚ', 'R': 'ℝ', 'Z': 'ℤ'},
+}
+
+// styleText returns the given letters and digits in the given font (e.g. "ℝ" for "R" in "double-struck").
+func styleText(text, variant string) string {
+	offsets, exists := alphanumericOffsets[variant]
+	if !exists {
+		return text
+	}
+
+	styled := []rune(text)
+	for index, character := range styled {
+		if symbol, exists := letterlikeSymbols[variant][character]; exists {
+			styled[index] = symbol
+			continue
+		}
+
+		switch {
+		case character >= 'A' && character <= 'Z':
+			styled[index] = offsets[0] + character - 'A'
+		case character >= 'a' && character <= 'z':
+			styled[index] = offsets[1] + character - 'a'
+		case character >= '0' && character <= '9' && offsets[2] != 0:
+			styled[index] = offsets[2] + character - '0'
+		}
+	}
+
+	return string(styled)
+}
@@ -2,27 +2,61 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+// Package shutdown executes the registered callbacks (e.g. stopping the web server,
+// saving an index) when the application is stopped.
 package shutdown
 
 import (
-	"fmt"
+	"errors"
+	"sync"
 )
 
 var (
+	lock      sync.Mutex
 	callbacks = make([]func() error, 0)
+
+	once sync.Once
+	done = make(chan struct{})
+
+	// the errors of the callbacks
+	shutdownError error
 )
 
+// Register adds a callback which is executed on shutdown.
 func Register(callback func() error) {
+	lock.Lock()
+	defer lock.Unlock()
+
 	callbacks = append(callbacks, callback)
 }
 
-func Shutdown() {
+// Shutdown executes all registered callbacks in the reverse order of their
+// registration (like deferred function calls), so components are stopped before
+// the components they depend on. All callbacks are executed even if some of them fail;
+// the errors are returned. Subsequent calls wait for the first call to finish.
+func Shutdown() error {
+
+	once.Do(func() {
+		lock.Lock()
+		registeredCallbacks := append([]func() error(nil), callbacks...)
+		lock.Unlock()
 
-	for _, callback := range callbacks {
-		err := callback()
-		if err != nil {
-			fmt.Println(err.Error())
+		var errs []error
+		for index := len(registeredCallbacks) - 1; index >= 0; index-- {
+			if err := registeredCallbacks[index](); err != nil {
+				errs = append(errs, err)
+			}
 		}
-	}
 
+		shutdownError = errors.Join(errs...)
+		close(done)
+	})
+
+	return Wait()
+}
+
+// Wait blocks until Shutdown has executed all callbacks and returns the errors of the callbacks.
+func Wait() error {
+	<-done
+	return shutdownError
 }
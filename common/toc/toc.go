@@ -0,0 +1,173 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package toc creates tables of contents from the headings of HTML documents.
+package toc
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/shurcooL/sanitized_anchor_name"
+)
+
+var (
+	// <h2>Title</h2>, <h3 id="title">Title</h3>
+	headingPattern = regexp.MustCompile(`(?is)<h([1-6])(\s[^>]*)?>(.*?)</h[1-6]>`)
+
+	// id="title"
+	idAttributePattern = regexp.MustCompile(`(?i)\sid=["']([^"']*)["']`)
+
+	// any HTML tag
+	tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+	// <annotation encoding="application/x-tex">x^2</annotation> (the source code of math expressions)
+	annotationPattern = regexp.MustCompile(`(?is)<annotation[\s>].*?</annotation>`)
+)
+
+// An Entry is a heading of a document with the headings below it.
+type Entry struct {
+	Id       string
+	Title    string
+	Level    int
+	Children []Entry
+}
+
+// AddHeadingIds adds an id to all headings of the given HTML which don't have one.
+// The id is derived from the text of the heading; headings with the same text
+// are numbered in the order of their appearance (e.g. "example", "example-2").
+func AddHeadingIds(htmlCode string) string {
+
+	// reserve the existing ids
+	usedIds := make(map[string]bool)
+	for _, match := range headingPattern.FindAllStringSubmatch(htmlCode, -1) {
+		if idMatch := idAttributePattern.FindStringSubmatch(match[2]); idMatch != nil {
+			usedIds[idMatch[1]] = true
+		}
+	}
+
+	return headingPattern.ReplaceAllStringFunc(htmlCode, func(heading string) string {
+		match := headingPattern.FindStringSubmatch(heading)
+		level, attributes, content := match[1], match[2], match[3]
+
+		if idAttributePattern.MatchString(attributes) {
+			return heading
+		}
+
+		id := getUniqueId(getAnchorName(getText(content)), usedIds)
+		return fmt.Sprintf(`<h%s id="%s"%s>%s</h%s>`, level, id, attributes, content, level)
+	})
+}
+
+// Parse returns the hierarchical table of contents of all headings with an id in the given HTML.
+func Parse(htmlCode string) []Entry {
+
+	// the top-level entries are the children of an imaginary root
+	root := &entryNode{}
+	path := []*entryNode{root}
+
+	for _, match := range headingPattern.FindAllStringSubmatch(htmlCode, -1) {
+
+		idMatch := idAttributePattern.FindStringSubmatch(match[2])
+		if idMatch == nil {
+			continue
+		}
+
+		node := &entryNode{
+			entry: Entry{
+				Id:    idMatch[1],
+				Title: getText(match[3]),
+				Level: int(match[1][0] - '0'),
+			},
+		}
+
+		// find the parent (the last entry with a smaller level)
+		for len(path) > 1 && path[len(path)-1].entry.Level >= node.entry.Level {
+			path = path[:len(path)-1]
+		}
+
+		parent := path[len(path)-1]
+		parent.children = append(parent.children, node)
+		path = append(path, node)
+	}
+
+	return root.Children()
+}
+
+// Render returns the given table of contents as nested HTML lists.
+func Render(entries []Entry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+
+	buffer := new(bytes.Buffer)
+	buffer.WriteString(`<nav class="toc">`)
+	renderEntries(buffer, entries)
+	buffer.WriteString(`</nav>`)
+
+	return buffer.String()
+}
+
+func renderEntries(buffer *bytes.Buffer, entries []Entry) {
+	buffer.WriteString("<ol>")
+
+	for _, entry := range entries {
+		fmt.Fprintf(buffer, `<li><a href="#%s">%s</a>`, html.EscapeString(entry.Id), html.EscapeString(entry.Title))
+
+		if len(entry.Children) > 0 {
+			renderEntries(buffer, entry.Children)
+		}
+
+		buffer.WriteString("</li>")
+	}
+
+	buffer.WriteString("</ol>")
+}
+
+// entryNode is used to assemble the entry hierarchy.
+type entryNode struct {
+	entry    Entry
+	children []*entryNode
+}
+
+// Children returns the entries of the child nodes.
+func (node *entryNode) Children() []Entry {
+	entries := make([]Entry, 0, len(node.children))
+	for _, child := range node.children {
+		entry := child.entry
+		entry.Children = child.Children()
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// getText returns the unescaped text of the given HTML code.
+func getText(htmlCode string) string {
+	htmlCode = annotationPattern.ReplaceAllString(htmlCode, "")
+	return strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(htmlCode, "")))
+}
+
+// getAnchorName returns the anchor name for the given heading text.
+func getAnchorName(text string) string {
+	if anchorName := sanitized_anchor_name.Create(text); anchorName != "" {
+		return anchorName
+	}
+
+	return "section"
+}
+
+// getUniqueId returns the given id or, if it is used already, the id with the next free number.
+func getUniqueId(id string, usedIds map[string]bool) string {
+	uniqueId := id
+	for number := 2; usedIds[uniqueId]; number++ {
+		uniqueId = fmt.Sprintf("%s-%d", id, number)
+	}
+
+	usedIds[uniqueId] = true
+	return uniqueId
+}
@@ -0,0 +1,94 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package toc
+
+import (
+	"testing"
+)
+
+func Test_AddHeadingIds_HeadingsWithoutId_IdsAreAdded(t *testing.T) {
+	// arrange
+	input := `<h1>Getting Started</h1><p>Text</p><h2>Install <em>allmark</em></h2>`
+	expected := `<h1 id="getting-started">Getting Started</h1><p>Text</p><h2 id="install-allmark">Install <em>allmark</em></h2>`
+
+	// act
+	result := AddHeadingIds(input)
+
+	// assert
+	if result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
+
+func Test_AddHeadingIds_DuplicateHeadings_IdsAreNumbered(t *testing.T) {
+	// arrange
+	input := `<h2>Example</h2><h2 id="example-2">Other</h2><h2>Example</h2><h2>Example</h2>`
+	expected := `<h2 id="example">Example</h2><h2 id="example-2">Other</h2><h2 id="example-3">Example</h2><h2 id="example-4">Example</h2>`
+
+	// act
+	result := AddHeadingIds(input)
+
+	// assert
+	if result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
+
+func Test_AddHeadingIds_HeadingWithoutText_FallbackIdIsUsed(t *testing.T) {
+	// arrange
+	input := `<h3>!!!</h3>`
+	expected := `<h3 id="section">!!!</h3>`
+
+	// act
+	result := AddHeadingIds(input)
+
+	// assert
+	if result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
+
+func Test_Parse_NestedHeadings_HierarchyIsReturned(t *testing.T) {
+	// arrange
+	input := `<h2 id="a">A</h2><h3 id="a1">A &amp; 1</h3><h4 id="a1x">A1x</h4><h3 id="a2">A2</h3><h2 id="b">B</h2><h3>No id</h3>`
+
+	// act
+	result := Parse(input)
+
+	// assert
+	if len(result) != 2 {
+		t.Fatalf("Parse should return 2 top-level entries but returned %d: %#v", len(result), result)
+	}
+
+	if result[0].Id != "a" || len(result[0].Children) != 2 {
+		t.Errorf("The first entry should be %q with 2 children but was %#v", "a", result[0])
+	}
+
+	if child := result[0].Children[0]; child.Title != "A & 1" || child.Level != 3 || len(child.Children) != 1 {
+		t.Errorf("The first child should be %q (level 3) with one child but was %#v", "A & 1", child)
+	}
+
+	if result[1].Id != "b" || len(result[1].Children) != 0 {
+		t.Errorf("The second entry should be %q without children but was %#v", "b", result[1])
+	}
+}
+
+func Test_Render_Entries_NestedListIsReturned(t *testing.T) {
+	// arrange
+	entries := []Entry{
+		{Id: "a", Title: "A <b>", Level: 2, Children: []Entry{
+			{Id: "a1", Title: "A1", Level: 3},
+		}},
+	}
+	expected := `<nav class="toc"><ol><li><a href="#a">A &lt;b&gt;</a><ol><li><a href="#a1">A1</a></li></ol></li></ol></nav>`
+
+	// act
+	result := Render(entries)
+
+	// assert
+	if result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
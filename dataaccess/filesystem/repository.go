@@ -6,8 +6,11 @@ package filesystem
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/andreaskoch/allmark/common/config"
@@ -15,6 +18,7 @@ import (
 	"github.com/andreaskoch/allmark/common/route"
 	"github.com/andreaskoch/allmark/common/util/fsutil"
 	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/dataaccess/git"
 )
 
 type Repository struct {
@@ -31,6 +35,12 @@ type Repository struct {
 
 	// live reload
 	livereloadIsEnabled bool
+
+	// revision history (only available for git working copies)
+	history *git.History
+
+	// serializes the changes made through WriteItem
+	writeLock sync.Mutex
 }
 
 func NewRepository(logger logger.Logger, directory string, config config.Config) (*Repository, error) {
@@ -93,6 +103,14 @@ func NewRepository(logger logger.Logger, directory string, config config.Config)
 		repository.logger.Info("Live Reload: Off")
 	}
 
+	// revision history
+	if history, err := git.NewHistory(directory); err == nil {
+		repository.logger.Info("Revision History: On")
+		repository.history = history
+	} else {
+		repository.logger.Info("Revision History: Off")
+	}
+
 	return repository, nil
 }
 
@@ -175,6 +193,171 @@ func (repository *Repository) StopWatching(route route.Route) {
 	repository.watcher.Stop(route)
 }
 
+// HasHistory indicates whether a revision history is available for this repository.
+// This is the case if the repository directory is part of a git working copy.
+func (repository *Repository) HasHistory() bool {
+	return repository.history != nil
+}
+
+// Revisions returns the commits which changed the item with the given route.
+func (repository *Repository) Revisions(route route.Route) ([]dataaccess.Revision, error) {
+	paths, err := repository.getHistoryPaths(route)
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.history.Revisions("HEAD", paths...)
+}
+
+// Diff returns the unified diff of the item with the given route between the two revisions.
+func (repository *Repository) Diff(route route.Route, fromRevision, toRevision string) (string, error) {
+	paths, err := repository.getHistoryPaths(route)
+	if err != nil {
+		return "", err
+	}
+
+	return repository.history.Diff(fromRevision, toRevision, paths...)
+}
+
+// CanWrite indicates whether the item with the given route can be changed.
+// Only physical items have a markdown file that can be written.
+func (repository *Repository) CanWrite(route route.Route) bool {
+	item := repository.Item(route)
+	return item != nil && item.Type() == dataaccess.TypePhysical
+}
+
+// WriteItem replaces the content of the markdown file of the item with the given route.
+// The file is replaced atomically and only if its current hash still matches the given lastHash.
+func (repository *Repository) WriteItem(itemRoute route.Route, content []byte, lastHash string) error {
+
+	repository.writeLock.Lock()
+	defer repository.writeLock.Unlock()
+
+	item := repository.Item(itemRoute)
+	if item == nil {
+		return fmt.Errorf("The item %q was not found.", itemRoute)
+	}
+
+	if item.Type() != dataaccess.TypePhysical {
+		return fmt.Errorf("The item %q does not have a markdown file.", itemRoute)
+	}
+
+	itemDirectory := item.(*Item).Directory()
+	found, markdownFilePath := findMarkdownFileInDirectory(itemDirectory)
+	if !found {
+		return fmt.Errorf("The markdown file of item %q was not found.", itemRoute)
+	}
+
+	// make sure nobody changed the file since the given version has been read.
+	// The hash is read from the file directly so the last hash of the indexed item
+	// stays untouched and the index update below recognizes the change.
+	currentHash, err := getHashFromFile(markdownFilePath, item.Route())
+	if err != nil {
+		return fmt.Errorf("Cannot determine the hash of item %q. Error: %s", itemRoute, err.Error())
+	}
+
+	if currentHash != lastHash {
+		return dataaccess.ErrConflict
+	}
+
+	if err := writeFileAtomically(markdownFilePath, content); err != nil {
+		return fmt.Errorf("Cannot write the markdown file of item %q. Error: %s", itemRoute, err.Error())
+	}
+
+	repository.logger.Info("Item %q has been changed. Updating the index.", itemRoute)
+
+	// update the index and notify the subscribers
+	limitDepth := true
+	maxDepth := 2
+	repository.updateIndex(repository.index, item.Route(), itemDirectory, limitDepth, maxDepth)
+
+	return nil
+}
+
+// WriteFile stores the given content in the files directory of the item with the given route.
+// If a file with the given name exists already a number is appended to the name.
+func (repository *Repository) WriteFile(itemRoute route.Route, name string, content io.Reader) (string, error) {
+
+	if !isValidFileName(name) {
+		return "", fmt.Errorf("The file name %q is not valid.", name)
+	}
+
+	repository.writeLock.Lock()
+	defer repository.writeLock.Unlock()
+
+	item := repository.Item(itemRoute)
+	if item == nil {
+		return "", fmt.Errorf("The item %q was not found.", itemRoute)
+	}
+
+	if item.Type() != dataaccess.TypePhysical {
+		return "", fmt.Errorf("Files cannot be added to item %q.", itemRoute)
+	}
+
+	itemDirectory := item.(*Item).Directory()
+	filesDirectory := filepath.Join(itemDirectory, config.FilesDirectoryName)
+	if err := os.MkdirAll(filesDirectory, 0755); err != nil {
+		return "", fmt.Errorf("Cannot create the files directory of item %q. Error: %s", itemRoute, err.Error())
+	}
+
+	filePath := getUnusedFilePath(filesDirectory, name)
+	if err := createFileAtomically(filePath, content); err != nil {
+		return "", fmt.Errorf("Cannot write file %q of item %q. Error: %s", filepath.Base(filePath), itemRoute, err.Error())
+	}
+
+	repository.logger.Info("File %q has been added to item %q.", filepath.Base(filePath), itemRoute)
+
+	// the hash of the item does not cover its files, so the item is
+	// marked as modified explicitly after the index has been updated
+	limitDepth := true
+	maxDepth := 2
+	repository.updateIndex(repository.index, item.Route(), itemDirectory, limitDepth, maxDepth)
+	repository.sendUpdate(dataaccess.NewUpdate(nil, []route.Route{item.Route()}, nil))
+
+	return config.FilesDirectoryName + "/" + filepath.Base(filePath), nil
+}
+
+// getHistoryPaths returns the paths of the markdown file and the files of the item with the given
+// route relative to the working copy.
+func (repository *Repository) getHistoryPaths(route route.Route) ([]string, error) {
+	if !repository.HasHistory() {
+		return nil, fmt.Errorf("The repository %q is not a git working copy.", repository.directory)
+	}
+
+	item := repository.Item(route)
+	if item == nil {
+		return nil, fmt.Errorf("The item %q was not found.", route)
+	}
+
+	itemDirectory := item.(*Item).Directory()
+	filesDirectory := filepath.Join(itemDirectory, config.FilesDirectoryName)
+
+	var paths []string
+	switch item.Type() {
+	case dataaccess.TypePhysical:
+		_, markdownFile := findMarkdownFileInDirectory(itemDirectory)
+		paths = []string{markdownFile, filesDirectory}
+
+	case dataaccess.TypeVirtual:
+		paths = []string{filesDirectory}
+
+	default:
+		paths = []string{itemDirectory}
+	}
+
+	relativePaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		relativePath, err := repository.history.RelativePath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		relativePaths = append(relativePaths, relativePath)
+	}
+
+	return relativePaths, nil
+}
+
 // Initialize the repository - scan all folders and update the index.
 func (repository *Repository) init() {
 
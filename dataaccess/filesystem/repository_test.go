@@ -0,0 +1,195 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filesystem
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+)
+
+func Test_WriteItem_CurrentHash_FileIsReplacedAndUpdateIsSent(t *testing.T) {
+	// arrange
+	directory := newTestDirectory(t, map[string]string{
+		"readme.md":           "# Root",
+		"documents/readme.md": "# Documents",
+	})
+
+	repository := newTestRepository(t, directory)
+	updates := make(chan dataaccess.Update, 1)
+	repository.Subscribe(updates)
+
+	itemRoute := route.NewFromRequest("documents")
+	item := repository.Item(itemRoute)
+
+	// act
+	err := repository.WriteItem(itemRoute, []byte("# Documents (changed)"), item.LastHash())
+
+	// assert
+	if err != nil {
+		t.Fatalf("WriteItem returned an error: %s", err)
+	}
+
+	content, _ := ioutil.ReadFile(filepath.Join(directory, "documents", "readme.md"))
+	if string(content) != "# Documents (changed)" {
+		t.Errorf("The markdown file should contain the new content but contains %q.", string(content))
+	}
+
+	select {
+	case update := <-updates:
+		if len(update.Modified()) != 1 || update.Modified()[0].Value() != "documents" {
+			t.Errorf("The update should contain the modified item %q but was %s.", "documents", update.String())
+		}
+	default:
+		t.Errorf("WriteItem should send an update to the subscribers.")
+	}
+
+	if files, _ := ioutil.ReadDir(filepath.Join(directory, "documents")); len(files) != 1 {
+		t.Errorf("The item directory should only contain the markdown file but contains %d files.", len(files))
+	}
+}
+
+func Test_WriteItem_FileChangedInTheMeantime_ConflictIsReturned(t *testing.T) {
+	// arrange
+	directory := newTestDirectory(t, map[string]string{
+		"readme.md": "# Root",
+	})
+
+	repository := newTestRepository(t, directory)
+	lastHash := repository.Item(route.New()).LastHash()
+
+	markdownFile := filepath.Join(directory, "readme.md")
+	ioutil.WriteFile(markdownFile, []byte("# Root (changed by someone else)"), 0644)
+
+	// act
+	err := repository.WriteItem(route.New(), []byte("# Root (changed)"), lastHash)
+
+	// assert
+	if err != dataaccess.ErrConflict {
+		t.Errorf("WriteItem should return %q but returned %v.", dataaccess.ErrConflict, err)
+	}
+
+	content, _ := ioutil.ReadFile(markdownFile)
+	if string(content) != "# Root (changed by someone else)" {
+		t.Errorf("The markdown file should not have been changed but contains %q.", string(content))
+	}
+}
+
+func Test_WriteItem_VirtualItem_ErrorIsReturned(t *testing.T) {
+	// arrange
+	directory := newTestDirectory(t, map[string]string{
+		"readme.md":                 "# Root",
+		"documents/example/item.md": "# Example",
+	})
+
+	repository := newTestRepository(t, directory)
+	itemRoute := route.NewFromRequest("documents")
+
+	// act
+	err := repository.WriteItem(itemRoute, []byte("# Documents"), repository.Item(itemRoute).LastHash())
+
+	// assert
+	if err == nil || repository.CanWrite(itemRoute) {
+		t.Errorf("Virtual items should not be writable.")
+	}
+}
+
+func Test_WriteFile_NameIsTaken_NumberIsAppended(t *testing.T) {
+	// arrange
+	directory := newTestDirectory(t, map[string]string{
+		"readme.md":       "# Root",
+		"files/image.png": "old",
+	})
+
+	repository := newTestRepository(t, directory)
+	updates := make(chan dataaccess.Update, 1)
+	repository.Subscribe(updates)
+
+	// act
+	path, err := repository.WriteFile(route.New(), "image.png", strings.NewReader("new"))
+
+	// assert
+	if err != nil {
+		t.Fatalf("WriteFile returned an error: %s", err)
+	}
+
+	if path != "files/image-2.png" {
+		t.Errorf("The file should be stored as %q but was stored as %q.", "files/image-2.png", path)
+	}
+
+	if content, _ := ioutil.ReadFile(filepath.Join(directory, "files", "image.png")); string(content) != "old" {
+		t.Errorf("The existing file should not be replaced but contains %q.", string(content))
+	}
+
+	select {
+	case update := <-updates:
+		if len(update.Modified()) != 1 {
+			t.Errorf("The update should contain the modified item but was %s.", update.String())
+		}
+	default:
+		t.Errorf("WriteFile should send an update to the subscribers.")
+	}
+}
+
+func Test_WriteFile_InvalidName_ErrorIsReturned(t *testing.T) {
+	// arrange
+	directory := newTestDirectory(t, map[string]string{
+		"readme.md": "# Root",
+	})
+
+	repository := newTestRepository(t, directory)
+
+	for _, name := range []string{"", ".htaccess", "../readme.md", `..\readme.md`, "files/image.png"} {
+
+		// act
+		_, err := repository.WriteFile(route.New(), name, strings.NewReader("content"))
+
+		// assert
+		if err == nil {
+			t.Errorf("WriteFile(%q) should return an error.", name)
+		}
+	}
+
+	if content, _ := ioutil.ReadFile(filepath.Join(directory, "readme.md")); string(content) != "# Root" {
+		t.Errorf("The markdown file should not have been changed but contains %q.", string(content))
+	}
+}
+
+func newTestRepository(t *testing.T, directory string) *Repository {
+	repository, err := NewRepository(console.New(loglevel.Off), directory, *config.Default(directory))
+	if err != nil {
+		t.Fatalf("NewRepository returned an error: %s", err)
+	}
+
+	return repository
+}
+
+// newTestDirectory creates a temporary directory with the given files.
+func newTestDirectory(t *testing.T, files map[string]string) string {
+	directory, err := ioutil.TempDir("", "allmark-filesystem-test")
+	if err != nil {
+		t.Fatalf("Cannot create a temporary directory. Error: %s", err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(directory) })
+
+	for path, content := range files {
+		filePath := filepath.Join(directory, filepath.FromSlash(path))
+		os.MkdirAll(filepath.Dir(filePath), 0755)
+		if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Cannot create the file %q. Error: %s", filePath, err)
+		}
+	}
+
+	return directory
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/andreaskoch/allmark/common/config"
 	"github.com/andreaskoch/allmark/common/util/fsutil"
 	"io/ioutil"
+	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
 )
@@ -128,3 +131,96 @@ func isMarkdownFile(fileNameOrPath string) bool {
 
 	panic("Unreachable")
 }
+
+// isValidFileName checks whether the given name can be used for a new file in a files directory.
+// Paths and hidden files are not allowed.
+func isValidFileName(name string) bool {
+	if name == "" || strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	return !strings.ContainsAny(name, `/\`) && filepath.Base(name) == name
+}
+
+// getUnusedFilePath returns the path of a file with the given name in the given directory
+// that does not exist yet. If the name is taken a number is appended to it (e.g. "image-2.png").
+func getUnusedFilePath(directory, name string) string {
+	extension := filepath.Ext(name)
+	baseName := strings.TrimSuffix(name, extension)
+
+	filePath := filepath.Join(directory, name)
+	for number := 2; fsutil.PathExists(filePath); number++ {
+		filePath = filepath.Join(directory, fmt.Sprintf("%s-%d%s", baseName, number, extension))
+	}
+
+	return filePath
+}
+
+// createFileAtomically creates a new file with the given content. The content is written to a hidden
+// temporary file in the parent directory of the file's directory first so the file is never indexed partially.
+func createFileAtomically(path string, content io.Reader) error {
+
+	temporaryFile, err := ioutil.TempFile(filepath.Dir(filepath.Dir(path)), "."+filepath.Base(path)+".")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(temporaryFile, content); err != nil {
+		temporaryFile.Close()
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	if err := temporaryFile.Close(); err != nil {
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	if err := os.Chmod(temporaryFile.Name(), 0644); err != nil {
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	return os.Rename(temporaryFile.Name(), path)
+}
+
+// writeFileAtomically replaces the content of the given file. The content is written to a temporary
+// file in the same directory first so readers either see the old or the new content, never a partial file.
+func writeFileAtomically(path string, content []byte) error {
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	// the temporary file does not have a markdown file extension so it is never indexed
+	temporaryFile, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".")
+	if err != nil {
+		return err
+	}
+
+	if _, err := temporaryFile.Write(content); err != nil {
+		temporaryFile.Close()
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	if err := temporaryFile.Sync(); err != nil {
+		temporaryFile.Close()
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	if err := temporaryFile.Close(); err != nil {
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	// keep the permissions of the original file
+	if err := os.Chmod(temporaryFile.Name(), fileInfo.Mode().Perm()); err != nil {
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	return os.Rename(temporaryFile.Name(), path)
+}
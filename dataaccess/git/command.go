@@ -0,0 +1,200 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commitTimeMarker prefixes the commit time lines in the output of "git log".
+const commitTimeMarker = "\x01"
+
+// treeEntry is a blob in the tree of a commit.
+type treeEntry struct {
+	hash string
+
+	// path is the slash-separated path of the blob relative to the repository root.
+	path string
+}
+
+// newGitCommand creates a git command runner for the given repository
+// directory which can either be a bare repository or a working copy.
+func newGitCommand(directory string) (*gitCommand, error) {
+
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		return nil, fmt.Errorf("The git executable was not found. Error: %s", err.Error())
+	}
+
+	output, err := exec.Command(gitPath, "-C", directory, "rev-parse", "--absolute-git-dir").Output()
+	if err != nil {
+		return nil, fmt.Errorf("The path %q is not a git repository. Error: %s", directory, err.Error())
+	}
+
+	return &gitCommand{
+		gitPath:      gitPath,
+		gitDirectory: strings.TrimSpace(string(output)),
+	}, nil
+}
+
+type gitCommand struct {
+	gitPath      string
+	gitDirectory string
+}
+
+// run executes git with the given arguments against the object database
+// of the repository and returns the standard output.
+func (command *gitCommand) run(arguments ...string) ([]byte, error) {
+
+	arguments = append([]string{"--git-dir=" + command.gitDirectory, "-c", "core.quotepath=off"}, arguments...)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(command.gitPath, arguments...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s failed. Error: %s %s", strings.Join(arguments, " "), err.Error(), strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// resolve returns the commit hash the given ref (branch, tag or commit) points to.
+func (command *gitCommand) resolve(ref string) (string, error) {
+	output, err := command.run("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("The ref %q cannot be resolved to a commit.", ref)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// fullName returns the full name of the given ref (e.g. "refs/heads/main" for "main")
+// or, if the ref is not a branch or tag, the hash of the commit it points to.
+func (command *gitCommand) fullName(ref string) (string, error) {
+	output, err := command.run("rev-parse", "--symbolic-full-name", ref)
+	if err != nil {
+		return "", fmt.Errorf("The ref %q cannot be resolved. Error: %s", ref, err.Error())
+	}
+
+	if name := strings.TrimSpace(string(output)); name != "" {
+		return name, nil
+	}
+
+	return command.resolve(ref)
+}
+
+// commitTime returns the commit time of the given commit.
+func (command *gitCommand) commitTime(commit string) (time.Time, error) {
+	output, err := command.run("show", "--no-patch", "--format=%ct", commit)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parseUnixTimestamp(strings.TrimSpace(string(output)))
+}
+
+// listTree returns all blobs in the tree of the given commit.
+func (command *gitCommand) listTree(commit string) ([]treeEntry, error) {
+	output, err := command.run("ls-tree", "-r", "-z", "--full-tree", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]treeEntry, 0)
+	for _, line := range strings.Split(string(output), "\x00") {
+		if line == "" {
+			continue
+		}
+
+		// format: <mode> SP <type> SP <object> TAB <path>
+		tabPosition := strings.Index(line, "\t")
+		if tabPosition == -1 {
+			return nil, fmt.Errorf("Unexpected ls-tree output %q.", line)
+		}
+
+		fields := strings.Fields(line[:tabPosition])
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("Unexpected ls-tree output %q.", line)
+		}
+
+		// skip submodules and other non-blob entries
+		if fields[1] != "blob" {
+			continue
+		}
+
+		entries = append(entries, treeEntry{
+			hash: fields[2],
+			path: line[tabPosition+1:],
+		})
+	}
+
+	return entries, nil
+}
+
+// readBlob returns the content of the blob with the given hash.
+func (command *gitCommand) readBlob(hash string) ([]byte, error) {
+	return command.run("cat-file", "blob", hash)
+}
+
+// lastModificationTimes returns the time of the last commit which changed
+// each path in the history of the given commit.
+func (command *gitCommand) lastModificationTimes(commit string) (map[string]time.Time, error) {
+	output, err := command.run("log", "--format="+commitTimeMarker+"%ct", "--name-only", "--no-renames", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	times := make(map[string]time.Time)
+
+	var commitTime time.Time
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, commitTimeMarker) {
+			commitTime, err = parseUnixTimestamp(strings.TrimPrefix(line, commitTimeMarker))
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		// paths with special characters are quoted
+		path := line
+		if strings.HasPrefix(path, `"`) {
+			if unquoted, err := strconv.Unquote(path); err == nil {
+				path = unquoted
+			}
+		}
+
+		// the log is ordered from the newest to the oldest commit
+		if _, exists := times[path]; !exists {
+			times[path] = commitTime
+		}
+	}
+
+	return times, scanner.Err()
+}
+
+func parseUnixTimestamp(value string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Cannot parse the timestamp %q. Error: %s", value, err.Error())
+	}
+
+	return time.Unix(seconds, 0), nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/content"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/common/util/hashutil"
+)
+
+// newBlobContentProvider creates a content provider which reads the given blob from the object database.
+func newBlobContentProvider(snapshot *snapshot, entry treeEntry, route route.Route) (*content.ContentProvider, error) {
+
+	// mimeType
+	mimeType := func() (string, error) {
+		contentType := mime.TypeByExtension(path.Ext(entry.path))
+		if contentType != "" {
+			return contentType, nil
+		}
+
+		// fallback: derive content type from data
+		data, err := snapshot.command.readBlob(entry.hash)
+		if err != nil {
+			return "", err
+		}
+
+		return http.DetectContentType(data), nil
+	}
+
+	// content provider
+	dataProvider := func(callback func(content io.ReadSeeker) error) error {
+		data, err := snapshot.command.readBlob(entry.hash)
+		if err != nil {
+			return err
+		}
+
+		return callback(bytes.NewReader(data))
+	}
+
+	// hash provider
+	// blobs are immutable so the object hash identifies the content
+	hashProvider := func() (string, error) {
+
+		routeHash, routeHashErr := getStringHash(route.String())
+		if routeHashErr != nil {
+			return "", fmt.Errorf("Unable to determine the hash for route %q. Error: %s", route, routeHashErr)
+		}
+
+		return fmt.Sprintf("%s%s", routeHash, entry.hash), nil
+	}
+
+	// last modified provider
+	lastModifiedProvider := func() (time.Time, error) {
+		return snapshot.LastModified(entry.path), nil
+	}
+
+	return content.NewContentProvider(mimeType, dataProvider, hashProvider, lastModifiedProvider)
+}
+
+func newTextContentProvider(text string, route route.Route) (*content.ContentProvider, error) {
+
+	// mimeType
+	mimeType := func() (string, error) {
+		return "text/x-markdown", nil
+	}
+
+	// content provider
+	dataProvider := func(callback func(content io.ReadSeeker) error) error {
+		contentReader := strings.NewReader(text)
+		return callback(contentReader)
+	}
+
+	// hash provider
+	hashProvider := func() (string, error) {
+
+		routeHash, routeHashErr := getStringHash(route.String())
+		if routeHashErr != nil {
+			return "", fmt.Errorf("Unable to determine the hash for route %q. Error: %s", route, routeHashErr)
+		}
+
+		contentHash, contentHashErr := getStringHash(text)
+		if contentHashErr != nil {
+			return "", fmt.Errorf("Unable to determine the hash for content %q. Error: %s", text, contentHashErr)
+		}
+
+		return fmt.Sprintf("%s%s", routeHash, contentHash), nil
+	}
+
+	// last modified provider
+	lastModifiedProvider := func() (time.Time, error) {
+		return time.Time{}, nil
+	}
+
+	return content.NewContentProvider(mimeType, dataProvider, hashProvider, lastModifiedProvider)
+}
+
+func getStringHash(text string) (string, error) {
+	return hashutil.GetHash(strings.NewReader(text))
+}
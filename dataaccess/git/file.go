@@ -0,0 +1,39 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"github.com/andreaskoch/allmark/common/content"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/common/util/hashutil"
+)
+
+// A File is a blob which belongs to an item.
+type File struct {
+	*content.ContentProvider
+
+	parentRoute route.Route
+	fileRoute   route.Route
+}
+
+func (file *File) String() string {
+	return file.fileRoute.Value()
+}
+
+func (file *File) Id() string {
+	return hashutil.FromString(file.fileRoute.Value())
+}
+
+func (file *File) Name() string {
+	return file.fileRoute.LastComponentName()
+}
+
+func (file *File) Parent() route.Route {
+	return file.parentRoute
+}
+
+func (file *File) Route() route.Route {
+	return file.fileRoute
+}
@@ -0,0 +1,155 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/andreaskoch/allmark/dataaccess"
+)
+
+const (
+	// revisionFieldSeparator separates the fields of a revision in the output of "git log".
+	revisionFieldSeparator = "\x1f"
+
+	// revisionSeparator separates the revisions in the output of "git log".
+	revisionSeparator = "\x1e"
+)
+
+// revisions must be (abbreviated) commit hashes so they cannot be mistaken for options or other refs.
+var revisionPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// NewHistory creates a history reader for the git repository which contains the given directory.
+func NewHistory(directory string) (*History, error) {
+
+	command, err := newGitCommand(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	// bare repositories don't have a top-level directory
+	topLevelDirectory := ""
+	if output, err := exec.Command(command.gitPath, "-C", directory, "rev-parse", "--show-toplevel").Output(); err == nil {
+		topLevelDirectory = strings.TrimSpace(string(output))
+	}
+
+	return &History{
+		command:           command,
+		topLevelDirectory: topLevelDirectory,
+	}, nil
+}
+
+// History reads the revision history of paths in a git repository.
+type History struct {
+	command           *gitCommand
+	topLevelDirectory string
+}
+
+// RelativePath returns the slash-separated path of the given file or directory relative to
+// the top-level directory of the working copy.
+func (history *History) RelativePath(path string) (string, error) {
+	if history.topLevelDirectory == "" {
+		return "", fmt.Errorf("The repository %q does not have a working copy.", history.command.gitDirectory)
+	}
+
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	// resolve symlinks because git reports the physical top-level directory
+	// (the path itself might not exist, e.g. an item without a files directory)
+	if resolvedPath, err := filepath.EvalSymlinks(absolutePath); err == nil {
+		absolutePath = resolvedPath
+	} else if resolvedDirectory, err := filepath.EvalSymlinks(filepath.Dir(absolutePath)); err == nil {
+		absolutePath = filepath.Join(resolvedDirectory, filepath.Base(absolutePath))
+	}
+
+	relativePath, err := filepath.Rel(history.topLevelDirectory, absolutePath)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return "", fmt.Errorf("The path %q is not inside the working copy %q.", path, history.topLevelDirectory)
+	}
+
+	return filepath.ToSlash(relativePath), nil
+}
+
+// Revisions returns the commits in the history of the given ref which changed any of the given paths (newest first).
+func (history *History) Revisions(ref string, paths ...string) ([]dataaccess.Revision, error) {
+
+	format := strings.Join([]string{"%H", "%an", "%ae", "%at", "%B"}, revisionFieldSeparator) + revisionSeparator
+	arguments := append([]string{"log", "--format=" + format, ref, "--"}, getPathspecs(paths)...)
+
+	output, err := history.command.run(arguments...)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]dataaccess.Revision, 0)
+	for _, record := range strings.Split(string(output), revisionSeparator) {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, revisionFieldSeparator, 5)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("Unexpected log output %q.", record)
+		}
+
+		date, err := parseUnixTimestamp(fields[3])
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, dataaccess.Revision{
+			Id:          fields[0],
+			Author:      fields[1],
+			AuthorEmail: fields[2],
+			Date:        date,
+			Message:     strings.TrimSpace(fields[4]),
+		})
+	}
+
+	return revisions, nil
+}
+
+// Diff returns the unified diff of the given paths between the two revisions.
+func (history *History) Diff(fromRevision, toRevision string, paths ...string) (string, error) {
+
+	for _, revision := range []string{fromRevision, toRevision} {
+		if !revisionPattern.MatchString(revision) {
+			return "", fmt.Errorf("%q is not a valid revision.", revision)
+		}
+	}
+
+	arguments := append([]string{"diff", "--no-color", "--no-ext-diff", "--find-renames", fromRevision, toRevision, "--"}, getPathspecs(paths)...)
+
+	output, err := history.command.run(arguments...)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+// getPathspecs converts the given repository-relative paths into pathspecs which are
+// independent of the current directory and don't interpret wildcards.
+func getPathspecs(paths []string) []string {
+	pathspecs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path == "" || path == "." {
+			pathspecs = append(pathspecs, ":(top)")
+			continue
+		}
+
+		pathspecs = append(pathspecs, ":(top,literal)"+path)
+	}
+
+	return pathspecs
+}
@@ -0,0 +1,107 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/common/route"
+)
+
+func Test_Revisions_OnlyCommitsWhichChangedTheItemAreReturned(t *testing.T) {
+	// arrange
+	bareRepository := newTestRepository(t, map[string]string{
+		"readme.md":           "# Root",
+		"documents/readme.md": "# Documents",
+	})
+
+	commitFiles(t, bareRepository, map[string]string{
+		"documents/files/image.png": "png",
+	})
+
+	commitFiles(t, bareRepository, map[string]string{
+		"readme.md": "# Root (changed)",
+	})
+
+	repository, err := NewRepository(console.New(loglevel.Off), bareRepository, "master", testConfig())
+	if err != nil {
+		t.Fatalf("NewRepository returned an error: %s", err)
+	}
+
+	// act
+	revisions, err := repository.Revisions(route.NewFromRequest("documents"))
+
+	// assert
+	if err != nil {
+		t.Fatalf("Revisions returned an error: %s", err)
+	}
+
+	if len(revisions) != 2 {
+		t.Fatalf("The item should have 2 revisions but has %d.", len(revisions))
+	}
+
+	if revisions[0].Author != "test" || revisions[0].Message != "test" || revisions[0].Date.IsZero() {
+		t.Errorf("The revision was not read correctly: %v", revisions[0])
+	}
+}
+
+func Test_Diff_ChangesOfTheItemAreReturned(t *testing.T) {
+	// arrange
+	bareRepository := newTestRepository(t, map[string]string{
+		"readme.md":           "# Root",
+		"documents/readme.md": "# Documents",
+	})
+
+	commitFiles(t, bareRepository, map[string]string{
+		"readme.md":           "# Root (changed)",
+		"documents/readme.md": "# Documents (changed)",
+	})
+
+	repository, err := NewRepository(console.New(loglevel.Off), bareRepository, "master", testConfig())
+	if err != nil {
+		t.Fatalf("NewRepository returned an error: %s", err)
+	}
+
+	itemRoute := route.NewFromRequest("documents")
+	revisions, _ := repository.Revisions(itemRoute)
+	if len(revisions) != 2 {
+		t.Fatalf("The item should have 2 revisions but has %d.", len(revisions))
+	}
+
+	// act
+	diff, err := repository.Diff(itemRoute, revisions[1].Id, revisions[0].Id)
+
+	// assert
+	if err != nil {
+		t.Fatalf("Diff returned an error: %s", err)
+	}
+
+	if !strings.Contains(diff, "+# Documents (changed)") || strings.Contains(diff, "Root") {
+		t.Errorf("The diff should only contain the changes of the item but was:\n%s", diff)
+	}
+}
+
+func Test_Diff_InvalidRevision_ErrorIsReturned(t *testing.T) {
+	// arrange
+	bareRepository := newTestRepository(t, map[string]string{
+		"readme.md": "# Root",
+	})
+
+	repository, err := NewRepository(console.New(loglevel.Off), bareRepository, "master", testConfig())
+	if err != nil {
+		t.Fatalf("NewRepository returned an error: %s", err)
+	}
+
+	// act
+	_, err = repository.Diff(route.New(), "--output=/tmp/diff", "master")
+
+	// assert
+	if err == nil {
+		t.Errorf("Diff should return an error for invalid revisions.")
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"github.com/andreaskoch/allmark/common/content"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/common/util/hashutil"
+	"github.com/andreaskoch/allmark/dataaccess"
+)
+
+// Create a new item with the given item type.
+func newItem(itemType dataaccess.ItemType,
+	route route.Route,
+	contentProvider *content.ContentProvider,
+	files []dataaccess.File,
+	paths []string) dataaccess.Item {
+
+	return &Item{
+		contentProvider,
+		itemType,
+		route,
+		files,
+		paths,
+	}
+
+}
+
+// An Item represents a single document at the selected ref of a git repository.
+type Item struct {
+	*content.ContentProvider
+
+	itemType dataaccess.ItemType
+	route    route.Route
+	files    []dataaccess.File
+
+	// the repository-relative paths of the blobs which make up this item
+	paths []string
+}
+
+func (item *Item) String() string {
+	return item.route.String()
+}
+
+func (item *Item) Id() string {
+	return hashutil.FromString(item.route.Value())
+}
+
+// Get the type of this item (e.g. "physical", "virtual", ...)
+func (item *Item) Type() dataaccess.ItemType {
+	return item.itemType
+}
+
+// Gets a flag inidicating whether this item can have children or not.
+func (item *Item) CanHaveChildren() bool {
+	return item.itemType != dataaccess.TypeFileCollection
+}
+
+// Get the route of this item.
+func (item *Item) Route() route.Route {
+	return item.route
+}
+
+// Get the files of this item. Returns a slice of zero or more files.
+func (item *Item) Files() []dataaccess.File {
+	return item.files
+}
+
+// Paths returns the repository-relative paths of the markdown file and the files of this item.
+func (item *Item) Paths() []string {
+	return item.paths
+}
@@ -0,0 +1,179 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+)
+
+// newItemProvider creates an item provider which derives the items from the
+// directory tree of a commit using the same rules as the filesystem repository.
+func newItemProvider(logger logger.Logger, repositoryPath string, snapshot *snapshot) *itemProvider {
+	return &itemProvider{
+		logger:         logger,
+		repositoryPath: repositoryPath,
+		snapshot:       snapshot,
+	}
+}
+
+type itemProvider struct {
+	logger         logger.Logger
+	repositoryPath string
+
+	snapshot *snapshot
+}
+
+// GetAllItems returns all items of the snapshot. Parents are returned before their children.
+func (itemProvider *itemProvider) GetAllItems() []dataaccess.Item {
+	return itemProvider.getItemsFromDirectory(itemProvider.snapshot.root)
+}
+
+func (itemProvider *itemProvider) getItemsFromDirectory(itemDirectory *treeDirectory) []dataaccess.Item {
+
+	items := make([]dataaccess.Item, 0)
+
+	item, err := itemProvider.GetItemFromDirectory(itemDirectory)
+	if err != nil {
+		itemProvider.logger.Error("Could not create an item from folder %q. Error: %s", itemDirectory.path, err.Error())
+		return items
+	}
+
+	items = append(items, item)
+
+	// abort if the item cannot have children
+	if !item.CanHaveChildren() {
+		return items
+	}
+
+	// recurse for child items
+	for _, childDirectory := range itemDirectory.ChildDirectories() {
+		items = append(items, itemProvider.getItemsFromDirectory(childDirectory)...)
+	}
+
+	return items
+}
+
+func (itemProvider *itemProvider) GetItemFromDirectory(itemDirectory *treeDirectory) (dataaccess.Item, error) {
+
+	// physical item from markdown file
+	if found, markdownFile := itemDirectory.MarkdownFile(); found {
+		return itemProvider.newPhysicalItem(itemDirectory, markdownFile)
+	}
+
+	// virtual item
+	if itemDirectory.ContainsItems(3) {
+		return itemProvider.newVirtualItem(itemDirectory)
+	}
+
+	// file collection item
+	return itemProvider.newFileCollectionItem(itemDirectory)
+}
+
+func (itemProvider *itemProvider) newPhysicalItem(itemDirectory *treeDirectory, markdownFile treeEntry) (dataaccess.Item, error) {
+
+	route := route.NewFromItemPath(itemProvider.repositoryPath, itemProvider.getPath(markdownFile.path))
+	itemProvider.logger.Debug("Creating a physical item from route %q", route)
+
+	// content
+	contentProvider, contentProviderError := newBlobContentProvider(itemProvider.snapshot, markdownFile, route)
+	if contentProviderError != nil {
+		return nil, contentProviderError
+	}
+
+	// files
+	files := itemProvider.getFiles(itemDirectory, itemDirectory.Directory(config.FilesDirectoryName))
+
+	paths := []string{markdownFile.path, path.Join(itemDirectory.path, config.FilesDirectoryName)}
+	return newItem(dataaccess.TypePhysical, route, contentProvider, files, paths), nil
+}
+
+func (itemProvider *itemProvider) newVirtualItem(itemDirectory *treeDirectory) (dataaccess.Item, error) {
+
+	route := route.NewFromItemDirectory(itemProvider.repositoryPath, itemProvider.getPath(itemDirectory.path))
+	itemProvider.logger.Debug("Creating a virtual item from route %q", route)
+
+	// content
+	content := fmt.Sprintf(`# %s`, itemProvider.getTitle(itemDirectory))
+	contentProvider, contentProviderError := newTextContentProvider(content, route)
+	if contentProviderError != nil {
+		return nil, contentProviderError
+	}
+
+	// files
+	files := itemProvider.getFiles(itemDirectory, itemDirectory.Directory(config.FilesDirectoryName))
+
+	paths := []string{path.Join(itemDirectory.path, config.FilesDirectoryName)}
+	return newItem(dataaccess.TypeVirtual, route, contentProvider, files, paths), nil
+}
+
+func (itemProvider *itemProvider) newFileCollectionItem(itemDirectory *treeDirectory) (dataaccess.Item, error) {
+
+	route := route.NewFromItemDirectory(itemProvider.repositoryPath, itemProvider.getPath(itemDirectory.path))
+	itemProvider.logger.Debug("Creating a file collection item from route %q", route)
+
+	// content
+	content := fmt.Sprintf(`# %s
+
+files: [Attachments](/)`, itemProvider.getTitle(itemDirectory))
+	contentProvider, contentProviderError := newTextContentProvider(content, route)
+	if contentProviderError != nil {
+		return nil, contentProviderError
+	}
+
+	// files
+	files := itemProvider.getFiles(itemDirectory, itemDirectory)
+
+	paths := []string{itemDirectory.path}
+	return newItem(dataaccess.TypeFileCollection, route, contentProvider, files, paths), nil
+}
+
+// getFiles returns all files in the given files directory and its sub directories.
+func (itemProvider *itemProvider) getFiles(itemDirectory, filesDirectory *treeDirectory) []dataaccess.File {
+
+	files := make([]dataaccess.File, 0)
+	if filesDirectory == nil {
+		return files
+	}
+
+	parentRoute := route.NewFromFilePath(itemProvider.repositoryPath, itemProvider.getPath(itemDirectory.path))
+	for _, entry := range filesDirectory.AllFiles() {
+
+		fileRoute := route.NewFromFilePath(itemProvider.repositoryPath, itemProvider.getPath(entry.path))
+		contentProvider, err := newBlobContentProvider(itemProvider.snapshot, entry, fileRoute)
+		if err != nil {
+			itemProvider.logger.Error("Unable to add file %q to index. Error: %s", entry.path, err)
+			continue
+		}
+
+		files = append(files, &File{
+			contentProvider,
+			parentRoute,
+			fileRoute,
+		})
+	}
+
+	return files
+}
+
+// getTitle returns the title of virtual and file collection items.
+func (itemProvider *itemProvider) getTitle(itemDirectory *treeDirectory) string {
+	if itemDirectory.path == "" {
+		return filepath.Base(itemProvider.repositoryPath)
+	}
+
+	return itemDirectory.Name()
+}
+
+// getPath returns the given repository-relative path as if the tree was checked out in the repository path.
+func (itemProvider *itemProvider) getPath(relativePath string) string {
+	return filepath.Join(itemProvider.repositoryPath, filepath.FromSlash(relativePath))
+}
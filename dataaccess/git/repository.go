@@ -0,0 +1,320 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package git serves the items of a git repository at a given ref (branch, tag or commit)
+// straight from the object database. No working tree is required so the same bare clone
+// can be used to serve different refs side by side.
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+)
+
+type Repository struct {
+	logger    logger.Logger
+	directory string
+	ref       string
+	name      string
+
+	command *gitCommand
+	history *History
+
+	// the commit the ref pointed to when the index was created
+	commit string
+
+	// refreshLock serializes the refreshes (e.g. of the ref watcher and a manual reindex)
+	refreshLock sync.Mutex
+
+	indexLock sync.RWMutex
+	items     []dataaccess.Item
+	routeMap  map[string]dataaccess.Item
+
+	// Update Subscription
+	updateSubscribers []chan dataaccess.Update
+}
+
+// NewRepository creates a repository which serves the items of the git repository
+// in the given directory at the given ref. The directory can be a bare repository or
+// a working copy; the working tree is never read.
+func NewRepository(logger logger.Logger, directory, ref string, config config.Config) (*Repository, error) {
+
+	directory, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot determine the absolute path of %q. Error: %s", directory, err.Error())
+	}
+
+	command, err := newGitCommand(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := command.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := command.fullName(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &Repository{
+		logger:    logger,
+		directory: directory,
+		ref:       ref,
+		name:      name,
+
+		command: command,
+		history: &History{command: command},
+
+		routeMap: make(map[string]dataaccess.Item),
+
+		updateSubscribers: make([]chan dataaccess.Update, 0),
+	}
+
+	// index the repository
+	if err := repository.update(commit); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Serving %q at ref %q (%s)", directory, ref, commit)
+
+	// watch the ref
+	repository.watchRef(config.Indexing.IntervalInSeconds)
+
+	return repository, nil
+}
+
+func (repository *Repository) Path() string {
+	return repository.directory
+}
+
+// Ref returns the full name of the served ref (e.g. "refs/heads/main")
+// or the commit hash if a commit is served.
+func (repository *Repository) Ref() string {
+	return repository.name
+}
+
+func (repository *Repository) Items() []dataaccess.Item {
+	repository.indexLock.RLock()
+	defer repository.indexLock.RUnlock()
+
+	return repository.items
+}
+
+func (repository *Repository) Item(itemRoute route.Route) dataaccess.Item {
+	repository.indexLock.RLock()
+	defer repository.indexLock.RUnlock()
+
+	return repository.routeMap[route.ToKey(itemRoute)]
+}
+
+func (repository *Repository) Routes() []route.Route {
+	return itemsToRoutes(repository.Items())
+}
+
+// Subscribe registers the supplied updates channel in the repository.
+// All updates (new, modified or deleted items) caused by a moving ref will be passed down this channel.
+func (repository *Repository) Subscribe(updates chan dataaccess.Update) {
+	repository.updateSubscribers = append(repository.updateSubscribers, updates)
+}
+
+// StartWatching is not supported because the items can only change when the ref moves.
+func (repository *Repository) StartWatching(route route.Route) {
+	repository.logger.Debug("Live reload is not available for git repositories.")
+}
+
+// StopWatching is not supported because the items can only change when the ref moves.
+func (repository *Repository) StopWatching(route route.Route) {
+}
+
+// HasHistory indicates whether a revision history is available for this repository.
+func (repository *Repository) HasHistory() bool {
+	return true
+}
+
+// Revisions returns the commits in the history of the ref which changed the item with the given route.
+func (repository *Repository) Revisions(itemRoute route.Route) ([]dataaccess.Revision, error) {
+	item, commit, err := repository.getItemAndCommit(itemRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	return repository.history.Revisions(commit, item.Paths()...)
+}
+
+// Diff returns the unified diff of the item with the given route between the two revisions.
+func (repository *Repository) Diff(itemRoute route.Route, fromRevision, toRevision string) (string, error) {
+	item, _, err := repository.getItemAndCommit(itemRoute)
+	if err != nil {
+		return "", err
+	}
+
+	return repository.history.Diff(fromRevision, toRevision, item.Paths()...)
+}
+
+// getItemAndCommit returns the item with the given route and the commit it was read from.
+func (repository *Repository) getItemAndCommit(itemRoute route.Route) (*Item, string, error) {
+	repository.indexLock.RLock()
+	defer repository.indexLock.RUnlock()
+
+	item, exists := repository.routeMap[route.ToKey(itemRoute)]
+	if !exists {
+		return nil, "", fmt.Errorf("The item %q was not found.", itemRoute)
+	}
+
+	return item.(*Item), repository.commit, nil
+}
+
+// watchRef periodically checks if the ref points to a different commit.
+func (repository *Repository) watchRef(intervalInSeconds int) {
+
+	if intervalInSeconds <= 1 {
+		repository.logger.Info("Watching ref %q: Off", repository.ref)
+		return
+	}
+
+	repository.logger.Info("Watching ref %q: On (every %d seconds)", repository.ref, intervalInSeconds)
+
+	go func() {
+		sleepInterval := time.Second * time.Duration(intervalInSeconds)
+
+		for {
+
+			// wait for the next turn
+			time.Sleep(sleepInterval)
+
+			if err := repository.Refresh(); err != nil {
+				repository.logger.Error("%s", err)
+			}
+		}
+	}()
+}
+
+// Refresh updates the index if the ref points to a different commit than before.
+func (repository *Repository) Refresh() error {
+	repository.refreshLock.Lock()
+	defer repository.refreshLock.Unlock()
+
+	commit, err := repository.command.resolve(repository.ref)
+	if err != nil {
+		return err
+	}
+
+	if commit == repository.commit {
+		return nil
+	}
+
+	repository.logger.Info("The ref %q moved from %s to %s.", repository.ref, repository.commit, commit)
+	return repository.update(commit)
+}
+
+// update replaces the index with the items of the given commit and notifies all subscribers about the changes.
+func (repository *Repository) update(commit string) error {
+
+	snapshot, err := newSnapshot(repository.command, commit)
+	if err != nil {
+		return fmt.Errorf("Cannot read the tree of commit %q. Error: %s", commit, err.Error())
+	}
+
+	newItems := newItemProvider(repository.logger, repository.directory, snapshot).GetAllItems()
+	newRouteMap := make(map[string]dataaccess.Item, len(newItems))
+	for _, item := range newItems {
+		newRouteMap[route.ToKey(item.Route())] = item
+	}
+
+	// determine the changes
+	oldItems := repository.Items()
+	addedItems, modifiedItems, deletedItems := diffItems(oldItems, newItems, newRouteMap)
+
+	repository.logger.Debug("------- Difference ---------------")
+	repository.logger.Debug("New: %v", len(addedItems))
+	repository.logger.Debug("Modified: %v", len(modifiedItems))
+	repository.logger.Debug("Deleted: %v", len(deletedItems))
+
+	// assign the new index
+	repository.indexLock.Lock()
+	repository.commit = commit
+	repository.items = newItems
+	repository.routeMap = newRouteMap
+	repository.indexLock.Unlock()
+
+	// send out updates
+	repository.sendUpdate(dataaccess.NewUpdate(itemsToRoutes(addedItems), itemsToRoutes(modifiedItems), itemsToRoutes(deletedItems)))
+
+	return nil
+}
+
+// sendUpdate send an update to all subscribers.
+func (repository *Repository) sendUpdate(update dataaccess.Update) {
+	if update.IsEmpty() {
+		repository.logger.Debug("sendUpdate(%s): Nothing to send.", update.String())
+		return
+	}
+
+	repository.logger.Debug("sendUpdate(%s): Notifying all subscribers", update.String())
+	for _, updateSubscriber := range repository.updateSubscribers {
+		updateSubscriber <- update
+	}
+}
+
+// diffItems calculates the differences between the specified old and new items.
+func diffItems(oldItems, newItems []dataaccess.Item, newRouteMap map[string]dataaccess.Item) (addedItems, modifiedItems, deletedItems []dataaccess.Item) {
+
+	oldRouteMap := make(map[string]dataaccess.Item, len(oldItems))
+	for _, oldItem := range oldItems {
+		oldRouteMap[route.ToKey(oldItem.Route())] = oldItem
+	}
+
+	// new or modified
+	for _, newItem := range newItems {
+
+		oldItem, existsInOldIndex := oldRouteMap[route.ToKey(newItem.Route())]
+		if !existsInOldIndex {
+			addedItems = append(addedItems, newItem)
+			continue
+		}
+
+		if oldItem.LastHash() != newItem.LastHash() || filesHash(oldItem) != filesHash(newItem) {
+			modifiedItems = append(modifiedItems, newItem)
+		}
+	}
+
+	// deleted
+	for _, oldItem := range oldItems {
+		if _, existsInNewIndex := newRouteMap[route.ToKey(oldItem.Route())]; !existsInNewIndex {
+			deletedItems = append(deletedItems, oldItem)
+		}
+	}
+
+	return
+}
+
+// filesHash combines the hashes of all files of the given item.
+func filesHash(item dataaccess.Item) string {
+	hash := ""
+	for _, file := range item.Files() {
+		fileHash, _ := file.Hash()
+		hash += fileHash
+	}
+
+	return hash
+}
+
+// itemsToRoutes returns the list of routes for a given listen if items.
+func itemsToRoutes(items []dataaccess.Item) []route.Route {
+	var routes []route.Route
+	for _, item := range items {
+		routes = append(routes, item.Route())
+	}
+	return routes
+}
@@ -0,0 +1,318 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+)
+
+func Test_NewRepository_ItemsAreReadFromTheRef(t *testing.T) {
+	// arrange
+	bareRepository := newTestRepository(t, map[string]string{
+		"readme.md":                   "# Root",
+		"documents/readme.md":         "# Documents",
+		"documents/files/image.png":   "png",
+		"documents/.hidden/readme.md": "# Hidden",
+		"virtual/sub/readme.md":       "# Sub",
+		"attachments/a.txt":           "a",
+		"attachments/nested/b.txt":    "b",
+	})
+
+	// act
+	repository, err := NewRepository(console.New(loglevel.Off), bareRepository, "master", testConfig())
+
+	// assert
+	if err != nil {
+		t.Fatalf("NewRepository returned an error: %s", err)
+	}
+
+	expectedTypes := map[string]dataaccess.ItemType{
+		"":            dataaccess.TypePhysical,
+		"documents":   dataaccess.TypePhysical,
+		"virtual":     dataaccess.TypeVirtual,
+		"virtual/sub": dataaccess.TypePhysical,
+		"attachments": dataaccess.TypeFileCollection,
+	}
+
+	if len(repository.Items()) != len(expectedTypes) {
+		t.Errorf("The repository should contain %d items but contains %d: %v", len(expectedTypes), len(repository.Items()), repository.Routes())
+	}
+
+	for routeValue, expectedType := range expectedTypes {
+		item := repository.Item(route.NewFromRequest(routeValue))
+		if item == nil {
+			t.Errorf("The item %q was not found.", routeValue)
+			continue
+		}
+
+		if item.Type() != expectedType {
+			t.Errorf("The item %q should be of type %q but is %q.", routeValue, expectedType, item.Type())
+		}
+	}
+
+	documents := repository.Item(route.NewFromRequest("documents"))
+	if documents == nil {
+		t.FailNow()
+	}
+
+	if content := readAll(t, documents); content != "# Documents" {
+		t.Errorf("The content of %q should be %q but was %q.", documents, "# Documents", content)
+	}
+
+	if files := documents.Files(); len(files) != 1 || files[0].Route().Value() != "documents/files/image.png" {
+		t.Errorf("The item %q should have the file %q but has %v.", documents, "documents/files/image.png", files)
+	}
+
+	if files := repository.Item(route.NewFromRequest("attachments")).Files(); len(files) != 2 {
+		t.Errorf("The file collection should have 2 files but has %d.", len(files))
+	}
+}
+
+func Test_Refresh_RefMoved_UpdateIsSent(t *testing.T) {
+	// arrange
+	bareRepository := newTestRepository(t, map[string]string{
+		"readme.md":           "# Root",
+		"documents/readme.md": "# Documents",
+		"obsolete/readme.md":  "# Obsolete",
+		"unchanged/readme.md": "# Unchanged",
+	})
+
+	repository, err := NewRepository(console.New(loglevel.Off), bareRepository, "master", testConfig())
+	if err != nil {
+		t.Fatalf("NewRepository returned an error: %s", err)
+	}
+
+	updates := make(chan dataaccess.Update, 1)
+	repository.Subscribe(updates)
+
+	commitFiles(t, bareRepository, map[string]string{
+		"documents/readme.md": "# Documents (changed)",
+		"obsolete/readme.md":  "",
+		"added/readme.md":     "# Added",
+	})
+
+	// act
+	if err := repository.Refresh(); err != nil {
+		t.Fatalf("Refresh returned an error: %s", err)
+	}
+
+	// assert
+	var update dataaccess.Update
+	select {
+	case update = <-updates:
+	default:
+		t.Fatalf("Refresh should have sent an update.")
+	}
+
+	assertRoutes(t, "new", update.New(), "added")
+	assertRoutes(t, "modified", update.Modified(), "documents")
+	assertRoutes(t, "deleted", update.Deleted(), "obsolete")
+
+	if content := readAll(t, repository.Item(route.NewFromRequest("documents"))); content != "# Documents (changed)" {
+		t.Errorf("The repository should serve the new content but served %q.", content)
+	}
+}
+
+func Test_Refresh_ConcurrentRefreshes_EachCommitIsIndexedOnce(t *testing.T) {
+	// arrange
+	bareRepository := newTestRepository(t, map[string]string{
+		"readme.md": "# Root",
+	})
+
+	repository, err := NewRepository(console.New(loglevel.Off), bareRepository, "master", testConfig())
+	if err != nil {
+		t.Fatalf("NewRepository returned an error: %s", err)
+	}
+
+	updates := make(chan dataaccess.Update, 100)
+	repository.Subscribe(updates)
+
+	// act
+	for _, name := range []string{"first", "second", "third"} {
+		commitFiles(t, bareRepository, map[string]string{name + "/readme.md": "# " + name})
+
+		var waitGroup sync.WaitGroup
+		for index := 0; index < 4; index++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				if err := repository.Refresh(); err != nil {
+					t.Errorf("Refresh returned an error: %s", err)
+				}
+			}()
+		}
+
+		waitGroup.Wait()
+	}
+
+	// assert
+	if len(updates) != 3 {
+		t.Errorf("The refreshes should have sent one update per commit (3) but sent %d.", len(updates))
+	}
+}
+
+func Test_NewRepository_Tag_TagIsServedWhileBranchMoves(t *testing.T) {
+	// arrange
+	bareRepository := newTestRepository(t, map[string]string{
+		"readme.md": "# Version 1",
+	})
+
+	runGit(t, bareRepository, "tag", "v1", "master")
+
+	commitFiles(t, bareRepository, map[string]string{
+		"readme.md": "# Version 2",
+	})
+
+	// act
+	release, releaseErr := NewRepository(console.New(loglevel.Off), bareRepository, "v1", testConfig())
+	master, masterErr := NewRepository(console.New(loglevel.Off), bareRepository, "master", testConfig())
+
+	// assert
+	if releaseErr != nil || masterErr != nil {
+		t.Fatalf("NewRepository returned an error: %v %v", releaseErr, masterErr)
+	}
+
+	if content := readAll(t, release.Item(route.New())); content != "# Version 1" {
+		t.Errorf("The tag should serve %q but served %q.", "# Version 1", content)
+	}
+
+	if content := readAll(t, master.Item(route.New())); content != "# Version 2" {
+		t.Errorf("The branch should serve %q but served %q.", "# Version 2", content)
+	}
+
+	if release.Ref() != "refs/tags/v1" || master.Ref() != "refs/heads/master" {
+		t.Errorf("The refs should be %q and %q but were %q and %q.", "refs/tags/v1", "refs/heads/master", release.Ref(), master.Ref())
+	}
+}
+
+func Test_NewRepository_UnknownRef_ErrorIsReturned(t *testing.T) {
+	// arrange
+	bareRepository := newTestRepository(t, map[string]string{
+		"readme.md": "# Root",
+	})
+
+	// act
+	_, err := NewRepository(console.New(loglevel.Off), bareRepository, "does-not-exist", testConfig())
+
+	// assert
+	if err == nil {
+		t.Errorf("NewRepository should return an error for an unknown ref.")
+	}
+}
+
+// testConfig returns a configuration which disables the ref polling.
+func testConfig() config.Config {
+	configuration := config.Config{}
+	configuration.Indexing.IntervalInSeconds = 0
+	return configuration
+}
+
+// newTestRepository creates a bare repository with a master branch containing the given files.
+func newTestRepository(t *testing.T, files map[string]string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	directory, err := ioutil.TempDir("", "allmark-git-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		os.RemoveAll(directory)
+	})
+
+	bareRepository := filepath.Join(directory, "repository.git")
+	runGit(t, directory, "init", "--quiet", "--bare", "--initial-branch=master", bareRepository)
+	commitFiles(t, bareRepository, files)
+
+	return bareRepository
+}
+
+// commitFiles commits the given files to the master branch of the given bare repository.
+// Files with an empty content are deleted.
+func commitFiles(t *testing.T, bareRepository string, files map[string]string) {
+
+	workingCopy, err := ioutil.TempDir("", "allmark-git-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(workingCopy)
+
+	runGit(t, workingCopy, "clone", "--quiet", bareRepository, ".")
+
+	for path, content := range files {
+		filePath := filepath.Join(workingCopy, filepath.FromSlash(path))
+		if content == "" {
+			os.Remove(filePath)
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(filePath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runGit(t, workingCopy, "add", "--all")
+	runGit(t, workingCopy, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "test")
+	runGit(t, workingCopy, "push", "--quiet", "origin", "HEAD:master")
+}
+
+func runGit(t *testing.T, directory string, arguments ...string) {
+	cmd := exec.Command("git", arguments...)
+	cmd.Dir = directory
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %s %s", arguments, err, output)
+	}
+}
+
+func readAll(t *testing.T, item dataaccess.Item) string {
+	if item == nil {
+		t.Fatalf("The item was not found.")
+	}
+
+	var content []byte
+	err := item.Data(func(reader io.ReadSeeker) error {
+		var readErr error
+		content, readErr = ioutil.ReadAll(reader)
+		return readErr
+	})
+
+	if err != nil {
+		t.Fatalf("Cannot read the content of %q: %s", item, err)
+	}
+
+	return string(content)
+}
+
+func assertRoutes(t *testing.T, name string, routes []route.Route, expectedRouteValues ...string) {
+	if len(routes) != len(expectedRouteValues) {
+		t.Errorf("The %s routes should be %v but were %v.", name, expectedRouteValues, routes)
+		return
+	}
+
+	for index, expectedRouteValue := range expectedRouteValues {
+		if routes[index].Value() != expectedRouteValue {
+			t.Errorf("The %s routes should be %v but were %v.", name, expectedRouteValues, routes)
+		}
+	}
+}
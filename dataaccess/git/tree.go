@@ -0,0 +1,228 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+)
+
+var (
+	ReservedDirectoryNames = []string{config.FilesDirectoryName, config.MetaDataFolderName}
+)
+
+// newSnapshot creates a directory tree from the blobs of the given commit.
+func newSnapshot(command *gitCommand, commit string) (*snapshot, error) {
+
+	entries, err := command.listTree(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	commitTime, err := command.commitTime(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &snapshot{
+		command:    command,
+		commit:     commit,
+		commitTime: commitTime,
+		root:       newTreeDirectory(""),
+	}
+
+	for _, entry := range entries {
+		directory := snapshot.root
+		components := strings.Split(entry.path, "/")
+		for _, name := range components[:len(components)-1] {
+			directory = directory.getOrCreateDirectory(name)
+		}
+
+		directory.files = append(directory.files, entry)
+	}
+
+	snapshot.root.sort()
+
+	return snapshot, nil
+}
+
+// A snapshot is the directory tree of a single commit.
+type snapshot struct {
+	command    *gitCommand
+	commit     string
+	commitTime time.Time
+	root       *treeDirectory
+
+	modificationTimesOnce sync.Once
+	modificationTimes     map[string]time.Time
+}
+
+// LastModified returns the time of the last commit which changed the given path.
+// The modification times are determined on first use because they require
+// a walk through the history.
+func (snapshot *snapshot) LastModified(path string) time.Time {
+
+	snapshot.modificationTimesOnce.Do(func() {
+		times, err := snapshot.command.lastModificationTimes(snapshot.commit)
+		if err != nil {
+			times = make(map[string]time.Time)
+		}
+
+		snapshot.modificationTimes = times
+	})
+
+	if modificationTime, exists := snapshot.modificationTimes[path]; exists {
+		return modificationTime
+	}
+
+	return snapshot.commitTime
+}
+
+func newTreeDirectory(path string) *treeDirectory {
+	return &treeDirectory{
+		path:        path,
+		files:       make([]treeEntry, 0),
+		directories: make([]*treeDirectory, 0),
+	}
+}
+
+// A treeDirectory is a directory in the tree of a commit.
+type treeDirectory struct {
+
+	// path is the slash-separated path of the directory relative to the repository root.
+	path string
+
+	files       []treeEntry
+	directories []*treeDirectory
+}
+
+// Name returns the name of the directory.
+func (directory *treeDirectory) Name() string {
+	return path.Base(directory.path)
+}
+
+// Directory returns the sub directory with the given name or nil if it does not exist.
+func (directory *treeDirectory) Directory(name string) *treeDirectory {
+	for _, childDirectory := range directory.directories {
+		if childDirectory.Name() == name {
+			return childDirectory
+		}
+	}
+
+	return nil
+}
+
+// ChildDirectories returns all sub directories which are not reserved.
+func (directory *treeDirectory) ChildDirectories() []*treeDirectory {
+	childDirectories := make([]*treeDirectory, 0)
+	for _, childDirectory := range directory.directories {
+		if isReservedDirectory(childDirectory.Name()) {
+			continue
+		}
+
+		childDirectories = append(childDirectories, childDirectory)
+	}
+
+	return childDirectories
+}
+
+// AllFiles returns the files of this directory and of all its sub directories.
+func (directory *treeDirectory) AllFiles() []treeEntry {
+	files := make([]treeEntry, 0, len(directory.files))
+	files = append(files, directory.files...)
+	for _, childDirectory := range directory.directories {
+		files = append(files, childDirectory.AllFiles()...)
+	}
+
+	return files
+}
+
+// MarkdownFile returns the first markdown file of this directory.
+func (directory *treeDirectory) MarkdownFile() (found bool, file treeEntry) {
+	for _, file := range directory.files {
+		if isMarkdownFile(file.path) {
+			return true, file
+		}
+	}
+
+	return false, treeEntry{}
+}
+
+// ContainsItems checks if the directory contains an item within the range of the given max depth.
+func (directory *treeDirectory) ContainsItems(maxdepth int) bool {
+	if found, _ := directory.MarkdownFile(); found {
+		return true
+	}
+
+	if maxdepth <= 0 {
+		return false
+	}
+
+	for _, childDirectory := range directory.ChildDirectories() {
+		if childDirectory.ContainsItems(maxdepth - 1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (directory *treeDirectory) getOrCreateDirectory(name string) *treeDirectory {
+	if childDirectory := directory.Directory(name); childDirectory != nil {
+		return childDirectory
+	}
+
+	childDirectory := newTreeDirectory(path.Join(directory.path, name))
+	directory.directories = append(directory.directories, childDirectory)
+	return childDirectory
+}
+
+// sort orders the files and directories by name like a directory listing.
+func (directory *treeDirectory) sort() {
+	sort.Slice(directory.files, func(i, j int) bool {
+		return directory.files[i].path < directory.files[j].path
+	})
+
+	sort.Slice(directory.directories, func(i, j int) bool {
+		return directory.directories[i].path < directory.directories[j].path
+	})
+
+	for _, childDirectory := range directory.directories {
+		childDirectory.sort()
+	}
+}
+
+func isReservedDirectory(directoryName string) bool {
+
+	directoryName = strings.ToLower(directoryName)
+
+	// all dot-directories are ignored
+	if strings.HasPrefix(directoryName, ".") {
+		return true
+	}
+
+	// check the reserved directory names
+	for _, reservedDirectoryName := range ReservedDirectoryNames {
+		if directoryName == strings.ToLower(reservedDirectoryName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMarkdownFile(fileNameOrPath string) bool {
+	switch strings.ToLower(path.Ext(fileNameOrPath)) {
+	case ".md", ".markdown", ".mdown":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dataaccess
+
+import (
+	"time"
+
+	"github.com/andreaskoch/allmark/common/route"
+)
+
+// A HistoryProvider gives access to the revision history of the items in a repository.
+// Repositories can optionally implement this interface.
+type HistoryProvider interface {
+
+	// HasHistory indicates whether a revision history is available for this repository.
+	HasHistory() bool
+
+	// Revisions returns the revisions which changed the item with the given route (newest first).
+	Revisions(route route.Route) ([]Revision, error)
+
+	// Diff returns the unified diff of the item with the given route between the two revisions.
+	Diff(route route.Route, fromRevision, toRevision string) (string, error)
+}
+
+// A Revision is a commit which changed an item.
+type Revision struct {
+	Id          string
+	Author      string
+	AuthorEmail string
+	Date        time.Time
+	Message     string
+}
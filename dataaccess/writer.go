@@ -0,0 +1,37 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dataaccess
+
+import (
+	"errors"
+	"io"
+
+	"github.com/andreaskoch/allmark/common/route"
+)
+
+// ErrConflict is returned if an item is saved based on a version that is no longer the current one.
+var ErrConflict = errors.New("The item has been changed in the meantime.")
+
+// An ItemWriter can change the markdown content and add files to the items of a repository.
+type ItemWriter interface {
+	// CanWrite indicates whether the item with the given route can be changed.
+	CanWrite(route route.Route) bool
+
+	// WriteItem replaces the markdown content of the item with the given route.
+	// The lastHash is the LastHash() of the item version the changes are based on;
+	// if the item has been changed since then ErrConflict is returned.
+	WriteItem(route route.Route, content []byte, lastHash string) error
+
+	// WriteFile stores the given content as a new file of the item with the given route.
+	// Existing files are not replaced; if the name is taken a number is appended to it.
+	// Returns the path of the new file relative to the item (e.g. "files/image.png").
+	WriteFile(route route.Route, name string, content io.Reader) (path string, err error)
+}
+
+// A WritableRepository is a repository whose items can be changed.
+type WritableRepository interface {
+	Repository
+	ItemWriter
+}
@@ -98,6 +98,32 @@ func (item *Item) GetFile(fileRoute route.Route) *File {
 	return nil
 }
 
+// HasName indicates whether the supplied name is the title, an alias or the folder name of the item.
+// Case is ignored and spaces match dashes (e.g. "Getting Started" matches the alias "getting-started").
+func (item *Item) HasName(name string) bool {
+	normalizedName := normalizeName(name)
+	if normalizedName == "" {
+		return false
+	}
+
+	if normalizeName(item.Title) == normalizedName || normalizeName(item.FolderName()) == normalizedName {
+		return true
+	}
+
+	for _, alias := range item.MetaData.Aliases {
+		if normalizeName(alias) == normalizedName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeName returns the lowercase version of the given name with dashes instead of spaces.
+func normalizeName(name string) string {
+	return strings.Replace(strings.ToLower(strings.TrimSpace(name)), " ", "-", -1)
+}
+
 func (item *Item) IsPhysical() bool {
 	return item.sourceType == dataaccess.TypePhysical
 }
@@ -17,9 +17,14 @@ type MetaData struct {
 	Aliases          []string
 	Author           string
 	GeoInformation   GeoInformation
+
+	// Custom contains the values of all meta data keys which are not known to allmark (e.g. "status: draft").
+	Custom map[string][]string
 }
 
 // NewMetaData creates a new instance of the the MetaData struct.
 func NewMetaData() *MetaData {
-	return &MetaData{}
+	return &MetaData{
+		Custom: make(map[string][]string),
+	}
 }
@@ -0,0 +1,244 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package access decides which items the users can see based on their roles
+// and the configured access rules.
+package access
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/model"
+)
+
+// New creates a new access policy from the given settings.
+func New(settings config.Access) (*Policy, error) {
+
+	rules := make([]rule, 0, len(settings.Rules))
+	for index, accessRule := range settings.Rules {
+
+		metaDataKey := strings.ToLower(strings.TrimSpace(accessRule.MetaDataKey))
+		if strings.TrimSpace(accessRule.Route) == "" && metaDataKey == "" {
+			return nil, fmt.Errorf("The access rule %d has neither a route nor a meta data key.", index+1)
+		}
+
+		if metaDataKey == "" && strings.TrimSpace(accessRule.MetaDataValue) != "" {
+			return nil, fmt.Errorf("The access rule %d has a meta data value but no meta data key.", index+1)
+		}
+
+		rules = append(rules, rule{
+			route:         getRuleRoute(accessRule.Route),
+			metaDataKey:   metaDataKey,
+			metaDataValue: strings.ToLower(strings.TrimSpace(accessRule.MetaDataValue)),
+			roles:         normalizeRoles(accessRule.Roles),
+		})
+	}
+
+	userRoles := make(map[string][]string)
+	for username, roles := range settings.Roles {
+		userRoles[username] = normalizeRoles(roles)
+	}
+
+	return &Policy{
+		userRoles: userRoles,
+		rules:     rules,
+	}, nil
+}
+
+// Policy decides which items the different audiences can see.
+type Policy struct {
+	userRoles map[string][]string
+	rules     []rule
+}
+
+// IsRestricted returns a flag indicating whether there are any access rules.
+// Without access rules all items are visible to everybody.
+func (policy *Policy) IsRestricted() bool {
+	return len(policy.rules) > 0
+}
+
+// Roles returns the configured roles of the user with the given name.
+func (policy *Policy) Roles(username string) []string {
+	return policy.userRoles[username]
+}
+
+// Anonymous returns the audience of the users which are not logged in.
+func (policy *Policy) Anonymous() Audience {
+	return Audience{
+		policy: policy,
+		roles:  map[string]bool{},
+	}
+}
+
+// Authenticated returns the audience of the logged in users with the given roles.
+func (policy *Policy) Authenticated(roles []string) Audience {
+	audienceRoles := make(map[string]bool)
+	for _, role := range normalizeRoles(roles) {
+		audienceRoles[role] = true
+	}
+
+	return Audience{
+		policy:        policy,
+		authenticated: true,
+		roles:         audienceRoles,
+	}
+}
+
+// Audience is a group of users which can see the same items.
+type Audience struct {
+	policy        *Policy
+	authenticated bool
+	roles         map[string]bool
+}
+
+// IsAuthenticated returns a flag indicating whether the users of the audience are logged in.
+func (audience Audience) IsAuthenticated() bool {
+	return audience.authenticated
+}
+
+// Name returns a unique name of the audience which can be used as a file name
+// (e.g. "anonymous", "authenticated" or "roles-2ad7b8ad4c0e").
+func (audience Audience) Name() string {
+	if !audience.authenticated {
+		return "anonymous"
+	}
+
+	if len(audience.roles) == 0 {
+		return "authenticated"
+	}
+
+	roles := make([]string, 0, len(audience.roles))
+	for role := range audience.roles {
+		roles = append(roles, role)
+	}
+
+	sort.Strings(roles)
+
+	hash := sha1.Sum([]byte(strings.Join(roles, "\n")))
+	return fmt.Sprintf("roles-%x", hash[:6])
+}
+
+// CanView returns a flag indicating whether the given item passes all matching access rules.
+// The rules of the parent items are not evaluated.
+func (audience Audience) CanView(item *model.Item) bool {
+
+	for _, rule := range audience.policy.rules {
+		matches, itemRoles := rule.match(item)
+		if !matches {
+			continue
+		}
+
+		if !audience.isAllowed(rule, itemRoles) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isAllowed checks whether the audience has one of the roles required by the given rule.
+func (audience Audience) isAllowed(rule rule, itemRoles []string) bool {
+	if !audience.authenticated {
+		return false
+	}
+
+	requiredRoles := rule.roles
+	if len(requiredRoles) == 0 && rule.metaDataKey != "" && rule.metaDataValue == "" {
+		requiredRoles = itemRoles
+	}
+
+	// all authenticated users
+	if len(requiredRoles) == 0 {
+		return true
+	}
+
+	for _, role := range requiredRoles {
+		if audience.roles[role] {
+			return true
+		}
+	}
+
+	return false
+}
+
+type rule struct {
+	route         *route.Route
+	metaDataKey   string
+	metaDataValue string
+	roles         []string
+}
+
+// match checks whether the given item matches the current rule and returns the
+// meta data values of the item for the rule's meta data key.
+func (rule rule) match(item *model.Item) (matches bool, values []string) {
+
+	if rule.route != nil && !isSameOrChildRoute(item.Route(), *rule.route) {
+		return false, nil
+	}
+
+	if rule.metaDataKey == "" {
+		return true, nil
+	}
+
+	values = normalizeRoles(item.MetaData.Custom[rule.metaDataKey])
+	if len(values) == 0 {
+		return false, nil
+	}
+
+	if rule.metaDataValue == "" {
+		return true, values
+	}
+
+	for _, value := range values {
+		if value == rule.metaDataValue {
+			return true, values
+		}
+	}
+
+	return false, nil
+}
+
+// getRuleRoute returns the route for the given route value of a rule or nil if the value is empty.
+func getRuleRoute(value string) *route.Route {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	ruleRoute := route.NewFromRequest(value)
+	return &ruleRoute
+}
+
+// isSameOrChildRoute checks whether the given route equals the parent route or is located below it.
+func isSameOrChildRoute(itemRoute, parentRoute route.Route) bool {
+	parentValue := strings.ToLower(parentRoute.Value())
+	itemValue := strings.ToLower(itemRoute.Value())
+
+	if parentValue == "" || itemValue == parentValue {
+		return true
+	}
+
+	return strings.HasPrefix(itemValue, parentValue+"/")
+}
+
+// normalizeRoles splits the given comma-separated values and returns them in lower case without empty values.
+func normalizeRoles(values []string) []string {
+	roles := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, role := range strings.Split(value, ",") {
+			role = strings.ToLower(strings.TrimSpace(role))
+			if role == "" {
+				continue
+			}
+
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
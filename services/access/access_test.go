@@ -0,0 +1,181 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package access
+
+import (
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/model"
+)
+
+func getTestItem(routeValue string, metaData map[string][]string) *model.Item {
+	item := model.NewItem(route.NewFromRequest(routeValue), nil, 0)
+	item.MetaData.Custom = metaData
+	return item
+}
+
+func getTestPolicy(t *testing.T, rules ...config.AccessRule) *Policy {
+	policy, err := New(config.Access{Rules: rules})
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	return policy
+}
+
+func Test_CanView_NoRules_AnonymousCanViewItem(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t)
+	item := getTestItem("internal/document", nil)
+
+	// act
+	result := policy.Anonymous().CanView(item)
+
+	// assert
+	if !result {
+		t.Errorf("Items should be visible to anonymous users if there are no rules.")
+	}
+}
+
+func Test_CanView_RouteRule_ItemBelowRouteIsHiddenFromAnonymous(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t, config.AccessRule{Route: "/internal"})
+	item := getTestItem("internal/document", nil)
+
+	// act
+	result := policy.Anonymous().CanView(item)
+
+	// assert
+	if result {
+		t.Errorf("The item %q should be hidden from anonymous users.", item.Route())
+	}
+}
+
+func Test_CanView_RouteRule_ItemWithSamePrefixIsVisible(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t, config.AccessRule{Route: "/internal"})
+	item := getTestItem("internals/document", nil)
+
+	// act
+	result := policy.Anonymous().CanView(item)
+
+	// assert
+	if !result {
+		t.Errorf("The item %q is not below the route of the rule and should be visible.", item.Route())
+	}
+}
+
+func Test_CanView_RouteRuleWithoutRoles_AuthenticatedUserCanViewItem(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t, config.AccessRule{Route: "/internal"})
+	item := getTestItem("internal/document", nil)
+
+	// act
+	result := policy.Authenticated(nil).CanView(item)
+
+	// assert
+	if !result {
+		t.Errorf("The item %q should be visible to all authenticated users.", item.Route())
+	}
+}
+
+func Test_CanView_MetaDataValueRule_UserWithoutRoleCannotViewItem(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t, config.AccessRule{MetaDataKey: "Visibility", MetaDataValue: "Internal", Roles: []string{"staff"}})
+	item := getTestItem("document", map[string][]string{"visibility": {"internal"}})
+
+	// act
+	result := policy.Authenticated([]string{"guest"}).CanView(item)
+
+	// assert
+	if result {
+		t.Errorf("The item should only be visible to users with the role %q.", "staff")
+	}
+}
+
+func Test_CanView_MetaDataValueRule_UserWithRoleCanViewItem(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t, config.AccessRule{MetaDataKey: "Visibility", MetaDataValue: "Internal", Roles: []string{"staff"}})
+	item := getTestItem("document", map[string][]string{"visibility": {"internal"}})
+
+	// act
+	result := policy.Authenticated([]string{"guest", "Staff"}).CanView(item)
+
+	// assert
+	if !result {
+		t.Errorf("The item should be visible to users with the role %q.", "staff")
+	}
+}
+
+func Test_CanView_MetaDataKeyRuleWithoutRoles_ItemValuesAreTheRoles(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t, config.AccessRule{MetaDataKey: "groups"})
+	item := getTestItem("document", map[string][]string{"groups": {"eng, ops"}})
+
+	inputs := map[string]bool{
+		"eng":   true,
+		"ops":   true,
+		"sales": false,
+	}
+
+	for role, expected := range inputs {
+
+		// act
+		result := policy.Authenticated([]string{role}).CanView(item)
+
+		// assert
+		if result != expected {
+			t.Errorf("CanView for a user with the role %q returned %t but %t was expected.", role, result, expected)
+		}
+	}
+}
+
+func Test_CanView_MultipleMatchingRules_AllRulesMustPass(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t,
+		config.AccessRule{Route: "/internal", Roles: []string{"staff"}},
+		config.AccessRule{MetaDataKey: "groups"})
+
+	item := getTestItem("internal/document", map[string][]string{"groups": {"eng"}})
+
+	// act
+	result := policy.Authenticated([]string{"staff"}).CanView(item)
+
+	// assert
+	if result {
+		t.Errorf("The item should be hidden because the user does not have the role %q.", "eng")
+	}
+}
+
+func Test_New_RuleWithoutRouteAndMetaDataKey_ErrorIsReturned(t *testing.T) {
+	// arrange
+	settings := config.Access{
+		Rules: []config.AccessRule{{Roles: []string{"staff"}}},
+	}
+
+	// act
+	_, err := New(settings)
+
+	// assert
+	if err == nil {
+		t.Errorf("New should return an error for a rule without a route and a meta data key.")
+	}
+}
+
+func Test_Name_SameRolesInDifferentOrder_NamesAreEqual(t *testing.T) {
+	// arrange
+	policy := getTestPolicy(t)
+
+	// act
+	name1 := policy.Authenticated([]string{"eng", "ops"}).Name()
+	name2 := policy.Authenticated([]string{"OPS", "eng"}).Name()
+
+	// assert
+	if name1 != name2 {
+		t.Errorf("The audience names %q and %q should be equal.", name1, name2)
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package checker finds unresolved references, missing files and dead links in the items of a repository.
+package checker
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/services/converter"
+	"github.com/andreaskoch/allmark/services/converter/markdowntohtml"
+	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/imageprovider"
+	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/util"
+	"github.com/andreaskoch/allmark/services/diagram"
+	"github.com/andreaskoch/allmark/services/parser"
+	"github.com/andreaskoch/allmark/services/thumbnail"
+)
+
+const (
+	// ProblemTypeItem is the type of problems with items which cannot be parsed or converted.
+	ProblemTypeItem = "item"
+
+	// ProblemTypeReference is the type of problems with references and wiki links to unknown items.
+	ProblemTypeReference = "reference"
+
+	// ProblemTypeFile is the type of problems with markdown extensions which refer to missing files.
+	ProblemTypeFile = "file"
+
+	// ProblemTypeLink is the type of problems with relative links to items or files which don't exist.
+	ProblemTypeLink = "link"
+)
+
+var (
+	// files: [Title](path), imagegallery: [Title](path), csv: [Title](path), ...
+	fileExtensionPattern = regexp.MustCompile(`(files|imagegallery|csv|filepreview|audio|video): \[([^\]]*)\]\(([^)]+)\)`)
+
+	// href="path", src="path"
+	linkTargetPattern = regexp.MustCompile(`\s(?:href|src)="([^"]*)"`)
+)
+
+// A Problem is an issue found in the markdown of an item.
+type Problem struct {
+	Route   string `json:"route"`
+	Type    string `json:"type"`
+	Target  string `json:"target"`
+	Message string `json:"message"`
+}
+
+func (problem Problem) String() string {
+	return fmt.Sprintf("/%s: %s", problem.Route, problem.Message)
+}
+
+// New creates a new checker for the items of the given repository.
+func New(logger logger.Logger, config config.Config, repository dataaccess.Repository, parser parser.Parser) *Checker {
+
+	pathProvider := rootPather{}
+	imageProvider := imageprovider.NewImageProvider(pathProvider, thumbnail.EmptyIndex())
+
+	return &Checker{
+		logger:       logger,
+		repository:   repository,
+		parser:       parser,
+		converter:    markdowntohtml.New(logger, config, imageProvider, diagram.New(logger, diagram.EmptyCache())),
+		pathProvider: pathProvider,
+	}
+}
+
+// Checker finds problems in the items of a repository.
+type Checker struct {
+	logger       logger.Logger
+	repository   dataaccess.Repository
+	parser       parser.Parser
+	converter    converter.Converter
+	pathProvider rootPather
+}
+
+// Check parses and converts all items of the repository and
+// returns the problems that were found ordered by route.
+func (checker *Checker) Check() []Problem {
+
+	problems := make([]Problem, 0)
+
+	// parse all items
+	var items []*model.Item
+	for _, repositoryItem := range checker.repository.Items() {
+		item, err := checker.parser.ParseItem(repositoryItem)
+		if err != nil {
+			problems = append(problems, Problem{
+				Route:   repositoryItem.Route().Value(),
+				Type:    ProblemTypeItem,
+				Message: fmt.Sprintf("The item cannot be parsed (Error: %s)", err),
+			})
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	index := newRepositoryIndex(items)
+	for _, item := range items {
+		problems = append(problems, checker.checkItem(index, item)...)
+	}
+
+	sort.Stable(problemsByRoute(problems))
+
+	return problems
+}
+
+// checkItem returns the problems of the given item.
+func (checker *Checker) checkItem(index *repositoryIndex, item *model.Item) []Problem {
+
+	problems := newProblemList(item.Route())
+
+	// files referenced by markdown extensions
+	for _, match := range fileExtensionPattern.FindAllStringSubmatch(item.Content, -1) {
+		extensionName := match[1]
+		filePath := strings.TrimSpace(match[3])
+
+		if util.IsExternalLink(filePath) || hasMatchingFile(item, extensionName, filePath) {
+			continue
+		}
+
+		problems.Add(ProblemTypeFile, filePath, fmt.Sprintf("The %s %q of the %s extension was not found", getFileType(extensionName), filePath, extensionName))
+	}
+
+	// references (an alias resolver which records the aliases that cannot be resolved)
+	aliasResolver := func(alias string) *model.Item {
+		if referencedItem, exists := index.itemsByAlias[alias]; exists {
+			return referencedItem
+		}
+
+		problems.Add(ProblemTypeReference, alias, fmt.Sprintf("The alias %q of the reference was not found", alias))
+		return nil
+	}
+
+	// wiki links
+	nameResolver := func(name string) *model.Item {
+		if referencedItem := index.GetItemByName(name); referencedItem != nil {
+			return referencedItem
+		}
+
+		problems.Add(ProblemTypeReference, name, fmt.Sprintf("The page %q of the wiki link was not found", name))
+		return nil
+	}
+
+	html, err := checker.converter.Convert(aliasResolver, nameResolver, checker.pathProvider, item)
+	if err != nil {
+		problems.Add(ProblemTypeItem, "", fmt.Sprintf("The item cannot be converted (Error: %s)", err))
+		return problems.problems
+	}
+
+	// relative links
+	for _, match := range linkTargetPattern.FindAllStringSubmatch(html, -1) {
+		target := match[1]
+
+		linkedRoute, isRelative := getRelativeLinkRoute(item.Route(), target)
+		if !isRelative || index.Contains(linkedRoute) {
+			continue
+		}
+
+		problems.Add(ProblemTypeLink, target, fmt.Sprintf("The link target %q was not found", target))
+	}
+
+	return problems.problems
+}
+
+// hasMatchingFile indicates whether the given item has a file (or, for the files and
+// imagegallery extensions, a folder with files) which matches the given path.
+func hasMatchingFile(item *model.Item, extensionName, filePath string) bool {
+
+	switch extensionName {
+	case "files", "imagegallery":
+		folderRoute := route.Combine(item.Route(), route.NewFromRequest(filePath))
+		for _, file := range item.Files() {
+			if file.Route().IsChildOf(folderRoute) {
+				return true
+			}
+		}
+
+	default:
+		for _, file := range item.Files() {
+			if file.Route().IsMatch(filePath) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getFileType returns the type of file system entry the given extension refers to.
+func getFileType(extensionName string) string {
+	if extensionName == "files" || extensionName == "imagegallery" {
+		return "folder"
+	}
+
+	return "file"
+}
+
+// getRelativeLinkRoute returns the route the given relative link target of the
+// item with the given route points to. Relative links are relative to the item.
+// Returns false if the target is not a relative link.
+func getRelativeLinkRoute(itemRoute route.Route, target string) (route.Route, bool) {
+
+	targetURL, err := url.Parse(strings.TrimSpace(target))
+	if err != nil || targetURL.Scheme != "" || targetURL.Host != "" || targetURL.Path == "" || strings.HasPrefix(targetURL.Path, "/") {
+		return route.Route{}, false
+	}
+
+	linkPath := path.Join("/"+itemRoute.OriginalValue(), targetURL.Path)
+	return route.NewFromRequest(linkPath), true
+}
+
+// newRepositoryIndex creates an index of the routes and aliases of the given items.
+func newRepositoryIndex(items []*model.Item) *repositoryIndex {
+
+	index := &repositoryIndex{
+		items:        items,
+		itemsByAlias: make(map[string]*model.Item),
+		routes:       make(map[string]bool),
+	}
+
+	for _, item := range items {
+		index.routes[route.ToKey(item.Route())] = true
+
+		for _, file := range item.Files() {
+			index.routes[route.ToKey(file.Route())] = true
+		}
+
+		for _, alias := range item.MetaData.Aliases {
+			index.itemsByAlias[alias] = item
+		}
+	}
+
+	return index
+}
+
+// repositoryIndex contains the routes of all items and files and the items by alias.
+type repositoryIndex struct {
+	items        []*model.Item
+	itemsByAlias map[string]*model.Item
+	routes       map[string]bool
+}
+
+// GetItemByName returns the item with the given alias, title or folder name or nil if there is no such item.
+func (index *repositoryIndex) GetItemByName(name string) *model.Item {
+	if item, exists := index.itemsByAlias[strings.TrimSpace(name)]; exists {
+		return item
+	}
+
+	for _, item := range index.items {
+		if item.HasName(name) {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// Contains indicates whether there is an item or file with the given route.
+func (index *repositoryIndex) Contains(r route.Route) bool {
+	return index.routes[route.ToKey(r)]
+}
+
+// newProblemList creates a problem list for the item with the given route.
+func newProblemList(itemRoute route.Route) *problemList {
+	return &problemList{
+		route:    itemRoute,
+		problems: make([]Problem, 0),
+		reported: make(map[string]bool),
+	}
+}
+
+// problemList collects the problems of an item. Every problem is reported only once.
+type problemList struct {
+	route    route.Route
+	problems []Problem
+	reported map[string]bool
+}
+
+// Add adds a problem with the given type, target and message if it has not been reported yet.
+func (list *problemList) Add(problemType, target, message string) {
+	key := problemType + ":" + target
+
+	// extensions with missing files fall back to links to these files
+	if problemType == ProblemTypeLink {
+		key = ProblemTypeFile + ":" + target
+	}
+
+	if list.reported[key] {
+		return
+	}
+
+	list.reported[key] = true
+	list.problems = append(list.problems, Problem{
+		Route:   list.route.Value(),
+		Type:    problemType,
+		Target:  target,
+		Message: message,
+	})
+}
+
+// rootPather returns all paths relative to the repository root (e.g. "/documents/example").
+type rootPather struct{}
+
+func (pather rootPather) Path(itemPath string) string {
+	if util.IsExternalLink(itemPath) {
+		return itemPath
+	}
+
+	return "/" + strings.TrimPrefix(itemPath, "/")
+}
+
+func (pather rootPather) Base() route.Route {
+	return route.New()
+}
+
+// problemsByRoute sorts problems by the route of the item.
+type problemsByRoute []Problem
+
+func (problems problemsByRoute) Len() int {
+	return len(problems)
+}
+
+func (problems problemsByRoute) Swap(i, j int) {
+	problems[i], problems[j] = problems[j], problems[i]
+}
+
+func (problems problemsByRoute) Less(i, j int) bool {
+	return problems[i].Route < problems[j].Route
+}
@@ -0,0 +1,98 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package checker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/dataaccess/filesystem"
+	"github.com/andreaskoch/allmark/services/parser"
+)
+
+func Test_Check_NoProblems_EmptyListIsReturned(t *testing.T) {
+	// arrange
+	checker := newTestChecker(t, map[string]string{
+		"readme.md":                     "# Root\n\nSee [reference:first], [[First]], [[first|the first one]] and [the document](first).\n",
+		"first/first.md":                "# First\n\n[Back](..), [data](files/data.csv)\n\ncsv: [Data](files/data.csv)\n\n---\nalias: first\n",
+		"first/files/data.csv":          "a,b\n1,2\n",
+		"first/files/gallery/image.png": "",
+	})
+
+	// act
+	problems := checker.Check()
+
+	// assert
+	if len(problems) != 0 {
+		t.Errorf("Check should not return any problems but returned %#v", problems)
+	}
+}
+
+func Test_Check_BrokenReferencesFilesAndLinks_ProblemsAreReturned(t *testing.T) {
+	// arrange
+	checker := newTestChecker(t, map[string]string{
+		"readme.md":      "# Root\n\nSee [reference:unknown], [[Unknown Page]] and [missing](second).\n",
+		"first/first.md": "# First\n\ncsv: [Data](files/data.csv)\n\nimagegallery: [Images](files/images)\n\n[External](http://example.com)\n",
+	})
+
+	expected := []Problem{
+		{Route: "", Type: ProblemTypeReference, Target: "unknown"},
+		{Route: "", Type: ProblemTypeReference, Target: "Unknown Page"},
+		{Route: "", Type: ProblemTypeLink, Target: "second"},
+		{Route: "first", Type: ProblemTypeFile, Target: "files/data.csv"},
+		{Route: "first", Type: ProblemTypeFile, Target: "files/images"},
+	}
+
+	// act
+	problems := checker.Check()
+
+	// assert
+	if len(problems) != len(expected) {
+		t.Fatalf("Check should return %d problems but returned %d: %#v", len(expected), len(problems), problems)
+	}
+
+	for index, problem := range problems {
+		if problem.Route != expected[index].Route || problem.Type != expected[index].Type || problem.Target != expected[index].Target {
+			t.Errorf("Problem %d should be a %q problem with %q in %q but was %#v", index, expected[index].Type, expected[index].Target, expected[index].Route, problem)
+		}
+	}
+}
+
+// newTestChecker creates a checker for a temporary repository with the given files.
+func newTestChecker(t *testing.T, files map[string]string) *Checker {
+	directory, err := ioutil.TempDir("", "allmark-checker-test")
+	if err != nil {
+		t.Fatalf("Cannot create a temporary directory. Error: %s", err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(directory) })
+
+	for path, content := range files {
+		filePath := filepath.Join(directory, filepath.FromSlash(path))
+		os.MkdirAll(filepath.Dir(filePath), 0755)
+		if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Cannot create the file %q. Error: %s", filePath, err)
+		}
+	}
+
+	logger := console.New(loglevel.Off)
+	configuration := config.Default(directory)
+	repository, err := filesystem.NewRepository(logger, directory, *configuration)
+	if err != nil {
+		t.Fatalf("NewRepository returned an error: %s", err)
+	}
+
+	itemParser, err := parser.New(logger)
+	if err != nil {
+		t.Fatalf("parser.New returned an error: %s", err)
+	}
+
+	return New(logger, *configuration, repository, itemParser)
+}
@@ -10,6 +10,7 @@ import (
 )
 
 type Converter interface {
-	// Convert the supplied item with all paths relative to the supplied base route
-	Convert(aliasResolver func(alias string) *model.Item, pathProvider paths.Pather, item *model.Item) (convertedContent string, converterError error)
+	// Convert the supplied item with all paths relative to the supplied base route.
+	// The alias resolver looks up the items of references, the name resolver the items of wiki links (by title, alias or folder name).
+	Convert(aliasResolver func(alias string) *model.Item, nameResolver func(name string) *model.Item, pathProvider paths.Pather, item *model.Item) (convertedContent string, converterError error)
 }
@@ -5,13 +5,17 @@
 package markdowntohtml
 
 import (
+	"github.com/andreaskoch/allmark/common/config"
 	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/markdown"
 	"github.com/andreaskoch/allmark/common/paths"
 	"github.com/andreaskoch/allmark/model"
 	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/imageprovider"
 	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/postprocessor"
 	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/preprocessor"
-	"github.com/russross/blackfriday"
+	"github.com/andreaskoch/allmark/services/diagram"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
 )
 
 // Converter converts markdown to HTML
@@ -19,32 +23,36 @@ type Converter struct {
 	logger        logger.Logger
 	preprocessor  *preprocessor.Preprocessor
 	postprocessor *postprocessor.Postprocessor
+	markdown      goldmark.Markdown
 }
 
 // New creates a new Markdown-to-HTML converter instance.
-func New(logger logger.Logger, imageProvider *imageprovider.ImageProvider) *Converter {
+func New(logger logger.Logger, config config.Config, imageProvider *imageprovider.ImageProvider, diagrams *diagram.Renderer) *Converter {
+	htmlPostprocessor := postprocessor.New(logger, imageProvider, diagrams)
+
+	// the markdown the extensions are converted into is rendered without the extensions
+	markdownPreprocessor := preprocessor.New(logger, config, imageProvider, markdown.New(htmlPostprocessor))
+
 	return &Converter{
 		logger:        logger,
-		preprocessor:  preprocessor.New(logger, imageProvider),
-		postprocessor: postprocessor.New(logger, imageProvider),
+		preprocessor:  markdownPreprocessor,
+		postprocessor: htmlPostprocessor,
+		markdown:      markdown.New(htmlPostprocessor, markdownPreprocessor),
 	}
 }
 
 // Convert the supplied item with all paths relative to the supplied base route
-func (converter *Converter) Convert(aliasResolver func(alias string) *model.Item, pathProvider paths.Pather, item *model.Item) (convertedContent string, converterError error) {
+func (converter *Converter) Convert(aliasResolver func(alias string) *model.Item, nameResolver func(name string) *model.Item, pathProvider paths.Pather, item *model.Item) (convertedContent string, converterError error) {
 
 	converter.logger.Debug("Converting markdown for item %q.", item)
 
-	// preprocessor
-	rawMarkdownContent := item.Content
-	preprocessedMarkdownContent, err := converter.preprocessor.Convert(aliasResolver, pathProvider, item.Route(), item.Files(), rawMarkdownContent)
+	// markdown to html (the preprocessor converts the markdown extensions while the markdown is parsed)
+	parserContext := converter.preprocessor.NewParserContext(aliasResolver, nameResolver, pathProvider, item.Route(), item.Files())
+	htmlContent, err := markdown.ToHTML(converter.markdown, item.Content, parser.WithContext(parserContext))
 	if err != nil {
 		return "", err
 	}
 
-	// markdown to html
-	htmlContent := markdownToHTML(preprocessedMarkdownContent)
-
 	// postprocessing
 	postProcessedHTMLContent, err := converter.postprocessor.Convert(pathProvider, item.Route(), item.Files(), htmlContent)
 	if err != nil {
@@ -53,25 +61,3 @@ func (converter *Converter) Convert(aliasResolver func(alias string) *model.Item
 
 	return postProcessedHTMLContent, nil
 }
-
-func markdownToHTML(markdown string) (html string) {
-	// set up the HTML renderer
-	htmlFlags := 0
-	htmlFlags |= blackfriday.HTML_USE_XHTML
-	htmlFlags |= blackfriday.HTML_USE_SMARTYPANTS
-	htmlFlags |= blackfriday.HTML_SMARTYPANTS_FRACTIONS
-	htmlFlags |= blackfriday.HTML_SMARTYPANTS_LATEX_DASHES
-	renderer := blackfriday.HtmlRenderer(htmlFlags, "", "")
-
-	// set up the parser
-	extensions := 0
-	extensions |= blackfriday.EXTENSION_NO_INTRA_EMPHASIS
-	extensions |= blackfriday.EXTENSION_TABLES
-	extensions |= blackfriday.EXTENSION_FENCED_CODE
-	extensions |= blackfriday.EXTENSION_AUTOLINK
-	extensions |= blackfriday.EXTENSION_STRIKETHROUGH
-	extensions |= blackfriday.EXTENSION_SPACE_HEADERS
-	extensions |= blackfriday.EXTENSION_HARD_LINE_BREAK
-
-	return string(blackfriday.Markdown([]byte(markdown), renderer, extensions))
-}
@@ -0,0 +1,78 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package markdowntohtml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/imageprovider"
+	"github.com/andreaskoch/allmark/services/diagram"
+	"github.com/andreaskoch/allmark/services/thumbnail"
+)
+
+type testPather struct {
+}
+
+func (pather testPather) Path(itemPath string) string {
+	return "/" + itemPath
+}
+
+func (pather testPather) Base() route.Route {
+	return route.New()
+}
+
+// convert converts the given markdown with the given configuration.
+func convert(t *testing.T, configuration *config.Config, markdown string) string {
+	logger := console.New(loglevel.Fatal)
+	converter := New(logger, *configuration, imageprovider.NewImageProvider(testPather{}, thumbnail.EmptyIndex()), diagram.New(logger, diagram.EmptyCache()))
+
+	item := model.NewItem(route.NewFromRequest("documents/example"), nil, dataaccess.TypePhysical)
+	item.Content = markdown
+
+	result, err := converter.Convert(func(alias string) *model.Item { return nil }, func(name string) *model.Item { return nil }, testPather{}, item)
+	if err != nil {
+		t.Fatalf("Convert returned an error: %s", err)
+	}
+
+	return result
+}
+
+func Test_Convert_MathPlaceholderInCode_CodeIsNotChanged(t *testing.T) {
+	// arrange
+	input := "Inline `allmarkmathinline78end` code and $x$ math.\n\n```\nallmarkmathinline78end\n```\n"
+
+	// act
+	result := convert(t, config.Default(t.TempDir()), input)
+
+	// assert
+	if strings.Count(result, "allmarkmathinline78end") != 2 || strings.Count(result, "<math") != 1 {
+		t.Errorf("The code should be rendered as text and only the math should be rendered as MathML but the result was %q", result)
+	}
+}
+
+func Test_Convert_HTMLPlaceholderInDocument_TextIsNotReplaced(t *testing.T) {
+	// arrange
+	configuration := config.Default(t.TempDir())
+	configuration.Conversion.Extensions.Commands = []config.CommandExtension{
+		{Name: "bold", Command: "sh", Arguments: []string{"-c", "printf '<b>%s</b>' \"$ALLMARK_DESCRIPTION\""}, Output: config.CommandOutputHTML},
+	}
+
+	input := "allmarkhtml3c623e4849end and `allmarkhtml3c623e4849end`\n\nexec:bold [Bold](text)\n"
+
+	// act
+	result := convert(t, configuration, input)
+
+	// assert
+	if strings.Count(result, "allmarkhtml3c623e4849end") != 2 || strings.Count(result, "<b>") != 1 || !strings.Contains(result, "<b>Bold</b>") {
+		t.Errorf("Only the output of the command should be inserted as HTML but the result was %q", result)
+	}
+}
@@ -5,33 +5,53 @@
 package postprocessor
 
 import (
-	"github.com/kyokomi/emoji"
 	"regexp"
-	"strings"
+
+	"github.com/kyokomi/emoji"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
 )
 
 var (
 	emojiPattern = regexp.MustCompile(`:[\w\d_]+:`)
 )
 
-// addEmojis searches the supplied HTML code for supported emojis
-// and replaces them with the respective emoji icon (see: http://www.emoji-cheat-sheet.com/).
+// emojiTransformer replaces the supported emojis in the text of the document (but not in code)
+// with the respective emoji icon (see: http://www.emoji-cheat-sheet.com/).
 // Example: :dancers: becomes 👯
-func addEmojis(html string) string {
+type emojiTransformer struct {
+}
 
-	allMatches := emojiPattern.FindAllStringSubmatch(html, -1)
-	for _, matches := range allMatches {
-		if len(matches) == 0 {
-			continue
+// Transform adds the emojis to the text nodes of the given document.
+func (transformer *emojiTransformer) Transform(document *ast.Document, reader text.Reader, context parser.Context) {
+
+	var textNodes []*ast.Text
+	ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
 		}
 
-		originalText := strings.TrimSpace(matches[0])
-		emojifiedText := emoji.Sprint(originalText)
+		if node.Kind() == ast.KindCodeSpan {
+			return ast.WalkSkipChildren, nil
+		}
 
-		// replace all occurances
-		html = strings.Replace(html, originalText, emojifiedText, -1)
+		if textNode, isText := node.(*ast.Text); isText && emojiPattern.Match(textNode.Segment.Value(reader.Source())) {
+			textNodes = append(textNodes, textNode)
+		}
 
-	}
+		return ast.WalkContinue, nil
+	})
+
+	for _, textNode := range textNodes {
+		value := textNode.Segment.Value(reader.Source())
+		emojifiedText := emoji.Sprint(string(value))
+		if emojifiedText == string(value) {
+			continue
+		}
 
-	return emoji.Sprint(html)
+		// the text node is kept (without text) for the line breaks
+		textNode.Parent().InsertBefore(textNode.Parent(), textNode, ast.NewString([]byte(emojifiedText)))
+		textNode.Segment = textNode.Segment.WithStart(textNode.Segment.Stop)
+	}
 }
@@ -0,0 +1,91 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postprocessor
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+
+	"github.com/andreaskoch/allmark/common/highlight"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/services/diagram"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+func newCodeBlockRenderer(logger logger.Logger, diagrams *diagram.Renderer) *codeBlockRenderer {
+	return &codeBlockRenderer{
+		logger:   logger,
+		diagrams: diagrams,
+	}
+}
+
+// codeBlockRenderer renders code blocks with syntax highlighting. The language and the options
+// (line numbers, highlighted lines) are taken from the info string of fenced code blocks
+// (e.g. "```go {linenos=true hl_lines=[2,"4-6"]}"). Dot code blocks are rendered as SVG images
+// and mermaid code blocks are marked so they can be rendered in the browser.
+type codeBlockRenderer struct {
+	logger   logger.Logger
+	diagrams *diagram.Renderer
+}
+
+// RegisterFuncs registers the renderer for fenced and indented code blocks.
+func (codeBlockRenderer *codeBlockRenderer) RegisterFuncs(registerer renderer.NodeRendererFuncRegisterer) {
+	registerer.Register(ast.KindFencedCodeBlock, codeBlockRenderer.render)
+	registerer.Register(ast.KindCodeBlock, codeBlockRenderer.render)
+}
+
+func (codeBlockRenderer *codeBlockRenderer) render(writer util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	info := ""
+	if fencedCodeBlock, isFenced := node.(*ast.FencedCodeBlock); isFenced && fencedCodeBlock.Info != nil {
+		info = string(fencedCodeBlock.Info.Segment.Value(source))
+	}
+
+	var code bytes.Buffer
+	lines := node.Lines()
+	for index := 0; index < lines.Len(); index++ {
+		line := lines.At(index)
+		code.Write(line.Value(source))
+	}
+
+	writer.WriteString(codeBlockRenderer.getCodeBlockCode(info, code.String()))
+	writer.WriteString("\n")
+
+	return ast.WalkSkipChildren, nil
+}
+
+// getCodeBlockCode returns the HTML code for the code block with the given info string and code.
+func (codeBlockRenderer *codeBlockRenderer) getCodeBlockCode(info, code string) string {
+
+	language, options := highlight.ParseInfo(info)
+
+	// diagrams
+	if language == "mermaid" {
+		return fmt.Sprintf(`<pre class="%s">%s</pre>`, language, html.EscapeString(code))
+	}
+
+	if codeBlockRenderer.diagrams.CanRender(language) {
+		svg, err := codeBlockRenderer.diagrams.Render(language, code)
+		if err == nil {
+			return fmt.Sprintf(`<figure class="diagram">%s</figure>`, svg)
+		}
+
+		codeBlockRenderer.logger.Warn("Cannot render the %s diagram. Error: %s", language, err)
+	}
+
+	// syntax highlighting
+	class := "hljs"
+	if language = highlight.GetLanguage(language); language != "" {
+		class = fmt.Sprintf("language-%s hljs %s", html.EscapeString(language), html.EscapeString(language))
+	}
+
+	return fmt.Sprintf(`<pre><code class="%s">%s</code></pre>`, class, highlight.Highlight(code, language, options))
+}
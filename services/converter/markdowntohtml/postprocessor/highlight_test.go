@@ -0,0 +1,68 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postprocessor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/common/markdown"
+	"github.com/andreaskoch/allmark/services/diagram"
+)
+
+func getTestMarkdownConverter(input string) string {
+	logger := console.New(loglevel.Fatal)
+	converter := markdown.New(New(logger, nil, diagram.New(logger, diagram.EmptyCache())))
+
+	html, _ := markdown.ToHTML(converter, input)
+	return html
+}
+
+func Test_codeBlockRenderer_InfoStringWithOptions_LinesAreNumberedAndHighlighted(t *testing.T) {
+	// arrange
+	input := "```go {linenos=true hl_lines=[2]}\nx\ny\n```\n"
+	expected := `<pre><code class="language-go hljs go"><span class="line"><span class="line-number">1</span>x</span>` + "\n" +
+		`<span class="line highlighted"><span class="line-number">2</span>y</span>` + "\n</code></pre>"
+
+	// act
+	result := getTestMarkdownConverter(input)
+
+	// assert
+	if !strings.Contains(result, expected) {
+		t.Errorf("The result should contain %q but was %q", expected, result)
+	}
+}
+
+func Test_codeBlockRenderer_Diagrams_AreRendered(t *testing.T) {
+	// arrange
+	input := "```dot\ndigraph { a -> b }\n```\n\n```mermaid\ngraph TD; A-->B;\n```\n"
+
+	// act
+	result := getTestMarkdownConverter(input)
+
+	// assert
+	if !strings.Contains(result, `<figure class="diagram"><svg`) {
+		t.Errorf("The dot diagram should have been rendered but the result was %q", result)
+	}
+
+	if !strings.Contains(result, "<pre class=\"mermaid\">graph TD; A--&gt;B;\n</pre>") {
+		t.Errorf("The mermaid diagram should have been marked but the result was %q", result)
+	}
+}
+
+func Test_emojiTransformer_EmojisInText_AreReplacedButNotInCode(t *testing.T) {
+	// arrange
+	input := "A :smile: and `:smile:`\n"
+
+	// act
+	result := getTestMarkdownConverter(input)
+
+	// assert
+	if strings.Count(result, ":smile:") != 1 || !strings.Contains(result, "<code>:smile:</code>") {
+		t.Errorf("Only the emoji outside of the code span should have been replaced but the result was %q", result)
+	}
+}
@@ -10,22 +10,41 @@ import (
 	"github.com/andreaskoch/allmark/common/route"
 	"github.com/andreaskoch/allmark/model"
 	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/imageprovider"
+	"github.com/andreaskoch/allmark/services/diagram"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
 )
 
 // Postprocessor provides post-processing capabilities for HTML code.
 type Postprocessor struct {
 	logger        logger.Logger
 	imageProvider *imageprovider.ImageProvider
+	diagrams      *diagram.Renderer
 }
 
 // New creates a new Postprocessor.
-func New(logger logger.Logger, imageProvider *imageprovider.ImageProvider) *Postprocessor {
+func New(logger logger.Logger, imageProvider *imageprovider.ImageProvider, diagrams *diagram.Renderer) *Postprocessor {
 	return &Postprocessor{
 		logger:        logger,
 		imageProvider: imageProvider,
+		diagrams:      diagrams,
 	}
 }
 
+// Extend adds the post-processing steps which operate on the AST of the markdown
+// document (syntax highlighting, diagrams and emojis) to the given markdown converter.
+func (postprocessor *Postprocessor) Extend(markdown goldmark.Markdown) {
+	markdown.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(&emojiTransformer{}, 500),
+	))
+
+	markdown.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(newCodeBlockRenderer(postprocessor.logger, postprocessor.diagrams), 100),
+	))
+}
+
 // Convert applies post-processing to the supplied HTML code.
 func (postprocessor *Postprocessor) Convert(
 	pathProvider paths.Pather,
@@ -43,8 +62,8 @@ func (postprocessor *Postprocessor) Convert(
 	// Rewrite Links
 	html = rewireLinks(pathProvider, itemRoute, files, html)
 
-	// Add Emojis
-	html = addEmojis(html)
+	// Heading Anchors and Table of Contents
+	html = addTableOfContents(html)
 
 	return html, nil
 }
@@ -0,0 +1,26 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package postprocessor
+
+import (
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/toc"
+	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/preprocessor"
+)
+
+// addTableOfContents adds anchor ids to all headings and replaces the
+// table of contents placeholders with the table of contents.
+func addTableOfContents(html string) string {
+
+	html = toc.AddHeadingIds(html)
+
+	if !strings.Contains(html, preprocessor.TableOfContentsPlaceholder) {
+		return html
+	}
+
+	tableOfContents := toc.Render(toc.Parse(html))
+	return strings.Replace(html, preprocessor.TableOfContentsPlaceholder, tableOfContents, -1)
+}
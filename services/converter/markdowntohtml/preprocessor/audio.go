@@ -16,7 +16,7 @@ import (
 
 var (
 	// audio: [*description text*](*a link to an audio file*)
-	audioMarkdownExtensionPattern = regexp.MustCompile(`audio: \[([^\]]+)\]\(([^)]+)\)`)
+	audioMarkdownExtensionPattern = regexp.MustCompile(`^audio: \[([^\]]+)\]\(([^)]+)\)`)
 )
 
 func newAudioExtension(pathProvider paths.Pather, files []*model.File) *audioExtension {
@@ -31,30 +31,22 @@ type audioExtension struct {
 	files        []*model.File
 }
 
-func (converter *audioExtension) Convert(markdown string) (convertedContent string, converterError error) {
-
-	convertedContent = markdown
-
-	for _, match := range audioMarkdownExtensionPattern.FindAllStringSubmatch(convertedContent, -1) {
-
-		if len(match) != 3 {
-			continue
-		}
-
-		// parameters
-		originalText := strings.TrimSpace(match[0])
-		title := strings.TrimSpace(match[1])
-		path := strings.TrimSpace(match[2])
-
-		// get the code
-		renderedCode := converter.getAudioCode(title, path)
+func (converter *audioExtension) Prefixes() []string {
+	return []string{"audio:"}
+}
 
-		// replace markdown
-		convertedContent = strings.Replace(convertedContent, originalText, renderedCode, 1)
+func (converter *audioExtension) Convert(markdown string) (length int, conversion Conversion) {
 
+	match := audioMarkdownExtensionPattern.FindStringSubmatch(markdown)
+	if match == nil {
+		return 0, Conversion{}
 	}
 
-	return convertedContent, nil
+	// parameters
+	title := strings.TrimSpace(match[1])
+	path := strings.TrimSpace(match[2])
+
+	return len(match[0]), Conversion{Markdown: converter.getAudioCode(title, path)}
 }
 
 func (converter *audioExtension) getMatchingFile(path string) *model.File {
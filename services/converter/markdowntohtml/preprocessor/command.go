@@ -0,0 +1,303 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/util/fsutil"
+	"github.com/andreaskoch/allmark/model"
+)
+
+// commandWaitDelay is the time the output of a command which has been killed is waited
+// for, before processes which have inherited the output of the command are ignored.
+const commandWaitDelay = time.Second
+
+var (
+	// exec:chart [*description text*](*file or parameter*)
+	commandExtensionPattern = regexp.MustCompile(`^exec:([\w-]+)\s*\[([^\]]*)\]\(([^)]*)\)`)
+
+	// chart, data-table
+	commandNamePattern = regexp.MustCompile(`^[\w-]+$`)
+)
+
+// newCommandRunner creates a runner for the given command which caches the output in the given folder.
+// The output is only cached in memory if the folder is empty.
+func newCommandRunner(logger logger.Logger, command config.CommandExtension, baseFolder, cacheFolder string) *commandRunner {
+
+	// relative paths (e.g. "scripts/chart.sh") are relative to the repository
+	executable := command.Command
+	if strings.ContainsRune(executable, filepath.Separator) && !filepath.IsAbs(executable) {
+		executable = filepath.Join(baseFolder, executable)
+	}
+
+	return &commandRunner{
+		logger:     logger,
+		command:    command,
+		executable: executable,
+		folder:     cacheFolder,
+		outputs:    make(map[string]string),
+	}
+}
+
+// validateCommand returns an error if the given command extension cannot be used.
+func validateCommand(command config.CommandExtension) error {
+	if !commandNamePattern.MatchString(command.Name) {
+		return fmt.Errorf("The name %q of the command extension is invalid. Only letters, digits, underscores and dashes are allowed.", command.Name)
+	}
+
+	if strings.TrimSpace(command.Command) == "" {
+		return fmt.Errorf("The command extension %q has no command.", command.Name)
+	}
+
+	if output := strings.ToLower(command.Output); output != "" && output != config.CommandOutputMarkdown && output != config.CommandOutputHTML {
+		return fmt.Errorf("The output %q of the command extension %q is invalid. Use %q or %q.", command.Output, command.Name, config.CommandOutputMarkdown, config.CommandOutputHTML)
+	}
+
+	return nil
+}
+
+// commandRunner executes an external command and caches its output by the hash of the input.
+type commandRunner struct {
+	logger     logger.Logger
+	command    config.CommandExtension
+	executable string
+	folder     string
+
+	lock    sync.RWMutex
+	outputs map[string]string
+}
+
+// Run pipes the given input through the command and returns its output. The command is
+// executed in an empty temporary folder with a minimal environment and is killed
+// if it does not finish within the configured timeout.
+func (runner *commandRunner) Run(description, parameter string, input []byte) (string, error) {
+
+	hash := runner.getHash(description, parameter, input)
+	if output, exists := runner.get(hash); exists {
+		return output, nil
+	}
+
+	workingDirectory, err := ioutil.TempDir("", "allmark-command-")
+	if err != nil {
+		return "", fmt.Errorf("Cannot create a working directory for the command %q. Error: %s", runner.command.Name, err)
+	}
+
+	defer os.RemoveAll(workingDirectory)
+
+	ctx, cancel := context.WithTimeout(context.Background(), runner.command.Timeout())
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, runner.executable, runner.command.Arguments...)
+	killProcessGroup(cmd)
+	cmd.WaitDelay = commandWaitDelay
+	cmd.Dir = workingDirectory
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + workingDirectory,
+		"TMPDIR=" + workingDirectory,
+		"ALLMARK_COMMAND=" + runner.command.Name,
+		"ALLMARK_DESCRIPTION=" + description,
+		"ALLMARK_PARAMETER=" + parameter,
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("The command %q did not finish within %s.", runner.command.Name, runner.command.Timeout())
+		}
+
+		return "", fmt.Errorf("The command %q failed. Error: %s %s", runner.command.Name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := stdout.String()
+	if err := runner.put(hash, output); err != nil {
+		runner.logger.Warn("%s", err)
+	}
+
+	return output, nil
+}
+
+// getHash returns the hash of the command definition, the version of the executable and the given input.
+func (runner *commandRunner) getHash(description, parameter string, input []byte) string {
+	hash := sha256.New()
+	for _, value := range append([]string{runner.command.Name, runner.executable, runner.getExecutableVersion(), runner.command.Output, description, parameter}, runner.command.Arguments...) {
+		io.WriteString(hash, value)
+		hash.Write([]byte{0})
+	}
+
+	hash.Write(input)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// getExecutableVersion returns the modification time and the size of the executable,
+// so the cached output is not used anymore once the executable has been changed.
+func (runner *commandRunner) getExecutableVersion() string {
+	path, err := exec.LookPath(runner.executable)
+	if err != nil {
+		return ""
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d-%d", fileInfo.ModTime().UnixNano(), fileInfo.Size())
+}
+
+func (runner *commandRunner) get(hash string) (string, bool) {
+	runner.lock.RLock()
+	output, exists := runner.outputs[hash]
+	runner.lock.RUnlock()
+
+	if exists || runner.folder == "" {
+		return output, exists
+	}
+
+	path := filepath.Join(runner.folder, hash)
+	if !fsutil.FileExists(path) {
+		return "", false
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		runner.logger.Warn("Cannot read the cached command output %q. Error: %s", path, err)
+		return "", false
+	}
+
+	output = string(content)
+
+	runner.lock.Lock()
+	runner.outputs[hash] = output
+	runner.lock.Unlock()
+
+	return output, true
+}
+
+func (runner *commandRunner) put(hash, output string) error {
+	runner.lock.Lock()
+	runner.outputs[hash] = output
+	runner.lock.Unlock()
+
+	if runner.folder == "" {
+		return nil
+	}
+
+	if !fsutil.CreateDirectory(runner.folder) {
+		return fmt.Errorf("Cannot create the command folder %q.", runner.folder)
+	}
+
+	path := filepath.Join(runner.folder, hash)
+	if err := ioutil.WriteFile(path, []byte(output), 0644); err != nil {
+		return fmt.Errorf("Cannot save the command output %q. Error: %s", path, err)
+	}
+
+	return nil
+}
+
+func newCommandExtension(logger logger.Logger, runner *commandRunner, files []*model.File) *commandExtension {
+	return &commandExtension{
+		logger: logger,
+		runner: runner,
+		files:  files,
+	}
+}
+
+// commandExtension converts the "exec:<name> [*description*](*file or parameter*)" extensions
+// of one configured command into the output of the command.
+type commandExtension struct {
+	logger logger.Logger
+	runner *commandRunner
+	files  []*model.File
+}
+
+func (converter *commandExtension) Prefixes() []string {
+	return []string{CommandExtensionPrefix + converter.runner.command.Name}
+}
+
+func (converter *commandExtension) Convert(markdown string) (length int, conversion Conversion) {
+
+	match := commandExtensionPattern.FindStringSubmatch(markdown)
+	if match == nil || match[1] != converter.runner.command.Name {
+		return 0, Conversion{}
+	}
+
+	// parameters
+	description := strings.TrimSpace(match[2])
+	parameter := strings.TrimSpace(match[3])
+
+	return len(match[0]), converter.getCommandCode(description, parameter)
+}
+
+// getCommandCode returns the output of the command for the given parameter.
+// The content of the file which matches the parameter is piped to the command;
+// if there is no such file the parameter itself is used.
+func (converter *commandExtension) getCommandCode(description, parameter string) Conversion {
+
+	input := []byte(parameter)
+	if file := converter.getMatchingFile(parameter); file != nil {
+
+		bytesBuffer := new(bytes.Buffer)
+		contentReader := func(content io.ReadSeeker) error {
+			_, err := io.Copy(bytesBuffer, content)
+			return err
+		}
+
+		if err := file.Data(contentReader); err != nil {
+			return Conversion{HTML: fmt.Sprintf("<!-- Cannot read the file %q (Error: %s) -->", parameter, err)}
+		}
+
+		input = bytesBuffer.Bytes()
+	}
+
+	output, err := converter.runner.Run(description, parameter, input)
+	if err != nil {
+		converter.logger.Warn("%s", err)
+		return Conversion{HTML: fmt.Sprintf("<!-- Cannot execute the command %q (Error: %s) -->", converter.runner.command.Name, strings.Replace(err.Error(), "--", "- -", -1))}
+	}
+
+	if !converter.runner.command.OutputIsHTML() {
+		return Conversion{Markdown: output}
+	}
+
+	return Conversion{
+		HTML:  fmt.Sprintf("<div class=\"command command-%s\">%s</div>", converter.runner.command.Name, output),
+		Block: true,
+	}
+}
+
+func (converter *commandExtension) getMatchingFile(path string) *model.File {
+	if path == "" {
+		return nil
+	}
+
+	for _, file := range converter.files {
+		if file.Route().IsMatch(path) {
+			return file
+		}
+	}
+
+	return nil
+}
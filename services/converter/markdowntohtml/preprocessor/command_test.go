@@ -0,0 +1,206 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+)
+
+func getTestCommandExtension(command config.CommandExtension, cacheFolder string) *commandExtension {
+	logger := console.New(loglevel.Fatal)
+	return newCommandExtension(logger, newCommandRunner(logger, command, "", cacheFolder), nil)
+}
+
+func Test_Convert_MarkdownCommand_ParameterIsPipedThroughCommand(t *testing.T) {
+	// arrange
+	extension := getTestCommandExtension(config.CommandExtension{
+		Name:      "upper",
+		Command:   "tr",
+		Arguments: []string{"a-z", "A-Z"},
+	}, "")
+
+	markdown := "exec:upper [Description](some *text*)"
+
+	// act
+	length, result := extension.Convert(markdown + "\n\nAfter")
+
+	// assert
+	expected := "SOME *TEXT*"
+	if length != len(markdown) || result.Markdown != expected {
+		t.Errorf("The result should be %q (length %d) but was %q (length %d)", expected, len(markdown), result.Markdown, length)
+	}
+}
+
+func Test_Convert_OtherCommand_ExtensionIsNotReplaced(t *testing.T) {
+	// arrange
+	extension := getTestCommandExtension(config.CommandExtension{
+		Name:    "upper",
+		Command: "cat",
+	}, "")
+
+	// act
+	length, _ := extension.Convert("exec:lower [Description](text)")
+
+	// assert
+	if length != 0 {
+		t.Errorf("The extension of another command should not be converted but %d characters were converted", length)
+	}
+}
+
+func Test_Convert_HTMLCommand_OutputIsInsertedAsHTMLBlock(t *testing.T) {
+	// arrange
+	extension := getTestCommandExtension(config.CommandExtension{
+		Name:      "table",
+		Command:   "sh",
+		Arguments: []string{"-c", `printf '<table>\n\n<tr><td>%s</td></tr></table>' "$ALLMARK_DESCRIPTION"`},
+		Output:    config.CommandOutputHTML,
+	}, "")
+
+	// act
+	_, result := extension.Convert("exec:table [Cell](data)")
+
+	// assert
+	expected := Conversion{HTML: `<div class="command command-table"><table>` + "\n\n" + `<tr><td>Cell</td></tr></table></div>`, Block: true}
+	if result != expected {
+		t.Errorf("The result should be %v but was %v", expected, result)
+	}
+}
+
+func Test_Convert_CommandExceedsTimeout_ErrorCommentIsInserted(t *testing.T) {
+	// arrange
+	extension := getTestCommandExtension(config.CommandExtension{
+		Name:             "slow",
+		Command:          "sleep",
+		Arguments:        []string{"5"},
+		TimeoutInSeconds: 1,
+	}, "")
+
+	// act
+	_, result := extension.Convert("exec:slow [Description](text)")
+
+	// assert
+	if !strings.HasPrefix(result.HTML, "<!-- Cannot execute the command \"slow\"") {
+		t.Errorf("The result should be an error comment but was %q", result.HTML)
+	}
+}
+
+func Test_Run_ChildProcessExceedsTimeout_CommandIsStoppedInTime(t *testing.T) {
+	// arrange
+	command := config.CommandExtension{
+		Name:             "slow",
+		Command:          "sh",
+		Arguments:        []string{"-c", "sleep 8; echo done"},
+		TimeoutInSeconds: 1,
+	}
+
+	runner := newCommandRunner(console.New(loglevel.Fatal), command, "", "")
+	start := time.Now()
+
+	// act
+	_, err := runner.Run("Description", "text", nil)
+
+	// assert
+	if err == nil {
+		t.Errorf("Run should return an error if the command exceeds the timeout.")
+	}
+
+	if duration := time.Since(start); duration > 4*time.Second {
+		t.Errorf("The command and its child processes should be stopped after the timeout but Run returned after %s.", duration)
+	}
+}
+
+func Test_Run_CommandHasBeenExecutedBefore_CachedOutputIsReturned(t *testing.T) {
+	// arrange
+	cacheFolder, err := ioutil.TempDir("", "allmark-command-test-")
+	if err != nil {
+		t.Fatalf("Cannot create the cache folder. Error: %s", err)
+	}
+
+	defer os.RemoveAll(cacheFolder)
+
+	// the command counts its executions
+	counterFile := filepath.Join(cacheFolder, "counter")
+	command := config.CommandExtension{
+		Name:      "count",
+		Command:   "sh",
+		Arguments: []string{"-c", "echo run >> " + counterFile + "; cat"},
+	}
+
+	logger := console.New(loglevel.Fatal)
+	newCommandRunner(logger, command, "", cacheFolder).Run("Description", "text", []byte("output"))
+
+	// act
+	result, err := newCommandRunner(logger, command, "", cacheFolder).Run("Description", "text", []byte("output"))
+
+	// assert
+	if err != nil || result != "output" {
+		t.Errorf("The result should be %q but was %q (Error: %v)", "output", result, err)
+	}
+
+	if executions, _ := ioutil.ReadFile(counterFile); string(executions) != "run\n" {
+		t.Errorf("The command should have been executed once but the executions were %q", executions)
+	}
+}
+
+func Test_getExtensionOrder_Commands_CommandsAreAppliedLast(t *testing.T) {
+	// arrange
+	configuration := config.MarkdownExtensions{
+		Order:    []string{"exec:second"},
+		Disabled: []string{"exec:third"},
+		Commands: []config.CommandExtension{{Name: "first"}, {Name: "second"}, {Name: "third"}},
+	}
+
+	expected := append([]string{"exec:second"}, RegisteredExtensions()...)
+	expected = append(expected, "exec:first")
+
+	// act
+	names, unknownNames := getExtensionOrder(configuration)
+
+	// assert
+	if !reflect.DeepEqual(names, expected) || len(unknownNames) > 0 {
+		t.Errorf("The extensions should be %v but were %v (unknown: %v)", expected, names, unknownNames)
+	}
+}
+
+func Test_Run_ExecutableHasChanged_CachedOutputIsNotUsed(t *testing.T) {
+	// arrange
+	folder, err := ioutil.TempDir("", "allmark-command-test-")
+	if err != nil {
+		t.Fatalf("Cannot create the test folder. Error: %s", err)
+	}
+
+	defer os.RemoveAll(folder)
+
+	script := filepath.Join(folder, "script.sh")
+	if err := ioutil.WriteFile(script, []byte("#!/bin/sh\necho old\n"), 0755); err != nil {
+		t.Fatalf("Cannot create the script. Error: %s", err)
+	}
+
+	command := config.CommandExtension{Name: "script", Command: script}
+	logger := console.New(loglevel.Fatal)
+	newCommandRunner(logger, command, "", filepath.Join(folder, "cache")).Run("Description", "text", nil)
+
+	if err := ioutil.WriteFile(script, []byte("#!/bin/sh\necho new version\n"), 0755); err != nil {
+		t.Fatalf("Cannot change the script. Error: %s", err)
+	}
+
+	// act
+	result, err := newCommandRunner(logger, command, "", filepath.Join(folder, "cache")).Run("Description", "text", nil)
+
+	// assert
+	if err != nil || result != "new version\n" {
+		t.Errorf("The result should be the output of the changed script %q but was %q (Error: %v)", "new version\n", result, err)
+	}
+}
@@ -20,7 +20,7 @@ import (
 
 var (
 	// csv: [*description text*](*file path*)
-	csvMarkdownExtensionPattern = regexp.MustCompile(`csv: \[([^\]]+)\]\(([^)]+)\)`)
+	csvMarkdownExtensionPattern = regexp.MustCompile(`^csv: \[([^\]]+)\]\(([^)]+)\)`)
 )
 
 func newCSVExtension(pathProvider paths.Pather, files []*model.File) *csvTableExtension {
@@ -35,30 +35,22 @@ type csvTableExtension struct {
 	files        []*model.File
 }
 
-func (converter *csvTableExtension) Convert(markdown string) (convertedContent string, converterError error) {
-
-	convertedContent = markdown
-
-	for _, match := range csvMarkdownExtensionPattern.FindAllStringSubmatch(convertedContent, -1) {
-
-		if len(match) != 3 {
-			continue
-		}
-
-		// parameters
-		originalText := strings.TrimSpace(match[0])
-		title := strings.TrimSpace(match[1])
-		path := strings.TrimSpace(match[2])
-
-		// get the code
-		renderedCode := converter.getTableCode(title, path)
+func (converter *csvTableExtension) Prefixes() []string {
+	return []string{"csv:"}
+}
 
-		// replace markdown
-		convertedContent = strings.Replace(convertedContent, originalText, renderedCode, 1)
+func (converter *csvTableExtension) Convert(markdown string) (length int, conversion Conversion) {
 
+	match := csvMarkdownExtensionPattern.FindStringSubmatch(markdown)
+	if match == nil {
+		return 0, Conversion{}
 	}
 
-	return convertedContent, nil
+	// parameters
+	title := strings.TrimSpace(match[1])
+	path := strings.TrimSpace(match[2])
+
+	return len(match[0]), Conversion{Markdown: converter.getTableCode(title, path)}
 }
 
 func (converter *csvTableExtension) getMatchingFile(path string) *model.File {
@@ -0,0 +1,206 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/paths"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/imageprovider"
+)
+
+// An Extension converts a markdown extension (e.g. "imagegallery: [*description*](*folder*)")
+// into regular markdown or HTML. The extensions are converted while the markdown is parsed,
+// so they are neither applied to code nor to raw HTML.
+type Extension interface {
+	// Prefixes returns the texts the extension starts with (e.g. "imagegallery:"). The prefixes
+	// are compared case-insensitively. Extensions which start with a letter are only
+	// recognized at the beginning of a line or after a space.
+	Prefixes() []string
+
+	// Convert converts the extension at the beginning of the given markdown (the remaining
+	// text of the paragraph). It returns the length of the converted markdown, which is
+	// zero if the markdown does not start with the extension.
+	Convert(markdown string) (length int, conversion Conversion)
+}
+
+// Conversion is the markdown or the HTML code an extension is converted into.
+type Conversion struct {
+	// Markdown is rendered without applying the extensions again.
+	Markdown string
+
+	// HTML is inserted as it is if there is no markdown.
+	HTML string
+
+	// Block indicates that the HTML code must not be placed inside a paragraph (e.g. a table).
+	Block bool
+}
+
+// Context contains the item which is being converted and the services an extension can use.
+type Context struct {
+	Logger        logger.Logger
+	AliasResolver func(alias string) *model.Item
+	NameResolver  func(name string) *model.Item
+	PathProvider  paths.Pather
+	ItemRoute     route.Route
+	Files         []*model.File
+	ImageProvider *imageprovider.ImageProvider
+}
+
+// ExtensionFactory creates an extension for the item in the given context.
+type ExtensionFactory func(context Context) Extension
+
+// The names of the built-in extensions.
+const (
+	MathExtension            = "math"
+	AudioExtension           = "audio"
+	VideoExtension           = "video"
+	FilesExtension           = "files"
+	FilePreviewExtension     = "filepreview"
+	ImageGalleryExtension    = "imagegallery"
+	CSVExtension             = "csv"
+	ReferenceExtension       = "reference"
+	TableOfContentsExtension = "toc"
+)
+
+// CommandExtensionPrefix is the prefix of the names of the command extensions
+// which are defined in the configuration (e.g. "exec:chart").
+const CommandExtensionPrefix = "exec:"
+
+var (
+	extensionLock      sync.RWMutex
+	extensionNames     []string
+	extensionFactories = make(map[string]ExtensionFactory)
+)
+
+func init() {
+
+	RegisterExtension(MathExtension, func(context Context) Extension {
+		return newMathExtension()
+	})
+
+	RegisterExtension(AudioExtension, func(context Context) Extension {
+		return newAudioExtension(context.PathProvider, context.Files)
+	})
+
+	RegisterExtension(VideoExtension, func(context Context) Extension {
+		return newVideoExtension(context.PathProvider, context.Files)
+	})
+
+	RegisterExtension(FilesExtension, func(context Context) Extension {
+		return newFilesExtension(context.PathProvider, context.ItemRoute, context.Files)
+	})
+
+	RegisterExtension(FilePreviewExtension, func(context Context) Extension {
+		return newFilePreviewExtension(context.PathProvider, context.Files)
+	})
+
+	RegisterExtension(ImageGalleryExtension, func(context Context) Extension {
+		return newImageGalleryExtension(context.PathProvider, context.ItemRoute, context.Files, context.ImageProvider)
+	})
+
+	RegisterExtension(CSVExtension, func(context Context) Extension {
+		return newCSVExtension(context.PathProvider, context.Files)
+	})
+
+	RegisterExtension(ReferenceExtension, func(context Context) Extension {
+		return newReferenceExtension(context.PathProvider, context.AliasResolver, context.NameResolver)
+	})
+
+	RegisterExtension(TableOfContentsExtension, func(context Context) Extension {
+		return newTableOfContentsExtension()
+	})
+}
+
+// RegisterExtension makes a markdown extension available under the given name.
+// If several extensions start at the same position, the extension which has been
+// registered first is applied unless the order is changed in the configuration.
+// Extensions which are compiled into allmark can register themselves in the init
+// function of their package.
+// RegisterExtension panics if the name is empty or already taken.
+func RegisterExtension(name string, factory ExtensionFactory) {
+	extensionLock.Lock()
+	defer extensionLock.Unlock()
+
+	if name == "" || factory == nil {
+		panic("preprocessor: RegisterExtension requires a name and a factory")
+	}
+
+	if _, exists := extensionFactories[name]; exists {
+		panic(fmt.Sprintf("preprocessor: RegisterExtension called twice for extension %q", name))
+	}
+
+	extensionNames = append(extensionNames, name)
+	extensionFactories[name] = factory
+}
+
+// RegisteredExtensions returns the names of all registered extensions in their default order.
+func RegisteredExtensions() []string {
+	extensionLock.RLock()
+	defer extensionLock.RUnlock()
+
+	return append([]string(nil), extensionNames...)
+}
+
+// getExtensionOrder returns the names of the enabled extensions in the order of their
+// precedence: the extensions listed in the configured order come first, all other
+// extensions follow in their default order and the configured command extensions
+// come last. Unknown extension names are returned separately.
+func getExtensionOrder(configuration config.MarkdownExtensions) (names, unknownNames []string) {
+	extensionLock.RLock()
+	defer extensionLock.RUnlock()
+
+	available := make(map[string]bool)
+	for _, name := range extensionNames {
+		available[name] = true
+	}
+
+	var commandNames []string
+	for _, command := range configuration.Commands {
+		name := CommandExtensionPrefix + command.Name
+		commandNames = append(commandNames, name)
+		available[name] = true
+	}
+
+	disabled := make(map[string]bool)
+	for _, name := range configuration.Disabled {
+		if !available[name] {
+			unknownNames = append(unknownNames, name)
+		}
+
+		disabled[name] = true
+	}
+
+	added := make(map[string]bool)
+	for _, name := range append(append(append([]string(nil), configuration.Order...), extensionNames...), commandNames...) {
+		if !available[name] {
+			unknownNames = append(unknownNames, name)
+			continue
+		}
+
+		if disabled[name] || added[name] {
+			continue
+		}
+
+		names = append(names, name)
+		added[name] = true
+	}
+
+	return names, unknownNames
+}
+
+// getExtension creates the extension with the given name for the given context.
+func getExtension(name string, context Context) Extension {
+	extensionLock.RLock()
+	factory := extensionFactories[name]
+	extensionLock.RUnlock()
+
+	return factory(context)
+}
@@ -0,0 +1,73 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+)
+
+type upperCaseExtension struct{}
+
+func (upperCaseExtension) Prefixes() []string {
+	return []string{"audio:"}
+}
+
+func (upperCaseExtension) Convert(markdown string) (int, Conversion) {
+	line := strings.SplitN(markdown, "\n", 2)[0]
+	return len(line), Conversion{Markdown: strings.ToUpper(line)}
+}
+
+func Test_getExtensionOrder_OrderAndDisabledExtensions_ExtensionsAreSortedAndFiltered(t *testing.T) {
+	// arrange
+	configuration := config.MarkdownExtensions{
+		Order:    []string{TableOfContentsExtension, CSVExtension, "unknown"},
+		Disabled: []string{MathExtension, AudioExtension},
+	}
+
+	expected := []string{TableOfContentsExtension, CSVExtension}
+	for _, name := range RegisteredExtensions() {
+		if name != TableOfContentsExtension && name != CSVExtension && name != MathExtension && name != AudioExtension {
+			expected = append(expected, name)
+		}
+	}
+
+	// act
+	names, unknownNames := getExtensionOrder(configuration)
+
+	// assert
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("The extensions should be %v but were %v", expected, names)
+	}
+
+	if !reflect.DeepEqual(unknownNames, []string{"unknown"}) {
+		t.Errorf("The unknown extensions should be %v but were %v", []string{"unknown"}, unknownNames)
+	}
+}
+
+func Test_Convert_RegisteredExtension_ExtensionIsApplied(t *testing.T) {
+	// arrange
+	RegisterExtension("test-uppercase", func(context Context) Extension {
+		return upperCaseExtension{}
+	})
+
+	configuration := config.Config{}
+	for _, name := range RegisteredExtensions() {
+		if name != "test-uppercase" {
+			configuration.Conversion.Extensions.Disabled = append(configuration.Conversion.Extensions.Disabled, name)
+		}
+	}
+
+	// act
+	result := convert(configuration, "audio: [a](b)")
+
+	// assert
+	if expected := "<p>AUDIO: <a href=\"B\">A</a></p>\n"; result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
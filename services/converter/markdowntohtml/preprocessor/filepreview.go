@@ -5,6 +5,7 @@
 package preprocessor
 
 import (
+	"github.com/andreaskoch/allmark/common/highlight"
 	"github.com/andreaskoch/allmark/common/paths"
 	"github.com/andreaskoch/allmark/model"
 	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/util"
@@ -12,14 +13,13 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"path/filepath"
 	"regexp"
 	"strings"
 )
 
 var (
 	// filepreview: [*description text*](*file path*)
-	filePreviewMarkdownExtension = regexp.MustCompile(`filepreview: \[([^\]]+)\]\(([^)]+)\)`)
+	filePreviewMarkdownExtension = regexp.MustCompile(`^filepreview: \[([^\]]+)\]\(([^)]+)\)`)
 )
 
 func newFilePreviewExtension(pathProvider paths.Pather, files []*model.File) *filePreviewExtension {
@@ -34,29 +34,22 @@ type filePreviewExtension struct {
 	files        []*model.File
 }
 
-func (converter *filePreviewExtension) Convert(markdown string) (convertedContent string, converterError error) {
-
-	convertedContent = markdown
-
-	for _, match := range filePreviewMarkdownExtension.FindAllStringSubmatch(convertedContent, -1) {
-
-		if len(match) != 3 {
-			continue
-		}
-
-		// parameters
-		originalText := strings.TrimSpace(match[0])
-		title := strings.TrimSpace(match[1])
-		path := strings.TrimSpace(match[2])
+func (converter *filePreviewExtension) Prefixes() []string {
+	return []string{"filepreview:"}
+}
 
-		// get the code
-		renderedCode := converter.getPreviewCode(title, path)
+func (converter *filePreviewExtension) Convert(markdown string) (length int, conversion Conversion) {
 
-		// replace markdown
-		convertedContent = strings.Replace(convertedContent, originalText, renderedCode, 1)
+	match := filePreviewMarkdownExtension.FindStringSubmatch(markdown)
+	if match == nil {
+		return 0, Conversion{}
 	}
 
-	return convertedContent, nil
+	// parameters
+	title := strings.TrimSpace(match[1])
+	path := strings.TrimSpace(match[2])
+
+	return len(match[0]), Conversion{Markdown: converter.getPreviewCode(title, path)}
 }
 
 func (converter *filePreviewExtension) getPreviewCode(title, path string) string {
@@ -78,10 +71,17 @@ func (converter *filePreviewExtension) getPreviewCode(title, path string) string
 
 		if err := file.Data(contentReader); err == nil {
 
+			// the fence must be longer than any sequence of backticks in the file
+			content := strings.TrimSpace(bytesBuffer.String())
+			fence := "```"
+			for strings.Contains(content, fence) {
+				fence += "`"
+			}
+
 			code := fmt.Sprintf("**[%s](%s)**\n\n", title, filepath)
-			code += fmt.Sprintf("```%s\n", contentLanguage)
-			code += strings.TrimSpace(bytesBuffer.String()) + "\n"
-			code += "```"
+			code += fmt.Sprintf("%s%s\n", fence, contentLanguage)
+			code += content + "\n"
+			code += fence
 
 			return code
 		}
@@ -105,5 +105,5 @@ func (converter *filePreviewExtension) getMatchingFile(path string) *model.File
 
 // getContentLanguageFromFile derives the file content language (e.g. go, php, js, ...)
 func getContentLanguageFromFile(file *model.File) string {
-	return filepath.Ext(file.Route().Value())
+	return highlight.GetLanguageFromFilename(file.Route().Value())
 }
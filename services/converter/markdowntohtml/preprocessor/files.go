@@ -15,7 +15,7 @@ import (
 
 var (
 	// files: [*description text*](*folder path*)
-	filesMarkdownExtensionPattern = regexp.MustCompile(`files: \[([^\]]+)\]\(([^)]+)\)`)
+	filesMarkdownExtensionPattern = regexp.MustCompile(`^files: \[([^\]]+)\]\(([^)]+)\)`)
 )
 
 func newFilesExtension(pathProvider paths.Pather, baseRoute route.Route, files []*model.File) *filesExtension {
@@ -32,31 +32,23 @@ type filesExtension struct {
 	fileTreeRenderer *filetreerenderer.FileTreeRenderer
 }
 
-func (converter *filesExtension) Convert(markdown string) (convertedContent string, converterError error) {
-
-	convertedContent = markdown
-
-	for _, match := range filesMarkdownExtensionPattern.FindAllStringSubmatch(convertedContent, -1) {
-
-		if len(match) != 3 {
-			continue
-		}
-
-		// extract the parameters from the pattern matches
-		originalText := strings.TrimSpace(match[0])
-		title := strings.TrimSpace(match[1])
-		path := strings.TrimSpace(match[2])
+func (converter *filesExtension) Prefixes() []string {
+	return []string{"files:"}
+}
 
-		// normalize the path with the current path provider
-		path = converter.pathProvider.Path(path)
+func (converter *filesExtension) Convert(markdown string) (length int, conversion Conversion) {
 
-		// get the code
-		renderedCode := converter.fileTreeRenderer.Render(title, "filelinks", path)
+	match := filesMarkdownExtensionPattern.FindStringSubmatch(markdown)
+	if match == nil {
+		return 0, Conversion{}
+	}
 
-		// replace markdown with link list
-		convertedContent = strings.Replace(convertedContent, originalText, renderedCode, 1)
+	// extract the parameters from the pattern matches
+	title := strings.TrimSpace(match[1])
+	path := strings.TrimSpace(match[2])
 
-	}
+	// normalize the path with the current path provider
+	path = converter.pathProvider.Path(path)
 
-	return convertedContent, nil
+	return len(match[0]), Conversion{Markdown: converter.fileTreeRenderer.Render(title, "filelinks", path)}
 }
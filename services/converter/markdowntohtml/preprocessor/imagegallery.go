@@ -16,7 +16,7 @@ import (
 
 var (
 	// imagegallery: [*description text*](*folder path*)
-	imageGalleryExtensionPattern = regexp.MustCompile(`imagegallery: \[([^\]]*)\]\(([^)]+)\)`)
+	imageGalleryExtensionPattern = regexp.MustCompile(`^imagegallery: \[([^\]]*)\]\(([^)]+)\)`)
 )
 
 func newImageGalleryExtension(pathProvider paths.Pather, baseRoute route.Route, files []*model.File, imageProvider *imageprovider.ImageProvider) *imageGalleryExtension {
@@ -35,29 +35,22 @@ type imageGalleryExtension struct {
 	imageProvider *imageprovider.ImageProvider
 }
 
-func (converter *imageGalleryExtension) Convert(markdown string) (convertedContent string, converterError error) {
-
-	convertedContent = markdown
-
-	for _, match := range imageGalleryExtensionPattern.FindAllStringSubmatch(convertedContent, -1) {
-
-		if len(match) != 3 {
-			continue
-		}
-
-		// parameters
-		originalText := strings.TrimSpace(match[0])
-		title := strings.TrimSpace(match[1])
-		path := strings.TrimSpace(match[2])
+func (converter *imageGalleryExtension) Prefixes() []string {
+	return []string{"imagegallery:"}
+}
 
-		// get the code
-		renderedCode := converter.getGalleryCode(title, path)
+func (converter *imageGalleryExtension) Convert(markdown string) (length int, conversion Conversion) {
 
-		// replace markdown
-		convertedContent = strings.Replace(convertedContent, originalText, renderedCode, 1)
+	match := imageGalleryExtensionPattern.FindStringSubmatch(markdown)
+	if match == nil {
+		return 0, Conversion{}
 	}
 
-	return convertedContent, nil
+	// parameters
+	title := strings.TrimSpace(match[1])
+	path := strings.TrimSpace(match[2])
+
+	return len(match[0]), Conversion{Markdown: converter.getGalleryCode(title, path)}
 }
 
 func (converter *imageGalleryExtension) getGalleryCode(galleryTitle, path string) string {
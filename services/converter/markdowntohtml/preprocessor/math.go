@@ -0,0 +1,87 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/mathml"
+)
+
+func newMathExtension() *mathExtension {
+	return &mathExtension{}
+}
+
+// mathExtension converts inline ($...$) and display math ($$...$$) into MathML.
+type mathExtension struct {
+}
+
+func (converter *mathExtension) Prefixes() []string {
+	return []string{"$"}
+}
+
+func (converter *mathExtension) Convert(markdown string) (length int, conversion Conversion) {
+	tex, end, display := getMath(markdown, 0)
+	if end == 0 {
+		return 0, Conversion{}
+	}
+
+	return end, Conversion{HTML: mathml.Render(tex, display)}
+}
+
+// getMath returns the LaTeX code of the math expression which starts at the given position, the
+// position after the expression and true if it is display math. The end position is zero if
+// there is no valid math expression at the given position (e.g. "$5 and $10").
+func getMath(markdown string, start int) (tex string, end int, display bool) {
+
+	// display math: $$...$$ (may span multiple lines)
+	if strings.HasPrefix(markdown[start:], "$$") {
+		length := strings.Index(markdown[start+2:], "$$")
+		if length == -1 {
+			return "", 0, false
+		}
+
+		tex = markdown[start+2 : start+2+length]
+		if strings.TrimSpace(tex) == "" || strings.Contains(tex, "\n\n") {
+			return "", 0, false
+		}
+
+		return strings.TrimSpace(tex), start + 2 + length + 2, true
+	}
+
+	// inline math: $...$ (the expression must neither start nor end with a space, must
+	// not contain code and the closing dollar sign must not be followed by a digit)
+	if start+1 >= len(markdown) || isSpace(markdown[start+1]) {
+		return "", 0, false
+	}
+
+	for position := start + 1; position < len(markdown); position++ {
+		switch markdown[position] {
+		case '\\':
+			position++
+
+		case '`':
+			return "", 0, false
+
+		case '\n':
+			if position+1 < len(markdown) && markdown[position+1] == '\n' {
+				return "", 0, false
+			}
+
+		case '$':
+			if isSpace(markdown[position-1]) || position+1 < len(markdown) && markdown[position+1] >= '0' && markdown[position+1] <= '9' {
+				continue
+			}
+
+			return markdown[start+1 : position], position + 1, false
+		}
+	}
+
+	return "", 0, false
+}
+
+func isSpace(character byte) bool {
+	return character == ' ' || character == '\t' || character == '\n' || character == '\r'
+}
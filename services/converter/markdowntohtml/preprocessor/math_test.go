@@ -0,0 +1,59 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/mathml"
+)
+
+func Test_mathExtension_Convert_InlineAndDisplayMath_MathIsConverted(t *testing.T) {
+	// arrange
+	converter := newMathExtension()
+	inputs := []struct {
+		markdown string
+		tex      string
+		length   int
+		display  bool
+	}{
+		{"$a_1 * b$ and more", "a_1 * b", 9, false},
+		{"$$\nx^2\n$$\n", "x^2", 9, true},
+	}
+
+	for _, input := range inputs {
+
+		// act
+		length, result := converter.Convert(input.markdown)
+
+		// assert
+		if expected := mathml.Render(input.tex, input.display); length != input.length || result.HTML != expected {
+			t.Errorf("Convert(%q) should return %q (length %d) but returned %q (length %d)", input.markdown, expected, input.length, result.HTML, length)
+		}
+	}
+}
+
+func Test_mathExtension_Convert_DollarAmounts_AreNotConverted(t *testing.T) {
+	// arrange
+	converter := newMathExtension()
+	inputs := []string{
+		"$5 and $10.",
+		"$ 5 and $ 10.",
+		"$a `b` c$",
+		"$x\n\ny$",
+		"$",
+	}
+
+	for _, input := range inputs {
+
+		// act
+		length, _ := converter.Convert(input)
+
+		// assert
+		if length != 0 {
+			t.Errorf("Convert(%q) should not convert the markdown but converted %d characters", input, length)
+		}
+	}
+}
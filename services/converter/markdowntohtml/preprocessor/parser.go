@@ -0,0 +1,383 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/markdown"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var (
+	// extensionsKey is the key of the extensions of a document in the parser context
+	extensionsKey = parser.NewContextKey()
+
+	// the space (for the extensions which start with a letter) and the punctuation characters (e.g. "$" or "[")
+	extensionTriggers = getExtensionTriggers()
+
+	// the kinds of the nodes which contain the HTML code of an extension
+	kindExtension      = ast.NewNodeKind("Extension")
+	kindExtensionBlock = ast.NewNodeKind("ExtensionBlock")
+)
+
+func getExtensionTriggers() []byte {
+	triggers := []byte{' '}
+	for character := byte(0); character < 128; character++ {
+		if util.IsPunct(character) {
+			triggers = append(triggers, character)
+		}
+	}
+
+	return triggers
+}
+
+// documentExtensions contains the extensions of the document which is being parsed.
+type documentExtensions struct {
+	extensions []Extension
+
+	// the prefixes of the extensions by extension
+	prefixes [][][]byte
+
+	// indicates whether there is a prefix which starts with the (lower case) character
+	firstCharacters [256]bool
+}
+
+func newDocumentExtensions(extensions []Extension) *documentExtensions {
+	document := &documentExtensions{
+		extensions: extensions,
+	}
+
+	for _, extension := range extensions {
+		var prefixes [][]byte
+		for _, prefix := range extension.Prefixes() {
+			if prefix == "" {
+				continue
+			}
+
+			prefixes = append(prefixes, []byte(prefix))
+			document.firstCharacters[toLower(prefix[0])] = true
+		}
+
+		document.prefixes = append(document.prefixes, prefixes)
+	}
+
+	return document
+}
+
+// getExtensions returns the extensions which have a prefix the given line starts with.
+func (document *documentExtensions) getExtensions(line []byte) []Extension {
+	if len(line) == 0 || !document.firstCharacters[toLower(line[0])] {
+		return nil
+	}
+
+	var extensions []Extension
+	for index, prefixes := range document.prefixes {
+		for _, prefix := range prefixes {
+			if len(line) >= len(prefix) && bytes.EqualFold(line[:len(prefix)], prefix) {
+				extensions = append(extensions, document.extensions[index])
+				break
+			}
+		}
+	}
+
+	return extensions
+}
+
+func toLower(character byte) byte {
+	if character >= 'A' && character <= 'Z' {
+		return character + 'a' - 'A'
+	}
+
+	return character
+}
+
+// extensionParser converts the markdown extensions while the inline markdown is parsed.
+type extensionParser struct {
+	preprocessor *Preprocessor
+}
+
+func (extensionParser *extensionParser) Trigger() []byte {
+	return extensionTriggers
+}
+
+func (extensionParser *extensionParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	document, isDocument := pc.Get(extensionsKey).(*documentExtensions)
+	if !isDocument {
+		return nil
+	}
+
+	line, segment := block.PeekLine()
+
+	// the spaces before an extension are returned as text, so the extension is parsed next
+	spaces := 0
+	for spaces < len(line) && (line[spaces] == ' ' || line[spaces] == '\t') {
+		spaces++
+	}
+
+	if spaces > 0 {
+		if document.getExtensions(line[spaces:]) == nil {
+			return nil
+		}
+
+		block.Advance(spaces)
+		return ast.NewTextSegment(segment.WithStop(segment.Start + spaces))
+	}
+
+	remainingText := ""
+	for _, extension := range document.getExtensions(line) {
+		if remainingText == "" {
+			remainingText = getRemainingText(block)
+		}
+
+		length, conversion := extension.Convert(remainingText)
+		if length <= 0 {
+			continue
+		}
+
+		advance(block, length)
+		return extensionParser.preprocessor.getExtensionNode(conversion)
+	}
+
+	return nil
+}
+
+// getRemainingText returns the text of the block from the current position on.
+func getRemainingText(block text.Reader) string {
+	line, position := block.Position()
+	defer block.SetPosition(line, position)
+
+	var remainingText []byte
+	for {
+		text, _ := block.PeekLine()
+		if text == nil {
+			return string(remainingText)
+		}
+
+		remainingText = append(remainingText, text...)
+		block.AdvanceLine()
+	}
+}
+
+// advance moves the position of the given block by the given number of characters (across lines).
+func advance(block text.Reader, length int) {
+	for length > 0 {
+		line, _ := block.PeekLine()
+		if line == nil {
+			return
+		}
+
+		if length < len(line) {
+			block.Advance(length)
+			return
+		}
+
+		length -= len(line)
+		block.AdvanceLine()
+	}
+}
+
+// getExtensionNode returns the node for the given conversion of an extension. Markdown which
+// is rendered to a single paragraph is inserted into the paragraph of the extension.
+func (preprocessor *Preprocessor) getExtensionNode(conversion Conversion) ast.Node {
+	if conversion.Markdown == "" {
+		return &extensionNode{html: conversion.HTML, block: conversion.Block}
+	}
+
+	htmlCode, err := markdown.ToHTML(preprocessor.markdown, conversion.Markdown)
+	if err != nil {
+		preprocessor.logger.Warn("Cannot render the markdown of an extension. Error: %s", err)
+		return &extensionNode{}
+	}
+
+	if content, isParagraph := getParagraphContent(htmlCode); isParagraph {
+		return &extensionNode{html: content}
+	}
+
+	return &extensionNode{html: htmlCode, block: true}
+}
+
+// getParagraphContent returns the content of the given HTML code if it is a single paragraph.
+func getParagraphContent(htmlCode string) (string, bool) {
+	htmlCode = strings.TrimSpace(htmlCode)
+	if !strings.HasPrefix(htmlCode, "<p>") || !strings.HasSuffix(htmlCode, "</p>") || strings.Count(htmlCode, "</p>") != 1 {
+		return "", false
+	}
+
+	return htmlCode[len("<p>") : len(htmlCode)-len("</p>")], true
+}
+
+// extensionNode contains the HTML code of an extension inside a paragraph.
+type extensionNode struct {
+	ast.BaseInline
+
+	html string
+
+	// the HTML code must be moved out of the paragraph
+	block bool
+}
+
+func (node *extensionNode) Kind() ast.NodeKind {
+	return kindExtension
+}
+
+func (node *extensionNode) Dump(source []byte, level int) {
+	ast.DumpHelper(node, source, level, map[string]string{"HTML": node.html}, nil)
+}
+
+// extensionBlockNode contains the HTML code of an extension which is a block of its own.
+type extensionBlockNode struct {
+	ast.BaseBlock
+
+	html string
+}
+
+func (node *extensionBlockNode) Kind() ast.NodeKind {
+	return kindExtensionBlock
+}
+
+func (node *extensionBlockNode) Dump(source []byte, level int) {
+	ast.DumpHelper(node, source, level, map[string]string{"HTML": node.html}, nil)
+}
+
+// extensionBlockTransformer moves the extensions which must not be inside a paragraph
+// (e.g. a table) out of their paragraph.
+type extensionBlockTransformer struct {
+}
+
+func (transformer *extensionBlockTransformer) Transform(document *ast.Document, reader text.Reader, pc parser.Context) {
+
+	var paragraphs []ast.Node
+	ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		extension, isExtension := node.(*extensionNode)
+		if !entering || !isExtension || !extension.block {
+			return ast.WalkContinue, nil
+		}
+
+		// extensions in headings, table cells, links, ... stay where they are
+		paragraph := extension.Parent()
+		if paragraph.Kind() != ast.KindParagraph && paragraph.Kind() != ast.KindTextBlock {
+			return ast.WalkContinue, nil
+		}
+
+		if len(paragraphs) == 0 || paragraphs[len(paragraphs)-1] != paragraph {
+			paragraphs = append(paragraphs, paragraph)
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	for _, paragraph := range paragraphs {
+		splitParagraph(paragraph, reader.Source())
+	}
+}
+
+// splitParagraph replaces the given paragraph with the blocks of its extensions
+// and paragraphs which contain the other inline nodes (without the spaces and
+// line breaks next to the blocks).
+func splitParagraph(paragraph ast.Node, source []byte) {
+	container := paragraph.Parent()
+
+	var current ast.Node
+	for child := paragraph.FirstChild(); child != nil; {
+		next := child.NextSibling()
+		paragraph.RemoveChild(paragraph, child)
+
+		if extension, isExtension := child.(*extensionNode); isExtension && extension.block {
+			closeParagraph(current, source)
+			container.InsertBefore(container, paragraph, &extensionBlockNode{html: extension.html})
+			current = nil
+		} else if current != nil || !isBlank(child, source) {
+			if current == nil {
+				current = newParagraph(paragraph)
+				container.InsertBefore(container, paragraph, current)
+
+				if text, isText := child.(*ast.Text); isText {
+					text.Segment = text.Segment.TrimLeftSpace(source)
+				}
+			}
+
+			current.AppendChild(current, child)
+		}
+
+		child = next
+	}
+
+	closeParagraph(current, source)
+	container.RemoveChild(container, paragraph)
+}
+
+// newParagraph creates an empty paragraph of the same kind as the given paragraph.
+func newParagraph(paragraph ast.Node) ast.Node {
+	if paragraph.Kind() == ast.KindTextBlock {
+		return ast.NewTextBlock()
+	}
+
+	return ast.NewParagraph()
+}
+
+// closeParagraph removes the spaces and the line break at the end of the given
+// paragraph or the whole paragraph if it contains nothing but spaces.
+func closeParagraph(paragraph ast.Node, source []byte) {
+	if paragraph == nil {
+		return
+	}
+
+	if lastText, isText := paragraph.LastChild().(*ast.Text); isText {
+		lastText.Segment = lastText.Segment.TrimRightSpace(source)
+		lastText.SetSoftLineBreak(false)
+		lastText.SetHardLineBreak(false)
+	}
+
+	for child := paragraph.FirstChild(); child != nil; child = child.NextSibling() {
+		if !isBlank(child, source) {
+			return
+		}
+	}
+
+	paragraph.Parent().RemoveChild(paragraph.Parent(), paragraph)
+}
+
+// isBlank indicates whether the given node is a text which contains nothing but spaces.
+func isBlank(node ast.Node, source []byte) bool {
+	text, isText := node.(*ast.Text)
+	return isText && util.IsBlank(text.Segment.Value(source))
+}
+
+// extensionRenderer writes the HTML code of the extensions.
+type extensionRenderer struct {
+}
+
+func (extensionRenderer *extensionRenderer) RegisterFuncs(registerer renderer.NodeRendererFuncRegisterer) {
+	registerer.Register(kindExtension, extensionRenderer.renderExtension)
+	registerer.Register(kindExtensionBlock, extensionRenderer.renderExtensionBlock)
+}
+
+func (extensionRenderer *extensionRenderer) renderExtension(writer util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		writer.WriteString(node.(*extensionNode).html)
+	}
+
+	return ast.WalkContinue, nil
+}
+
+func (extensionRenderer *extensionRenderer) renderExtensionBlock(writer util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	htmlCode := node.(*extensionBlockNode).html
+	writer.WriteString(htmlCode)
+	if !strings.HasSuffix(htmlCode, "\n") {
+		writer.WriteByte('\n')
+	}
+
+	return ast.WalkContinue, nil
+}
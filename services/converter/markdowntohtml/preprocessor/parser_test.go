@@ -0,0 +1,77 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/common/markdown"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/yuin/goldmark/parser"
+)
+
+// convert converts the given markdown to HTML with the extensions of the given configuration.
+func convert(configuration config.Config, markdownCode string) string {
+	preprocessor := New(console.New(loglevel.Fatal), configuration, nil, markdown.New())
+	parserContext := preprocessor.NewParserContext(nil, nil, nil, route.Route{}, nil)
+
+	result, _ := markdown.ToHTML(markdown.New(preprocessor), markdownCode, parser.WithContext(parserContext))
+	return result
+}
+
+func Test_Convert_ExtensionsInCode_AreNotApplied(t *testing.T) {
+	// arrange
+	input := "[toc]\n\n```markdown\n[toc]\n```\n\nInline `[[Page Title]]` code\n"
+	expected := TableOfContentsPlaceholder + "\n<pre><code class=\"language-markdown\">[toc]\n</code></pre>\n<p>Inline <code>[[Page Title]]</code> code</p>\n"
+
+	// act
+	result := convert(config.Config{}, input)
+
+	// assert
+	if result != expected {
+		t.Errorf("The result should be %q but was %q", expected, result)
+	}
+}
+
+func Test_Convert_MathInCodeAndEscapedDollarSigns_IsNotConverted(t *testing.T) {
+	// arrange
+	inputs := []string{
+		"It costs $5 and $10, `$x$`.",
+		"Use `$x$` or ``a $b$ c``.",
+		"```\necho $x$\n```\n",
+		"~~~~ bash\n$$x$$\n~~~\n~~~~\n",
+		`An escaped \$x$ sign.`,
+		`<span title="$x$">text</span>`,
+	}
+
+	for _, input := range inputs {
+
+		// act
+		result := convert(config.Config{}, input)
+
+		// assert
+		if strings.Contains(result, "<math") {
+			t.Errorf("The markdown %q should not contain math but was converted to %q", input, result)
+		}
+	}
+}
+
+func Test_Convert_BlockExtensionInParagraph_ParagraphIsSplit(t *testing.T) {
+	// arrange
+	input := "Before\n[toc]\nAfter $x$"
+	expected := "<p>Before</p>\n" + TableOfContentsPlaceholder + "\n<p>After "
+
+	// act
+	result := convert(config.Config{}, input)
+
+	// assert
+	if !strings.HasPrefix(result, expected) || strings.Count(result, "<math") != 1 {
+		t.Errorf("The result should start with %q and contain the math but was %q", expected, result)
+	}
+}
@@ -5,84 +5,124 @@
 package preprocessor
 
 import (
+	"github.com/andreaskoch/allmark/common/config"
 	"github.com/andreaskoch/allmark/common/logger"
 	"github.com/andreaskoch/allmark/common/paths"
 	"github.com/andreaskoch/allmark/common/route"
 	"github.com/andreaskoch/allmark/model"
 	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/imageprovider"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
 )
 
-// Preprocessor provides pre-processing capabilties for markdown code.
+// Preprocessor converts the markdown extensions (e.g. "imagegallery: [*description*](*folder*)")
+// while the markdown is parsed.
 type Preprocessor struct {
 	logger        logger.Logger
 	imageProvider *imageprovider.ImageProvider
-}
 
-// New creates an instance of a Markdown Preprocessor.
-func New(logger logger.Logger, imageProvider *imageprovider.ImageProvider) *Preprocessor {
-	return &Preprocessor{
-		logger:        logger,
-		imageProvider: imageProvider,
-	}
+	// renders the markdown the extensions are converted into
+	markdown goldmark.Markdown
+
+	// the names of the enabled extensions in the order of their precedence
+	extensions []string
+
+	// the runners of the configured command extensions by extension name
+	commands map[string]*commandRunner
 }
 
-// Convert converts all markdown extensions in the supplied markdown to normal markdown code or HTML.
-func (preprocessor *Preprocessor) Convert(
-	aliasResolver func(alias string) *model.Item,
-	pathProvider paths.Pather,
-	itemRoute route.Route,
-	files []*model.File,
-	markdown string) (processedMarkdown string, errors error) {
-
-	// markdown extension: audio
-	audioConverter := newAudioExtension(pathProvider, files)
-	markdown, audioConversionError := audioConverter.Convert(markdown)
-	if audioConversionError != nil {
-		preprocessor.logger.Warn("Error while converting audio extensions. Error: %s", audioConversionError)
-	}
+// New creates an instance of a Markdown Preprocessor which applies the
+// registered extensions and the configured command extensions in the order
+// defined by the given configuration. The markdown the extensions are
+// converted into is rendered with the given converter.
+func New(logger logger.Logger, config config.Config, imageProvider *imageprovider.ImageProvider, markdown goldmark.Markdown) *Preprocessor {
 
-	// markdown extension: video
-	videoConverter := newVideoExtension(pathProvider, files)
-	markdown, videoConversionError := videoConverter.Convert(markdown)
-	if videoConversionError != nil {
-		preprocessor.logger.Warn("Error while converting video extensions. Error: %s", videoConversionError)
+	// the command output is only cached in memory if there is no meta-data folder
+	cacheFolder := ""
+	if config.MetaDataFolder() != "" {
+		cacheFolder = config.CommandFolder()
 	}
 
-	// markdown extension: files
-	filesConverter := newFilesExtension(pathProvider, itemRoute, files)
-	markdown, filesConversionError := filesConverter.Convert(markdown)
-	if filesConversionError != nil {
-		preprocessor.logger.Warn("Error while converting files extensions. Error: %s", filesConversionError)
-	}
+	extensionConfiguration := config.Conversion.Extensions
+	extensionConfiguration.Commands = nil
+
+	commands := make(map[string]*commandRunner)
+	for _, command := range config.Conversion.Extensions.Commands {
+		name := CommandExtensionPrefix + command.Name
+		if err := validateCommand(command); err != nil {
+			logger.Warn("%s", err)
+			continue
+		}
 
-	// markdown extension: filepreview
-	filePreviewConverter := newFilePreviewExtension(pathProvider, files)
-	markdown, filePreviewConversionError := filePreviewConverter.Convert(markdown)
-	if filePreviewConversionError != nil {
-		preprocessor.logger.Warn("Error while converting file preview extensions. Error: %s", filePreviewConversionError)
+		if _, exists := commands[name]; exists {
+			logger.Warn("The command extension %q is defined more than once.", command.Name)
+			continue
+		}
+
+		commands[name] = newCommandRunner(logger, command, config.BaseFolder(), cacheFolder)
+		extensionConfiguration.Commands = append(extensionConfiguration.Commands, command)
 	}
 
-	// markdown extension: imagegallery
-	imagegalleryConverter := newImageGalleryExtension(pathProvider, itemRoute, files, preprocessor.imageProvider)
-	markdown, imagegalleryConversionError := imagegalleryConverter.Convert(markdown)
-	if imagegalleryConversionError != nil {
-		preprocessor.logger.Warn("Error while converting image gallery extensions. Error: %s", imagegalleryConversionError)
+	extensions, unknownExtensions := getExtensionOrder(extensionConfiguration)
+	for _, name := range unknownExtensions {
+		logger.Warn("The markdown extension %q is not available.", name)
 	}
 
-	// markdown extension: csv table
-	csvTableConverter := newCSVExtension(pathProvider, files)
-	markdown, csvTableConversionError := csvTableConverter.Convert(markdown)
-	if csvTableConversionError != nil {
-		preprocessor.logger.Warn("Error while converting csv table extensions. Error: %s", csvTableConversionError)
+	return &Preprocessor{
+		logger:        logger,
+		imageProvider: imageProvider,
+		markdown:      markdown,
+		extensions:    extensions,
+		commands:      commands,
 	}
+}
+
+// Extend adds the parser which converts the markdown extensions to the given markdown converter.
+func (preprocessor *Preprocessor) Extend(markdown goldmark.Markdown) {
+	markdown.Parser().AddOptions(
+		parser.WithInlineParsers(
+			util.Prioritized(&extensionParser{preprocessor}, 150),
+		),
+		parser.WithASTTransformers(
+			util.Prioritized(&extensionBlockTransformer{}, 100),
+		),
+	)
 
-	// markdown extension: reference
-	referenceConverter := newReferenceExtension(pathProvider, aliasResolver)
-	markdown, referenceConversionError := referenceConverter.Convert(markdown)
-	if referenceConversionError != nil {
-		preprocessor.logger.Warn("Error while converting reference extensions. Error: %s", referenceConversionError)
+	markdown.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(&extensionRenderer{}, 100),
+	))
+}
+
+// NewParserContext creates the parser context for converting the markdown extensions of the given item.
+func (preprocessor *Preprocessor) NewParserContext(
+	aliasResolver func(alias string) *model.Item,
+	nameResolver func(name string) *model.Item,
+	pathProvider paths.Pather,
+	itemRoute route.Route,
+	files []*model.File) parser.Context {
+
+	context := Context{
+		Logger:        preprocessor.logger,
+		AliasResolver: aliasResolver,
+		NameResolver:  nameResolver,
+		PathProvider:  pathProvider,
+		ItemRoute:     itemRoute,
+		Files:         files,
+		ImageProvider: preprocessor.imageProvider,
 	}
 
-	return markdown, nil
+	var extensions []Extension
+	for _, name := range preprocessor.extensions {
+		if runner, isCommand := preprocessor.commands[name]; isCommand {
+			extensions = append(extensions, newCommandExtension(preprocessor.logger, runner, files))
+		} else {
+			extensions = append(extensions, getExtension(name, context))
+		}
+	}
 
+	parserContext := parser.NewContext()
+	parserContext.Set(extensionsKey, newDocumentExtensions(extensions))
+	return parserContext
 }
@@ -0,0 +1,23 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package preprocessor
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroup makes the given command start in a process group of its own and
+// kill the whole group when it is cancelled, so the processes the command has started
+// (e.g. the commands of a shell script) are stopped as well.
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
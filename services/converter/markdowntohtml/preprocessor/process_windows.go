@@ -0,0 +1,14 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"os/exec"
+)
+
+// killProcessGroup is not supported on Windows; only the command itself is killed
+// when it is cancelled.
+func killProcessGroup(cmd *exec.Cmd) {
+}
@@ -8,59 +8,87 @@ import (
 	"github.com/andreaskoch/allmark/common/paths"
 	"github.com/andreaskoch/allmark/model"
 	"fmt"
+	"html"
 	"regexp"
 	"strings"
 )
 
 var (
 	// [reference:*alias-of-referenced-item*]
-	referencePattern = regexp.MustCompile(`\[reference:([^\]]+)\]`)
+	referencePattern = regexp.MustCompile(`^\[reference:([^\]]+)\]`)
+
+	// [[Title of the referenced item]], [[alias-of-referenced-item|link text]]
+	wikiLinkPattern = regexp.MustCompile(`^\[\[([^\]|]+)(?:\|([^\]]+))?\]\]`)
 )
 
-func newReferenceExtension(pathProvider paths.Pather, aliasResolver func(alias string) *model.Item) *referenceExtension {
+func newReferenceExtension(pathProvider paths.Pather, aliasResolver func(alias string) *model.Item, nameResolver func(name string) *model.Item) *referenceExtension {
 	return &referenceExtension{
 		pathProvider:  pathProvider,
 		aliasResolver: aliasResolver,
+		nameResolver:  nameResolver,
 	}
 }
 
 type referenceExtension struct {
 	pathProvider  paths.Pather
 	aliasResolver func(alias string) *model.Item
+	nameResolver  func(name string) *model.Item
 }
 
-func (converter *referenceExtension) Convert(markdown string) (convertedContent string, converterError error) {
+func (converter *referenceExtension) Prefixes() []string {
+	return []string{"[reference:", "[["}
+}
 
-	convertedContent = markdown
+func (converter *referenceExtension) Convert(markdown string) (length int, conversion Conversion) {
 
-	for _, match := range referencePattern.FindAllStringSubmatch(convertedContent, -1) {
+	if match := referencePattern.FindStringSubmatch(markdown); match != nil {
+		return len(match[0]), converter.getReferenceCode(strings.TrimSpace(match[1]))
+	}
 
-		if len(match) != 2 {
-			continue
-		}
+	if match := wikiLinkPattern.FindStringSubmatch(markdown); match != nil {
+		return len(match[0]), converter.getWikiLinkCode(strings.TrimSpace(match[1]), strings.TrimSpace(match[2]))
+	}
 
-		// extract the parameters from the pattern matches
-		originalText := strings.TrimSpace(match[0])
-		alias := strings.TrimSpace(match[1])
+	return 0, Conversion{}
+}
 
-		// lookup the item
-		item := converter.aliasResolver(alias)
-		if item == nil {
-			// an item with the alias was not found
-			convertedContent = strings.Replace(convertedContent, originalText, fmt.Sprintf("<!-- Alias %q not found -->", alias), 1)
-			continue
-		}
+// getReferenceCode returns a link to the item with the given alias.
+func (converter *referenceExtension) getReferenceCode(alias string) Conversion {
 
-		// normalize the path with the current path provider
-		path := converter.pathProvider.Path(item.Route().Value())
+	// lookup the item
+	item := converter.aliasResolver(alias)
+	if item == nil {
+		// an item with the alias was not found
+		return Conversion{HTML: fmt.Sprintf("<!-- Alias %q not found -->", alias)}
+	}
 
-		// assemble the link
-		linkCode := fmt.Sprintf("[%s](%s)", item.Title, path)
+	// normalize the path with the current path provider
+	path := converter.pathProvider.Path(item.Route().Value())
 
-		// replace markdown with link list
-		convertedContent = strings.Replace(convertedContent, originalText, linkCode, 1)
+	// assemble the link
+	return Conversion{Markdown: fmt.Sprintf("[%s](%s)", item.Title, path)}
+}
+
+// getWikiLinkCode returns a link to the item with the given name or, if
+// there is no such item, the link text marked as a missing page.
+func (converter *referenceExtension) getWikiLinkCode(name, text string) Conversion {
+
+	// lookup the item
+	item := converter.nameResolver(name)
+	if item == nil {
+		if text == "" {
+			text = name
+		}
 
+		return Conversion{HTML: fmt.Sprintf(`<span class="wikilink missing" title="%s">%s</span>`, html.EscapeString(fmt.Sprintf("Page %q not found", name)), html.EscapeString(text))}
 	}
 
-	return convertedContent, nil
+	if text == "" {
+		text = item.Title
+	}
+
+	// normalize the path with the current path provider
+	path := converter.pathProvider.Path(item.Route().Value())
+
+	return Conversion{Markdown: fmt.Sprintf("[%s](%s)", text, path)}
 }
@@ -0,0 +1,37 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocessor
+
+import (
+	"regexp"
+)
+
+// TableOfContentsPlaceholder marks the position of the table of contents in the HTML code.
+// The placeholder is replaced with the table of contents by the postprocessor once all headings have an id.
+const TableOfContentsPlaceholder = "<!-- table of contents -->"
+
+var (
+	// [toc] (at the end of a line)
+	tocPattern = regexp.MustCompile(`(?i)^\[toc\][ \t\r]*(?:\n|$)`)
+)
+
+func newTableOfContentsExtension() *tableOfContentsExtension {
+	return &tableOfContentsExtension{}
+}
+
+type tableOfContentsExtension struct {
+}
+
+func (converter *tableOfContentsExtension) Prefixes() []string {
+	return []string{"[toc]"}
+}
+
+func (converter *tableOfContentsExtension) Convert(markdown string) (length int, conversion Conversion) {
+	if !tocPattern.MatchString(markdown) {
+		return 0, Conversion{}
+	}
+
+	return len("[toc]"), Conversion{HTML: TableOfContentsPlaceholder, Block: true}
+}
@@ -16,7 +16,7 @@ import (
 
 var (
 	// video: [*description text*](*a link to a youtube video or to a video file*)
-	markdownPattern = regexp.MustCompile(`video: \[([^\]]+)\]\(([^)]+)\)`)
+	markdownPattern = regexp.MustCompile(`^video: \[([^\]]+)\]\(([^)]+)\)`)
 
 	// youtube video link pattern
 	youTubeVideoPattern = regexp.MustCompile(`http[s]?://www\.youtube\.com/watch\?v=([^&]+)`)
@@ -37,30 +37,22 @@ type videoExtension struct {
 	files        []*model.File
 }
 
-func (converter *videoExtension) Convert(markdown string) (convertedContent string, converterError error) {
-
-	convertedContent = markdown
-
-	for _, match := range markdownPattern.FindAllStringSubmatch(convertedContent, -1) {
-
-		if len(match) != 3 {
-			continue
-		}
-
-		// parameters
-		originalText := strings.TrimSpace(match[0])
-		title := strings.TrimSpace(match[1])
-		path := strings.TrimSpace(match[2])
-
-		// get the code
-		renderedCode := converter.getVideoCode(title, path)
+func (converter *videoExtension) Prefixes() []string {
+	return []string{"video:"}
+}
 
-		// replace markdown
-		convertedContent = strings.Replace(convertedContent, originalText, renderedCode, 1)
+func (converter *videoExtension) Convert(markdown string) (length int, conversion Conversion) {
 
+	match := markdownPattern.FindStringSubmatch(markdown)
+	if match == nil {
+		return 0, Conversion{}
 	}
 
-	return convertedContent, nil
+	// parameters
+	title := strings.TrimSpace(match[1])
+	path := strings.TrimSpace(match[2])
+
+	return len(match[0]), Conversion{Markdown: converter.getVideoCode(title, path)}
 }
 
 func (converter *videoExtension) getMatchingFile(path string) *model.File {
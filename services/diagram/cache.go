@@ -0,0 +1,96 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diagram
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/util/fsutil"
+)
+
+// NewCache creates a diagram cache which stores the rendered diagrams in the given folder.
+func NewCache(logger logger.Logger, folder string) *Cache {
+	return &Cache{
+		logger:   logger,
+		folder:   folder,
+		diagrams: make(map[string]string),
+	}
+}
+
+// EmptyCache creates a diagram cache which only keeps the rendered diagrams in memory.
+func EmptyCache() *Cache {
+	return &Cache{
+		diagrams: make(map[string]string),
+	}
+}
+
+// Cache stores rendered diagrams by the hash of their source code.
+type Cache struct {
+	logger logger.Logger
+	folder string
+
+	lock     sync.RWMutex
+	diagrams map[string]string
+}
+
+// Get returns the rendered diagram with the given hash (if it exists).
+func (cache *Cache) Get(hash string) (string, bool) {
+	cache.lock.RLock()
+	diagram, exists := cache.diagrams[hash]
+	cache.lock.RUnlock()
+
+	if exists || cache.folder == "" {
+		return diagram, exists
+	}
+
+	path := cache.getPath(hash)
+	if !fsutil.FileExists(path) {
+		return "", false
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		cache.logger.Warn("Cannot read the cached diagram %q. Error: %s", path, err)
+		return "", false
+	}
+
+	diagram = string(content)
+
+	cache.lock.Lock()
+	cache.diagrams[hash] = diagram
+	cache.lock.Unlock()
+
+	return diagram, true
+}
+
+// Put stores the given rendered diagram under the given hash.
+func (cache *Cache) Put(hash, diagram string) error {
+	cache.lock.Lock()
+	cache.diagrams[hash] = diagram
+	cache.lock.Unlock()
+
+	if cache.folder == "" {
+		return nil
+	}
+
+	if !fsutil.CreateDirectory(cache.folder) {
+		return fmt.Errorf("Cannot create the diagram folder %q.", cache.folder)
+	}
+
+	path := cache.getPath(hash)
+	if err := ioutil.WriteFile(path, []byte(diagram), 0644); err != nil {
+		return fmt.Errorf("Cannot save the diagram %q. Error: %s", path, err)
+	}
+
+	return nil
+}
+
+func (cache *Cache) getPath(hash string) string {
+	return filepath.Join(cache.folder, hash+".svg")
+}
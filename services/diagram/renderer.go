@@ -0,0 +1,66 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package diagram renders diagrams (e.g. Graphviz dot graphs) to SVG and caches the results.
+package diagram
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/andreaskoch/allmark/common/dot"
+	"github.com/andreaskoch/allmark/common/logger"
+)
+
+// rendererVersion is part of the cache key. Increase it when the output of the renderer changes.
+const rendererVersion = "1"
+
+// New creates a new diagram renderer which stores the rendered diagrams in the given cache.
+func New(logger logger.Logger, cache *Cache) *Renderer {
+	return &Renderer{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+// Renderer renders the source code of diagrams to SVG.
+type Renderer struct {
+	logger logger.Logger
+	cache  *Cache
+}
+
+// CanRender returns true if diagrams of the given language can be rendered on the server.
+func (renderer *Renderer) CanRender(language string) bool {
+	return language == "dot"
+}
+
+// Render returns the SVG image of the given diagram code.
+func (renderer *Renderer) Render(language, code string) (string, error) {
+	if !renderer.CanRender(language) {
+		return "", fmt.Errorf("Diagrams of type %q cannot be rendered.", language)
+	}
+
+	hash := getHash(language, code)
+	if svg, exists := renderer.cache.Get(hash); exists {
+		return svg, nil
+	}
+
+	svg, err := dot.RenderSVG(code)
+	if err != nil {
+		return "", err
+	}
+
+	if err := renderer.cache.Put(hash, svg); err != nil {
+		renderer.logger.Warn("%s", err)
+	}
+
+	return svg, nil
+}
+
+// getHash returns the cache key of the given diagram.
+func getHash(language, code string) string {
+	hash := sha256.Sum256([]byte(rendererVersion + "\x00" + language + "\x00" + code))
+	return hex.EncodeToString(hash[:])
+}
@@ -15,6 +15,33 @@ import (
 
 func Parse(item *model.Item, lastModifiedDate time.Time, lines []string) (warning, err error) {
 
+	// use the title of the front matter as the fallback title
+	fallbackTitle := item.FolderName()
+	if frontMatterTitle, found := metadata.GetFrontMatterValue(lines, "title"); found {
+		fallbackTitle = frontMatterTitle
+	}
+
+	// title, description and content (the front matter is not part of the content)
+	parseContent(item, fallbackTitle, metadata.SkipFrontMatter(lines))
+
+	// use the description of the front matter if the content does not contain one
+	if item.Description == "" {
+		if frontMatterDescription, found := metadata.GetFrontMatterValue(lines, "description"); found {
+			item.Description = frontMatterDescription
+		}
+	}
+
+	// meta data
+	if err := metadata.Parse(item, lastModifiedDate, lines); err != nil {
+		return fmt.Errorf("Unable to parse the meta data of item %q. Error: %s", item, err), nil
+	}
+
+	return
+}
+
+// parseContent parses the title, description and content from the supplied lines.
+func parseContent(item *model.Item, fallbackTitle string, lines []string) {
+
 	// title
 	titleLineNumber := len(lines)
 	for lineNumber, line := range lines {
@@ -35,7 +62,7 @@ func Parse(item *model.Item, lastModifiedDate time.Time, lines []string) (warnin
 		} else {
 
 			// assign a fallback title
-			item.Title = item.FolderName()
+			item.Title = fallbackTitle
 
 			// reuse this line for the description or content
 			titleLineNumber = -1
@@ -47,7 +74,7 @@ func Parse(item *model.Item, lastModifiedDate time.Time, lines []string) (warnin
 
 	// abort if there are no more lines
 	if len(lines) < titleLineNumber+1 {
-		return nil, nil
+		return
 	}
 
 	// description
@@ -93,11 +120,4 @@ func Parse(item *model.Item, lastModifiedDate time.Time, lines []string) (warnin
 
 	contentLines := lines[contentStartIndex:contentEndIndex]
 	item.Content = strings.Join(contentLines, "\n")
-
-	// meta data
-	if err := metadata.Parse(item, lastModifiedDate, lines); err != nil {
-		return fmt.Errorf("Unable to parse the meta data of item %q. Error: %s", item, err), nil
-	}
-
-	return
 }
@@ -0,0 +1,87 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metadata
+
+import (
+	"strings"
+
+	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/services/parser/pattern"
+)
+
+// knownMetaDataKeys contains all meta data keys which are handled by allmark itself.
+var knownMetaDataKeys = map[string]bool{
+	"type":        true,
+	"title":       true,
+	"description": true,
+	"language":    true,
+	"lang":        true,
+	"author":      true,
+	"alias":       true,
+	"created at":  true,
+	"date":        true,
+	"modified at": true,
+	"modified":    true,
+	"tags":        true,
+	"street":      true,
+	"city":        true,
+	"country":     true,
+	"latitude":    true,
+	"lat":         true,
+	"longitude":   true,
+	"long":        true,
+	"maptype":     true,
+	"zoom":        true,
+}
+
+// parseCustomMetaData stores the values of all unknown meta data keys in the custom meta data.
+// Single-line definitions ("key: value") have one value, multi-line lists one value per list item.
+func parseCustomMetaData(metaData *model.MetaData, lines []string) (remainingLines []string) {
+
+	currentKey := ""
+	for _, line := range lines {
+
+		// list items of multi-line definitions
+		if isListItem, value := pattern.IsListItem(line); isListItem && currentKey != "" {
+			metaData.Custom[currentKey] = append(metaData.Custom[currentKey], value)
+			continue
+		}
+
+		currentKey = ""
+		if !pattern.IsMetaDataDefinition(line) {
+			continue
+		}
+
+		rawKey := pattern.GetMetaDataKey(line)
+		key := strings.ToLower(strings.TrimSpace(rawKey))
+		if knownMetaDataKeys[key] {
+			continue
+		}
+
+		// the first definition of a key wins
+		if _, exists := metaData.Custom[key]; exists {
+			continue
+		}
+
+		value := strings.TrimSpace(line[len(rawKey)+1:])
+		if value != "" {
+			metaData.Custom[key] = []string{value}
+			continue
+		}
+
+		// multi-line definition
+		metaData.Custom[key] = []string{}
+		currentKey = key
+	}
+
+	// remove keys without values
+	for key, values := range metaData.Custom {
+		if len(values) == 0 {
+			delete(metaData.Custom, key)
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,334 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metadata
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/andreaskoch/allmark/services/parser/pattern"
+)
+
+var (
+	// YAML front matter: starts with "---" and ends with "---" or "..."
+	yamlFrontMatterStartPattern = regexp.MustCompile(`^-{3}\s*$`)
+	yamlFrontMatterEndPattern   = regexp.MustCompile(`^(-{3}|\.{3})\s*$`)
+
+	// TOML front matter: starts and ends with "+++"
+	tomlFrontMatterPattern = regexp.MustCompile(`^\+{3}\s*$`)
+
+	// YAML "key: value" and TOML "key = value" definitions
+	yamlKeyValuePattern = regexp.MustCompile(`^(\s*)(\w[\w-]*)\s*:(?:\s+(.*))?$`)
+	tomlKeyValuePattern = regexp.MustCompile(`^(\s*)(\w[\w-]*)\s*=\s*(.*)$`)
+
+	// YAML list items (e.g. "  - value")
+	yamlListItemPattern = regexp.MustCompile(`^\s*-\s+(.*)$`)
+
+	// TOML tables (e.g. "[geo]")
+	tomlTablePattern = regexp.MustCompile(`^\s*\[+[^\]]+\]+\s*$`)
+
+	// comments
+	frontMatterCommentPattern = regexp.MustCompile(`^\s*#`)
+
+	// ISO 8601 timestamps with a "T" separator (e.g. "2015-03-01T10:00:00Z")
+	isoTimestampPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})T(\d{2}:\d{2})`)
+)
+
+// frontMatterKeyNames maps the front matter keys used by other static site
+// generators (e.g. Hugo or Jekyll) to the corresponding allmark meta data keys.
+var frontMatterKeyNames = map[string]string{
+	"aliases":          "alias",
+	"authors":          "author",
+	"lastmod":          "modified",
+	"last_modified_at": "modified",
+	"updated":          "modified",
+}
+
+// singleLineFrontMatterKeyNames contains the keys whose values are
+// joined into a single line if the front matter contains a list.
+var singleLineFrontMatterKeyNames = map[string]bool{
+	"author": true,
+}
+
+// frontMatterEntry is a single key with one or more values.
+type frontMatterEntry struct {
+	key    string
+	values []string
+}
+
+// GetFrontMatterLines returns the attributes of the YAML ("---") or TOML ("+++") front matter
+// at the beginning of the supplied lines as allmark meta data definitions (e.g. "author: John Doe").
+func GetFrontMatterLines(lines []string) []string {
+
+	entries, _, found := parseFrontMatter(lines)
+	if !found {
+		return []string{}
+	}
+
+	metaDataLines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+
+		keyName := strings.ToLower(entry.key)
+		if mappedKeyName, isMapped := frontMatterKeyNames[keyName]; isMapped {
+			keyName = mappedKeyName
+		}
+
+		values := make([]string, 0, len(entry.values))
+		for _, value := range entry.values {
+			value = normalizeFrontMatterValue(keyName, value)
+			if value == "" {
+				continue
+			}
+
+			values = append(values, value)
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		// lists are converted into multi-line definitions
+		if len(values) > 1 && !singleLineFrontMatterKeyNames[keyName] {
+			metaDataLines = append(metaDataLines, fmt.Sprintf("%s:", keyName))
+			for _, value := range values {
+				metaDataLines = append(metaDataLines, fmt.Sprintf("- %s", value))
+			}
+
+			continue
+		}
+
+		metaDataLines = append(metaDataLines, fmt.Sprintf("%s: %s", keyName, strings.Join(values, ", ")))
+	}
+
+	return metaDataLines
+}
+
+// GetFrontMatterValue returns the value of the front matter attribute with the given key name (e.g. "title").
+func GetFrontMatterValue(lines []string, keyName string) (value string, found bool) {
+	found, value, _ = getSingleLineMetaData([]string{keyName}, GetFrontMatterLines(lines))
+	return value, found
+}
+
+// SkipFrontMatter returns the supplied lines without the front matter.
+func SkipFrontMatter(lines []string) []string {
+	return lines[getFrontMatterEnd(lines):]
+}
+
+// getFrontMatterEnd returns the index of the first line after the front matter
+// or zero if the supplied lines do not start with a front matter block.
+func getFrontMatterEnd(lines []string) int {
+	_, end, _ := parseFrontMatter(lines)
+	return end
+}
+
+// parseFrontMatter parses the front matter block at the beginning of the supplied lines.
+func parseFrontMatter(lines []string) (entries []frontMatterEntry, end int, found bool) {
+
+	// skip leading empty lines
+	start := 0
+	for start < len(lines) && pattern.IsEmpty(lines[start]) {
+		start++
+	}
+
+	if start >= len(lines) {
+		return nil, 0, false
+	}
+
+	// determine the format
+	isTOML := false
+	endPattern := yamlFrontMatterEndPattern
+	switch {
+	case yamlFrontMatterStartPattern.MatchString(lines[start]):
+		isTOML = false
+
+	case tomlFrontMatterPattern.MatchString(lines[start]):
+		isTOML = true
+		endPattern = tomlFrontMatterPattern
+
+	default:
+		return nil, 0, false
+	}
+
+	// locate the end of the block
+	for lineNumber := start + 1; lineNumber < len(lines); lineNumber++ {
+		if !endPattern.MatchString(lines[lineNumber]) {
+			continue
+		}
+
+		blockLines := lines[start+1 : lineNumber]
+
+		if isTOML {
+			entries, found = parseTOMLFrontMatter(blockLines)
+		} else {
+			entries, found = parseYAMLFrontMatter(blockLines)
+		}
+
+		if !found {
+			return nil, 0, false
+		}
+
+		return entries, lineNumber + 1, true
+	}
+
+	// no closing delimiter
+	return nil, 0, false
+}
+
+// parseYAMLFrontMatter parses the subset of YAML which is commonly used for front matter:
+// "key: value" pairs, inline lists ("[a, b]"), block lists ("- a") and nested attributes,
+// which are flattened. If a line cannot be parsed the block is not treated as front matter.
+func parseYAMLFrontMatter(lines []string) (entries []frontMatterEntry, isValid bool) {
+
+	for _, line := range lines {
+
+		// skip empty lines and comments
+		if pattern.IsEmpty(line) || frontMatterCommentPattern.MatchString(line) {
+			continue
+		}
+
+		// list items belong to the previous key
+		if matches := yamlListItemPattern.FindStringSubmatch(line); len(matches) > 1 {
+			if len(entries) == 0 {
+				return nil, false
+			}
+
+			last := &entries[len(entries)-1]
+			last.values = append(last.values, matches[1])
+			continue
+		}
+
+		// key: value
+		if matches := yamlKeyValuePattern.FindStringSubmatch(line); len(matches) > 3 {
+			entries = append(entries, frontMatterEntry{
+				key:    matches[2],
+				values: splitInlineList(matches[3]),
+			})
+			continue
+		}
+
+		// indented lines continue the value of the previous key (e.g. folded text)
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if len(entries) == 0 {
+				return nil, false
+			}
+
+			last := &entries[len(entries)-1]
+			last.values = appendToLastValue(last.values, strings.TrimSpace(line))
+			continue
+		}
+
+		return nil, false
+	}
+
+	return entries, len(entries) > 0
+}
+
+// parseTOMLFrontMatter parses the subset of TOML which is commonly used for front matter:
+// "key = value" pairs, (multi-line) arrays and tables, which are flattened.
+// If a line cannot be parsed the block is not treated as front matter.
+func parseTOMLFrontMatter(lines []string) (entries []frontMatterEntry, isValid bool) {
+
+	openArray := ""
+	for _, line := range lines {
+
+		// continue multi-line arrays
+		if openArray != "" {
+			openArray += " " + strings.TrimSpace(line)
+			if strings.Contains(line, "]") {
+				last := &entries[len(entries)-1]
+				last.values = splitInlineList(openArray)
+				openArray = ""
+			}
+
+			continue
+		}
+
+		// skip empty lines, comments and table headers
+		if pattern.IsEmpty(line) || frontMatterCommentPattern.MatchString(line) || tomlTablePattern.MatchString(line) {
+			continue
+		}
+
+		matches := tomlKeyValuePattern.FindStringSubmatch(line)
+		if len(matches) < 4 {
+			return nil, false
+		}
+
+		value := strings.TrimSpace(matches[3])
+		entries = append(entries, frontMatterEntry{
+			key:    matches[2],
+			values: splitInlineList(value),
+		})
+
+		if strings.HasPrefix(value, "[") && !strings.Contains(value, "]") {
+			openArray = value
+		}
+	}
+
+	return entries, len(entries) > 0
+}
+
+// splitInlineList splits inline lists (e.g. `["a", "b"]`) into their values.
+func splitInlineList(value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return []string{}
+	}
+
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return []string{value}
+	}
+
+	var values []string
+	for _, listValue := range strings.Split(strings.Trim(value, "[]"), ",") {
+		if listValue = strings.TrimSpace(listValue); listValue != "" {
+			values = append(values, listValue)
+		}
+	}
+
+	return values
+}
+
+// appendToLastValue appends the given text to the last value of the supplied list.
+func appendToLastValue(values []string, text string) []string {
+	if len(values) == 0 {
+		return []string{text}
+	}
+
+	values[len(values)-1] = strings.TrimSpace(values[len(values)-1] + " " + text)
+	return values
+}
+
+// normalizeFrontMatterValue removes quotes from the given value and converts it
+// into the format allmark expects for the given key.
+func normalizeFrontMatterValue(keyName, value string) string {
+
+	value = strings.TrimSpace(value)
+
+	// block scalar indicators (e.g. "description: >")
+	if value == "|" || value == ">" {
+		return ""
+	}
+
+	// remove quotes
+	if len(value) > 1 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+
+	switch keyName {
+
+	// aliases of other generators are paths (e.g. "/posts/old-url/")
+	case "alias":
+		value = path.Base(strings.Trim(value, "/"))
+
+	// allmark expects a space between the date and the time
+	case "date", "created at", "modified", "modified at":
+		value = isoTimestampPattern.ReplaceAllString(value, "$1 $2")
+
+	}
+
+	return strings.TrimSpace(value)
+}
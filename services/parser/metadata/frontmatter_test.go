@@ -0,0 +1,145 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metadata
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/model"
+)
+
+func Test_GetFrontMatterLines_YAML(t *testing.T) {
+	// arrange
+	lines := []string{
+		"---",
+		`title: "Some Title"`,
+		"tags: [go, markdown]",
+		"aliases:",
+		"  - /posts/old-url/",
+		"date: 2015-03-01T10:15:00Z",
+		"---",
+		"# Some Title",
+	}
+	expected := []string{
+		"title: Some Title",
+		"tags:",
+		"- go",
+		"- markdown",
+		"alias: old-url",
+		"date: 2015-03-01 10:15:00Z",
+	}
+
+	// act
+	result := GetFrontMatterLines(lines)
+
+	// assert
+	if strings.Join(result, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("GetFrontMatterLines should return %q but returned %q.", expected, result)
+	}
+}
+
+func Test_GetFrontMatterLines_TOML(t *testing.T) {
+	// arrange
+	lines := []string{
+		"+++",
+		`author = "John Doe"`,
+		"tags = [",
+		`  "go",`,
+		`  "markdown"`,
+		"]",
+		"[geo]",
+		"city = 'Berlin'",
+		"+++",
+	}
+	expected := []string{
+		"author: John Doe",
+		"tags:",
+		"- go",
+		"- markdown",
+		"city: Berlin",
+	}
+
+	// act
+	result := GetFrontMatterLines(lines)
+
+	// assert
+	if strings.Join(result, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("GetFrontMatterLines should return %q but returned %q.", expected, result)
+	}
+}
+
+func Test_GetFrontMatterLines_HorizontalRuleAndText_NoFrontMatter(t *testing.T) {
+	// arrange
+	lines := []string{
+		"---",
+		"Some text which is not front matter.",
+		"---",
+	}
+
+	// act
+	result := GetFrontMatterLines(lines)
+
+	// assert
+	if len(result) > 0 {
+		t.Errorf("GetFrontMatterLines should not return any lines but returned %q.", result)
+	}
+}
+
+func Test_GetMetaDataPosition_FrontMatterOnly_NoMetaData(t *testing.T) {
+	// arrange
+	lines := []string{
+		"---",
+		"language: de",
+		"---",
+		"# Title",
+		"",
+		"Some text: with a colon",
+	}
+
+	// act
+	_, err := GetMetaDataPosition(lines)
+
+	// assert
+	if err == nil {
+		t.Errorf("GetMetaDataPosition should not detect the front matter as a meta data section.")
+	}
+}
+
+func Test_Parse_FrontMatter(t *testing.T) {
+	// arrange
+	item := model.NewItem(route.New(), nil, 0)
+	lines := []string{
+		"---",
+		"language: de",
+		"author: John Doe",
+		"tags:",
+		"  - go",
+		"  - markdown",
+		"---",
+		"# Title",
+		"",
+		"---",
+		"author: Jane Doe",
+	}
+
+	// act
+	Parse(item, time.Now(), lines)
+
+	// assert
+	if item.MetaData.Language != "de" {
+		t.Errorf("The language should be %q but was %q.", "de", item.MetaData.Language)
+	}
+
+	if item.MetaData.Author != "Jane Doe" {
+		t.Errorf("The meta data section should take precedence over the front matter. The author should be %q but was %q.", "Jane Doe", item.MetaData.Author)
+	}
+
+	if len(item.MetaData.Tags) != 2 {
+		t.Errorf("The parser should have found 2 tags but found %v.", len(item.MetaData.Tags))
+	}
+}
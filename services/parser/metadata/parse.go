@@ -19,8 +19,11 @@ var aliasForbiddenCharacters = regexp.MustCompile(`[^\w\d-_]`)
 // Parse parses the supplied lines and writes the result to the specified item.
 func Parse(item *model.Item, lastModifiedDate time.Time, lines []string) (parseError error) {
 
-	// find the meta data section
-	metaDataLines := GetMetaDataLines(lines)
+	// find the meta data section and the front matter.
+	// Definitions in the meta data section take precedence.
+	metaDataLines := make([]string, 0)
+	metaDataLines = append(metaDataLines, GetMetaDataLines(lines)...)
+	metaDataLines = append(metaDataLines, GetFrontMatterLines(lines)...)
 
 	// create a new meta data object
 	metaData := model.NewMetaData()
@@ -33,6 +36,7 @@ func Parse(item *model.Item, lastModifiedDate time.Time, lines []string) (parseE
 	remainingLines = parseLastModifiedDate(metaData, lastModifiedDate, remainingLines)
 	remainingLines = parseTags(metaData, remainingLines)
 	remainingLines = parseGeoInformation(metaData, remainingLines)
+	remainingLines = parseCustomMetaData(metaData, remainingLines)
 
 	// assign the meta data to the item
 	item.MetaData = *metaData
@@ -5,6 +5,7 @@
 package metadata
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/andreaskoch/allmark/model"
@@ -40,3 +41,39 @@ func Test_parseTags(t *testing.T) {
 		t.Errorf("The parser should have found 3 tags but contained only %v.", len(metaData.Tags))
 	}
 }
+
+func Test_parseCustomMetaData(t *testing.T) {
+	// arrange
+	metaData := model.NewMetaData()
+	lines := []string{
+		"author: John Doe",
+		"status: in review",
+		"reviewed-at: 2015-03-01",
+		"owner: @john",
+		"reviewers:",
+		"- Jane",
+		"- Max",
+	}
+
+	// act
+	parseCustomMetaData(metaData, lines)
+
+	// assert
+	if _, exists := metaData.Custom["author"]; exists {
+		t.Errorf("Known keys should not be stored in the custom meta data.")
+	}
+
+	expected := map[string][]string{
+		"status":      {"in review"},
+		"reviewed-at": {"2015-03-01"},
+		"owner":       {"@john"},
+		"reviewers":   {"Jane", "Max"},
+	}
+
+	for key, expectedValues := range expected {
+		values := metaData.Custom[key]
+		if strings.Join(values, "|") != strings.Join(expectedValues, "|") {
+			t.Errorf("The custom meta data %q should be %q but was %q.", key, expectedValues, values)
+		}
+	}
+}
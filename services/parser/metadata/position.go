@@ -16,8 +16,11 @@ func GetMetaDataPosition(lines []string) (int, error) {
 		return 0, fmt.Errorf("There cannot be any meta data if the supplied lines are empty.")
 	}
 
+	// the front matter is not part of the meta data section
+	frontMatterEnd := getFrontMatterEnd(lines)
+
 	hasMetaDataDefinition := false
-	for lineNumber := len(lines) - 1; lineNumber >= 0; lineNumber-- {
+	for lineNumber := len(lines) - 1; lineNumber >= frontMatterEnd; lineNumber-- {
 		line := lines[lineNumber]
 
 		// skip empty lines
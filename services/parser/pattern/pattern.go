@@ -28,7 +28,7 @@ var (
 	horizontalRulePattern = regexp.MustCompile(`^-{3,}\s*$`)
 
 	// Lines with a "key: value" syntax
-	singleLineMetaDataPattern = regexp.MustCompile(`^(\w+[\w\s]+\w+):\s*([\pL\pN\p{Latin}]+.+)$`)
+	singleLineMetaDataPattern = regexp.MustCompile(`^(\w+[\w\s-]+\w+):\s*([\pL\pN\p{Latin}]+.+)$`)
 
 	// Multi-line tags meta data
 	multiLineTagsPattern = regexp.MustCompile(`(?is)tags:\n{0,2}(\n\s?-\s?[^\n]+)+\n*`)
@@ -37,7 +37,7 @@ var (
 	multiLineAliasPattern = regexp.MustCompile(`(?is)alias:\n{0,2}(\n\s?-\s?[^\n]+)+\n*`)
 
 	// Lines with a meta data label in them syntax
-	metaDataLabelPattern = regexp.MustCompile(`^(\w+[\w\s]+\w+):`)
+	metaDataLabelPattern = regexp.MustCompile(`^(\w+[\w\s-]+\w+):`)
 
 	// Meta data list item pattern
 	metaDataListItemPattern = regexp.MustCompile(`^\s?[*-]\s?(.+)$`)
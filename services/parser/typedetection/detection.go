@@ -14,8 +14,12 @@ import (
 
 func DetectType(lines []string) model.ItemType {
 
-	// get the meta data definitions
-	lines = metadata.GetMetaDataLines(lines)
+	// get the meta data definitions from the meta data section and the front matter
+	metaDataLines := make([]string, 0)
+	metaDataLines = append(metaDataLines, metadata.GetMetaDataLines(lines)...)
+	metaDataLines = append(metaDataLines, metadata.GetFrontMatterLines(lines)...)
+
+	lines = metaDataLines
 	if len(lines) == 0 {
 		return model.TypeDocument
 	}
@@ -81,3 +81,22 @@ func Test_DetectType_UnknownTypeFallsBackToDocument(t *testing.T) {
 		t.Errorf("The result type should be %s but was %s", expectedType, result)
 	}
 }
+
+func Test_DetectType_FrontMatter_Presentation(t *testing.T) {
+	// arrange
+	inputLines := []string{
+		"---",
+		"type: presentation",
+		"---",
+		"# Title",
+	}
+	expectedType := model.TypePresentation
+
+	// act
+	result := DetectType(inputLines)
+
+	// assert
+	if result != expectedType {
+		t.Errorf("The result type should be %s but was %s", expectedType, result)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"sync"
 )
 
 var (
@@ -49,6 +50,21 @@ func NewConversionService(logger logger.Logger, repository dataaccess.Repository
 	return conversionService
 }
 
+// CreateAll creates the thumbnails for all items of the given repository
+// and only returns when the conversion has finished.
+func CreateAll(logger logger.Logger, repository dataaccess.Repository, thumbnailIndex *Index) {
+
+	conversionService := &ConversionService{
+		logger: logger,
+
+		repository:      repository,
+		index:           thumbnailIndex,
+		thumbnailFolder: thumbnailIndex.GetThumbnailFolder(),
+	}
+
+	conversionService.fullConversion()
+}
+
 type ConversionService struct {
 	logger logger.Logger
 
@@ -57,6 +73,9 @@ type ConversionService struct {
 
 	index           *Index
 	thumbnailFolder string
+
+	// conversionLock prevents that the same thumbnail is created by two goroutines at once
+	conversionLock sync.Mutex
 }
 
 // Start the conversion process.
@@ -71,12 +90,12 @@ func (conversion *ConversionService) startConversion() {
 
 			// create thumbnails for new items
 			for _, newItemRoute := range update.New() {
-				conversion.createThumbnailsForItem(conversion.repository.Item(newItemRoute))
+				conversion.CreateThumbnails(conversion.repository.Item(newItemRoute))
 			}
 
 			// create thumbnails for modified items
 			for _, modifiedItemRoute := range update.Modified() {
-				conversion.createThumbnailsForItem(conversion.repository.Item(modifiedItemRoute))
+				conversion.CreateThumbnails(conversion.repository.Item(modifiedItemRoute))
 			}
 
 		}
@@ -89,10 +108,19 @@ func (conversion *ConversionService) startConversion() {
 // Process all items in the repository.
 func (conversion *ConversionService) fullConversion() {
 	for _, item := range conversion.repository.Items() {
-		conversion.createThumbnailsForItem(item)
+		conversion.CreateThumbnails(item)
 	}
 }
 
+// CreateThumbnails creates the missing thumbnails for the image files of the supplied
+// item and only returns when they have been created.
+func (conversion *ConversionService) CreateThumbnails(item dataaccess.Item) {
+	conversion.conversionLock.Lock()
+	defer conversion.conversionLock.Unlock()
+
+	conversion.createThumbnailsForItem(item)
+}
+
 // Create thumbnail for all image files found in the supplied item.
 func (conversion *ConversionService) createThumbnailsForItem(item dataaccess.Item) {
 
@@ -195,9 +223,13 @@ func (conversion *ConversionService) isInIndex(thumb Thumb) bool {
 }
 
 func (conversion *ConversionService) addToIndex(thumb Thumb) {
-	thumbs, entryExists := conversion.index.GetThumbs(thumb.Route)
-	if !entryExists {
-		thumbs = make(Thumbs)
+
+	// the thumbs of the index are copied because they can be read at the same time
+	thumbs := make(Thumbs)
+	if existingThumbs, entryExists := conversion.index.GetThumbs(thumb.Route); entryExists {
+		for dimensions, existingThumb := range existingThumbs {
+			thumbs[dimensions] = existingThumb
+		}
 	}
 
 	thumbs[thumb.Dimensions.String()] = thumb
@@ -17,6 +17,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 var dimensionPattern = regexp.MustCompile(`-maxWidth:(\d+)-maxHeight:(\d+)$`)
@@ -85,6 +86,9 @@ func saveIndex(index *Index, indexFilePath string) error {
 
 	defer file.Close()
 
+	index.lock.RLock()
+	defer index.lock.RUnlock()
+
 	// serialize the index
 	serializer := newIndexSerializer()
 	return serializer.SerializeIndex(file, index)
@@ -156,17 +160,43 @@ func (thumbs Thumbs) GetThumbBySize(dimensions ThumbDimension) (Thumb, bool) {
 type Index struct {
 	Thumbs          map[string]Thumbs `json:"thumbs"`
 	thumbnailFolder string
+
+	lock sync.RWMutex
 }
 
+// GetThumbs returns the thumbs of the file with the given route.
+// The returned thumbs must not be modified.
 func (i *Index) GetThumbs(thumbnailRoute string) (thumbs Thumbs, exists bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
 	thumbs, exists = i.Thumbs[thumbnailRoute]
 	return thumbs, exists
 }
 
 func (i *Index) SetThumbs(thumbnailRoute string, thumbs Thumbs) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
 	i.Thumbs[thumbnailRoute] = thumbs
 }
 
+// GetThumbByPath returns the thumb with the given file name (e.g. "25-F4D6192C-320-240.png").
+func (i *Index) GetThumbByPath(path string) (thumb Thumb, exists bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	for _, thumbs := range i.Thumbs {
+		for _, thumb := range thumbs {
+			if thumb.Path == path {
+				return thumb, true
+			}
+		}
+	}
+
+	return Thumb{}, false
+}
+
 func (i *Index) GetThumbnailFolder() string {
 	return i.thumbnailFolder
 }
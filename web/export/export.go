@@ -0,0 +1,314 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package export renders a repository into a folder of static files
+// which can be hosted by any web server or browsed offline.
+package export
+
+import (
+	"fmt"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/util/fsutil"
+	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/web/handlers"
+	"github.com/andreaskoch/allmark/web/orchestrator"
+	"github.com/andreaskoch/allmark/web/view/themes"
+	"github.com/andreaskoch/allmark/web/view/themes/themefiles"
+)
+
+// New creates a new Exporter which writes the responses of the given request handler
+// for all items and pages of the given repository into the target folder.
+// The site URL (e.g. "https://example.com") is used for all absolute URLs
+// such as the ones in the sitemap.xml and the RSS feed.
+func New(logger logger.Logger, config config.Config, repository dataaccess.Repository, requestHandler http.Handler, siteURL, targetFolder string) (*Exporter, error) {
+
+	if siteURL == "" {
+		siteURL = getDefaultSiteURL(config)
+	}
+
+	parsedSiteURL, err := url.Parse(strings.TrimSuffix(siteURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("The site URL %q is invalid. Error: %s", siteURL, err.Error())
+	}
+
+	if parsedSiteURL.Scheme == "" || parsedSiteURL.Host == "" {
+		return nil, fmt.Errorf("The site URL %q must contain a protocol and a hostname (e.g. %q)", siteURL, "https://example.com")
+	}
+
+	// collect the routes of all items
+	itemRoutes := make(map[string]bool)
+	for _, item := range repository.Items() {
+		itemRoutes[item.Route().Value()] = true
+	}
+
+	return &Exporter{
+		logger:     logger,
+		config:     config,
+		repository: repository,
+
+		requestHandler: requestHandler,
+		siteURL:        parsedSiteURL,
+		targetFolder:   targetFolder,
+
+		itemRoutes: itemRoutes,
+		visited:    make(map[string]bool),
+	}, nil
+}
+
+// Exporter renders a repository into a folder of static files.
+type Exporter struct {
+	logger     logger.Logger
+	config     config.Config
+	repository dataaccess.Repository
+
+	requestHandler http.Handler
+	siteURL        *url.URL
+	targetFolder   string
+
+	itemRoutes map[string]bool
+	queue      []string
+	visited    map[string]bool
+
+	exportedFiles int
+}
+
+// Export renders all items, files and pages of the repository into the target folder.
+func (exporter *Exporter) Export() error {
+
+	if !fsutil.CreateDirectory(exporter.targetFolder) {
+		return fmt.Errorf("Unable to create the target folder %q", exporter.targetFolder)
+	}
+
+	// theme files
+	for _, themeFilePath := range exporter.getThemeFilePaths() {
+		exporter.enqueue(themeFilePath)
+	}
+
+	// global pages
+	exporter.enqueue(handlers.BasePath)
+	exporter.enqueue(handlers.TagmapHandlerRoute)
+	exporter.enqueue(handlers.SitemapHandlerRoute)
+	exporter.enqueue(handlers.XMLSitemapHandlerRoute)
+	exporter.enqueue(handlers.RSSHandlerRoute)
+	exporter.enqueue(handlers.RobotsTxtHandlerRoute)
+	exporter.enqueue(handlers.OpenSearchDescriptionHandlerRoute)
+	exporter.enqueue(handlers.TypeAheadTitlesHandlerRoute)
+	exporter.enqueue(handlers.SearchHandlerRoute)
+	exporter.enqueue(handlers.AliasIndexHandlerRoute)
+
+	// items and their files
+	for _, item := range exporter.repository.Items() {
+		exporter.enqueue("/" + item.Route().Value())
+		exporter.enqueue(getJSONPath(item))
+
+		for _, file := range item.Files() {
+			exporter.enqueue("/" + file.Route().Value())
+		}
+	}
+
+	// export all queued paths and everything they link to
+	for len(exporter.queue) > 0 {
+		requestPath := exporter.queue[0]
+		exporter.queue = exporter.queue[1:]
+
+		if err := exporter.export(requestPath); err != nil {
+			exporter.logger.Warn("%s", err)
+		}
+	}
+
+	// replace the server-side search with a client-side search
+	if err := exporter.writeSearchIndex(); err != nil {
+		return err
+	}
+
+	staticSearchPath := strings.TrimPrefix(handlers.ThemeRoutePrefix, "/") + "/search.js"
+	if err := exporter.writeFile(staticSearchPath, []byte(themefiles.StaticSearchJs)); err != nil {
+		return err
+	}
+
+	exporter.logger.Info("Exported %d files to %q", exporter.exportedFiles, exporter.targetFolder)
+	return nil
+}
+
+// enqueue adds the given request path to the export queue if it has not been visited before.
+func (exporter *Exporter) enqueue(requestPath string) {
+	if exporter.visited[requestPath] {
+		return
+	}
+
+	exporter.visited[requestPath] = true
+	exporter.queue = append(exporter.queue, requestPath)
+}
+
+// export requests the given path from the request handler and writes the response to the target folder.
+func (exporter *Exporter) export(requestPath string) error {
+
+	response := exporter.get(requestPath)
+	outputPath := getOutputPath(requestPath, exporter.isPage)
+
+	switch response.Code {
+
+	case http.StatusOK:
+		body := response.Body.Bytes()
+
+		// make all links relative and export all linked files
+		if strings.HasPrefix(response.Header().Get("Content-Type"), "text/html") {
+			rewriter := &linkRewriter{
+				siteURL: exporter.siteURL,
+				isPage:  exporter.isPage,
+			}
+
+			body = []byte(rewriter.Rewrite(requestPath, string(body)))
+
+			for _, linkedPath := range rewriter.linkedPaths {
+				exporter.enqueue(linkedPath)
+			}
+		}
+
+		return exporter.writeFile(outputPath, body)
+
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect:
+		location, err := url.Parse(response.Header().Get("Location"))
+		if err != nil {
+			return fmt.Errorf("Cannot export %q. The redirect location is invalid: %s", requestPath, err.Error())
+		}
+
+		// redirects to other sites remain absolute
+		redirectTarget := location.String()
+		if location.Host == "" || location.Host == exporter.siteURL.Host {
+			exporter.enqueue(location.EscapedPath())
+			redirectTarget = getRelativePath(outputPath, getOutputPath(location.EscapedPath(), exporter.isPage))
+		}
+
+		return exporter.writeFile(outputPath, []byte(getRedirectPage(redirectTarget)))
+
+	case http.StatusNotFound:
+		exporter.logger.Debug("Skipping %q. The path was not found.", requestPath)
+		return nil
+
+	default:
+		return fmt.Errorf("Cannot export %q. The server responded with status code %d.", requestPath, response.Code)
+	}
+}
+
+// get executes a GET request for the given path against the request handler.
+func (exporter *Exporter) get(requestPath string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest("GET", exporter.siteURL.String()+requestPath, nil)
+	response := httptest.NewRecorder()
+	exporter.requestHandler.ServeHTTP(response, request)
+	return response
+}
+
+// writeFile writes the given data to the given (url-escaped) path relative to the target folder.
+func (exporter *Exporter) writeFile(outputPath string, data []byte) error {
+
+	unescapedPath, err := url.PathUnescape(outputPath)
+	if err != nil {
+		unescapedPath = outputPath
+	}
+
+	filePath := filepath.Join(exporter.targetFolder, filepath.FromSlash(unescapedPath))
+	if !fsutil.CreateDirectory(filepath.Dir(filePath)) {
+		return fmt.Errorf("Unable to create the folder for file %q", filePath)
+	}
+
+	if err := ioutil.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("Unable to write file %q. Error: %s", filePath, err.Error())
+	}
+
+	exporter.exportedFiles++
+	return nil
+}
+
+// isPage returns a flag indicating whether the given route is rendered as a html page
+// which is stored in a folder of the same name (e.g. "documents/example/index.html").
+func (exporter *Exporter) isPage(route string) bool {
+	if exporter.itemRoutes[route] {
+		return true
+	}
+
+	return route == strings.Trim(handlers.SearchHandlerRoute, "/") || route == strings.Trim(handlers.AliasIndexHandlerRoute, "/")
+}
+
+// getThemeFilePaths returns the request paths of all theme files.
+func (exporter *Exporter) getThemeFilePaths() []string {
+
+	var themeFilePaths []string
+
+	// custom theme
+	if themeFolder := exporter.config.ThemeFolder(); fsutil.DirectoryExists(themeFolder) {
+		filepath.Walk(themeFolder, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+
+			relativePath, err := filepath.Rel(themeFolder, path)
+			if err != nil {
+				return nil
+			}
+
+			themeFilePaths = append(themeFilePaths, handlers.ThemeRoutePrefix+"/"+filepath.ToSlash(relativePath))
+			return nil
+		})
+
+		return themeFilePaths
+	}
+
+	// default theme
+	for _, themeFile := range themes.GetTheme().Files {
+		themeFilePaths = append(themeFilePaths, handlers.ThemeRoutePrefix+"/"+themeFile.Path())
+	}
+
+	return themeFilePaths
+}
+
+// getJSONPath returns the request path of the JSON representation of the given item.
+func getJSONPath(item dataaccess.Item) string {
+	jsonURL := orchestrator.GetTypedItemURL(item.Route(), "json")
+	if !strings.HasPrefix(jsonURL, "/") {
+		return "/" + jsonURL
+	}
+
+	return jsonURL
+}
+
+// getDefaultSiteURL returns the URL of the configured domain.
+func getDefaultSiteURL(configuration config.Config) string {
+	protocol := "http"
+	if configuration.Server.HTTPS.Enabled {
+		protocol = "https"
+	}
+
+	domainName := configuration.Server.DomainName
+	if domainName == "" {
+		domainName = config.DefaultDomainName
+	}
+
+	return fmt.Sprintf("%s://%s", protocol, domainName)
+}
+
+// getRedirectPage returns a html page which redirects to the given URL.
+func getRedirectPage(targetURL string) string {
+	targetURL = html.EscapeString(targetURL)
+	return fmt.Sprintf(`<!DOCTYPE HTML>
+<html>
+<head>
+	<meta http-equiv="refresh" content="0; url=%s">
+	<link rel="canonical" href="%s">
+</head>
+<body>
+	<a href="%s">%s</a>
+</body>
+</html>`, targetURL, targetURL, targetURL, targetURL)
+}
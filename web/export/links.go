@@ -0,0 +1,178 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package export
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// <base href="/documents/example/">
+	baseTagPattern = regexp.MustCompile(`(?i)<base\s+href=["']([^"']*)["']\s*/?>`)
+
+	// href="..", src='..', action=..
+	linkAttributePattern = regexp.MustCompile(`(?i)(\s(?:href|src|action|data-src|srcset|data-srcset)=)("[^"]*"|'[^']*'|[^\s>"']+)`)
+
+	// typed item routes (e.g. "documents/example.print" or "print" for the root item)
+	typedRootRoutes = map[string]string{
+		"print":    "index.print.html",
+		"history":  "index.history.html",
+		"json":     "index.json",
+		"markdown": "index.markdown",
+	}
+)
+
+// getOutputPath returns the (url-escaped) path of the file in the build folder
+// that contains the response for the given request path.
+func getOutputPath(requestPath string, isPage func(route string) bool) string {
+
+	requestPath = strings.Trim(requestPath, "/")
+
+	// root item
+	if requestPath == "" {
+		return "index.html"
+	}
+
+	// typed versions of the root item
+	if outputPath, isTypedRootRoute := typedRootRoutes[requestPath]; isTypedRootRoute {
+		return outputPath
+	}
+
+	// pages (items, aliases, search) are stored as "index.html" in a folder of the same name
+	if isPage(requestPath) || strings.HasPrefix(requestPath, "!") {
+		return requestPath + "/index.html"
+	}
+
+	// print versions and histories are html documents
+	if strings.HasSuffix(requestPath, ".print") || strings.HasSuffix(requestPath, ".history") {
+		return requestPath + ".html"
+	}
+
+	return requestPath
+}
+
+// getRelativePath returns the relative link from the given source file to the target file.
+// Both paths must be relative to the build folder (e.g. "documents/index.html", "theme/screen.css").
+func getRelativePath(sourceFile, targetFile string) string {
+
+	sourceFolderComponents := strings.Split(path.Dir(sourceFile), "/")
+	if path.Dir(sourceFile) == "." {
+		sourceFolderComponents = []string{}
+	}
+
+	targetComponents := strings.Split(targetFile, "/")
+
+	// skip the common prefix
+	commonComponents := 0
+	for commonComponents < len(sourceFolderComponents) && commonComponents < len(targetComponents)-1 {
+		if sourceFolderComponents[commonComponents] != targetComponents[commonComponents] {
+			break
+		}
+
+		commonComponents++
+	}
+
+	relativePath := strings.Repeat("../", len(sourceFolderComponents)-commonComponents)
+	return relativePath + strings.Join(targetComponents[commonComponents:], "/")
+}
+
+// linkRewriter converts the root-relative and relative links of html documents into
+// links that are relative to the location of the documents in the build folder.
+type linkRewriter struct {
+	siteURL *url.URL
+	isPage  func(route string) bool
+
+	// linkedPaths contains the request paths of all local links found while rewriting
+	linkedPaths []string
+}
+
+// Rewrite rewrites all links of the given html document which has been requested via the given request path.
+func (rewriter *linkRewriter) Rewrite(requestPath, html string) string {
+
+	documentURL := rewriter.siteURL
+	if requestURL, err := url.Parse(requestPath); err == nil {
+		documentURL = rewriter.siteURL.ResolveReference(requestURL)
+	}
+
+	sourceFile := getOutputPath(requestPath, rewriter.isPage)
+
+	// remove the base tag because all links will be relative to the document
+	baseURL := documentURL
+	if matches := baseTagPattern.FindStringSubmatch(html); len(matches) > 1 {
+		if baseHref, err := url.Parse(matches[1]); err == nil {
+			baseURL = documentURL.ResolveReference(baseHref)
+		}
+
+		html = baseTagPattern.ReplaceAllString(html, "")
+	}
+
+	return linkAttributePattern.ReplaceAllStringFunc(html, func(match string) string {
+		parts := linkAttributePattern.FindStringSubmatch(match)
+		attribute, value := parts[1], parts[2]
+
+		// remove the quotes
+		quote := ""
+		if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, `'`) {
+			quote = value[:1]
+			value = value[1 : len(value)-1]
+		}
+
+		// srcset attributes contain a comma-separated list of urls with size descriptors
+		if strings.HasSuffix(strings.ToLower(attribute), "srcset=") {
+			candidates := strings.Split(value, ",")
+			for index, candidate := range candidates {
+				fields := strings.Fields(candidate)
+				if len(fields) == 0 {
+					continue
+				}
+
+				fields[0] = rewriter.rewriteLink(baseURL, sourceFile, fields[0])
+				candidates[index] = strings.Join(fields, " ")
+			}
+
+			return attribute + quote + strings.Join(candidates, ", ") + quote
+		}
+
+		return attribute + quote + rewriter.rewriteLink(baseURL, sourceFile, value) + quote
+	})
+}
+
+// rewriteLink returns the relative version of the given link if it points to the current site;
+// otherwise the link is returned unchanged.
+func (rewriter *linkRewriter) rewriteLink(baseURL *url.URL, sourceFile, link string) string {
+
+	// in-page anchors
+	if link == "" || strings.HasPrefix(link, "#") {
+		return link
+	}
+
+	linkURL, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	// absolute links (e.g. canonical urls, external sites, mailto:) stay as they are
+	if linkURL.Scheme != "" || linkURL.Host != "" {
+		return link
+	}
+
+	targetURL := baseURL.ResolveReference(linkURL)
+	requestPath := targetURL.EscapedPath()
+	rewriter.linkedPaths = append(rewriter.linkedPaths, requestPath)
+
+	relativeLink := getRelativePath(sourceFile, getOutputPath(requestPath, rewriter.isPage))
+	if targetURL.RawQuery != "" {
+		relativeLink += "?" + targetURL.RawQuery
+	}
+
+	if targetURL.Fragment != "" {
+		relativeLink += "#" + targetURL.EscapedFragment()
+	}
+
+	return relativeLink
+}
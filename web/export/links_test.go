@@ -0,0 +1,102 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package export
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func isTestPage(route string) bool {
+	return route == "documents" || route == "documents/example" || route == "search"
+}
+
+func Test_getOutputPath(t *testing.T) {
+	// arrange
+	inputs := map[string]string{
+		"/":                          "index.html",
+		"/json":                      "index.json",
+		"/documents":                 "documents/index.html",
+		"/documents/example/":        "documents/example/index.html",
+		"/documents/example.print":   "documents/example.print.html",
+		"/documents/example.history": "documents/example.history.html",
+		"/history":                   "index.history.html",
+		"/documents/example.json":    "documents/example.json",
+		"/documents/example/a.png":   "documents/example/a.png",
+		"/!docs":                     "!docs/index.html",
+		"/theme/screen.css":          "theme/screen.css",
+		"/thumbnails/a-320-240.png":  "thumbnails/a-320-240.png",
+	}
+
+	for input, expected := range inputs {
+
+		// act
+		result := getOutputPath(input, isTestPage)
+
+		// assert
+		if result != expected {
+			t.Errorf("getOutputPath(%q) should return %q but returned %q.", input, expected, result)
+		}
+	}
+}
+
+func Test_getRelativePath(t *testing.T) {
+	// arrange
+	inputs := [][]string{
+		{"index.html", "theme/screen.css", "theme/screen.css"},
+		{"documents/index.html", "theme/screen.css", "../theme/screen.css"},
+		{"documents/example/index.html", "documents/index.html", "../index.html"},
+		{"documents/index.html", "documents/example/index.html", "example/index.html"},
+		{"documents/index.html", "documents/index.html", "index.html"},
+	}
+
+	for _, input := range inputs {
+		source, target, expected := input[0], input[1], input[2]
+
+		// act
+		result := getRelativePath(source, target)
+
+		// assert
+		if result != expected {
+			t.Errorf("getRelativePath(%q, %q) should return %q but returned %q.", source, target, expected, result)
+		}
+	}
+}
+
+func Test_linkRewriter_Rewrite_LinksAreRelativeToTheDocument(t *testing.T) {
+	// arrange
+	siteURL, _ := url.Parse("https://example.com")
+	rewriter := &linkRewriter{siteURL: siteURL, isPage: isTestPage}
+	html := `<base href="/documents/example/">
+<link rel="canonical" href="https://example.com/documents/example">
+<a href="/documents">Documents</a>
+<img src="files/a.png" data-srcset="/thumbnails/a-320.png 320w, /thumbnails/a-640.png 640w">
+<a href=/tags.html#go>go</a>
+<form action="/search?q=go"></form>`
+
+	// act
+	result := rewriter.Rewrite("/documents/example", html)
+
+	// assert
+	expectedFragments := []string{
+		`<link rel="canonical" href="https://example.com/documents/example">`,
+		`<a href="../index.html">Documents</a>`,
+		`src="files/a.png"`,
+		`data-srcset="../../thumbnails/a-320.png 320w, ../../thumbnails/a-640.png 640w"`,
+		`<a href=../../tags.html#go>go</a>`,
+		`action="../../search/index.html?q=go"`,
+	}
+
+	for _, expected := range expectedFragments {
+		if !strings.Contains(result, expected) {
+			t.Errorf("The rewritten html should contain %q but was %q.", expected, result)
+		}
+	}
+
+	if strings.Contains(result, "<base") {
+		t.Errorf("The rewritten html should not contain a base tag but was %q.", result)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/andreaskoch/allmark/web/view/viewmodel"
+)
+
+// searchIndexFileName contains the name of the script which contains the client-side search index.
+const searchIndexFileName = "search-index.js"
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// searchIndexEntry is an entry in the client-side search index.
+type searchIndexEntry struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Route       string   `json:"route"`
+	Path        string   `json:"path"`
+	Tags        []string `json:"tags"`
+	Text        string   `json:"text"`
+}
+
+// writeSearchIndex writes the client-side search index for all items to the target folder.
+func (exporter *Exporter) writeSearchIndex() error {
+
+	var routes []string
+	for route := range exporter.itemRoutes {
+		routes = append(routes, route)
+	}
+
+	sort.Strings(routes)
+
+	entries := make([]searchIndexEntry, 0, len(routes))
+	for _, route := range routes {
+
+		jsonPath := "/" + route + ".json"
+		if route == "" {
+			jsonPath = "/json"
+		}
+
+		response := exporter.get(jsonPath)
+		if response.Code != http.StatusOK {
+			continue
+		}
+
+		var model viewmodel.Model
+		if err := json.Unmarshal(response.Body.Bytes(), &model); err != nil {
+			exporter.logger.Warn("Unable to add %q to the search index. Error: %s", route, err.Error())
+			continue
+		}
+
+		tags := make([]string, 0, len(model.Tags))
+		for _, tag := range model.Tags {
+			tags = append(tags, tag.Name)
+		}
+
+		entries = append(entries, searchIndexEntry{
+			Title:       model.Title,
+			Description: model.Description,
+			Route:       getOutputPath("/"+route, exporter.isPage),
+			Path:        "/" + route,
+			Tags:        tags,
+			Text:        getPlainText(model.Content),
+		})
+	}
+
+	serializedEntries, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("Unable to serialize the search index. Error: %s", err.Error())
+	}
+
+	script := fmt.Sprintf("var allmarkSearchIndex = %s;\n", serializedEntries)
+	return exporter.writeFile(searchIndexFileName, []byte(script))
+}
+
+// getPlainText removes all html tags from the given html code.
+func getPlainText(htmlCode string) string {
+	text := htmlTagPattern.ReplaceAllString(htmlCode, " ")
+	text = html.UnescapeString(text)
+	text = whitespacePattern.ReplaceAllString(text, " ")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,104 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/abbot/go-http-auth"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/services/access"
+)
+
+// AudienceHandlerProvider provides the request handlers for the different audiences.
+type AudienceHandlerProvider interface {
+
+	// AudienceHandler returns the request handler which only serves the items the given audience can see.
+	AudienceHandler(audience access.Audience) http.Handler
+
+	// IsHiddenFrom checks whether the given request path points to an item which exists but is hidden from the given audience.
+	IsHiddenFrom(audience access.Audience, requestPath string) bool
+}
+
+// Authenticator identifies the users who send requests.
+type Authenticator interface {
+
+	// Authenticate returns the ID and the roles of the user who sent the given request or an empty ID
+	// for anonymous users. The ID is the name of the user in the roles of the access settings.
+	// An error is returned if the request contains invalid credentials.
+	Authenticate(r *http.Request) (userID string, roles []string, err error)
+
+	// RequireLogin asks the user to log in.
+	RequireLogin(w http.ResponseWriter, r *http.Request)
+}
+
+// NewBasicAuthenticator returns an authenticator which asks for the credentials of the given user store.
+func NewBasicAuthenticator(userStore auth.SecretProvider) Authenticator {
+	return basicAuthenticator{auth.NewBasicAuthenticator("", userStore)}
+}
+
+// basicAuthenticator identifies the users by the credentials of basic-authentication.
+type basicAuthenticator struct {
+	authenticator *auth.BasicAuth
+}
+
+func (authenticator basicAuthenticator) Authenticate(r *http.Request) (userID string, roles []string, err error) {
+	username := authenticator.authenticator.CheckAuth(r)
+	if _, _, hasCredentials := r.BasicAuth(); hasCredentials && username == "" {
+		return "", nil, fmt.Errorf("Invalid credentials.")
+	}
+
+	return username, nil, nil
+}
+
+func (authenticator basicAuthenticator) RequireLogin(w http.ResponseWriter, r *http.Request) {
+	authenticator.authenticator.RequireAuth(w, r)
+}
+
+// RequireAccess serves every request with the request handler of the user's audience. Users log in with
+// the given authenticator (nil disables logging in). Anonymous users are asked to log in when they request
+// the login route or an item which is hidden from them and, if login is required, on every request.
+func RequireAccess(logger logger.Logger, policy *access.Policy, authenticator Authenticator, loginIsRequired bool, audienceHandlers AudienceHandlerProvider) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		// anonymous users only see the public items if logging in is not available
+		if authenticator == nil {
+			audienceHandlers.AudienceHandler(policy.Anonymous()).ServeHTTP(w, r)
+			return
+		}
+
+		// ask for the credentials again if the supplied ones are invalid
+		userID, roles, err := authenticator.Authenticate(r)
+		if err != nil {
+			logger.Debug("Cannot authenticate the request for %q. %s", r.URL.Path, err)
+			authenticator.RequireLogin(w, r)
+			return
+		}
+
+		if userID == "" {
+
+			audience := policy.Anonymous()
+			if loginIsRequired || r.URL.Path == LoginHandlerRoute || audienceHandlers.IsHiddenFrom(audience, r.URL.Path) {
+				authenticator.RequireLogin(w, r)
+				return
+			}
+
+			audienceHandlers.AudienceHandler(audience).ServeHTTP(w, r)
+			return
+		}
+
+		// logged in
+		if r.URL.Path == LoginHandlerRoute {
+			http.Redirect(w, r, BasePath, http.StatusFound)
+			return
+		}
+
+		audience := policy.Authenticated(append(policy.Roles(userID), roles...))
+		audienceHandlers.AudienceHandler(audience).ServeHTTP(w, r)
+	})
+
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/services/access"
+	"github.com/andreaskoch/allmark/web/oidc"
+)
+
+// testOIDCClient is an OpenID Connect client which has logged in the given user.
+type testOIDCClient struct {
+	user oidc.User
+}
+
+func (client testOIDCClient) User(r *http.Request) (oidc.User, bool) {
+	return client.user, true
+}
+
+func (client testOIDCClient) LoginURL(w http.ResponseWriter, r *http.Request, redirectURL, returnPath string) (string, error) {
+	return "/authorize", nil
+}
+
+// testAudienceHandlers records the audience of the served requests.
+type testAudienceHandlers struct {
+	audience *access.Audience
+}
+
+func (handlers testAudienceHandlers) AudienceHandler(audience access.Audience) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*handlers.audience = audience
+	})
+}
+
+func (handlers testAudienceHandlers) IsHiddenFrom(audience access.Audience, requestPath string) bool {
+	return false
+}
+
+func Test_RequireAccess_OIDCUsernameMatchesConfiguredUsername_RolesOfTheSubjectAreUsed(t *testing.T) {
+	// arrange
+	policy, err := access.New(config.Access{
+		Roles: map[string][]string{
+			"alice": {"admin"},
+			"1234":  {"eng"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("access.New returned an error: %s", err)
+	}
+
+	// the user has chosen the name of the configured user "alice" at the provider
+	authenticator := oidcAuthenticator{
+		logger: console.New(loglevel.Fatal),
+		client: testOIDCClient{oidc.User{Name: "alice", Subject: "1234"}},
+	}
+
+	var audience access.Audience
+	handler := RequireAccess(console.New(loglevel.Fatal), policy, authenticator, false, testAudienceHandlers{&audience})
+
+	// act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/internal", nil))
+
+	// assert
+	if expected := policy.Authenticated([]string{"eng"}); audience.Name() != expected.Name() {
+		t.Errorf("The request should be served with the roles of the subject (%s) but was served to %q.", expected.Name(), audience.Name())
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/web/header"
+	"github.com/andreaskoch/allmark/web/orchestrator"
+	"github.com/andreaskoch/allmark/web/view/templates"
+	"github.com/andreaskoch/allmark/web/view/viewmodel"
+)
+
+// the maximum size of the markdown that can be submitted
+const maxEditRequestSizeInBytes = 10 << 20
+
+// Edit returns a http handler which displays the markdown of the requested item in an editor (GET),
+// renders a preview of the submitted markdown (POST with action "preview") and saves the submitted
+// markdown (POST).
+func Edit(logger logger.Logger,
+	headerWriter header.HeaderWriter,
+	viewModelOrchestrator *orchestrator.ViewModelOrchestrator,
+	editOrchestrator *orchestrator.EditOrchestrator,
+	templateProvider templates.Provider,
+	error404Handler http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		// strip the "edit" or ".edit" suffix from the path
+		path := r.URL.Path
+		path = strings.TrimSuffix(path, "edit")
+		path = strings.TrimSuffix(path, ".")
+
+		// get the request route
+		requestRoute := route.NewFromRequest(path)
+
+		// make sure the request body is closed
+		defer r.Body.Close()
+
+		viewModel, found := viewModelOrchestrator.GetFullViewModel(requestRoute)
+		if !found {
+			error404Handler.ServeHTTP(w, r)
+			return
+		}
+
+		hostname := getBaseURLFromRequest(r)
+		statusCode := http.StatusOK
+
+		var editor viewmodel.Editor
+		switch r.Method {
+
+		case http.MethodGet:
+			editor, found = editOrchestrator.GetEditor(hostname, requestRoute)
+			if !found {
+				error404Handler.ServeHTTP(w, r)
+				return
+			}
+
+		case http.MethodPost:
+
+			// the browser sends the credentials along with any form that targets this site
+			if !isSameOriginRequest(r) {
+				http.Error(w, "Cross-origin requests are not allowed.", http.StatusForbidden)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, maxEditRequestSizeInBytes)
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, fmt.Sprintf("Cannot read the form. Error: %s", err.Error()), http.StatusBadRequest)
+				return
+			}
+
+			markdown := r.PostForm.Get("markdown")
+
+			// live preview
+			if r.PostForm.Get("action") == "preview" {
+				preview, found := editOrchestrator.GetPreview(hostname, requestRoute, markdown)
+				if !found {
+					error404Handler.ServeHTTP(w, r)
+					return
+				}
+
+				headerWriter.Write(w, header.CONTENTTYPE_HTML)
+				fmt.Fprintf(w, "%s", preview)
+				return
+			}
+
+			err := editOrchestrator.Save(requestRoute, markdown, r.PostForm.Get("hash"))
+			if err == nil {
+				http.Redirect(w, r, viewModel.BaseURL, http.StatusSeeOther)
+				return
+			}
+
+			if err != dataaccess.ErrConflict {
+				logger.Error("Cannot save item %q. Error: %s", requestRoute, err.Error())
+				http.Error(w, "The item could not be saved.", http.StatusInternalServerError)
+				return
+			}
+
+			// keep the draft but base it on the current version of the item
+			// so the changes can be reviewed and saved again
+			editor, found = editOrchestrator.GetEditor(hostname, requestRoute)
+			if !found {
+				error404Handler.ServeHTTP(w, r)
+				return
+			}
+
+			editor.Markdown = markdown
+			editor.Preview, _ = editOrchestrator.GetPreview(hostname, requestRoute, markdown)
+			editor.Conflict = true
+			statusCode = http.StatusConflict
+
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "Method not allowed.", http.StatusMethodNotAllowed)
+			return
+		}
+
+		editTemplate, err := templateProvider.GetEditTemplate(hostname)
+		if err != nil {
+			fmt.Fprintf(w, "Template not found. Error: %s", err)
+			return
+		}
+
+		// the edit page is based on the item
+		headline := fmt.Sprintf("Edit: %s", viewModel.Title)
+		viewModel.Type = "editor"
+		viewModel.PageTitle = editOrchestrator.GetPageTitle(headline)
+		viewModel.Title = headline
+		viewModel.Description = fmt.Sprintf("Change the markdown of %q.", viewModel.Route)
+		viewModel.LiveReloadEnabled = false
+
+		// keep the markdown of the editor
+		viewModel.Markdown = editor.Markdown
+		editor.Model = viewModel
+
+		// set headers
+		headerWriter.Write(w, header.CONTENTTYPE_HTML)
+		w.WriteHeader(statusCode)
+
+		renderTemplate(editTemplate, editor, w)
+	})
+
+}
+
+// isSameOriginRequest checks whether the request has been sent from a page of this site.
+func isSameOriginRequest(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		origin = r.Header.Get("Referer")
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil || origin == "" {
+		return false
+	}
+
+	return originURL.Host == r.Host
+}
@@ -50,6 +50,15 @@ var (
 	// LatestHandlerRoute defines the route for latest-handler requests.
 	LatestHandlerRoute = `/{path:.+\.latest$|latest$}`
 
+	// HistoryHandlerRoute defines the route for history-handler requests.
+	HistoryHandlerRoute = `/{path:.+\.history$|history$}`
+
+	// EditHandlerRoute defines the route for edit-handler requests.
+	EditHandlerRoute = `/{path:.+\.edit$|edit$}`
+
+	// UploadHandlerRoute defines the route for upload-handler requests.
+	UploadHandlerRoute = `/{path:.+\.upload$|upload$}`
+
 	// DOCXHandlerRoute defines the route for rich-text-handler requests.
 	DOCXHandlerRoute = `/{path:.+\.docx$|docx$}`
 
@@ -83,6 +92,9 @@ var (
 	// TypeAheadTitlesHandlerRoute defines the route for typeahead-titles-handler requests.
 	TypeAheadTitlesHandlerRoute = "/titles.json"
 
+	// LinksHandlerRoute defines the route for link-graph-handler requests.
+	LinksHandlerRoute = "/links.json"
+
 	// RedirectHandlerRoute defines the route for redirect-handler requests.
 	RedirectHandlerRoute = "/{path:.*$}"
 
@@ -91,6 +103,15 @@ var (
 
 	// AliasIndexHandlerRoute defines the route for alias-lookup-handler requests.
 	AliasIndexHandlerRoute = "/!"
+
+	// LoginHandlerRoute defines the route which asks anonymous users for their credentials.
+	LoginHandlerRoute = "/login"
+
+	// LoginCallbackHandlerRoute defines the route the OpenID Connect provider redirects to after logging in.
+	LoginCallbackHandlerRoute = "/login/callback"
+
+	// LogoutHandlerRoute defines the route which logs out the users who have logged in with an OpenID Connect provider.
+	LogoutHandlerRoute = "/logout"
 )
 
 // RouteAndHandler combines routes and http-handlers.
@@ -110,7 +131,18 @@ func (list *HandlerList) Add(route string, handler http.Handler) {
 // GetRedirectHandlers returns a list of redirect handlers.
 func GetRedirectHandlers(logger logger.Logger, baseURITarget string, baseHandler http.Handler) HandlerList {
 	handlers := make(HandlerList, 0)
-	handlers.Add(RedirectHandlerRoute, Redirect(logger, baseURITarget))
+
+	// requests which reached a trusted proxy over HTTPS are not redirected
+	redirectHandler := Redirect(logger, baseURITarget)
+	handlers.Add(RedirectHandlerRoute, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isSecureRequest(r) {
+			baseHandler.ServeHTTP(w, r)
+			return
+		}
+
+		redirectHandler.ServeHTTP(w, r)
+	}))
+
 	return handlers
 }
 
@@ -172,17 +204,12 @@ func GetBaseHandlers(logger logger.Logger, config config.Config, templateProvide
 			templateProvider))
 
 	// thumbnails
-	if thumbnailsFolder := config.ThumbnailFolder(); fsutil.DirectoryExists(thumbnailsFolder) {
-		requestPrefixToStripFromRequestURI := "/" + config.Conversion.Thumbnails.FolderName
-
-		handlers.Add(
-			ThumbnailHandlerRoute,
-			AddETAgToStaticFileHandler(Static(thumbnailsFolder,
-				ThumbnailRoutePrefix),
-				headerWriterFactory.Static(),
-				thumbnailsFolder,
-				requestPrefixToStripFromRequestURI))
-	}
+	handlers.Add(
+		ThumbnailHandlerRoute,
+		Thumbnail(
+			headerWriterFactory.Static(),
+			fileOrchestrator,
+			errorHandler))
 
 	// robots.txt
 	handlers.Add(RobotsTxtHandlerRoute, RobotsTxt(headerWriterFactory.Static(), templateProvider))
@@ -233,6 +260,12 @@ func GetBaseHandlers(logger logger.Logger, config config.Config, templateProvide
 		Titles(headerWriterFactory.Dynamic(),
 			orchestratorFactory.NewTitlesOrchestrator()))
 
+	// links.json
+	handlers.Add(
+		LinksHandlerRoute,
+		Links(headerWriterFactory.Dynamic(),
+			orchestratorFactory.NewLinksOrchestrator()))
+
 	// search.json
 	handlers.Add(
 		TypeAheadSearchHandlerRoute,
@@ -262,6 +295,41 @@ func GetBaseHandlers(logger logger.Logger, config config.Config, templateProvide
 			viewModelOrchestrator,
 			itemHandler))
 
+	// history
+	handlers.Add(HistoryHandlerRoute,
+		History(headerWriterFactory.Dynamic(),
+			viewModelOrchestrator,
+			orchestratorFactory.NewHistoryOrchestrator(),
+			templateProvider,
+			errorHandler))
+
+	// edit and upload
+	if config.EditingIsEnabled() {
+		editOrchestrator := orchestratorFactory.NewEditOrchestrator()
+
+		handlers.Add(EditHandlerRoute,
+			RequireDigestAuthentication(logger,
+				Edit(logger,
+					headerWriterFactory.NoCache(),
+					viewModelOrchestrator,
+					editOrchestrator,
+					templateProvider,
+					errorHandler),
+				config.GetEditorUserStore()))
+
+		handlers.Add(UploadHandlerRoute,
+			RequireDigestAuthentication(logger,
+				Upload(logger,
+					headerWriterFactory.NoCache(),
+					viewModelOrchestrator,
+					editOrchestrator,
+					errorHandler),
+				config.GetEditorUserStore()))
+
+	} else if config.Server.Editing.Enabled {
+		logger.Warn("Editing is only available over HTTPS (disable HTTP or force HTTPS) and requires the user store %q.", config.AuthenticationFilePath())
+	}
+
 	// conversion
 	conversionModelOrchestrator := orchestratorFactory.NewConversionModelOrchestrator()
 
@@ -0,0 +1,78 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/web/header"
+	"github.com/andreaskoch/allmark/web/orchestrator"
+	"github.com/andreaskoch/allmark/web/view/templates"
+)
+
+// History returns a http handler which displays the revision history of the requested item
+// and the changes between two revisions (URL parameters "from" and "to").
+func History(headerWriter header.HeaderWriter,
+	viewModelOrchestrator *orchestrator.ViewModelOrchestrator,
+	historyOrchestrator *orchestrator.HistoryOrchestrator,
+	templateProvider templates.Provider,
+	error404Handler http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		// strip the "history" or ".history" suffix from the path
+		path := r.URL.Path
+		path = strings.TrimSuffix(path, "history")
+		path = strings.TrimSuffix(path, ".")
+
+		// get the request route
+		requestRoute := route.NewFromRequest(path)
+
+		// make sure the request body is closed
+		defer r.Body.Close()
+
+		viewModel, found := viewModelOrchestrator.GetFullViewModel(requestRoute)
+		if !found {
+			error404Handler.ServeHTTP(w, r)
+			return
+		}
+
+		fromRevision := r.URL.Query().Get("from")
+		toRevision := r.URL.Query().Get("to")
+		historyModel, found := historyOrchestrator.GetHistory(requestRoute, fromRevision, toRevision)
+		if !found {
+			error404Handler.ServeHTTP(w, r)
+			return
+		}
+
+		// set headers
+		headerWriter.Write(w, header.CONTENTTYPE_HTML)
+
+		hostname := getBaseURLFromRequest(r)
+		historyTemplate, err := templateProvider.GetHistoryTemplate(hostname)
+		if err != nil {
+			fmt.Fprintf(w, "Template not found. Error: %s", err)
+			return
+		}
+
+		// the history page is based on the item
+		headline := fmt.Sprintf("History: %s", viewModel.Title)
+		viewModel.Type = "history"
+		viewModel.PageTitle = historyOrchestrator.GetPageTitle(headline)
+		viewModel.Title = headline
+		viewModel.Description = fmt.Sprintf("The revisions of %q.", viewModel.Route)
+		if historyModel.Diff != nil {
+			viewModel.Description = fmt.Sprintf("The changes of %q between two revisions.", viewModel.Route)
+		}
+
+		historyModel.Model = viewModel
+
+		renderTemplate(historyTemplate, historyModel, w)
+	})
+
+}
@@ -0,0 +1,40 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/andreaskoch/allmark/web/header"
+	"github.com/andreaskoch/allmark/web/orchestrator"
+	"github.com/andreaskoch/allmark/web/view/viewmodel"
+)
+
+// Links returns a handler which writes the graph of all items and the links between them as JSON.
+func Links(headerWriter header.HeaderWriter, linksOrchestrator *orchestrator.LinksOrchestrator) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		// set headers
+		headerWriter.Write(w, header.CONTENTTYPE_JSON)
+
+		// get the graph
+		graph := linksOrchestrator.GetLinkGraph()
+		writeLinkGraph(w, graph)
+	})
+
+}
+
+func writeLinkGraph(writer io.Writer, graph viewmodel.LinkGraph) error {
+	bytes, err := json.MarshalIndent(graph, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	writer.Write(bytes)
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/web/oidc"
+)
+
+// NewOIDCAuthenticator returns an authenticator which identifies the users by their session
+// cookie and sends anonymous users to the login page of the OpenID Connect provider.
+// The provider redirects back to the given redirect URL or, if it is empty, to the callback route.
+func NewOIDCAuthenticator(logger logger.Logger, client *oidc.Client, redirectURL string) Authenticator {
+	return oidcAuthenticator{logger, client, redirectURL}
+}
+
+// oidcClient logs users in with an OpenID Connect provider.
+type oidcClient interface {
+	User(r *http.Request) (oidc.User, bool)
+	LoginURL(w http.ResponseWriter, r *http.Request, redirectURL, returnPath string) (string, error)
+}
+
+// oidcAuthenticator identifies the users who have logged in with an OpenID Connect provider.
+type oidcAuthenticator struct {
+	logger      logger.Logger
+	client      oidcClient
+	redirectURL string
+}
+
+// Authenticate returns the subject of the user as the ID because the
+// name is chosen by the user and might match the name of another user.
+func (authenticator oidcAuthenticator) Authenticate(r *http.Request) (userID string, roles []string, err error) {
+	user, isLoggedIn := authenticator.client.User(r)
+	if !isLoggedIn {
+		return "", nil, nil
+	}
+
+	return user.Subject, user.Roles, nil
+}
+
+func (authenticator oidcAuthenticator) RequireLogin(w http.ResponseWriter, r *http.Request) {
+
+	// only pages can be redirected to the login page
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	returnPath := r.URL.RequestURI()
+	if r.URL.Path == LoginHandlerRoute {
+		returnPath = BasePath
+	}
+
+	loginURL, err := authenticator.client.LoginURL(w, r, getOIDCRedirectURL(r, authenticator.redirectURL), returnPath)
+	if err != nil {
+		authenticator.logger.Error("%s", err)
+		http.Error(w, "The login is currently not available.", http.StatusServiceUnavailable)
+		return
+	}
+
+	http.Redirect(w, r, loginURL, http.StatusFound)
+}
+
+// OIDCCallback completes the login with the OpenID Connect provider and
+// redirects the user to the page which was requested before logging in.
+func OIDCCallback(logger logger.Logger, client *oidc.Client, redirectURL string) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		user, returnPath, err := client.Callback(w, r, getOIDCRedirectURL(r, redirectURL))
+		if err != nil {
+			logger.Warn("Login failed. %s", err)
+			http.Error(w, "The login failed. Please try again.", http.StatusUnauthorized)
+			return
+		}
+
+		logger.Info("User %q logged in (roles: %v).", user.Name, user.Roles)
+		http.Redirect(w, r, returnPath, http.StatusFound)
+	})
+
+}
+
+// OIDCLogout logs out the user and, if the OpenID Connect provider
+// supports it, sends the user to the logout page of the provider.
+func OIDCLogout(logger logger.Logger, client *oidc.Client) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logoutURL := client.Logout(w, r, getBaseURLFromRequest(r)+BasePath)
+		http.Redirect(w, r, logoutURL, http.StatusFound)
+	})
+
+}
+
+// getOIDCRedirectURL returns the given redirect URL or, if it is empty,
+// the URL of the callback route on the host of the request.
+func getOIDCRedirectURL(r *http.Request, redirectURL string) string {
+	if redirectURL != "" {
+		return redirectURL
+	}
+
+	return getBaseURLFromRequest(r) + LoginCallbackHandlerRoute
+}
@@ -0,0 +1,168 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/logger"
+)
+
+// forwardedRequestKey is the context key for the protocol of requests which have been forwarded by a trusted proxy.
+type forwardedRequestKey struct{}
+
+// forwardedRequest contains the protocol a forwarded request used to reach the proxy.
+type forwardedRequest struct {
+	isSecure bool
+}
+
+// TrustProxies applies the X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-For headers of the requests
+// which come from one of the given trusted networks to the requests (protocol, host and remote address).
+// Only the values which have been added by the trusted proxies are used. The headers of all other requests are removed.
+func TrustProxies(logger logger.Logger, trustedNetworks []*net.IPNet, baseHandler http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if !isTrusted(trustedNetworks, getIP(r.RemoteAddr)) {
+			r.Header.Del("X-Forwarded-Proto")
+			r.Header.Del("X-Forwarded-Host")
+			r.Header.Del("X-Forwarded-For")
+
+			baseHandler.ServeHTTP(w, r)
+			return
+		}
+
+		// client address: the last address which has not been added by a trusted proxy
+		remoteAddress := r.RemoteAddr
+		hops := 1
+		addresses := getHeaderValues(r, "X-Forwarded-For")
+		for index := len(addresses) - 1; index >= 0; index-- {
+			ip := net.ParseIP(addresses[index])
+			if ip == nil {
+				break
+			}
+
+			remoteAddress = ip.String()
+			if !isTrusted(trustedNetworks, ip) {
+				break
+			}
+
+			hops++
+		}
+
+		// protocol
+		isSecure := r.TLS != nil
+		if protocol := getForwardedValue(r, "X-Forwarded-Proto", hops); protocol != "" {
+			isSecure = strings.EqualFold(protocol, "https")
+		}
+
+		forwarded := r.WithContext(context.WithValue(r.Context(), forwardedRequestKey{}, forwardedRequest{isSecure}))
+		forwarded.RemoteAddr = remoteAddress
+
+		// host
+		if host := getForwardedValue(r, "X-Forwarded-Host", hops); host != "" {
+			forwarded.Host = host
+		}
+
+		logger.Debug("Request %q from %s forwarded by %s (Host: %q, Secure: %t).", r.URL.Path, forwarded.RemoteAddr, r.RemoteAddr, forwarded.Host, isSecure)
+		baseHandler.ServeHTTP(w, forwarded)
+	})
+
+}
+
+// RequireHTTPS redirects the requests which have been forwarded by a trusted proxy but did not reach it over HTTPS
+// to HTTPS on the same host. Other insecure requests are rejected.
+func RequireHTTPS(logger logger.Logger, baseHandler http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if isSecureRequest(r) {
+			baseHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if !isForwardedRequest(r) || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			logger.Debug("Rejecting the insecure request for %q from %s.", r.URL.Path, r.RemoteAddr)
+			http.Error(w, "This site is only available over HTTPS.", http.StatusForbidden)
+			return
+		}
+
+		http.Redirect(w, r, "https://"+r.Host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+
+}
+
+// isSecureRequest checks whether the given request has been sent over HTTPS. For requests
+// which have been forwarded by a trusted proxy the protocol used to reach the proxy counts.
+func isSecureRequest(r *http.Request) bool {
+	if forwarded, ok := r.Context().Value(forwardedRequestKey{}).(forwardedRequest); ok {
+		return forwarded.isSecure
+	}
+
+	return r.TLS != nil
+}
+
+// isForwardedRequest checks whether the given request has been forwarded by a trusted proxy.
+func isForwardedRequest(r *http.Request) bool {
+	_, ok := r.Context().Value(forwardedRequestKey{}).(forwardedRequest)
+	return ok
+}
+
+// isTrusted checks whether the given IP address is part of one of the given trusted networks.
+func isTrusted(trustedNetworks []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range trustedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getIP returns the IP address of the given remote address (e.g. "10.0.0.1:52100").
+func getIP(remoteAddress string) net.IP {
+	host, _, err := net.SplitHostPort(remoteAddress)
+	if err != nil {
+		host = remoteAddress
+	}
+
+	return net.ParseIP(host)
+}
+
+// getForwardedValue returns the value of the given header which has been added by the trusted proxy
+// closest to the client. Every proxy appends its value, so the values left of the last given number
+// of hops (trusted proxies) have been sent by the client.
+func getForwardedValue(r *http.Request, name string, hops int) string {
+	values := getHeaderValues(r, name)
+	if len(values) == 0 {
+		return ""
+	}
+
+	index := len(values) - hops
+	if index < 0 {
+		index = 0
+	}
+
+	return values[index]
+}
+
+// getHeaderValues returns the comma-separated values of all lines of the given header.
+func getHeaderValues(r *http.Request, name string) []string {
+	var values []string
+	for _, line := range r.Header.Values(name) {
+		for _, value := range strings.Split(line, ",") {
+			values = append(values, strings.TrimSpace(value))
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,154 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+)
+
+// getTestNetworks returns the networks for the given CIDRs.
+func getTestNetworks(cidrs ...string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, _ := net.ParseCIDR(cidr)
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+// serveForwardedRequest sends the given request through the proxy handler and returns the request the base handler received.
+func serveForwardedRequest(trustedNetworks []*net.IPNet, r *http.Request) *http.Request {
+	var received *http.Request
+	handler := TrustProxies(console.New(loglevel.Fatal), trustedNetworks, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	return received
+}
+
+func Test_TrustProxies_RequestFromTrustedProxy_ForwardedHeadersAreApplied(t *testing.T) {
+	// arrange
+	request := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/feed.rss", nil)
+	request.RemoteAddr = "10.0.0.2:41000"
+	request.Header.Set("X-Forwarded-Proto", "https")
+	request.Header.Set("X-Forwarded-Host", "docs.example.com")
+	request.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.3")
+
+	// act
+	received := serveForwardedRequest(getTestNetworks("10.0.0.0/8"), request)
+
+	// assert
+	if baseURL := getBaseURLFromRequest(received); baseURL != "https://docs.example.com" {
+		t.Errorf("The base URL should be %q but was %q.", "https://docs.example.com", baseURL)
+	}
+
+	if received.RemoteAddr != "203.0.113.7" {
+		t.Errorf("The remote address should be the client %q but was %q.", "203.0.113.7", received.RemoteAddr)
+	}
+}
+
+func Test_TrustProxies_RequestFromUntrustedAddress_ForwardedHeadersAreIgnored(t *testing.T) {
+	// arrange
+	request := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/feed.rss", nil)
+	request.RemoteAddr = "203.0.113.7:41000"
+	request.Header.Set("X-Forwarded-Proto", "https")
+	request.Header.Set("X-Forwarded-Host", "evil.example.com")
+	request.Header.Set("X-Forwarded-For", "10.0.0.3")
+
+	// act
+	received := serveForwardedRequest(getTestNetworks("10.0.0.0/8"), request)
+
+	// assert
+	if baseURL := getBaseURLFromRequest(received); baseURL != "http://127.0.0.1:8080" {
+		t.Errorf("The base URL should be %q but was %q.", "http://127.0.0.1:8080", baseURL)
+	}
+
+	if received.RemoteAddr != "203.0.113.7:41000" || received.Header.Get("X-Forwarded-For") != "" {
+		t.Errorf("The remote address and the headers of untrusted requests should not be used.")
+	}
+}
+
+func Test_TrustProxies_SpoofedForwardedFor_LastUntrustedAddressIsTheClient(t *testing.T) {
+	// arrange
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.RemoteAddr = "10.0.0.2:41000"
+	request.Header.Set("X-Forwarded-For", "192.0.2.1, 203.0.113.7")
+
+	// act
+	received := serveForwardedRequest(getTestNetworks("10.0.0.0/8"), request)
+
+	// assert
+	if received.RemoteAddr != "203.0.113.7" {
+		t.Errorf("The remote address should be the address added by the proxy %q but was %q.", "203.0.113.7", received.RemoteAddr)
+	}
+}
+
+func Test_TrustProxies_SpoofedForwardedProtoAndHost_ValuesOfTheProxyAreUsed(t *testing.T) {
+	// arrange
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.RemoteAddr = "10.0.0.2:41000"
+	request.Header.Set("X-Forwarded-Proto", "https, http")
+	request.Header.Set("X-Forwarded-Host", "evil.example.com, docs.example.com")
+	request.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	// act
+	received := serveForwardedRequest(getTestNetworks("10.0.0.0/8"), request)
+
+	// assert
+	if isSecureRequest(received) {
+		t.Errorf("The request should use the protocol %q added by the proxy.", "http")
+	}
+
+	if received.Host != "docs.example.com" {
+		t.Errorf("The host should be the host added by the proxy %q but was %q.", "docs.example.com", received.Host)
+	}
+}
+
+func Test_RequireHTTPS_ForwardedHTTPRequest_RequestIsRedirectedToHTTPS(t *testing.T) {
+	// arrange
+	handler := TrustProxies(console.New(loglevel.Fatal), getTestNetworks("10.0.0.0/8"), RequireHTTPS(console.New(loglevel.Fatal), http.NotFoundHandler()))
+
+	request := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/internal?page=2", nil)
+	request.RemoteAddr = "10.0.0.2:41000"
+	request.Header.Set("X-Forwarded-Proto", "http")
+	request.Header.Set("X-Forwarded-Host", "docs.example.com")
+
+	response := httptest.NewRecorder()
+
+	// act
+	handler.ServeHTTP(response, request)
+
+	// assert
+	if location := response.Header().Get("Location"); response.Code != http.StatusMovedPermanently || location != "https://docs.example.com/internal?page=2" {
+		t.Errorf("The request should be redirected to %q but the response was %d %q.", "https://docs.example.com/internal?page=2", response.Code, location)
+	}
+}
+
+func Test_RequireHTTPS_DirectHTTPRequest_RequestIsRejected(t *testing.T) {
+	// arrange
+	handler := TrustProxies(console.New(loglevel.Fatal), getTestNetworks("10.0.0.0/8"), RequireHTTPS(console.New(loglevel.Fatal), http.NotFoundHandler()))
+
+	request := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/internal", nil)
+	request.RemoteAddr = "203.0.113.7:41000"
+	request.Header.Set("X-Forwarded-Proto", "https")
+
+	response := httptest.NewRecorder()
+
+	// act
+	handler.ServeHTTP(response, request)
+
+	// assert
+	if response.Code != http.StatusForbidden {
+		t.Errorf("Insecure requests which have not been forwarded by a trusted proxy should be rejected but the status was %d.", response.Code)
+	}
+}
@@ -13,6 +13,12 @@ func Redirect(logger logger.Logger, baseURITarget string) http.Handler {
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
+		// requests which have been forwarded by a trusted proxy are redirected to HTTPS on the host of the proxy
+		if isForwardedRequest(r) {
+			http.Redirect(w, r, "https://"+r.Host+r.URL.RequestURI(), http.StatusMovedPermanently)
+			return
+		}
+
 		requestPath := r.URL.Path
 		redirectURL := baseURITarget + "/" + requestPath
 
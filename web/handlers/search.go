@@ -32,8 +32,9 @@ func Search(headerWriter header.HeaderWriter,
 
 		hostname := getBaseURLFromRequest(r)
 
-		// get the query parameter
-		query, _ := getQueryParameterFromURL(*r.URL)
+		// get the query and the filter parameters
+		searchQuery := getSearchQueryFromURL(*r.URL)
+		query := searchQuery.String()
 
 		// read the page url-parameter
 		page, pageParameterIsAvailable := getPageParameterFromURL(*r.URL)
@@ -64,7 +65,7 @@ func Search(headerWriter header.HeaderWriter,
 		pageModel.BreadcrumbNavigation = navigationOrchestrator.GetBreadcrumbNavigation(route.New())
 
 		// get the search results
-		searchResultsModel := searchOrchestrator.GetSearchResults(query, page)
+		searchResultsModel := searchOrchestrator.GetSearchResults(searchQuery, page)
 
 		// display error 404 non-existing page has been requested
 		if searchResultsModel.ResultCount == 0 && page > 1 {
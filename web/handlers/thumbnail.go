@@ -0,0 +1,52 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/util/hashutil"
+	"github.com/andreaskoch/allmark/web/header"
+	"github.com/andreaskoch/allmark/web/orchestrator"
+)
+
+// Thumbnail creates a http handler which serves the thumbnails of the image files
+// the file orchestrator provides. The thumbnails of all other files are not found.
+func Thumbnail(headerWriter header.HeaderWriter, fileOrchestrator *orchestrator.FileOrchestrator, error404Handler http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		name := strings.TrimPrefix(r.URL.Path, ThumbnailRoutePrefix+"/")
+
+		thumbnailPath, found := fileOrchestrator.GetThumbnailFilePath(name)
+		if !found {
+			error404Handler.ServeHTTP(w, r)
+			return
+		}
+
+		file, err := os.Open(thumbnailPath)
+		if err != nil {
+			error404Handler.ServeHTTP(w, r)
+			return
+		}
+
+		defer file.Close()
+
+		fileInfo, err := file.Stat()
+		if err != nil {
+			error404Handler.ServeHTTP(w, r)
+			return
+		}
+
+		headerWriter.Write(w, "")
+		if etag, err := hashutil.GetHash(file); err == nil {
+			header.ETag(w, etag)
+		}
+
+		http.ServeContent(w, r, name, fileInfo.ModTime(), file)
+	})
+}
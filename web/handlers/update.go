@@ -7,6 +7,7 @@ package handlers
 import (
 	"github.com/andreaskoch/allmark/common/logger"
 	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/common/shutdown"
 	"github.com/andreaskoch/allmark/web/handlers/update"
 	"github.com/andreaskoch/allmark/web/header"
 	"github.com/andreaskoch/allmark/web/orchestrator"
@@ -15,6 +16,7 @@ import (
 	"github.com/andreaskoch/allmark/web/view/viewmodel"
 	"golang.org/x/net/websocket"
 	"strings"
+	"time"
 )
 
 func Update(logger logger.Logger,
@@ -24,6 +26,9 @@ func Update(logger logger.Logger,
 
 	hub := update.NewHub(logger, updateOrchestrator)
 
+	// close the live-reload connections on shutdown
+	shutdown.Register(hub.Close)
+
 	updateChannel := make(chan orchestrator.Update, 1)
 	updateOrchestrator.Subscribe(updateChannel)
 
@@ -39,7 +44,7 @@ func Update(logger logger.Logger,
 				viewModel, found := updateOrchestrator.GetUpdatedModel(update.Route())
 				if !found {
 					logger.Warn("The item for route %q was no longer found.", update.Route())
-					return
+					continue
 				}
 
 				var updateModel viewmodel.Update
@@ -75,6 +80,9 @@ func Update(logger logger.Logger,
 
 	return websocket.Handler(func(ws *websocket.Conn) {
 
+		// the read and write timeouts of the web server do not apply to websockets
+		ws.SetDeadline(time.Time{})
+
 		// strip the "ws" or ".ws" suffix from the path
 		path := ws.Request().URL.Path
 		path = strings.TrimSuffix(path, "ws")
@@ -5,6 +5,8 @@
 package update
 
 import (
+	"sync"
+
 	"github.com/andreaskoch/allmark/common/logger"
 	"github.com/andreaskoch/allmark/web/orchestrator"
 	"github.com/andreaskoch/allmark/web/view/viewmodel"
@@ -20,6 +22,9 @@ func NewHub(logger logger.Logger, updateOrchestrator *orchestrator.UpdateOrchest
 		subscribe:   make(chan *connection, 1),
 		unsubscribe: make(chan *connection, 1),
 		connections: make(map[*connection]bool),
+
+		quit:    make(chan struct{}),
+		stopped: make(chan struct{}),
 	}
 
 	// start the hub
@@ -44,12 +49,21 @@ type Hub struct {
 
 	// Unsubscribe requests from connections.
 	unsubscribe chan *connection
+
+	// Closed when the hub shall stop and when it has stopped.
+	quit      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
 }
 
 func (hub *Hub) Message(updateModel viewmodel.Update) {
 	go func() {
 		hub.logger.Debug("Broadcasting message for route %s", updateModel.Route)
-		hub.broadcast <- NewMessage(updateModel)
+
+		select {
+		case hub.broadcast <- NewMessage(updateModel):
+		case <-hub.quit:
+		}
 	}()
 }
 
@@ -61,7 +75,11 @@ func (hub *Hub) Subscribe(connection *connection) {
 		hub.updateOrchestrator.StartWatching(connection.Route)
 	}
 
-	hub.subscribe <- connection
+	select {
+	case hub.subscribe <- connection:
+	case <-hub.quit:
+		connection.ws.Close()
+	}
 }
 
 func (hub *Hub) Unsubscribe(connection *connection) {
@@ -71,7 +89,20 @@ func (hub *Hub) Unsubscribe(connection *connection) {
 		hub.updateOrchestrator.StopWatching(connection.Route)
 	}
 
-	hub.unsubscribe <- connection
+	select {
+	case hub.unsubscribe <- connection:
+	case <-hub.quit:
+	}
+}
+
+// Close stops the hub and closes all websocket connections.
+func (hub *Hub) Close() error {
+	hub.closeOnce.Do(func() {
+		close(hub.quit)
+	})
+
+	<-hub.stopped
+	return nil
 }
 
 func (hub *Hub) connectionsByRoute(routeValue string) []*connection {
@@ -88,9 +119,25 @@ func (hub *Hub) connectionsByRoute(routeValue string) []*connection {
 }
 
 func (hub *Hub) run() {
+	defer close(hub.stopped)
+
 	for {
 		select {
 
+		// close all connections
+		case <-hub.quit:
+			{
+				hub.logger.Debug("Closing %v connections", len(hub.connections))
+
+				for connection := range hub.connections {
+					delete(hub.connections, connection)
+					close(connection.send)
+					connection.ws.Close()
+				}
+
+				return
+			}
+
 		// subscribe a new connection
 		case connection := <-hub.subscribe:
 			{
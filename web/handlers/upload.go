@@ -0,0 +1,113 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/web/header"
+	"github.com/andreaskoch/allmark/web/orchestrator"
+	"github.com/andreaskoch/allmark/web/view/viewmodel"
+)
+
+// the maximum size of all files of an upload request
+const maxUploadRequestSizeInBytes = 100 << 20
+
+// Upload returns a http handler which adds the files of a multipart form (POST) to the files
+// of the requested item if the item is visible to the audience of the view model orchestrator.
+// The uploaded files are returned as JSON if the client accepts JSON;
+// otherwise the client is redirected to the editor of the item.
+func Upload(logger logger.Logger,
+	headerWriter header.HeaderWriter,
+	viewModelOrchestrator *orchestrator.ViewModelOrchestrator,
+	editOrchestrator *orchestrator.EditOrchestrator,
+	error404Handler http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		// strip the "upload" or ".upload" suffix from the path
+		path := r.URL.Path
+		path = strings.TrimSuffix(path, "upload")
+		path = strings.TrimSuffix(path, ".")
+
+		// get the request route
+		requestRoute := route.NewFromRequest(path)
+
+		// make sure the request body is closed
+		defer r.Body.Close()
+
+		// the edit orchestrator writes to the repository, which contains the hidden items as well
+		if !viewModelOrchestrator.ItemExists(requestRoute) || !editOrchestrator.CanEdit(requestRoute) {
+			error404Handler.ServeHTTP(w, r)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "Method not allowed.", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// the browser sends the credentials along with any form that targets this site
+		if !isSameOriginRequest(r) {
+			http.Error(w, "Cross-origin requests are not allowed.", http.StatusForbidden)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxUploadRequestSizeInBytes)
+		multipartReader, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, "The request does not contain a multipart form.", http.StatusBadRequest)
+			return
+		}
+
+		// store the files while they are read from the request
+		uploads := make([]viewmodel.Upload, 0)
+		for {
+			part, err := multipartReader.NextPart()
+			if err == io.EOF {
+				break
+			}
+
+			if err != nil {
+				http.Error(w, "The multipart form could not be read.", http.StatusBadRequest)
+				return
+			}
+
+			if part.FileName() == "" {
+				continue
+			}
+
+			upload, err := editOrchestrator.Upload(requestRoute, part.FileName(), part)
+			if err != nil {
+				logger.Error("Cannot upload file %q to item %q. Error: %s", part.FileName(), requestRoute, err.Error())
+				http.Error(w, "The file could not be saved.", http.StatusInternalServerError)
+				return
+			}
+
+			uploads = append(uploads, upload)
+		}
+
+		if !strings.Contains(r.Header.Get("Accept"), "application/json") {
+			http.Redirect(w, r, orchestrator.GetTypedItemURL(requestRoute, "edit"), http.StatusSeeOther)
+			return
+		}
+
+		headerWriter.Write(w, header.CONTENTTYPE_JSON)
+		bytes, err := json.MarshalIndent(uploads, "", "\t")
+		if err != nil {
+			logger.Error("Cannot serialize the uploads of item %q. Error: %s", requestRoute, err.Error())
+			return
+		}
+
+		w.Write(bytes)
+	})
+
+}
@@ -0,0 +1,155 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package handlers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/dataaccess/filesystem"
+	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/services/converter/markdowntohtml"
+	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/imageprovider"
+	"github.com/andreaskoch/allmark/services/diagram"
+	"github.com/andreaskoch/allmark/services/parser"
+	"github.com/andreaskoch/allmark/services/thumbnail"
+	"github.com/andreaskoch/allmark/web/header"
+	"github.com/andreaskoch/allmark/web/orchestrator"
+	"github.com/andreaskoch/allmark/web/webpaths"
+)
+
+// getTestOrchestratorFactory returns an orchestrator factory for an editable repository with the
+// given items which only contains the items that are not below the "internal" item.
+func getTestOrchestratorFactory(t *testing.T, itemPaths ...string) (factory *orchestrator.Factory, repositoryPath string) {
+	logger := console.New(loglevel.Fatal)
+
+	repositoryPath = t.TempDir()
+	for _, itemPath := range itemPaths {
+		itemDirectory := filepath.Join(repositoryPath, filepath.FromSlash(itemPath))
+		if err := os.MkdirAll(itemDirectory, 0700); err != nil {
+			t.Fatalf("Cannot create the item %q. Error: %s", itemPath, err)
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(itemDirectory, "README.md"), []byte("# "+itemPath+"\n"), 0600); err != nil {
+			t.Fatalf("Cannot create the item %q. Error: %s", itemPath, err)
+		}
+	}
+
+	// editing requires HTTPS and the user store
+	configuration := config.Default(repositoryPath)
+	configuration.Server.Editing.Enabled = true
+	configuration.Server.HTTP.Enabled = false
+	configuration.Server.HTTPS.Enabled = true
+
+	if err := os.MkdirAll(filepath.Dir(configuration.AuthenticationFilePath()), 0700); err != nil {
+		t.Fatalf("Cannot create the configuration folder. Error: %s", err)
+	}
+
+	if err := ioutil.WriteFile(configuration.AuthenticationFilePath(), nil, 0600); err != nil {
+		t.Fatalf("Cannot create the user store. Error: %s", err)
+	}
+
+	repository, err := filesystem.NewRepository(logger, repositoryPath, *configuration)
+	if err != nil {
+		t.Fatalf("filesystem.NewRepository returned an error: %s", err)
+	}
+
+	itemParser, err := parser.New(logger)
+	if err != nil {
+		t.Fatalf("parser.New returned an error: %s", err)
+	}
+
+	webPathProvider := webpaths.NewWebPathProvider(webpaths.NewFactory(logger, repository), BasePath, TagPathPrefix)
+	imageProvider := imageprovider.NewImageProvider(webPathProvider.AbsolutePather("/"), thumbnail.EmptyIndex())
+	converter := markdowntohtml.New(logger, *configuration, imageProvider, diagram.New(logger, diagram.EmptyCache()))
+
+	factory = orchestrator.NewFactory(logger, *configuration, repository, itemParser, converter, webPathProvider, thumbnail.EmptyIndex(), nil)
+	return factory.NewFilteredFactory("public", func(item *model.Item) bool {
+		return !strings.HasPrefix(item.Route().Value(), "internal")
+	}), repositoryPath
+}
+
+func Test_Upload_ItemIsHiddenFromTheAudience_FileIsNotSaved(t *testing.T) {
+	// arrange
+	factory, repositoryPath := getTestOrchestratorFactory(t, "public", "internal")
+
+	headerWriterFactory := header.NewHeaderWriterFactory(0)
+	handler := Upload(console.New(loglevel.Fatal),
+		headerWriterFactory.NoCache(),
+		factory.NewViewModelOrchestrator(),
+		factory.NewEditOrchestrator(),
+		http.NotFoundHandler())
+
+	body := new(bytes.Buffer)
+	form := multipart.NewWriter(body)
+	file, _ := form.CreateFormFile("file", "notes.txt")
+	file.Write([]byte("Notes"))
+	form.Close()
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/internal/upload", body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	request.Header.Set("Origin", "http://example.com")
+
+	response := httptest.NewRecorder()
+
+	// act
+	handler.ServeHTTP(response, request)
+
+	// assert
+	if response.Code != http.StatusNotFound {
+		t.Errorf("The upload to a hidden item should return %d but returned %d.", http.StatusNotFound, response.Code)
+	}
+
+	if _, err := os.Stat(filepath.Join(repositoryPath, "internal", "files", "notes.txt")); err == nil {
+		t.Errorf("The file should not have been added to the hidden item.")
+	}
+}
+
+func Test_Upload_ItemIsVisibleToTheAudience_FileIsSaved(t *testing.T) {
+	// arrange
+	factory, repositoryPath := getTestOrchestratorFactory(t, "public", "internal")
+
+	headerWriterFactory := header.NewHeaderWriterFactory(0)
+	handler := Upload(console.New(loglevel.Fatal),
+		headerWriterFactory.NoCache(),
+		factory.NewViewModelOrchestrator(),
+		factory.NewEditOrchestrator(),
+		http.NotFoundHandler())
+
+	body := new(bytes.Buffer)
+	form := multipart.NewWriter(body)
+	file, _ := form.CreateFormFile("file", "notes.txt")
+	file.Write([]byte("Notes"))
+	form.Close()
+
+	request := httptest.NewRequest(http.MethodPost, "http://example.com/public/upload", body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Origin", "http://example.com")
+
+	response := httptest.NewRecorder()
+
+	// act
+	handler.ServeHTTP(response, request)
+
+	// assert
+	if response.Code != http.StatusOK {
+		t.Errorf("The upload to a visible item should return %d but returned %d (%s).", http.StatusOK, response.Code, response.Body.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(repositoryPath, "public", "files", "notes.txt")); err != nil {
+		t.Errorf("The file should have been added to the item. Error: %s", err)
+	}
+}
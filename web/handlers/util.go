@@ -6,6 +6,7 @@ package handlers
 
 import (
 	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/web/orchestrator/search"
 	"github.com/andreaskoch/allmark/web/view/viewmodel"
 	"bufio"
 	"bytes"
@@ -21,9 +22,11 @@ func getRouteFromRequest(r *http.Request) route.Route {
 	return route.NewFromRequest(r.URL.Path)
 }
 
+// getBaseURLFromRequest returns the protocol and the host of the given request (e.g. "https://example.com").
+// For requests which have been forwarded by a trusted proxy the protocol and the host of the proxy are returned.
 func getBaseURLFromRequest(r *http.Request) string {
 	scheme := "http"
-	if r.TLS != nil {
+	if isSecureRequest(r) {
 		scheme = "https"
 	}
 
@@ -70,6 +73,22 @@ func getPageParameterFromURL(url url.URL) (page int, parameterIsAvailable bool)
 	return int(page64), true
 }
 
+// getSearchQueryFromURL parses the query parameter ("q") and applies
+// the search filter parameters (e.g. "tag" or "author") of the given url.
+func getSearchQueryFromURL(url url.URL) search.Query {
+	queryText, _ := getQueryParameterFromURL(url)
+	query := search.ParseQuery(queryText)
+
+	parameters := url.Query()
+	for _, filterName := range search.FilterNames {
+		for _, value := range parameters[filterName] {
+			query.SetFilter(filterName, value)
+		}
+	}
+
+	return query
+}
+
 func getQueryParameterFromURL(url url.URL) (query string, parameterIsAvailable bool) {
 	queryParam := url.Query().Get("q")
 	if queryParam == "" {
@@ -56,6 +56,22 @@ func (headerWriter configurableHeaderWriter) Write(w http.ResponseWriter, conten
 	VaryAcceptEncoding(w)
 }
 
+// private header writer (for responses which depend on the user)
+type privateHeaderWriter struct {
+	cacheDuration int
+}
+
+func (headerWriter privateHeaderWriter) Write(w http.ResponseWriter, contentType string) {
+	if headerWriter.cacheDuration > 0 {
+		w.Header().Add("Cache-Control", fmt.Sprintf("private, max-age=%d", headerWriter.cacheDuration))
+	} else {
+		w.Header().Add("Cache-Control", "private, no-cache")
+	}
+
+	ContentType(w, contentType)
+	w.Header().Set("Vary", "Accept-Encoding, Authorization, Cookie")
+}
+
 // no-cache header writer
 type noCacheHeaderWriter struct {
 }
@@ -73,18 +89,7 @@ type HeaderWriter interface {
 // HeaderWriter factory
 func NewHeaderWriterFactory(reindexIntervalInSeconds int) WriterFactory {
 
-	// default cache durations
-	cacheDurationDynamic := 86400   // 1 day
-	cacheDurationStatic := 31536000 // 1 year
-
-	reindexingIsEnabled := reindexIntervalInSeconds > 0
-	if reindexingIsEnabled {
-
-		// cache durations based on the reindex interval
-		cacheDurationDynamic = reindexIntervalInSeconds / 2
-		cacheDurationStatic = reindexIntervalInSeconds / 2
-
-	}
+	cacheDurationDynamic, cacheDurationStatic := getCacheDurations(reindexIntervalInSeconds)
 
 	// create the header writers
 	static := configurableHeaderWriter{cacheDurationStatic}
@@ -100,6 +105,39 @@ func NewHeaderWriterFactory(reindexIntervalInSeconds int) WriterFactory {
 
 }
 
+// NewPrivateHeaderWriterFactory creates a factory for header writers which prevent shared caches
+// from storing the responses. Dynamic responses must be revalidated on every request because the
+// items the user can see change when the user logs in.
+func NewPrivateHeaderWriterFactory(reindexIntervalInSeconds int) WriterFactory {
+
+	_, cacheDurationStatic := getCacheDurations(reindexIntervalInSeconds)
+
+	return WriterFactory{
+		privateHeaderWriter{cacheDurationStatic},
+		privateHeaderWriter{},
+		noCacheHeaderWriter{},
+	}
+}
+
+// getCacheDurations returns the cache durations in seconds for dynamic and static responses.
+func getCacheDurations(reindexIntervalInSeconds int) (cacheDurationDynamic, cacheDurationStatic int) {
+
+	// default cache durations
+	cacheDurationDynamic = 86400   // 1 day
+	cacheDurationStatic = 31536000 // 1 year
+
+	reindexingIsEnabled := reindexIntervalInSeconds > 0
+	if reindexingIsEnabled {
+
+		// cache durations based on the reindex interval
+		cacheDurationDynamic = reindexIntervalInSeconds / 2
+		cacheDurationStatic = reindexIntervalInSeconds / 2
+
+	}
+
+	return cacheDurationDynamic, cacheDurationStatic
+}
+
 type WriterFactory struct {
 	static  HeaderWriter
 	dynamic HeaderWriter
@@ -0,0 +1,156 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The minimum length of the key for signing the cookies.
+const minimumKeyLength = 32
+
+// LoadKey reads the key for signing the cookies from the file with the given path.
+// If the file does not exist, a random key is created and saved to the file.
+func LoadKey(keyFilePath string) ([]byte, error) {
+
+	key, err := ioutil.ReadFile(keyFilePath)
+	if err == nil {
+		if len(key) < minimumKeyLength {
+			return nil, fmt.Errorf("The session key %q must be at least %d bytes long.", keyFilePath, minimumKeyLength)
+		}
+
+		return key, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Cannot read the session key %q. Error: %s", keyFilePath, err)
+	}
+
+	key = make([]byte, minimumKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("Cannot create a session key. Error: %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(keyFilePath), 0700); err != nil {
+		return nil, fmt.Errorf("Cannot create the folder for the session key %q. Error: %s", keyFilePath, err)
+	}
+
+	if err := ioutil.WriteFile(keyFilePath, key, 0600); err != nil {
+		return nil, fmt.Errorf("Cannot save the session key %q. Error: %s", keyFilePath, err)
+	}
+
+	return key, nil
+}
+
+// signedCookies writes and reads cookies whose values are signed with a secret key.
+// The values are JSON-encoded and cannot be changed by the users.
+type signedCookies struct {
+	key []byte
+}
+
+// signedValue contains the value of a signed cookie and the time the value expires.
+type signedValue struct {
+	Value   json.RawMessage `json:"v"`
+	Expires int64           `json:"e"`
+}
+
+// set writes the given value to the cookie with the given name and path.
+// The cookie is only sent over HTTPS and cannot be read by scripts.
+func (cookies signedCookies) set(w http.ResponseWriter, name, path string, value interface{}, expires time.Time) error {
+
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(signedValue{encodedValue, expires.Unix()})
+	if err != nil {
+		return err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(cookies.sign(name, encodedPayload))
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    encodedPayload + "." + signature,
+		Path:     path,
+		Expires:  expires,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return nil
+}
+
+// get reads the value of the cookie with the given name into the given value.
+// An error is returned if the cookie is missing, has been changed or has expired.
+func (cookies signedCookies) get(r *http.Request, name string, value interface{}) error {
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return err
+	}
+
+	separator := strings.LastIndex(cookie.Value, ".")
+	if separator == -1 {
+		return fmt.Errorf("The cookie %q is not signed.", name)
+	}
+
+	encodedPayload := cookie.Value[:separator]
+	signature, err := base64.RawURLEncoding.DecodeString(cookie.Value[separator+1:])
+	if err != nil || !hmac.Equal(signature, cookies.sign(name, encodedPayload)) {
+		return fmt.Errorf("The signature of the cookie %q is invalid.", name)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return err
+	}
+
+	var signed signedValue
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return err
+	}
+
+	if time.Now().Unix() > signed.Expires {
+		return fmt.Errorf("The cookie %q has expired.", name)
+	}
+
+	return json.Unmarshal(signed.Value, value)
+}
+
+// remove deletes the cookie with the given name and path.
+func (cookies signedCookies) remove(w http.ResponseWriter, name, path string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    "",
+		Path:     path,
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// sign returns the signature of the given cookie value. The name is part of the signature
+// so that the value of one cookie cannot be used for another one.
+func (cookies signedCookies) sign(name, encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, cookies.key)
+	mac.Write([]byte(name + "=" + encodedPayload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,189 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"golang.org/x/oauth2"
+)
+
+const (
+	testClientID     = "allmark"
+	testClientSecret = "secret"
+	testKeyID        = "test-key"
+)
+
+// mockIssuer is a local OpenID Connect provider which logs in the configured user without asking for credentials.
+type mockIssuer struct {
+	*httptest.Server
+
+	t   *testing.T
+	key *rsa.PrivateKey
+
+	// claims contains the claims of the user which are added to the ID tokens.
+	claims map[string]interface{}
+
+	// nonce overrides the nonce of the ID tokens if it is set.
+	nonce string
+
+	lock   sync.Mutex
+	logins map[string]mockLogin
+}
+
+// mockLogin contains the parameters of an authorization request.
+type mockLogin struct {
+	redirectURL   string
+	nonce         string
+	codeChallenge string
+}
+
+// newMockIssuer starts a mock provider which issues ID tokens with the given claims.
+func newMockIssuer(t *testing.T, claims map[string]interface{}) *mockIssuer {
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Cannot create the signing key. Error: %s", err)
+	}
+
+	issuer := &mockIssuer{
+		t:      t,
+		key:    key,
+		claims: claims,
+		logins: make(map[string]mockLogin),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/keys", issuer.keys)
+	mux.HandleFunc("/authorize", issuer.authorize)
+	mux.HandleFunc("/token", issuer.token)
+
+	issuer.Server = httptest.NewServer(mux)
+	return issuer
+}
+
+func (issuer *mockIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"issuer":                                issuer.URL,
+		"authorization_endpoint":                issuer.URL + "/authorize",
+		"token_endpoint":                        issuer.URL + "/token",
+		"jwks_uri":                              issuer.URL + "/keys",
+		"end_session_endpoint":                  issuer.URL + "/logout",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (issuer *mockIssuer) keys(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{{Key: &issuer.key.PublicKey, KeyID: testKeyID, Algorithm: "RS256", Use: "sig"}},
+	})
+}
+
+// authorize logs in the user and redirects back to the client with an authorization code.
+func (issuer *mockIssuer) authorize(w http.ResponseWriter, r *http.Request) {
+
+	query := r.URL.Query()
+	if query.Get("client_id") != testClientID || query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	issuer.lock.Lock()
+	issuer.logins[code] = mockLogin{query.Get("redirect_uri"), query.Get("nonce"), query.Get("code_challenge")}
+	issuer.lock.Unlock()
+
+	redirectURL, _ := url.Parse(query.Get("redirect_uri"))
+	parameters := redirectURL.Query()
+	parameters.Set("code", code)
+	parameters.Set("state", query.Get("state"))
+	redirectURL.RawQuery = parameters.Encode()
+
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+// token exchanges an authorization code for a signed ID token.
+func (issuer *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+
+	clientID, clientSecret, _ := r.BasicAuth()
+	if clientID != testClientID || clientSecret != testClientSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	issuer.lock.Lock()
+	login, exists := issuer.logins[r.PostFormValue("code")]
+	delete(issuer.logins, r.PostFormValue("code"))
+	issuer.lock.Unlock()
+
+	if !exists || login.redirectURL != r.PostFormValue("redirect_uri") || login.codeChallenge != oauth2.S256ChallengeFromVerifier(r.PostFormValue("code_verifier")) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+		return
+	}
+
+	claims := map[string]interface{}{
+		"iss":   issuer.URL,
+		"aud":   testClientID,
+		"sub":   "1234",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": login.nonce,
+	}
+
+	if issuer.nonce != "" {
+		claims["nonce"] = issuer.nonce
+	}
+
+	for name, value := range issuer.claims {
+		claims[name] = value
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     issuer.sign(claims),
+	})
+}
+
+// sign returns the given claims as a signed JWT.
+func (issuer *mockIssuer) sign(claims map[string]interface{}) string {
+
+	signingKey := jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: issuer.key, KeyID: testKeyID}}
+	signer, err := jose.NewSigner(signingKey, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		issuer.t.Fatalf("Cannot create the signer. Error: %s", err)
+	}
+
+	payload, _ := json.Marshal(claims)
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		issuer.t.Fatalf("Cannot sign the ID token. Error: %s", err)
+	}
+
+	token, err := signature.CompactSerialize()
+	if err != nil {
+		issuer.t.Fatalf("Cannot serialize the ID token. Error: %s", err)
+	}
+
+	return token
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
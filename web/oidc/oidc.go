@@ -0,0 +1,408 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package oidc logs users in with an OpenID Connect provider (authorization code flow with PKCE)
+// and identifies them by a signed session cookie.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	goidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// SessionCookieName is the name of the cookie which identifies the users who have logged in.
+	SessionCookieName = "allmark_session"
+
+	// loginCookieName is the name of the cookie which holds the state of a login until the provider redirects back.
+	loginCookieName = "allmark_login"
+
+	// loginLifetime defines how long users have for logging in at the provider.
+	loginLifetime = 10 * time.Minute
+
+	// requestTimeout is the maximum duration of the requests to the provider.
+	requestTimeout = 10 * time.Second
+)
+
+// User contains the name and the roles of a user who has logged in.
+type User struct {
+	Name  string   `json:"n"`
+	Roles []string `json:"r,omitempty"`
+
+	// Subject is the ID of the user at the provider ("sub" claim). Unlike the name
+	// it cannot be chosen by the user and identifies the user in the access settings.
+	Subject string `json:"s"`
+}
+
+// loginState contains the values which tie the response of the provider to the login request.
+type loginState struct {
+	State        string `json:"s"`
+	Nonce        string `json:"n"`
+	CodeVerifier string `json:"c"`
+	ReturnPath   string `json:"p"`
+}
+
+// New creates a new client for the OpenID Connect provider with the given settings.
+// The session cookies are signed with the given key.
+func New(logger logger.Logger, settings config.OIDC, key []byte) (*Client, error) {
+
+	if strings.TrimSpace(settings.IssuerURL) == "" {
+		return nil, fmt.Errorf("The issuer URL of the OpenID Connect provider is not set.")
+	}
+
+	if strings.TrimSpace(settings.ClientID) == "" {
+		return nil, fmt.Errorf("The client ID for the OpenID Connect provider is not set.")
+	}
+
+	if len(key) < minimumKeyLength {
+		return nil, fmt.Errorf("The session key must be at least %d bytes long.", minimumKeyLength)
+	}
+
+	return &Client{
+		logger:     logger,
+		settings:   settings,
+		cookies:    signedCookies{key},
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Client logs users in with an OpenID Connect provider.
+type Client struct {
+	logger     logger.Logger
+	settings   config.OIDC
+	cookies    signedCookies
+	httpClient *http.Client
+
+	provider     *goidc.Provider
+	providerLock sync.Mutex
+}
+
+// User returns the user who sent the given request. The flag is false if the request
+// does not contain a valid session cookie.
+func (client *Client) User(r *http.Request) (User, bool) {
+	var user User
+	if err := client.cookies.get(r, SessionCookieName, &user); err != nil {
+		return User{}, false
+	}
+
+	return user, user.Name != "" && user.Subject != ""
+}
+
+// LoginURL returns the URL of the login page of the provider. The provider redirects back to the given
+// redirect URL which must call Callback. The state of the login is saved in a cookie.
+func (client *Client) LoginURL(w http.ResponseWriter, r *http.Request, redirectURL, returnPath string) (string, error) {
+
+	ctx := client.context(r.Context())
+	provider, err := client.getProvider(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	state := loginState{
+		State:        randomString(),
+		Nonce:        randomString(),
+		CodeVerifier: oauth2.GenerateVerifier(),
+		ReturnPath:   getSafeReturnPath(returnPath),
+	}
+
+	if err := client.cookies.set(w, loginCookieName, "/", state, time.Now().Add(loginLifetime)); err != nil {
+		return "", err
+	}
+
+	oauth2Config := client.getOAuth2Config(provider, redirectURL)
+	return oauth2Config.AuthCodeURL(state.State, goidc.Nonce(state.Nonce), oauth2.S256ChallengeOption(state.CodeVerifier)), nil
+}
+
+// Callback completes the login with the authorization code in the given request, saves the user in
+// the session cookie and returns the path the user requested before logging in.
+func (client *Client) Callback(w http.ResponseWriter, r *http.Request, redirectURL string) (User, string, error) {
+
+	var state loginState
+	err := client.cookies.get(r, loginCookieName, &state)
+	client.cookies.remove(w, loginCookieName, "/")
+	if err != nil {
+		return User{}, "", fmt.Errorf("The login has expired or has not been started in this browser. Error: %s", err)
+	}
+
+	query := r.URL.Query()
+	if providerError := query.Get("error"); providerError != "" {
+		return User{}, "", fmt.Errorf("The provider refused the login: %s %s", providerError, query.Get("error_description"))
+	}
+
+	if query.Get("state") != state.State {
+		return User{}, "", fmt.Errorf("The state of the login does not match.")
+	}
+
+	ctx := client.context(r.Context())
+	provider, err := client.getProvider(ctx)
+	if err != nil {
+		return User{}, "", err
+	}
+
+	oauth2Config := client.getOAuth2Config(provider, redirectURL)
+	token, err := oauth2Config.Exchange(ctx, query.Get("code"), oauth2.VerifierOption(state.CodeVerifier))
+	if err != nil {
+		return User{}, "", fmt.Errorf("Cannot exchange the authorization code. Error: %s", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return User{}, "", fmt.Errorf("The token response does not contain an ID token.")
+	}
+
+	idToken, err := provider.Verifier(&goidc.Config{ClientID: client.settings.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return User{}, "", fmt.Errorf("The ID token is invalid. Error: %s", err)
+	}
+
+	if idToken.Nonce != state.Nonce {
+		return User{}, "", fmt.Errorf("The nonce of the ID token does not match.")
+	}
+
+	claims := make(map[string]interface{})
+	if err := idToken.Claims(&claims); err != nil {
+		return User{}, "", fmt.Errorf("Cannot read the claims of the ID token. Error: %s", err)
+	}
+
+	// the provider might only return some claims from the user info endpoint
+	if client.isMissingClaims(claims) && provider.UserInfoEndpoint() != "" {
+		client.addUserInfoClaims(ctx, provider, token, idToken.Subject, claims)
+	}
+
+	user, err := getUser(client.settings, claims)
+	if err != nil {
+		return User{}, "", err
+	}
+
+	if err := client.cookies.set(w, SessionCookieName, "/", user, time.Now().Add(client.settings.SessionLifetime())); err != nil {
+		return User{}, "", err
+	}
+
+	return user, state.ReturnPath, nil
+}
+
+// Logout removes the session cookie and returns the URL the user is sent to. If the provider supports
+// it, the user is also logged out at the provider and then redirected to the given URL.
+func (client *Client) Logout(w http.ResponseWriter, r *http.Request, postLogoutRedirectURL string) string {
+
+	client.cookies.remove(w, SessionCookieName, "/")
+
+	provider, err := client.getProvider(client.context(r.Context()))
+	if err != nil {
+		client.logger.Warn("%s", err)
+		return postLogoutRedirectURL
+	}
+
+	var metaData struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+
+	if err := provider.Claims(&metaData); err != nil || metaData.EndSessionEndpoint == "" {
+		return postLogoutRedirectURL
+	}
+
+	endSessionURL, err := url.Parse(metaData.EndSessionEndpoint)
+	if err != nil {
+		return postLogoutRedirectURL
+	}
+
+	parameters := endSessionURL.Query()
+	parameters.Set("client_id", client.settings.ClientID)
+	parameters.Set("post_logout_redirect_uri", postLogoutRedirectURL)
+	endSessionURL.RawQuery = parameters.Encode()
+
+	return endSessionURL.String()
+}
+
+// getProvider returns the provider. The discovery document of the provider is loaded on first use.
+func (client *Client) getProvider(ctx context.Context) (*goidc.Provider, error) {
+
+	client.providerLock.Lock()
+	defer client.providerLock.Unlock()
+
+	if client.provider != nil {
+		return client.provider, nil
+	}
+
+	provider, err := goidc.NewProvider(ctx, client.settings.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot load the configuration of the OpenID Connect provider %q. Error: %s", client.settings.IssuerURL, err)
+	}
+
+	client.provider = provider
+	return provider, nil
+}
+
+// getOAuth2Config returns the OAuth 2.0 configuration for the given provider and redirect URL.
+func (client *Client) getOAuth2Config(provider *goidc.Provider, redirectURL string) *oauth2.Config {
+
+	scopes := []string{goidc.ScopeOpenID}
+	for _, scope := range client.settings.Scopes {
+		if scope != goidc.ScopeOpenID {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &oauth2.Config{
+		ClientID:     client.settings.ClientID,
+		ClientSecret: client.settings.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+	}
+}
+
+// context returns a context which uses the client's HTTP client for the requests to the provider.
+func (client *Client) context(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, client.httpClient)
+	return goidc.ClientContext(ctx, client.httpClient)
+}
+
+// isMissingClaims checks whether the username or the roles claim are missing in the given claims.
+func (client *Client) isMissingClaims(claims map[string]interface{}) bool {
+	if _, exists := claims[client.settings.Username()]; !exists {
+		return true
+	}
+
+	if client.settings.RolesClaim == "" {
+		return false
+	}
+
+	_, exists := getClaim(claims, client.settings.RolesClaim)
+	return !exists
+}
+
+// addUserInfoClaims adds the claims from the user info endpoint which are missing in the given claims.
+func (client *Client) addUserInfoClaims(ctx context.Context, provider *goidc.Provider, token *oauth2.Token, subject string, claims map[string]interface{}) {
+
+	userInfo, err := provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
+	if err != nil {
+		client.logger.Warn("Cannot read the user info of %q. Error: %s", subject, err)
+		return
+	}
+
+	if userInfo.Subject != subject {
+		client.logger.Warn("The subject of the user info %q does not match the subject of the ID token %q.", userInfo.Subject, subject)
+		return
+	}
+
+	userInfoClaims := make(map[string]interface{})
+	if err := userInfo.Claims(&userInfoClaims); err != nil {
+		client.logger.Warn("Cannot read the user info claims of %q. Error: %s", subject, err)
+		return
+	}
+
+	for name, value := range userInfoClaims {
+		if _, exists := claims[name]; !exists {
+			claims[name] = value
+		}
+	}
+}
+
+// getUser returns the user with the subject, the name and the roles from the given claims.
+// The email address is only used as the name if the provider has verified it.
+func getUser(settings config.OIDC, claims map[string]interface{}) (User, error) {
+
+	subject, _ := claims["sub"].(string)
+	if strings.TrimSpace(subject) == "" {
+		return User{}, fmt.Errorf("The claims do not contain a subject.")
+	}
+
+	claimNames := []string{settings.Username()}
+	if emailIsVerified, _ := claims["email_verified"].(bool); emailIsVerified {
+		claimNames = append(claimNames, "email")
+	}
+
+	username := subject
+	for _, claimName := range claimNames {
+		if value, ok := claims[claimName].(string); ok && strings.TrimSpace(value) != "" {
+			username = strings.TrimSpace(value)
+			break
+		}
+	}
+
+	var roles []string
+	if settings.RolesClaim != "" {
+		value, _ := getClaim(claims, settings.RolesClaim)
+		roles = getRoles(value)
+	}
+
+	return User{Name: username, Roles: roles, Subject: subject}, nil
+}
+
+// getClaim returns the value of the claim with the given name.
+// The names of nested claims are separated by dots (e.g. "realm_access.roles").
+func getClaim(claims map[string]interface{}, name string) (interface{}, bool) {
+
+	if value, exists := claims[name]; exists {
+		return value, true
+	}
+
+	components := strings.SplitN(name, ".", 2)
+	if len(components) != 2 {
+		return nil, false
+	}
+
+	nestedClaims, ok := claims[components[0]].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	return getClaim(nestedClaims, components[1])
+}
+
+// getRoles returns the roles from the given claim value which can be
+// a list of strings or a string with comma or space separated roles.
+func getRoles(value interface{}) []string {
+
+	var roles []string
+	switch value := value.(type) {
+
+	case string:
+		roles = strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+
+	case []interface{}:
+		for _, role := range value {
+			if role, ok := role.(string); ok && strings.TrimSpace(role) != "" {
+				roles = append(roles, strings.TrimSpace(role))
+			}
+		}
+
+	}
+
+	return roles
+}
+
+// getSafeReturnPath returns the given path if it is a local path; otherwise the root path.
+func getSafeReturnPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return "/"
+	}
+
+	return path
+}
+
+// randomString returns a random URL-safe string.
+func randomString() string {
+	value := make([]byte, 24)
+	if _, err := rand.Read(value); err != nil {
+		panic(fmt.Sprintf("Cannot read random bytes. Error: %s", err))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(value)
+}
@@ -0,0 +1,323 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package oidc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+)
+
+// testApplication is a web application which logs in its users with the given mock issuer.
+type testApplication struct {
+	*httptest.Server
+	client *Client
+}
+
+// newTestApplication starts a web application which logs in its users with the given issuer.
+// The application answers every other request with the name and the roles of the user.
+func newTestApplication(t *testing.T, issuer *mockIssuer, rolesClaim string) *testApplication {
+
+	settings := config.OIDC{
+		Enabled:      true,
+		IssuerURL:    issuer.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RolesClaim:   rolesClaim,
+	}
+
+	client, err := New(console.New(loglevel.Fatal), settings, []byte(strings.Repeat("k", minimumKeyLength)))
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	application := &testApplication{client: client}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		loginURL, err := client.LoginURL(w, r, application.URL+"/login/callback", r.URL.Query().Get("return"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		http.Redirect(w, r, loginURL, http.StatusFound)
+	})
+
+	mux.HandleFunc("/login/callback", func(w http.ResponseWriter, r *http.Request) {
+		_, returnPath, err := client.Callback(w, r, application.URL+"/login/callback")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		http.Redirect(w, r, returnPath, http.StatusFound)
+	})
+
+	mux.HandleFunc("/logout", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, client.Logout(w, r, application.URL+"/"), http.StatusFound)
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		user, isLoggedIn := client.User(r)
+		if !isLoggedIn {
+			fmt.Fprintf(w, "%s anonymous", r.URL.Path)
+			return
+		}
+
+		fmt.Fprintf(w, "%s %s %v", r.URL.Path, user.Name, user.Roles)
+	})
+
+	application.Server = httptest.NewTLSServer(mux)
+	return application
+}
+
+// getBrowser returns a HTTP client with a cookie jar which trusts the certificate of the application.
+func (application *testApplication) getBrowser(t *testing.T) *http.Client {
+	browser := application.Client()
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("Cannot create the cookie jar. Error: %s", err)
+	}
+
+	browser.Jar = jar
+	return browser
+}
+
+// get requests the given URL and returns the status code and the body of the response.
+func get(t *testing.T, browser *http.Client, url string) (int, string) {
+	response, err := browser.Get(url)
+	if err != nil {
+		t.Fatalf("The request for %q failed. Error: %s", url, err)
+	}
+
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+	return response.StatusCode, strings.TrimSpace(string(body))
+}
+
+func Test_Callback_ValidLogin_UserIsLoggedInWithTheRolesOfTheClaim(t *testing.T) {
+	// arrange
+	issuer := newMockIssuer(t, map[string]interface{}{
+		"preferred_username": "alice",
+		"groups":             []string{"eng", "ops"},
+	})
+	defer issuer.Close()
+
+	application := newTestApplication(t, issuer, "groups")
+	defer application.Close()
+
+	browser := application.getBrowser(t)
+
+	// act
+	status, body := get(t, browser, application.URL+"/login?return=/internal/document")
+
+	// assert
+	if status != http.StatusOK || body != "/internal/document alice [eng ops]" {
+		t.Errorf("The user should be logged in and redirected to the requested page but the response was %d %q.", status, body)
+	}
+
+	if _, body := get(t, browser, application.URL+"/other"); body != "/other alice [eng ops]" {
+		t.Errorf("The session cookie should identify the user on subsequent requests but the response was %q.", body)
+	}
+}
+
+func Test_Callback_NonceDoesNotMatch_LoginFails(t *testing.T) {
+	// arrange
+	issuer := newMockIssuer(t, map[string]interface{}{"preferred_username": "alice"})
+	issuer.nonce = "replayed"
+	defer issuer.Close()
+
+	application := newTestApplication(t, issuer, "")
+	defer application.Close()
+
+	browser := application.getBrowser(t)
+
+	// act
+	status, _ := get(t, browser, application.URL+"/login")
+
+	// assert
+	if status != http.StatusUnauthorized {
+		t.Errorf("The login should fail if the nonce of the ID token does not match but the status was %d.", status)
+	}
+
+	if _, body := get(t, browser, application.URL+"/"); body != "/ anonymous" {
+		t.Errorf("The user should not be logged in but the response was %q.", body)
+	}
+}
+
+func Test_Callback_LoginNotStartedInThisBrowser_LoginFails(t *testing.T) {
+	// arrange
+	issuer := newMockIssuer(t, map[string]interface{}{"preferred_username": "alice"})
+	defer issuer.Close()
+
+	application := newTestApplication(t, issuer, "")
+	defer application.Close()
+
+	// start the login in one browser and complete it in another
+	attacker := application.getBrowser(t)
+	attacker.CheckRedirect = func(r *http.Request, via []*http.Request) error {
+		if r.URL.Path == "/login/callback" {
+			return http.ErrUseLastResponse
+		}
+
+		return nil
+	}
+
+	response, err := attacker.Get(application.URL + "/login")
+	if err != nil {
+		t.Fatalf("The login request failed. Error: %s", err)
+	}
+	response.Body.Close()
+
+	callbackURL := response.Header.Get("Location")
+
+	// act
+	status, _ := get(t, application.getBrowser(t), callbackURL)
+
+	// assert
+	if status != http.StatusUnauthorized {
+		t.Errorf("The login should fail without the login cookie but the status was %d.", status)
+	}
+}
+
+func Test_Logout_LoggedIn_SessionIsRemovedAndUserIsSentToTheProvider(t *testing.T) {
+	// arrange
+	issuer := newMockIssuer(t, map[string]interface{}{"preferred_username": "alice"})
+	defer issuer.Close()
+
+	application := newTestApplication(t, issuer, "")
+	defer application.Close()
+
+	browser := application.getBrowser(t)
+	get(t, browser, application.URL+"/login")
+
+	var logoutURL string
+	browser.CheckRedirect = func(r *http.Request, via []*http.Request) error {
+		logoutURL = r.URL.String()
+		return http.ErrUseLastResponse
+	}
+
+	// act
+	get(t, browser, application.URL+"/logout")
+
+	// assert
+	if !strings.HasPrefix(logoutURL, issuer.URL+"/logout?") || !strings.Contains(logoutURL, "client_id="+testClientID) {
+		t.Errorf("The user should be sent to the logout page of the provider but was sent to %q.", logoutURL)
+	}
+
+	browser.CheckRedirect = nil
+	if _, body := get(t, browser, application.URL+"/"); body != "/ anonymous" {
+		t.Errorf("The user should be logged out but the response was %q.", body)
+	}
+}
+
+func Test_User_ChangedSessionCookie_UserIsAnonymous(t *testing.T) {
+	// arrange
+	cookies := signedCookies{[]byte(strings.Repeat("k", minimumKeyLength))}
+	client := &Client{cookies: cookies}
+
+	recorder := httptest.NewRecorder()
+	cookies.set(recorder, SessionCookieName, "/", User{Name: "bob"}, time.Now().Add(time.Hour))
+	cookie := recorder.Result().Cookies()[0]
+
+	otherRecorder := httptest.NewRecorder()
+	cookies.set(otherRecorder, SessionCookieName, "/", User{Name: "alice", Roles: []string{"admin"}}, time.Now().Add(time.Hour))
+	otherCookie := otherRecorder.Result().Cookies()[0]
+
+	// combine the user of one cookie with the signature of another one
+	cookie.Value = strings.Split(otherCookie.Value, ".")[0] + "." + strings.Split(cookie.Value, ".")[1]
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.AddCookie(cookie)
+
+	// act
+	_, isLoggedIn := client.User(request)
+
+	// assert
+	if isLoggedIn {
+		t.Errorf("A session cookie with an invalid signature should not identify a user.")
+	}
+}
+
+func Test_User_ExpiredSessionCookie_UserIsAnonymous(t *testing.T) {
+	// arrange
+	cookies := signedCookies{[]byte(strings.Repeat("k", minimumKeyLength))}
+	client := &Client{cookies: cookies}
+
+	recorder := httptest.NewRecorder()
+	cookies.set(recorder, SessionCookieName, "/", User{Name: "bob"}, time.Now().Add(-time.Hour))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Cookie", strings.Split(recorder.Header().Get("Set-Cookie"), ";")[0])
+
+	// act
+	_, isLoggedIn := client.User(request)
+
+	// assert
+	if isLoggedIn {
+		t.Errorf("An expired session cookie should not identify a user.")
+	}
+}
+
+func Test_getUser_NestedRolesClaim_RolesAreReturned(t *testing.T) {
+	// arrange
+	settings := config.OIDC{RolesClaim: "realm_access.roles"}
+	claims := map[string]interface{}{
+		"sub":            "1234",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"realm_access":   map[string]interface{}{"roles": []interface{}{"eng", " ops "}},
+	}
+
+	// act
+	user, err := getUser(settings, claims)
+
+	// assert
+	if err != nil || user.Name != "alice@example.com" || strings.Join(user.Roles, ",") != "eng,ops" {
+		t.Errorf("The user should be %q with the roles %v but was %q with the roles %v (error: %v).", "alice@example.com", []string{"eng", "ops"}, user.Name, user.Roles, err)
+	}
+}
+
+func Test_getUser_UnverifiedEmail_SubjectIsTheName(t *testing.T) {
+	// arrange
+	claims := map[string]interface{}{
+		"sub":            "1234",
+		"email":          "alice@example.com",
+		"email_verified": false,
+	}
+
+	// act
+	user, err := getUser(config.OIDC{}, claims)
+
+	// assert
+	if err != nil || user.Name != "1234" || user.Subject != "1234" {
+		t.Errorf("The name and the subject of the user should be %q but were %q and %q (error: %v).", "1234", user.Name, user.Subject, err)
+	}
+}
+
+func Test_getSafeReturnPath_ExternalURL_RootIsReturned(t *testing.T) {
+	inputs := []string{"https://example.com/", "//example.com/", "/\\example.com", "documents"}
+
+	for _, input := range inputs {
+
+		// act
+		result := getSafeReturnPath(input)
+
+		// assert
+		if result != "/" {
+			t.Errorf("getSafeReturnPath(%q) returned %q but %q was expected.", input, result, "/")
+		}
+	}
+}
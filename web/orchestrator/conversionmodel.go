@@ -35,7 +35,7 @@ func (orchestrator *ConversionModelOrchestrator) GetConversionModel(baseURL stri
 	rootPathProvider := orchestrator.absolutePather(fmt.Sprintf("%s/", baseURL))
 
 	// convert content
-	convertedContent, err := orchestrator.converter.Convert(orchestrator.getItemByAlias, rootPathProvider, item)
+	convertedContent, err := orchestrator.converter.Convert(orchestrator.getItemByAlias, orchestrator.getItemByName, rootPathProvider, item)
 	if err != nil {
 		return model, false
 	}
@@ -0,0 +1,182 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package orchestrator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/services/thumbnail"
+	"github.com/andreaskoch/allmark/web/view/viewmodel"
+)
+
+type EditOrchestrator struct {
+	*Orchestrator
+
+	thumbnailService *thumbnail.ConversionService
+}
+
+// GetEditor returns the current markdown of the item with the given route together with
+// the hash of this version and a preview. All links of the preview are prefixed with the given base URL.
+func (orchestrator *EditOrchestrator) GetEditor(baseURL string, itemRoute route.Route) (editor viewmodel.Editor, found bool) {
+
+	itemWriter, isAvailable := orchestrator.itemWriter()
+	if !isAvailable || !itemWriter.CanWrite(itemRoute) {
+		return viewmodel.Editor{}, false
+	}
+
+	item := orchestrator.repository.Item(itemRoute)
+	if item == nil {
+		return viewmodel.Editor{}, false
+	}
+
+	// refresh the hash before reading the content so changes which have
+	// not been indexed yet are detected when the item is saved
+	if _, err := item.Hash(); err != nil {
+		orchestrator.logger.Warn("Cannot determine the hash of item %q. Error: %s", itemRoute, err.Error())
+		return viewmodel.Editor{}, false
+	}
+
+	var markdown []byte
+	err := item.Data(func(content io.ReadSeeker) error {
+		data, err := ioutil.ReadAll(content)
+		markdown = data
+		return err
+	})
+
+	if err != nil {
+		orchestrator.logger.Warn("Cannot read the markdown of item %q. Error: %s", itemRoute, err.Error())
+		return viewmodel.Editor{}, false
+	}
+
+	editor.Markdown = string(markdown)
+	editor.LastHash = item.LastHash()
+	editor.UploadURL = GetTypedItemURL(itemRoute, "upload")
+	editor.Preview, _ = orchestrator.GetPreview(baseURL, itemRoute, editor.Markdown)
+
+	return editor, true
+}
+
+// GetPreview returns the HTML of the given markdown as if it was the content of the item with the given route.
+func (orchestrator *EditOrchestrator) GetPreview(baseURL string, itemRoute route.Route, markdown string) (string, bool) {
+
+	item := orchestrator.repository.Item(itemRoute)
+	if item == nil {
+		return "", false
+	}
+
+	itemModel := orchestrator.parseItem(draftItem{item, []byte(markdown)})
+	if itemModel == nil {
+		return "", false
+	}
+
+	pathProvider := orchestrator.absolutePather(fmt.Sprintf("%s/", baseURL))
+	convertedContent, err := orchestrator.converter.Convert(orchestrator.getItemByAlias, orchestrator.getItemByName, pathProvider, itemModel)
+	if err != nil {
+		orchestrator.logger.Warn("Cannot convert the preview of item %q. Error: %s", itemRoute, err.Error())
+		return "", false
+	}
+
+	return convertedContent, true
+}
+
+// Save replaces the markdown of the item with the given route. The lastHash identifies the
+// version the changes are based on; dataaccess.ErrConflict is returned if the item has been
+// changed in the meantime.
+func (orchestrator *EditOrchestrator) Save(itemRoute route.Route, markdown string, lastHash string) error {
+
+	itemWriter, isAvailable := orchestrator.itemWriter()
+	if !isAvailable {
+		return fmt.Errorf("The repository cannot be edited.")
+	}
+
+	// browsers submit the text of a textarea with CRLF line breaks
+	markdown = strings.Replace(markdown, "\r\n", "\n", -1)
+
+	return itemWriter.WriteItem(itemRoute, []byte(markdown), lastHash)
+}
+
+// CanEdit indicates whether the item with the given route can be edited.
+func (orchestrator *EditOrchestrator) CanEdit(itemRoute route.Route) bool {
+	itemWriter, isAvailable := orchestrator.itemWriter()
+	return isAvailable && itemWriter.CanWrite(itemRoute)
+}
+
+// Upload adds the given file to the files of the item with the given route.
+func (orchestrator *EditOrchestrator) Upload(itemRoute route.Route, fileName string, content io.Reader) (viewmodel.Upload, error) {
+
+	itemWriter, isAvailable := orchestrator.itemWriter()
+	if !isAvailable {
+		return viewmodel.Upload{}, fmt.Errorf("The repository cannot be edited.")
+	}
+
+	filePath, err := itemWriter.WriteFile(itemRoute, getUploadFileName(fileName), content)
+	if err != nil {
+		return viewmodel.Upload{}, err
+	}
+
+	// the thumbnails must be available when the uploaded image is displayed in the preview
+	if orchestrator.thumbnailService != nil {
+		orchestrator.thumbnailService.CreateThumbnails(orchestrator.repository.Item(itemRoute))
+	}
+
+	name := path.Base(filePath)
+	markdown := fmt.Sprintf("[%s](%s)", name, filePath)
+	if model.IsImage(mime.TypeByExtension(path.Ext(name))) {
+		markdown = "!" + markdown
+	}
+
+	return viewmodel.Upload{
+		Name:     name,
+		Path:     filePath,
+		Markdown: markdown,
+	}, nil
+}
+
+// getUploadFileName returns the name of the uploaded file without the directory
+// some browsers send along and with dashes instead of whitespace so it can be used
+// in markdown links.
+func getUploadFileName(fileName string) string {
+	fileName = fileName[strings.LastIndexAny(fileName, `/\`)+1:]
+	fileName = strings.TrimSpace(fileName)
+
+	return strings.Map(func(character rune) rune {
+		if unicode.IsSpace(character) {
+			return '-'
+		}
+
+		return character
+	}, fileName)
+}
+
+// itemWriter returns the item writer of the repository if editing is enabled.
+func (orchestrator *Orchestrator) itemWriter() (dataaccess.ItemWriter, bool) {
+	if !orchestrator.config.EditingIsEnabled() {
+		return nil, false
+	}
+
+	itemWriter, isItemWriter := orchestrator.repository.(dataaccess.ItemWriter)
+	return itemWriter, isItemWriter
+}
+
+// draftItem is a repository item whose markdown has been replaced with an unsaved draft.
+type draftItem struct {
+	dataaccess.Item
+
+	markdown []byte
+}
+
+func (item draftItem) Data(contentReader func(content io.ReadSeeker) error) error {
+	return contentReader(bytes.NewReader(item.markdown))
+}
@@ -0,0 +1,30 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package orchestrator
+
+import (
+	"testing"
+)
+
+func Test_getUploadFileName_PathAndWhitespaceAreRemoved(t *testing.T) {
+	// arrange
+	fileNames := map[string]string{
+		"image.png":                       "image.png",
+		"Screenshot 2015-06-01 10.12.png": "Screenshot-2015-06-01-10.12.png",
+		`C:\Users\me\Report.pdf`:          "Report.pdf",
+		"/home/me/notes.txt ":             "notes.txt",
+	}
+
+	for fileName, expected := range fileNames {
+
+		// act
+		result := getUploadFileName(fileName)
+
+		// assert
+		if result != expected {
+			t.Errorf("getUploadFileName(%q) should return %q but returned %q.", fileName, expected, result)
+		}
+	}
+}
@@ -8,12 +8,17 @@ import (
 	"github.com/andreaskoch/allmark/common/config"
 	"github.com/andreaskoch/allmark/common/logger"
 	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/model"
 	"github.com/andreaskoch/allmark/services/converter"
 	"github.com/andreaskoch/allmark/services/parser"
+	"github.com/andreaskoch/allmark/services/thumbnail"
 	"github.com/andreaskoch/allmark/web/webpaths"
 )
 
-func NewFactory(logger logger.Logger, config config.Config, repository dataaccess.Repository, parser parser.Parser, converter converter.Converter, webPathProvider webpaths.WebPathProvider) *Factory {
+// NewFactory creates a factory for the orchestrators of the given repository. The thumbnail index
+// contains the thumbnails of the image files and the thumbnail service
+// creates the thumbnails of uploaded images; it is nil if thumbnails are disabled.
+func NewFactory(logger logger.Logger, config config.Config, repository dataaccess.Repository, parser parser.Parser, converter converter.Converter, webPathProvider webpaths.WebPathProvider, thumbnailIndex *thumbnail.Index, thumbnailService *thumbnail.ConversionService) *Factory {
 
 	baseOrchestrator := newBaseOrchestrator(logger, config, repository, parser, converter, webPathProvider)
 
@@ -25,6 +30,7 @@ func NewFactory(logger logger.Logger, config config.Config, repository dataacces
 		for update := range repositoryUpdates {
 			logger.Info("Received and update (%s). Resetting the the cache.", update.String())
 			baseOrchestrator.UpdateCache(update)
+			baseOrchestrator.updateFilteredOrchestrators(update)
 		}
 	}()
 
@@ -32,6 +38,22 @@ func NewFactory(logger logger.Logger, config config.Config, repository dataacces
 		logger: logger,
 
 		baseOrchestrator: baseOrchestrator,
+		thumbnailIndex:   thumbnailIndex,
+		thumbnailService: thumbnailService,
+	}
+}
+
+// NewFilteredFactory creates a factory for orchestrators which only contain the items that pass
+// the given filter and whose parents pass it. Items which are hidden from the filtered orchestrators
+// do not appear in the navigation, search, tags, sitemaps, feeds or any other list.
+// The name must be unique; it separates the full-text index from the other orchestrators.
+func (factory *Factory) NewFilteredFactory(name string, filter func(item *model.Item) bool) *Factory {
+	return &Factory{
+		logger: factory.logger,
+
+		baseOrchestrator: newFilteredOrchestrator(factory.baseOrchestrator, name, filter),
+		thumbnailIndex:   factory.thumbnailIndex,
+		thumbnailService: factory.thumbnailService,
 	}
 }
 
@@ -39,11 +61,15 @@ type Factory struct {
 	logger logger.Logger
 
 	baseOrchestrator *Orchestrator
+	thumbnailIndex   *thumbnail.Index
+	thumbnailService *thumbnail.ConversionService
 
 	viewModelOrchestrator             *ViewModelOrchestrator
 	conversionModelOrchestrator       *ConversionModelOrchestrator
 	feedOrchestrator                  *FeedOrchestrator
 	fileOrchestrator                  *FileOrchestrator
+	historyOrchestrator               *HistoryOrchestrator
+	editOrchestrator                  *EditOrchestrator
 	navigationOrchestrator            *NavigationOrchestrator
 	openSearchDescriptionOrchestrator *OpenSearchDescriptionOrchestrator
 	searchOrchestrator                *SearchOrchestrator
@@ -52,6 +78,7 @@ type Factory struct {
 	xmlSitemapOrchestrator            *XmlSitemapOrchestrator
 	typeAheadOrchestrator             *TypeAheadOrchestrator
 	titlesOrchestrator                *TitlesOrchestrator
+	linksOrchestrator                 *LinksOrchestrator
 	updateOrchestrator                *UpdateOrchestrator
 }
 
@@ -88,12 +115,40 @@ func (factory *Factory) NewFileOrchestrator() *FileOrchestrator {
 	}
 
 	factory.fileOrchestrator = &FileOrchestrator{
-		Orchestrator: factory.baseOrchestrator,
+		Orchestrator:   factory.baseOrchestrator,
+		thumbnailIndex: factory.thumbnailIndex,
 	}
 
 	return factory.fileOrchestrator
 }
 
+func (factory *Factory) NewHistoryOrchestrator() *HistoryOrchestrator {
+
+	if factory.historyOrchestrator != nil {
+		return factory.historyOrchestrator
+	}
+
+	factory.historyOrchestrator = &HistoryOrchestrator{
+		Orchestrator: factory.baseOrchestrator,
+	}
+
+	return factory.historyOrchestrator
+}
+
+func (factory *Factory) NewEditOrchestrator() *EditOrchestrator {
+
+	if factory.editOrchestrator != nil {
+		return factory.editOrchestrator
+	}
+
+	factory.editOrchestrator = &EditOrchestrator{
+		Orchestrator:     factory.baseOrchestrator,
+		thumbnailService: factory.thumbnailService,
+	}
+
+	return factory.editOrchestrator
+}
+
 func (factory *Factory) NewNavigationOrchestrator() *NavigationOrchestrator {
 
 	if factory.navigationOrchestrator != nil {
@@ -129,6 +184,9 @@ func (factory *Factory) NewSearchOrchestrator() *SearchOrchestrator {
 		Orchestrator: factory.baseOrchestrator,
 	}
 
+	// load the full-text index in the background
+	go factory.baseOrchestrator.itemSearch()
+
 	return factory.searchOrchestrator
 }
 
@@ -218,6 +276,19 @@ func (factory *Factory) NewTitlesOrchestrator() *TitlesOrchestrator {
 	return factory.titlesOrchestrator
 }
 
+func (factory *Factory) NewLinksOrchestrator() *LinksOrchestrator {
+
+	if factory.linksOrchestrator != nil {
+		return factory.linksOrchestrator
+	}
+
+	factory.linksOrchestrator = &LinksOrchestrator{
+		Orchestrator: factory.baseOrchestrator,
+	}
+
+	return factory.linksOrchestrator
+}
+
 func (factory *Factory) NewUpdateOrchestrator() *UpdateOrchestrator {
 	if factory.updateOrchestrator != nil {
 		return factory.updateOrchestrator
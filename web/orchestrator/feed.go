@@ -78,7 +78,7 @@ func (orchestrator *FeedOrchestrator) createFeedEntryModel(baseURL string, item
 	location := rootPathProvider.Path(item.Route().Value())
 
 	// content
-	content, err := orchestrator.converter.Convert(orchestrator.getItemByAlias, rootPathProvider, item)
+	content, err := orchestrator.converter.Convert(orchestrator.getItemByAlias, orchestrator.getItemByName, rootPathProvider, item)
 	if err != nil {
 		content = err.Error()
 	}
@@ -8,13 +8,38 @@ import (
 	"github.com/andreaskoch/allmark/common/content"
 	"github.com/andreaskoch/allmark/common/paths"
 	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/common/util/fsutil"
 	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/services/thumbnail"
 	"github.com/andreaskoch/allmark/web/view/viewmodel"
 	"fmt"
 )
 
 type FileOrchestrator struct {
 	*Orchestrator
+
+	thumbnailIndex *thumbnail.Index
+}
+
+// GetThumbnailFilePath returns the path of the thumbnail with the given file name
+// (e.g. "25-F4D6192C-320-240.png") if the file it belongs to is available.
+func (orchestrator *FileOrchestrator) GetThumbnailFilePath(name string) (path string, found bool) {
+	if orchestrator.thumbnailIndex == nil {
+		return "", false
+	}
+
+	thumb, exists := orchestrator.thumbnailIndex.GetThumbByPath(name)
+	if !exists {
+		return "", false
+	}
+
+	// the file is not available if it has been removed or is hidden by the filter of the orchestrator
+	if orchestrator.getFile(route.NewFromRequest(thumb.Route)) == nil {
+		return "", false
+	}
+
+	path = orchestrator.thumbnailIndex.GetThumbnailFilepath(thumb)
+	return path, fsutil.FileExists(path)
 }
 
 func (orchestrator *FileOrchestrator) GetFileContentProvider(fileRoute route.Route) content.ContentProviderInterface {
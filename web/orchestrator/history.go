@@ -0,0 +1,232 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package orchestrator
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/web/view/viewmodel"
+)
+
+// the number of characters of abbreviated revision ids
+const shortRevisionIdLength = 7
+
+// hunk headers of unified diffs (e.g. "@@ -1,3 +1,4 @@ optional section")
+var diffHunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+type HistoryOrchestrator struct {
+	*Orchestrator
+}
+
+// IsAvailable indicates whether the repository provides a revision history.
+func (orchestrator *HistoryOrchestrator) IsAvailable() bool {
+	_, isAvailable := orchestrator.historyProvider()
+	return isAvailable
+}
+
+// GetHistory returns the revisions of the item with the given route. If a from- and to-revision
+// are supplied the history also contains the diff between these two revisions.
+func (orchestrator *HistoryOrchestrator) GetHistory(itemRoute route.Route, fromRevision, toRevision string) (history viewmodel.History, found bool) {
+
+	historyProvider, isAvailable := orchestrator.historyProvider()
+	if !isAvailable {
+		return viewmodel.History{}, false
+	}
+
+	revisions, err := historyProvider.Revisions(itemRoute)
+	if err != nil {
+		orchestrator.logger.Warn("Cannot read the history of item %q. Error: %s", itemRoute, err.Error())
+		return viewmodel.History{}, false
+	}
+
+	history.Revisions = make([]viewmodel.Revision, 0, len(revisions))
+	for index, revision := range revisions {
+		revisionModel := getRevisionModel(revision)
+
+		// link to the changes compared to the previous revision
+		if index+1 < len(revisions) {
+			revisionModel.DiffURL = getDiffURL(itemRoute, revisions[index+1].Id, revision.Id)
+		}
+
+		history.Revisions = append(history.Revisions, revisionModel)
+	}
+
+	if fromRevision == "" && toRevision == "" {
+		return history, true
+	}
+
+	// only revisions of this item can be compared
+	from, fromExists := findRevision(history.Revisions, fromRevision)
+	to, toExists := findRevision(history.Revisions, toRevision)
+	if !fromExists || !toExists {
+		return viewmodel.History{}, false
+	}
+
+	diff, err := historyProvider.Diff(itemRoute, from.Id, to.Id)
+	if err != nil {
+		orchestrator.logger.Warn("Cannot determine the changes of item %q between %q and %q. Error: %s", itemRoute, from.Id, to.Id, err.Error())
+		return viewmodel.History{}, false
+	}
+
+	history.Diff = &viewmodel.Diff{
+		From:  from,
+		To:    to,
+		Files: parseUnifiedDiff(diff),
+	}
+
+	return history, true
+}
+
+// historyProvider returns the history provider of the repository if a revision history is available.
+func (orchestrator *Orchestrator) historyProvider() (dataaccess.HistoryProvider, bool) {
+	historyProvider, isHistoryProvider := orchestrator.repository.(dataaccess.HistoryProvider)
+	if !isHistoryProvider || !historyProvider.HasHistory() {
+		return nil, false
+	}
+
+	return historyProvider, true
+}
+
+func getRevisionModel(revision dataaccess.Revision) viewmodel.Revision {
+
+	subject, message := revision.Message, ""
+	if lineBreak := strings.Index(revision.Message, "\n"); lineBreak != -1 {
+		subject = revision.Message[:lineBreak]
+		message = strings.TrimSpace(revision.Message[lineBreak+1:])
+	}
+
+	shortId := revision.Id
+	if len(shortId) > shortRevisionIdLength {
+		shortId = shortId[:shortRevisionIdLength]
+	}
+
+	return viewmodel.Revision{
+		Id:          revision.Id,
+		ShortId:     shortId,
+		Author:      revision.Author,
+		AuthorEmail: revision.AuthorEmail,
+		Date:        revision.Date.Format("2006-01-02 15:04"),
+		Subject:     subject,
+		Message:     message,
+	}
+}
+
+// findRevision returns the revision with the given (abbreviated) id.
+func findRevision(revisions []viewmodel.Revision, id string) (viewmodel.Revision, bool) {
+	if len(id) < shortRevisionIdLength {
+		return viewmodel.Revision{}, false
+	}
+
+	for _, revision := range revisions {
+		if strings.HasPrefix(revision.Id, id) {
+			return revision, true
+		}
+	}
+
+	return viewmodel.Revision{}, false
+}
+
+// getDiffURL returns the URL of the changes of the given item between the two revisions.
+func getDiffURL(itemRoute route.Route, fromRevision, toRevision string) string {
+	parameters := url.Values{}
+	parameters.Set("from", fromRevision)
+	parameters.Set("to", toRevision)
+	return fmt.Sprintf("%s?%s", GetTypedItemURL(itemRoute, "history"), parameters.Encode())
+}
+
+// parseUnifiedDiff converts the output of "git diff" into diff files.
+func parseUnifiedDiff(diff string) []viewmodel.DiffFile {
+
+	files := make([]viewmodel.DiffFile, 0)
+
+	var file *viewmodel.DiffFile
+	oldNumber, newNumber := 0, 0
+	isHeader := false
+
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+
+		// start of a new file
+		if strings.HasPrefix(line, "diff --git ") {
+			if file != nil {
+				files = append(files, *file)
+			}
+
+			file = &viewmodel.DiffFile{
+				Name:  getDiffFileName(line),
+				Lines: make([]viewmodel.DiffLine, 0),
+			}
+
+			isHeader = true
+			continue
+		}
+
+		if file == nil {
+			continue
+		}
+
+		// hunks
+		if matches := diffHunkHeaderPattern.FindStringSubmatch(line); matches != nil {
+			oldNumber, _ = strconv.Atoi(matches[1])
+			newNumber, _ = strconv.Atoi(matches[2])
+			isHeader = false
+
+			file.Lines = append(file.Lines, viewmodel.DiffLine{Type: "hunk", Text: line})
+			continue
+		}
+
+		// file header (index, mode, rename and ---/+++ lines)
+		if isHeader {
+			if strings.HasPrefix(line, "Binary files ") {
+				file.IsBinary = true
+			}
+
+			if strings.HasPrefix(line, "+++ ") && !strings.HasSuffix(line, "/dev/null") {
+				file.Name = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+			}
+
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+"):
+			file.Lines = append(file.Lines, viewmodel.DiffLine{Type: "added", NewNumber: newNumber, Text: line[1:]})
+			newNumber++
+
+		case strings.HasPrefix(line, "-"):
+			file.Lines = append(file.Lines, viewmodel.DiffLine{Type: "removed", OldNumber: oldNumber, Text: line[1:]})
+			oldNumber++
+
+		case strings.HasPrefix(line, " ") || line == "":
+			file.Lines = append(file.Lines, viewmodel.DiffLine{Type: "context", OldNumber: oldNumber, NewNumber: newNumber, Text: strings.TrimPrefix(line, " ")})
+			oldNumber++
+			newNumber++
+
+		}
+
+		// other lines (e.g. "\ No newline at end of file") are ignored
+	}
+
+	if file != nil {
+		files = append(files, *file)
+	}
+
+	return files
+}
+
+// getDiffFileName returns the name of the changed file from a "diff --git a/name b/name" line.
+func getDiffFileName(line string) string {
+	names := strings.TrimPrefix(line, "diff --git ")
+	if separator := strings.LastIndex(names, " b/"); separator != -1 {
+		return names[separator+len(" b/"):]
+	}
+
+	return names
+}
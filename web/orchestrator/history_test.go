@@ -0,0 +1,98 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/andreaskoch/allmark/web/view/viewmodel"
+)
+
+func Test_parseUnifiedDiff_LinesAreNumbered(t *testing.T) {
+	// arrange
+	diff := `diff --git a/documents/readme.md b/documents/readme.md
+index 1111111..2222222 100644
+--- a/documents/readme.md
++++ b/documents/readme.md
+@@ -2,3 +2,3 @@ Title
+ first
+-second
++second (changed)
+ --- third
+\ No newline at end of file
+diff --git a/documents/files/image.png b/documents/files/image.png
+new file mode 100644
+index 0000000..3333333
+Binary files /dev/null and b/documents/files/image.png differ
+`
+
+	// act
+	files := parseUnifiedDiff(diff)
+
+	// assert
+	if len(files) != 2 {
+		t.Fatalf("parseUnifiedDiff should return 2 files but returned %d.", len(files))
+	}
+
+	expectedLines := []viewmodel.DiffLine{
+		{Type: "hunk", Text: "@@ -2,3 +2,3 @@ Title"},
+		{Type: "context", OldNumber: 2, NewNumber: 2, Text: "first"},
+		{Type: "removed", OldNumber: 3, Text: "second"},
+		{Type: "added", NewNumber: 3, Text: "second (changed)"},
+		{Type: "context", OldNumber: 4, NewNumber: 4, Text: "--- third"},
+	}
+
+	if files[0].Name != "documents/readme.md" {
+		t.Errorf("The name of the first file should be %q but was %q.", "documents/readme.md", files[0].Name)
+	}
+
+	if len(files[0].Lines) != len(expectedLines) {
+		t.Fatalf("The first file should have %d lines but has %d: %v", len(expectedLines), len(files[0].Lines), files[0].Lines)
+	}
+
+	for index, expectedLine := range expectedLines {
+		if files[0].Lines[index] != expectedLine {
+			t.Errorf("Line %d should be %v but was %v.", index, expectedLine, files[0].Lines[index])
+		}
+	}
+
+	if !files[1].IsBinary || files[1].Name != "documents/files/image.png" {
+		t.Errorf("The second file should be the binary file %q but was %v.", "documents/files/image.png", files[1])
+	}
+}
+
+func Test_findRevision_AbbreviatedId_RevisionIsFound(t *testing.T) {
+	// arrange
+	revisions := []viewmodel.Revision{
+		{Id: "54c924f1827eec5253787559c65bd9686d69b896"},
+		{Id: "5203837e024c684e3fd180973380df8361be6868"},
+	}
+
+	// act
+	revision, found := findRevision(revisions, "5203837")
+
+	// assert
+	if !found || revision.Id != revisions[1].Id {
+		t.Errorf("findRevision should return %q but returned %q (found: %v).", revisions[1].Id, revision.Id, found)
+	}
+}
+
+func Test_findRevision_UnknownOrTooShortId_RevisionIsNotFound(t *testing.T) {
+	// arrange
+	revisions := []viewmodel.Revision{
+		{Id: "54c924f1827eec5253787559c65bd9686d69b896"},
+	}
+
+	for _, id := range []string{"", "5", "ffffffff"} {
+
+		// act
+		_, found := findRevision(revisions, id)
+
+		// assert
+		if found {
+			t.Errorf("findRevision(%q) should not find a revision.", id)
+		}
+	}
+}
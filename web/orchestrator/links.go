@@ -0,0 +1,331 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package orchestrator
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/andreaskoch/allmark/common/markdown"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/web/view/viewmodel"
+)
+
+var (
+	// [reference:alias]
+	referenceLinkPattern = regexp.MustCompile(`\[reference:([^\]]+)\]`)
+
+	// [[Title]], [[alias|text]]
+	wikiLinkPattern = regexp.MustCompile(`\[\[([^\]|]+)(?:\|([^\]]+))?\]\]`)
+
+	// [text](target), [text](target "title"), [text](<target>)
+	markdownLinkPattern = regexp.MustCompile(`\]\([ \t]*<?([^\s)>]+)>?(?:[ \t]+["'(][^)]*)?\)`)
+
+	// [id]: target "title"
+	markdownLinkDefinitionPattern = regexp.MustCompile(`(?m)^[ \t]{0,3}\[[^\]]+\]:[ \t]*<?([^\s>]+)>?`)
+
+	// <a href="target">
+	htmlLinkTargetPattern = regexp.MustCompile(`(?i)<a\s[^>]*href=["']([^"']+)["']`)
+)
+
+// LinksOrchestrator provides the links between the items of the repository.
+type LinksOrchestrator struct {
+	*Orchestrator
+}
+
+// GetLinkGraph returns all items and the links between them.
+func (orchestrator *LinksOrchestrator) GetLinkGraph() viewmodel.LinkGraph {
+
+	itemPathProvider := orchestrator.absolutePather("/")
+
+	graph := viewmodel.LinkGraph{
+		Nodes: make([]viewmodel.LinkGraphNode, 0),
+		Links: make([]viewmodel.LinkGraphLink, 0),
+	}
+
+	for _, item := range orchestrator.getAllItems() {
+		graph.Nodes = append(graph.Nodes, viewmodel.LinkGraphNode{
+			Route: itemPathProvider.Path(item.Route().Value()),
+			Title: item.Title,
+		})
+	}
+
+	linkGraph := orchestrator.getLinkGraph()
+	for _, sourceKey := range linkGraph.sortedSources() {
+		for _, target := range linkGraph.targets[sourceKey] {
+			graph.Links = append(graph.Links, viewmodel.LinkGraphLink{
+				Source: itemPathProvider.Path(linkGraph.routes[sourceKey].Value()),
+				Target: itemPathProvider.Path(target.Value()),
+			})
+		}
+	}
+
+	return graph
+}
+
+// getReferencingItems returns all items which link to the item with the given route.
+func (orchestrator *Orchestrator) getReferencingItems(itemRoute route.Route) []*model.Item {
+
+	var items []*model.Item
+	for _, sourceRoute := range orchestrator.getLinkGraph().sources[route.ToKey(itemRoute)] {
+		if item := orchestrator.getItem(sourceRoute); item != nil {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// getLinkGraph returns the resolved links between all items.
+// The graph is rebuilt from the link index after the repository has changed.
+func (orchestrator *Orchestrator) getLinkGraph() *linkGraph {
+
+	links := orchestrator.itemLinks()
+
+	links.Lock()
+	defer links.Unlock()
+
+	if links.graph != nil {
+		return links.graph
+	}
+
+	graph := &linkGraph{
+		routes:  make(map[string]route.Route),
+		targets: make(map[string][]route.Route),
+		sources: make(map[string][]route.Route),
+	}
+
+	for sourceKey, sourceLinks := range links.linksByRoute {
+
+		sourceRoute := sourceLinks.route
+		graph.routes[sourceKey] = sourceRoute
+
+		linkedRoutes := make(map[string]bool)
+		for _, link := range sourceLinks.links {
+
+			linkedItem := orchestrator.resolveItemLink(link)
+			if linkedItem == nil {
+				continue
+			}
+
+			// ignore links to the item itself and duplicate links
+			targetRoute := linkedItem.Route()
+			targetKey := route.ToKey(targetRoute)
+			if targetKey == sourceKey || linkedRoutes[targetKey] {
+				continue
+			}
+
+			linkedRoutes[targetKey] = true
+			graph.targets[sourceKey] = append(graph.targets[sourceKey], targetRoute)
+			graph.sources[targetKey] = append(graph.sources[targetKey], sourceRoute)
+		}
+	}
+
+	// sort the sources so the referencing items are always listed in the same order
+	for _, sourceRoutes := range graph.sources {
+		sort.Sort(routesByValue(sourceRoutes))
+	}
+
+	links.graph = graph
+	return links.graph
+}
+
+// resolveItemLink returns the item the given link points to or nil if there is no such item.
+func (orchestrator *Orchestrator) resolveItemLink(link itemLink) *model.Item {
+	if link.alias != "" {
+		return orchestrator.getItemByAlias(link.alias)
+	}
+
+	if link.name != "" {
+		return orchestrator.getItemByName(link.name)
+	}
+
+	return orchestrator.getItem(link.route)
+}
+
+// itemLinks returns the index of the links of all items. The index is created on first
+// use and afterwards only the links of the new, modified and deleted items are updated.
+func (orchestrator *Orchestrator) itemLinks() *linkIndex {
+
+	orchestrator.linkIndexLock.Lock()
+	defer orchestrator.linkIndexLock.Unlock()
+
+	if orchestrator.linkIndex != nil {
+		return orchestrator.linkIndex
+	}
+
+	// updateLinks updates the links of the item with the given route.
+	updateLinks := func(updatedRoute route.Route) {
+		item := orchestrator.getItem(updatedRoute)
+		if item == nil {
+			orchestrator.logger.Warn("Cannot update the links of %q. The item was not found.", updatedRoute.String())
+			return
+		}
+
+		orchestrator.linkIndex.Update(item)
+	}
+
+	// removeLinks removes the links of the item with the given route.
+	removeLinks := func(deletedRoute route.Route) {
+		orchestrator.linkIndex.Remove(deletedRoute)
+	}
+
+	// build the index
+	links := newLinkIndex()
+	for _, item := range orchestrator.getAllItems() {
+		links.Update(item)
+	}
+
+	orchestrator.linkIndex = links
+
+	// register update callbacks
+	orchestrator.registerUpdateCallback("update link index", UpdateTypeNew, updateLinks)
+	orchestrator.registerUpdateCallback("update link index", UpdateTypeModified, updateLinks)
+	orchestrator.registerUpdateCallback("update link index", UpdateTypeDeleted, removeLinks)
+
+	return orchestrator.linkIndex
+}
+
+// newLinkIndex creates a new, empty link index.
+func newLinkIndex() *linkIndex {
+	return &linkIndex{
+		linksByRoute: make(map[string]sourceLinks),
+	}
+}
+
+// linkIndex contains the (unresolved) links of all items.
+type linkIndex struct {
+	sync.Mutex
+
+	linksByRoute map[string]sourceLinks
+
+	// the resolved links (nil if the index has changed since the graph was built)
+	graph *linkGraph
+}
+
+// Update replaces the links of the given item.
+func (index *linkIndex) Update(item *model.Item) {
+	links := getItemLinks(item)
+
+	index.Lock()
+	defer index.Unlock()
+
+	index.linksByRoute[route.ToKey(item.Route())] = sourceLinks{item.Route(), links}
+
+	// aliases and routes of other items might have changed as well
+	index.graph = nil
+}
+
+// Remove removes the links of the item with the given route.
+func (index *linkIndex) Remove(itemRoute route.Route) {
+	index.Lock()
+	defer index.Unlock()
+
+	delete(index.linksByRoute, route.ToKey(itemRoute))
+	index.graph = nil
+}
+
+// sourceLinks are the links of a single item.
+type sourceLinks struct {
+	route route.Route
+	links []itemLink
+}
+
+// itemLink points to the alias, the name (see model.Item.HasName) or the route of another item.
+type itemLink struct {
+	alias string
+	name  string
+	route route.Route
+}
+
+// linkGraph contains the links between existing items by route key.
+type linkGraph struct {
+	routes  map[string]route.Route
+	targets map[string][]route.Route
+	sources map[string][]route.Route
+}
+
+// sortedSources returns the keys of all items with links in alphabetical order.
+func (graph *linkGraph) sortedSources() []string {
+	keys := make([]string, 0, len(graph.targets))
+	for key := range graph.targets {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// getItemLinks returns the references and internal links outside of the code in the markdown of the given item.
+func getItemLinks(item *model.Item) []itemLink {
+
+	var links []itemLink
+
+	// links in code are examples
+	content := markdown.RemoveCode(item.Content)
+
+	for _, match := range referenceLinkPattern.FindAllStringSubmatch(content, -1) {
+		links = append(links, itemLink{alias: strings.TrimSpace(match[1])})
+	}
+
+	for _, match := range wikiLinkPattern.FindAllStringSubmatch(content, -1) {
+		links = append(links, itemLink{name: strings.TrimSpace(match[1])})
+	}
+
+	for _, pattern := range []*regexp.Regexp{markdownLinkPattern, markdownLinkDefinitionPattern, htmlLinkTargetPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			if link, isInternal := getItemLink(item.Route(), match[1]); isInternal {
+				links = append(links, link)
+			}
+		}
+	}
+
+	return links
+}
+
+// getItemLink returns the link for the given link target of the item with the given route.
+// Relative targets are relative to the item; short links (e.g. "/!alias") point to an alias.
+// Returns false if the target is an external URL or an anchor on the same page.
+func getItemLink(itemRoute route.Route, target string) (itemLink, bool) {
+
+	targetURL, err := url.Parse(strings.TrimSpace(target))
+	if err != nil || targetURL.Scheme != "" || targetURL.Host != "" || targetURL.Path == "" {
+		return itemLink{}, false
+	}
+
+	linkPath := targetURL.Path
+	if !strings.HasPrefix(linkPath, "/") {
+		linkPath = path.Join("/"+itemRoute.OriginalValue(), linkPath)
+	}
+
+	linkPath = strings.TrimPrefix(path.Clean(linkPath), "/")
+
+	// short link
+	if strings.HasPrefix(linkPath, "!") && !strings.Contains(linkPath, "/") {
+		return itemLink{alias: strings.TrimPrefix(linkPath, "!")}, true
+	}
+
+	return itemLink{route: route.NewFromRequest(linkPath)}, true
+}
+
+// routesByValue sorts routes alphabetically.
+type routesByValue []route.Route
+
+func (routes routesByValue) Len() int {
+	return len(routes)
+}
+
+func (routes routesByValue) Swap(i, j int) {
+	routes[i], routes[j] = routes[j], routes[i]
+}
+
+func (routes routesByValue) Less(i, j int) bool {
+	return routes[i].Value() < routes[j].Value()
+}
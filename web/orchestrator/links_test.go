@@ -0,0 +1,126 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/model"
+)
+
+func Test_getItemLink_RelativeTarget_RouteIsRelativeToItem(t *testing.T) {
+	// arrange
+	itemRoute := route.NewFromRequest("documents/first")
+	inputs := map[string]string{
+		"second":             "documents/first/second",
+		"../second#a-header": "documents/second",
+		"/notes/third?x=1":   "notes/third",
+		"../../../../fourth": "fourth",
+	}
+
+	for target, expected := range inputs {
+
+		// act
+		link, isInternal := getItemLink(itemRoute, target)
+
+		// assert
+		if !isInternal {
+			t.Errorf("getItemLink(%q, %q) should return an internal link.", itemRoute, target)
+			continue
+		}
+
+		if link.route.Value() != expected {
+			t.Errorf("getItemLink(%q, %q) should return the route %q but returned %q.", itemRoute, target, expected, link.route.Value())
+		}
+	}
+}
+
+func Test_getItemLink_ShortLink_AliasIsReturned(t *testing.T) {
+	// arrange
+	itemRoute := route.NewFromRequest("documents/first")
+
+	// act
+	link, isInternal := getItemLink(itemRoute, "/!some-alias")
+
+	// assert
+	if !isInternal || link.alias != "some-alias" {
+		t.Errorf("getItemLink should return a link to the alias %q but returned %#v.", "some-alias", link)
+	}
+}
+
+func Test_getItemLink_ExternalOrAnchor_NoLinkIsReturned(t *testing.T) {
+	// arrange
+	itemRoute := route.NewFromRequest("documents/first")
+	targets := []string{
+		"http://example.com/documents/second",
+		"//example.com/documents",
+		"mailto:someone@example.com",
+		"#a-header",
+	}
+
+	for _, target := range targets {
+
+		// act
+		_, isInternal := getItemLink(itemRoute, target)
+
+		// assert
+		if isInternal {
+			t.Errorf("getItemLink(%q, %q) should not return an internal link.", itemRoute, target)
+		}
+	}
+}
+
+func Test_getItemLinks_MarkdownWithLinks_AllLinksAreReturned(t *testing.T) {
+	// arrange
+	item := model.NewItem(route.NewFromRequest("documents/first"), nil, dataaccess.TypePhysical)
+	item.Content = `See [reference:second], [[Sixth Item|the sixth one]] and [the third one](../third "Third").
+
+![An image](files/image.png)
+
+Read [more][more] or <a href="/fourth">the fourth</a> but not [this](http://example.com).
+
+[more]: /fifth
+`
+	expected := []string{"alias:second", "name:Sixth Item", "documents/third", "documents/first/files/image.png", "fifth", "fourth"}
+
+	// act
+	links := getItemLinks(item)
+
+	// assert
+	if len(links) != len(expected) {
+		t.Fatalf("getItemLinks should return %d links but returned %d: %#v", len(expected), len(links), links)
+	}
+
+	for index, link := range links {
+		result := link.route.Value()
+		if link.alias != "" {
+			result = "alias:" + link.alias
+		}
+
+		if link.name != "" {
+			result = "name:" + link.name
+		}
+
+		if result != expected[index] {
+			t.Errorf("Link %d should be %q but was %q.", index, expected[index], result)
+		}
+	}
+}
+
+func Test_getItemLinks_LinksOnlyInCode_NoLinksAreReturned(t *testing.T) {
+	// arrange
+	item := model.NewItem(route.NewFromRequest("documents/first"), nil, dataaccess.TypePhysical)
+	item.Content = "Write `[reference:second]` or `[[Third]]` to link an item.\n\n```markdown\nSee [the fourth one](/fourth).\n\n[more]: /fifth\n```\n"
+
+	// act
+	links := getItemLinks(item)
+
+	// assert
+	if len(links) != 0 {
+		t.Errorf("getItemLinks should not return the links in code but returned %#v.", links)
+	}
+}
@@ -6,6 +6,9 @@ package orchestrator
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/andreaskoch/allmark/common/config"
@@ -125,6 +128,8 @@ func newBaseOrchestrator(logger logger.Logger, config config.Config, repository
 
 		webPathProvider: webPathProvider,
 
+		searchIndexFolder: config.SearchIndexFolder(),
+
 		updateSubscribers: make([]chan Update, 0),
 		updateCallbacks:   make(map[UpdateType][]CacheUpdateCallback),
 	}
@@ -132,6 +137,23 @@ func newBaseOrchestrator(logger logger.Logger, config config.Config, repository
 	return orchestrator
 }
 
+// newFilteredOrchestrator creates an orchestrator which only contains the items of the given source
+// orchestrator that pass the filter. The name separates the full-text index from the other orchestrators.
+func newFilteredOrchestrator(source *Orchestrator, name string, filter func(item *model.Item) bool) *Orchestrator {
+
+	orchestrator := newBaseOrchestrator(source.logger, source.config, source.repository, source.parser, source.converter, source.webPathProvider)
+	orchestrator.source = source
+	orchestrator.filter = filter
+	orchestrator.searchIndexFolder = filepath.Join(source.searchIndexFolder, name)
+
+	// receive the updates of the source
+	source.filteredOrchestratorsLock.Lock()
+	source.filteredOrchestrators = append(source.filteredOrchestrators, orchestrator)
+	source.filteredOrchestratorsLock.Unlock()
+
+	return orchestrator
+}
+
 type Orchestrator struct {
 	logger logger.Logger
 
@@ -142,10 +164,22 @@ type Orchestrator struct {
 
 	webPathProvider webpaths.WebPathProvider
 
+	searchIndexFolder string
+
+	// filtering (only the items of the source which pass the filter are visible)
+	source *Orchestrator
+	filter func(item *model.Item) bool
+
+	filteredOrchestrators     []*Orchestrator
+	filteredOrchestratorsLock sync.Mutex
+
 	// caches and indizes (do not initialize!)
-	fulltextIndex   *search.ItemSearch
-	repositoryIndex *index.Index
-	itemsByAlias    ItemCache
+	fulltextIndex     *search.ItemSearch
+	fulltextIndexLock sync.Mutex
+	repositoryIndex   *index.Index
+	itemsByAlias      ItemCache
+	linkIndex         *linkIndex
+	linkIndexLock     sync.Mutex
 
 	// update handling
 	updateCallbacks   map[UpdateType][]CacheUpdateCallback
@@ -165,6 +199,14 @@ func (orchestrator *Orchestrator) Subscribe(update chan Update) {
 // Update all caches
 func (orchestrator *Orchestrator) UpdateCache(dataaccessLayerUpdate dataaccess.Update) {
 
+	// translate the update of the source into an update of the visible items
+	if orchestrator.source != nil {
+		dataaccessLayerUpdate = orchestrator.filterUpdate(dataaccessLayerUpdate)
+		if dataaccessLayerUpdate.IsEmpty() {
+			return
+		}
+	}
+
 	orchestrator.logger.Info("Received an update. Updating caches: %s", dataaccessLayerUpdate.String())
 
 	// inform subscribers ...
@@ -235,6 +277,94 @@ func (orchestrator *Orchestrator) UpdateCache(dataaccessLayerUpdate dataaccess.U
 	orchestrator.logger.Debug("Finished update (%s)", dataaccessLayerUpdate.String())
 }
 
+// updateFilteredOrchestrators passes the given update on to all filtered orchestrators of the current orchestrator.
+func (orchestrator *Orchestrator) updateFilteredOrchestrators(dataaccessLayerUpdate dataaccess.Update) {
+
+	orchestrator.filteredOrchestratorsLock.Lock()
+	filteredOrchestrators := orchestrator.filteredOrchestrators
+	orchestrator.filteredOrchestratorsLock.Unlock()
+
+	for _, filteredOrchestrator := range filteredOrchestrators {
+		filteredOrchestrator.UpdateCache(dataaccessLayerUpdate)
+	}
+}
+
+// filterUpdate translates an update of the source orchestrator into an update of the items which
+// pass the filter: items which are no longer visible are deleted and items which have become
+// visible are new. The visibility of all items below the updated routes is checked as well.
+func (orchestrator *Orchestrator) filterUpdate(sourceUpdate dataaccess.Update) dataaccess.Update {
+
+	newRoutes := make([]route.Route, 0)
+	modifiedRoutes := make([]route.Route, 0)
+	deletedRoutes := make([]route.Route, 0)
+
+	updatedRoutes := make(map[string]bool)
+	candidates := make([]route.Route, 0)
+	for _, updatedRoute := range append(append(sourceUpdate.New(), sourceUpdate.Modified()...), sourceUpdate.Deleted()...) {
+		updatedRoutes[route.ToKey(updatedRoute)] = true
+		candidates = append(candidates, updatedRoute)
+
+		// the children inherit the visibility of their parents
+		all := func(item *model.Item) bool { return true }
+		for _, child := range orchestrator.source.index().GetAllChildren(updatedRoute, all) {
+			candidates = append(candidates, child.Route())
+		}
+
+		for _, child := range orchestrator.index().GetAllChildren(updatedRoute, all) {
+			candidates = append(candidates, child.Route())
+		}
+	}
+
+	checkedRoutes := make(map[string]bool)
+	for _, candidate := range candidates {
+
+		key := route.ToKey(candidate)
+		if checkedRoutes[key] {
+			continue
+		}
+
+		checkedRoutes[key] = true
+
+		_, isIndexed := orchestrator.index().IsMatch(candidate)
+		item := orchestrator.source.getItem(candidate)
+		isVisible := item != nil && orchestrator.isVisible(item)
+
+		switch {
+		case isVisible && !isIndexed:
+			newRoutes = append(newRoutes, candidate)
+
+		case isVisible && isIndexed && updatedRoutes[key]:
+			modifiedRoutes = append(modifiedRoutes, candidate)
+
+		case !isVisible && isIndexed:
+			deletedRoutes = append(deletedRoutes, candidate)
+		}
+	}
+
+	return dataaccess.NewUpdate(newRoutes, modifiedRoutes, deletedRoutes)
+}
+
+// isVisible checks whether the given item and all of its parents in the source orchestrator pass the filter.
+// The root item is always visible.
+func (orchestrator *Orchestrator) isVisible(item *model.Item) bool {
+
+	for currentRoute := item.Route(); currentRoute.Level() > 0; {
+
+		if currentItem := orchestrator.source.getItem(currentRoute); currentItem != nil && !orchestrator.filter(currentItem) {
+			return false
+		}
+
+		parentRoute, exists := currentRoute.Parent()
+		if !exists {
+			break
+		}
+
+		currentRoute = parentRoute
+	}
+
+	return true
+}
+
 // registerUpdateCallback registers callbacks for new, modified and deleted items.
 func (orchestrator *Orchestrator) registerUpdateCallback(name string, updateType UpdateType, callback func(updatedRoute route.Route)) {
 
@@ -245,6 +375,13 @@ func (orchestrator *Orchestrator) registerUpdateCallback(name string, updateType
 	orchestrator.updateCallbacks[updateType] = append(orchestrator.updateCallbacks[updateType], cacheUpdate(name, updateType, callback))
 }
 
+// registerIndexUpdateCallback registers callbacks which are executed before all other callbacks.
+// All other caches are derived from the index so it must be updated first.
+func (orchestrator *Orchestrator) registerIndexUpdateCallback(name string, updateType UpdateType, callback func(updatedRoute route.Route)) {
+	callbacks := []CacheUpdateCallback{cacheUpdate(name, updateType, callback)}
+	orchestrator.updateCallbacks[updateType] = append(callbacks, orchestrator.updateCallbacks[updateType]...)
+}
+
 func (orchestrator *Orchestrator) ItemExists(route route.Route) bool {
 	_, exists := orchestrator.index().IsMatch(route)
 	return exists
@@ -318,17 +455,8 @@ func (orchestrator *Orchestrator) index() *index.Index {
 	// newItem fetches the item with the given route and adds it to the index.
 	updateItem := func(updatedRoute route.Route) {
 
-		// get the updated item from the repository
-		updatedRepositoryItem := orchestrator.repository.Item(updatedRoute)
-		if updatedRepositoryItem == nil {
-			orchestrator.logger.Warn("The item with the route %q was not found in the repository.", updatedRoute.String())
-			return
-		}
-
-		// parse the item
-		parsedItem := orchestrator.parseItem(updatedRepositoryItem)
+		parsedItem := orchestrator.loadItem(updatedRoute)
 		if parsedItem == nil {
-			orchestrator.logger.Warn("Unable to parse item %q", updatedRepositoryItem.String())
 			return
 		}
 
@@ -349,47 +477,160 @@ func (orchestrator *Orchestrator) index() *index.Index {
 	// create a new index
 	orchestrator.repositoryIndex = index.New(orchestrator.logger)
 
-	// parse all items
-	repositoryItems := orchestrator.repository.Items()
-	for _, repositoryItem := range repositoryItems {
+	// add all items
+	for _, item := range orchestrator.loadItems() {
+		orchestrator.repositoryIndex.Add(item)
+	}
+
+	// register update callbacks
+	orchestrator.registerIndexUpdateCallback("update index", UpdateTypeNew, updateItem)
+	orchestrator.registerIndexUpdateCallback("update index", UpdateTypeModified, updateItem)
+	orchestrator.registerIndexUpdateCallback("update index", UpdateTypeDeleted, deleteItem)
+
+	return orchestrator.repositoryIndex
+}
+
+// loadItem returns the parsed item with the given route from the repository or, if the
+// current orchestrator is filtered, the visible item from the source orchestrator.
+func (orchestrator *Orchestrator) loadItem(itemRoute route.Route) *model.Item {
+
+	if orchestrator.source != nil {
+		item := orchestrator.source.getItem(itemRoute)
+		if item == nil || !orchestrator.isVisible(item) {
+			orchestrator.logger.Warn("The item with the route %q was not found in the source.", itemRoute.String())
+			return nil
+		}
+
+		return item
+	}
+
+	// get the item from the repository
+	repositoryItem := orchestrator.repository.Item(itemRoute)
+	if repositoryItem == nil {
+		orchestrator.logger.Warn("The item with the route %q was not found in the repository.", itemRoute.String())
+		return nil
+	}
+
+	// parse the item
+	parsedItem := orchestrator.parseItem(repositoryItem)
+	if parsedItem == nil {
+		orchestrator.logger.Warn("Unable to parse item %q", repositoryItem.String())
+		return nil
+	}
+
+	return parsedItem
+}
+
+// loadItems returns all parsed items of the repository or, if the current
+// orchestrator is filtered, all visible items of the source orchestrator.
+func (orchestrator *Orchestrator) loadItems() []*model.Item {
+
+	items := make([]*model.Item, 0)
+
+	if orchestrator.source != nil {
+		for _, item := range orchestrator.source.index().GetAllItems() {
+			if !orchestrator.isVisible(item) {
+				continue
+			}
+
+			items = append(items, item)
+		}
+
+		return items
+	}
+
+	for _, repositoryItem := range orchestrator.repository.Items() {
 		parsedItem := orchestrator.parseItem(repositoryItem)
 		if parsedItem == nil {
 			orchestrator.logger.Warn("Unable to parse item %q", repositoryItem.String())
 			continue
 		}
 
-		orchestrator.repositoryIndex.Add(parsedItem)
+		items = append(items, parsedItem)
 	}
 
-	// register update callbacks
-	orchestrator.registerUpdateCallback("update index", UpdateTypeNew, updateItem)
-	orchestrator.registerUpdateCallback("update index", UpdateTypeModified, updateItem)
-	orchestrator.registerUpdateCallback("update index", UpdateTypeDeleted, deleteItem)
-
-	return orchestrator.repositoryIndex
+	return items
 }
 
 func (orchestrator *Orchestrator) search(keywords string, maxiumNumberOfResults int) []search.Result {
+	return orchestrator.itemSearch().Search(keywords, maxiumNumberOfResults)
+}
+
+// itemSearch returns the full-text index. On first use the index is loaded from
+// the meta data folder and only the items which have changed are re-indexed.
+func (orchestrator *Orchestrator) itemSearch() *search.ItemSearch {
+
+	orchestrator.fulltextIndexLock.Lock()
+	defer orchestrator.fulltextIndexLock.Unlock()
 
 	if orchestrator.fulltextIndex != nil {
-		return orchestrator.fulltextIndex.Search(keywords, maxiumNumberOfResults)
+		return orchestrator.fulltextIndex
 	}
 
-	// updateFulltextIndex creates a new full-text index and replaces the existing one.
+	// updateFulltextIndex updates the full-text index entries of the given route.
 	updateFulltextIndex := func(r route.Route) {
-		newFullTextIndex := search.NewItemSearch(orchestrator.logger, orchestrator.getAllItems())
-		orchestrator.fulltextIndex = newFullTextIndex
+		item := orchestrator.getItem(r)
+		if item == nil {
+			orchestrator.logger.Warn("Cannot update the fulltext index for %q. The item was not found.", r.String())
+			return
+		}
+
+		orchestrator.fulltextIndex.Update(item)
+	}
+
+	// removeFromFulltextIndex removes the given route from the full-text index.
+	removeFromFulltextIndex := func(r route.Route) {
+		orchestrator.fulltextIndex.Remove(r)
 	}
 
 	// initialize
-	updateFulltextIndex(route.New())
+	orchestrator.fulltextIndex = search.NewPersistentItemSearch(orchestrator.logger, orchestrator.searchIndexFolder, orchestrator.getAllItems())
 
 	// register update callbacks
 	orchestrator.registerUpdateCallback("update fulltext index", UpdateTypeNew, updateFulltextIndex)
 	orchestrator.registerUpdateCallback("update fulltext index", UpdateTypeModified, updateFulltextIndex)
-	orchestrator.registerUpdateCallback("update fulltext index", UpdateTypeDeleted, updateFulltextIndex)
+	orchestrator.registerUpdateCallback("update fulltext index", UpdateTypeDeleted, removeFromFulltextIndex)
+
+	return orchestrator.fulltextIndex
+}
+
+// find returns the items which match the keywords and filters of the given query.
+// Queries without keywords return all items which match the filters ordered by date.
+// A maximum of zero or less returns all matching items.
+func (orchestrator *Orchestrator) find(query search.Query, maxiumNumberOfResults int) []search.Result {
+
+	allItems := orchestrator.getAllItems()
+	if maxiumNumberOfResults <= 0 || maxiumNumberOfResults > len(allItems) {
+		maxiumNumberOfResults = len(allItems)
+	}
+
+	var candidates []search.Result
+	if strings.TrimSpace(query.Keywords) == "" {
+		for _, item := range allItems {
+			candidates = append(candidates, search.Result{Route: item.Route()})
+		}
+	} else {
+
+		// the filters are applied after the fulltext search
+		// so the search must not be limited to the maximum number of results
+		candidates = orchestrator.search(query.Keywords, len(allItems))
+	}
+
+	results := make([]search.Result, 0)
+	for _, candidate := range candidates {
+		if len(results) >= maxiumNumberOfResults {
+			break
+		}
+
+		if !query.Filter.IsEmpty() && !query.Filter.Matches(orchestrator.getItem(candidate.Route)) {
+			continue
+		}
+
+		candidate.Number = len(results) + 1
+		results = append(results, candidate)
+	}
 
-	return orchestrator.fulltextIndex.Search(keywords, maxiumNumberOfResults)
+	return results
 }
 
 func (orchestrator *Orchestrator) getAllItems() []*model.Item {
@@ -529,6 +770,24 @@ func (orchestrator *Orchestrator) getAliasMap() ItemCache {
 	return orchestrator.itemsByAlias
 }
 
+// getItemByName returns the item with the given alias, title or folder name (see model.Item.HasName).
+// Returns nil if there is no matching item.
+func (orchestrator *Orchestrator) getItemByName(name string) *model.Item {
+
+	// aliases are looked up in the alias cache
+	if item := orchestrator.getItemByAlias(strings.TrimSpace(name)); item != nil {
+		return item
+	}
+
+	for _, item := range orchestrator.getAllItems() {
+		if item.HasName(name) {
+			return item
+		}
+	}
+
+	return nil
+}
+
 // Get the item that has the specified alias. Returns nil if there is no matching item.
 func (orchestrator *Orchestrator) getItemByAlias(alias string) *model.Item {
 
@@ -0,0 +1,108 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/model"
+	"github.com/andreaskoch/allmark/services/parser"
+	"github.com/andreaskoch/allmark/web/orchestrator/index"
+	"github.com/andreaskoch/allmark/web/webpaths"
+)
+
+// getTestSourceOrchestrator returns an orchestrator with an index that contains the items with the given routes.
+func getTestSourceOrchestrator(routes ...string) *Orchestrator {
+	logger := console.New(loglevel.Fatal)
+	source := newBaseOrchestrator(logger, *config.Default("/tmp"), nil, parser.Parser{}, nil, webpaths.WebPathProvider{})
+
+	source.repositoryIndex = index.New(logger)
+	for _, routeValue := range append([]string{""}, routes...) {
+		source.repositoryIndex.Add(model.NewItem(route.NewFromRequest(routeValue), nil, dataaccess.TypePhysical))
+	}
+
+	return source
+}
+
+// isNotInternal hides the items which have an "internal" meta data key.
+func isNotInternal(item *model.Item) bool {
+	_, isInternal := item.MetaData.Custom["internal"]
+	return !isInternal
+}
+
+func Test_newFilteredOrchestrator_HiddenParent_ChildrenAreHidden(t *testing.T) {
+	// arrange
+	source := getTestSourceOrchestrator("public", "internal", "internal/document")
+	source.getItem(route.NewFromRequest("internal")).MetaData.Custom = map[string][]string{"internal": {"yes"}}
+
+	// act
+	filtered := newFilteredOrchestrator(source, "test", isNotInternal)
+
+	// assert
+	if !filtered.ItemExists(route.NewFromRequest("public")) {
+		t.Errorf("The item %q should be visible.", "public")
+	}
+
+	for _, routeValue := range []string{"internal", "internal/document"} {
+		if filtered.ItemExists(route.NewFromRequest(routeValue)) {
+			t.Errorf("The item %q should be hidden.", routeValue)
+		}
+	}
+}
+
+func Test_filterUpdate_ItemBecomesHidden_ItemAndChildrenAreDeleted(t *testing.T) {
+	// arrange
+	source := getTestSourceOrchestrator("internal", "internal/document")
+	filtered := newFilteredOrchestrator(source, "test", isNotInternal)
+	filtered.index()
+
+	modifiedRoute := route.NewFromRequest("internal")
+	source.getItem(modifiedRoute).MetaData.Custom = map[string][]string{"internal": {"yes"}}
+
+	// act
+	update := filtered.filterUpdate(dataaccess.NewUpdate(nil, []route.Route{modifiedRoute}, nil))
+
+	// assert
+	if len(update.New()) != 0 || len(update.Modified()) != 0 || len(update.Deleted()) != 2 {
+		t.Errorf("The item and its child should be deleted but the update was: %s", update.String())
+	}
+}
+
+func Test_filterUpdate_NewVisibleItem_ItemIsNew(t *testing.T) {
+	// arrange
+	source := getTestSourceOrchestrator("documents")
+	filtered := newFilteredOrchestrator(source, "test", isNotInternal)
+	filtered.index()
+
+	newRoute := route.NewFromRequest("documents/new")
+	source.repositoryIndex.Add(model.NewItem(newRoute, nil, dataaccess.TypePhysical))
+
+	// act
+	update := filtered.filterUpdate(dataaccess.NewUpdate([]route.Route{newRoute}, nil, nil))
+
+	// assert
+	if len(update.New()) != 1 || len(update.Modified()) != 0 || len(update.Deleted()) != 0 {
+		t.Errorf("The item should be new but the update was: %s", update.String())
+	}
+}
+
+func Test_registerIndexUpdateCallback_RegisteredAfterOtherCallbacks_IndexCallbackIsFirst(t *testing.T) {
+	// arrange
+	source := getTestSourceOrchestrator()
+	source.registerUpdateCallback("other", UpdateTypeNew, func(updatedRoute route.Route) {})
+
+	// act
+	source.registerIndexUpdateCallback("update index", UpdateTypeNew, func(updatedRoute route.Route) {})
+
+	// assert
+	if name := source.updateCallbacks[UpdateTypeNew][0].Name(); name != "update index" {
+		t.Errorf("The index callback should be executed first but the first callback is %q.", name)
+	}
+}
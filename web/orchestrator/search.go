@@ -5,20 +5,38 @@
 package orchestrator
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andreaskoch/allmark/model"
 	"github.com/andreaskoch/allmark/web/orchestrator/search"
 	"github.com/andreaskoch/allmark/web/view/viewmodel"
-	"strings"
 )
 
 var (
 	itemsPerPage = 50
+
+	// the maximum number of values per search facet
+	maximumNumberOfFacetValues = 20
 )
 
+// searchFacetTitles contains the titles of the search facets.
+var searchFacetTitles = map[string]string{
+	search.FilterNameTag:      "Tags",
+	search.FilterNameAuthor:   "Authors",
+	search.FilterNameLanguage: "Languages",
+	search.FilterNameType:     "Types",
+	search.FilterNamePath:     "Sections",
+	search.FilterNameCreated:  "Created",
+	search.FilterNameModified: "Modified",
+}
+
 type SearchOrchestrator struct {
 	*Orchestrator
 }
 
-func (orchestrator *SearchOrchestrator) GetSearchResults(keywords string, page int) viewmodel.SearchResults {
+func (orchestrator *SearchOrchestrator) GetSearchResults(query search.Query, page int) viewmodel.SearchResults {
 
 	// validate page number
 	if page < 1 {
@@ -33,14 +51,14 @@ func (orchestrator *SearchOrchestrator) GetSearchResults(keywords string, page i
 
 	// collect the search results
 	searchResultModels := make([]viewmodel.SearchResult, 0)
+	searchFacets := make([]viewmodel.SearchFacet, 0)
 
-	maximumNumberOfResults := 100
 	totalResultCount := 0
 
-	if strings.TrimSpace(keywords) != "" {
+	if !query.IsEmpty() {
 
 		// execute the search
-		searchResults := orchestrator.search(keywords, maximumNumberOfResults)
+		searchResults := orchestrator.find(query, 0)
 
 		// count the number of search results
 		totalResultCount = len(searchResults)
@@ -53,13 +71,16 @@ func (orchestrator *SearchOrchestrator) GetSearchResults(keywords string, page i
 				continue
 			}
 
-			searchResultModels = append(searchResultModels, orchestrator.createSearchResultModel(searchResult))
+			searchResultModels = append(searchResultModels, orchestrator.createSearchResultModel(searchResult, query.Keywords))
 		}
 
+		// count the facet values of all results
+		searchFacets = orchestrator.getSearchFacets(query, searchResults)
+
 	}
 
 	return viewmodel.SearchResults{
-		Query:   keywords,
+		Query:   query.String(),
 		Results: searchResultModels,
 
 		Page:         page,
@@ -68,10 +89,12 @@ func (orchestrator *SearchOrchestrator) GetSearchResults(keywords string, page i
 		StartIndex:       getStartIndex(itemsPerPage, page),
 		ResultCount:      len(searchResultModels),
 		TotalResultCount: totalResultCount,
+
+		Facets: searchFacets,
 	}
 }
 
-func (orchestrator *SearchOrchestrator) createSearchResultModel(searchResult search.Result) viewmodel.SearchResult {
+func (orchestrator *SearchOrchestrator) createSearchResultModel(searchResult search.Result, keywords string) viewmodel.SearchResult {
 
 	item := orchestrator.getItem(searchResult.Route)
 	if item == nil {
@@ -88,7 +111,175 @@ func (orchestrator *SearchOrchestrator) createSearchResultModel(searchResult sea
 		Description: item.Description,
 		Route:       location,
 		Path:        item.Route().OriginalValue(),
+		Snippet:     search.GetSnippet(item.Content, keywords),
+	}
+}
+
+// getSearchFacets counts the tags, authors, languages, types, sections and
+// creation/modification years of the items in the given search results.
+func (orchestrator *SearchOrchestrator) getSearchFacets(query search.Query, searchResults []search.Result) []viewmodel.SearchFacet {
+
+	counts := make(map[string]map[string]int)
+	for _, filterName := range search.FilterNames {
+		counts[filterName] = make(map[string]int)
+	}
+
+	for _, searchResult := range searchResults {
+
+		item := orchestrator.getItem(searchResult.Route)
+		if item == nil {
+			continue
+		}
+
+		for filterName, values := range getFacetValues(item) {
+			for _, value := range values {
+				counts[filterName][value]++
+			}
+		}
+	}
+
+	facets := make([]viewmodel.SearchFacet, 0)
+	for _, filterName := range search.FilterNames {
+
+		values := make([]viewmodel.SearchFacetValue, 0, len(counts[filterName]))
+		for value, count := range counts[filterName] {
+
+			isActive := isActiveFacetValue(query, filterName, value)
+
+			// active values remove the filter, inactive values apply it
+			facetQuery := query.With(filterName, value)
+			if isActive {
+				facetQuery = query.Without(filterName, value)
+			}
+
+			values = append(values, viewmodel.SearchFacetValue{
+				Value:    value,
+				Count:    count,
+				Query:    facetQuery.String(),
+				IsActive: isActive,
+			})
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		sort.Sort(facetValuesByCount(values))
+		if len(values) > maximumNumberOfFacetValues {
+			values = values[:maximumNumberOfFacetValues]
+		}
+
+		facets = append(facets, viewmodel.SearchFacet{
+			Name:   filterName,
+			Title:  searchFacetTitles[filterName],
+			Values: values,
+		})
+	}
+
+	return facets
+}
+
+// getFacetValues returns the facet values of the given item by filter name.
+func getFacetValues(item *model.Item) map[string][]string {
+	values := map[string][]string{
+		search.FilterNameTag:      uniqueTags(item.MetaData.Tags),
+		search.FilterNameLanguage: {search.GetLanguage(item)},
+		search.FilterNameType:     {item.Type.String()},
+	}
+
+	if author := strings.TrimSpace(item.MetaData.Author); author != "" {
+		values[search.FilterNameAuthor] = []string{author}
 	}
+
+	if item.Route().Level() > 0 {
+		section := strings.Split(item.Route().OriginalValue(), "/")[0]
+		values[search.FilterNamePath] = []string{"/" + section}
+	}
+
+	if !item.MetaData.CreationDate.IsZero() {
+		values[search.FilterNameCreated] = []string{fmt.Sprintf("%d", item.MetaData.CreationDate.Year())}
+	}
+
+	if !item.MetaData.LastModifiedDate.IsZero() {
+		values[search.FilterNameModified] = []string{fmt.Sprintf("%d", item.MetaData.LastModifiedDate.Year())}
+	}
+
+	return values
+}
+
+// isActiveFacetValue returns true if the given facet value is used as a filter in the given query.
+func isActiveFacetValue(query search.Query, filterName, value string) bool {
+	filter := query.Filter
+	switch filterName {
+	case search.FilterNameTag:
+		for _, tag := range filter.Tags {
+			if strings.EqualFold(tag, value) {
+				return true
+			}
+		}
+
+		return false
+
+	case search.FilterNameAuthor:
+		return strings.EqualFold(filter.Author, value)
+
+	case search.FilterNameLanguage:
+		return strings.EqualFold(filter.Language, value)
+
+	case search.FilterNameType:
+		return strings.EqualFold(filter.Type, value)
+
+	case search.FilterNamePath:
+		return strings.EqualFold(filter.Path, value)
+
+	case search.FilterNameCreated:
+		return filter.Created.String() == value
+
+	case search.FilterNameModified:
+		return filter.Modified.String() == value
+	}
+
+	return false
+}
+
+// uniqueTags returns the given tags without case-insensitive duplicates.
+func uniqueTags(tags []string) []string {
+	unique := make([]string, 0, len(tags))
+	for _, tag := range tags {
+
+		isDuplicate := false
+		for _, uniqueTag := range unique {
+			if strings.EqualFold(tag, uniqueTag) {
+				isDuplicate = true
+				break
+			}
+		}
+
+		if !isDuplicate {
+			unique = append(unique, tag)
+		}
+	}
+
+	return unique
+}
+
+// facetValuesByCount sorts facet values by their count and name.
+type facetValuesByCount []viewmodel.SearchFacetValue
+
+func (values facetValuesByCount) Len() int {
+	return len(values)
+}
+
+func (values facetValuesByCount) Swap(i, j int) {
+	values[i], values[j] = values[j], values[i]
+}
+
+func (values facetValuesByCount) Less(i, j int) bool {
+	if values[i].Count != values[j].Count {
+		return values[i].Count > values[j].Count
+	}
+
+	return strings.ToLower(values[i].Value) < strings.ToLower(values[j].Value)
 }
 
 func getStartIndex(itemsPerPage, pageNumber int) int {
@@ -5,23 +5,36 @@
 package search
 
 import (
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/andreaskoch/allmark/common/logger"
 	"github.com/andreaskoch/allmark/common/route"
 	"github.com/andreaskoch/allmark/model"
 	"github.com/andreaskoch/fulltext"
-	"github.com/spf13/afero"
-	"strings"
 )
 
 type indexValueProvider func(item *model.Item) []string
 
-// newIndex creates a new FullTextIndex and initializes it with the given number of items.
-func newIndex(logger logger.Logger, items []*model.Item, name string, indexValueFunc indexValueProvider) *FullTextIndex {
+// newIndex creates a new FullTextIndex for the given items. If a storage folder is given
+// the index is loaded from and saved to this folder and only the items which have changed
+// since the index has been saved are re-indexed. Otherwise the index is kept in memory.
+func newIndex(logger logger.Logger, items []*model.Item, name, storageFolder string, indexValueFunc indexValueProvider) *FullTextIndex {
 
 	index := &FullTextIndex{
 		logger:         logger,
-		filesystem:     &afero.MemMapFs{},
+		name:           name,
 		indexValueFunc: indexValueFunc,
+
+		documents: make(map[string]indexDocument),
+		words:     make(map[string]map[string]int),
+	}
+
+	if storageFolder != "" {
+		index.storage = newDocumentStorage(storageFolder)
 	}
 
 	index.initialize(items)
@@ -32,95 +45,265 @@ func newIndex(logger logger.Logger, items []*model.Item, name string, indexValue
 // FullTextIndex indexes a given set if repository items and enables a full-text search on these items.
 type FullTextIndex struct {
 	logger logger.Logger
+	name   string
 
-	filesystem afero.Fs
+	// storage persists the indexed documents (optional)
+	storage *documentStorage
 
 	indexValueFunc indexValueProvider
+
+	lock      sync.RWMutex
+	documents map[string]indexDocument  // documents by route
+	words     map[string]map[string]int // word counts by word and route
 }
 
 // Search scans the fulltext index for the given keywords and returns any matching search results.
 func (index *FullTextIndex) Search(keywords string, maxiumNumberOfResults int) []Result {
 
-	// open the search index file
-	searchIndexFile, err := index.filesystem.Open("searchindex")
-	if err != nil {
-		index.logger.Error(err.Error())
-		return []Result{}
+	index.lock.RLock()
+	defer index.lock.RUnlock()
+
+	// sum up the word counts of all keywords per document
+	scores := make(map[string]int64)
+	for _, word := range getWords(keywords) {
+		for documentRoute, count := range index.words[word] {
+			scores[documentRoute] += int64(count)
+		}
 	}
 
-	// create a new searcher for the given search index
-	searcher, err := fulltext.NewSearcher(searchIndexFile)
-	if err != nil {
-		index.logger.Error(err.Error())
-		return []Result{}
+	var searchResults []Result
+	for documentRoute, score := range scores {
+		searchResults = append(searchResults, Result{
+			Score:      score,
+			StoreValue: index.documents[documentRoute].StoreValue,
+			Route:      route.NewFromRequest(documentRoute),
+		})
 	}
 
-	defer searcher.Close()
+	// sort by score
+	sort.Sort(resultsByScore(searchResults))
 
-	// peform the search
-	searchResult, err := searcher.SimpleSearch(keywords, maxiumNumberOfResults)
-	if err != nil {
-		index.logger.Error(err.Error())
+	// limit the number of results
+	if len(searchResults) > maxiumNumberOfResults {
+		searchResults = searchResults[:maxiumNumberOfResults]
 	}
 
-	var searchResults []Result
-	for number, v := range searchResult.Items {
+	for number := range searchResults {
+		searchResults[number].Number = number + 1
+	}
 
-		route := route.NewFromRequest(string(v.Id))
+	return searchResults
+}
 
-		// append the search results
-		searchResults = append(searchResults, Result{
-			Number: number + 1,
+// Update adds the given item to the index or replaces the existing entry.
+func (index *FullTextIndex) Update(item *model.Item) {
 
-			Score:      v.Score,
-			StoreValue: string(v.StoreValue),
-			Route:      route,
-		})
+	index.lock.Lock()
+	defer index.lock.Unlock()
 
-	}
+	index.update(item)
+}
 
-	return searchResults
+// Remove removes the item with the given route from the index.
+func (index *FullTextIndex) Remove(itemRoute route.Route) {
+
+	index.lock.Lock()
+	defer index.lock.Unlock()
+
+	index.remove(itemRoute.Value())
 }
 
-// initialize creates a fulltext index from the given repository items.
+// initialize loads the stored documents (if available) and updates all
+// documents whose hash does not match the hash of the given items.
 func (index *FullTextIndex) initialize(items []*model.Item) {
 
-	// fulltext search
-	indexer, err := fulltext.NewIndexer()
-	if err != nil {
-		index.logger.Error(err.Error())
-		return
-	}
+	index.lock.Lock()
+	defer index.lock.Unlock()
+
+	// load the stored documents
+	if index.storage != nil {
+		documents, err := index.storage.Load()
+		if err != nil {
+			index.logger.Warn("Unable to load the %q search index. Rebuilding the index. Error: %s", index.name, err.Error())
+		}
 
-	defer indexer.Close()
+		for _, document := range documents {
+			index.add(document)
+		}
+	}
 
+	// update new and modified items
+	itemRoutes := make(map[string]bool)
+	updatedItems := 0
 	for _, item := range items {
 
-		doc := fulltext.IndexDoc{
-			Id:         []byte(item.Route().Value()),              // unique identifier (the path to a webpage works...)
-			StoreValue: []byte(item.Content),                      // bytes you want to be able to retrieve from search results
-			IndexValue: getIndexValue(index.indexValueFunc(item)), // bytes you want to be split into words and indexed
+		itemRoute := item.Route().Value()
+		itemRoutes[itemRoute] = true
+
+		if document, exists := index.documents[itemRoute]; exists && document.Hash == index.getDocumentHash(item) {
+			continue
+		}
+
+		index.update(item)
+		updatedItems++
+	}
+
+	// remove deleted items
+	removedItems := 0
+	for documentRoute := range index.documents {
+		if itemRoutes[documentRoute] {
+			continue
 		}
 
-		indexer.AddDoc(doc)
+		index.remove(documentRoute)
+		removedItems++
 	}
 
-	// create a search index file
-	searchIndexFile, err := index.filesystem.Create("searchindex")
-	if err != nil {
-		index.logger.Error(err.Error())
+	if index.storage != nil && (updatedItems > 0 || removedItems > 0) {
+		index.logger.Info("The %q search index was out of date. Updated %d and removed %d documents.", index.name, updatedItems, removedItems)
+	}
+}
+
+// update indexes the given item and saves the indexed document. The caller must hold the lock.
+func (index *FullTextIndex) update(item *model.Item) {
+
+	if item == nil {
 		return
 	}
 
-	// save the index to file
-	if err := indexer.FinalizeAndWrite(searchIndexFile); err != nil {
-		index.logger.Error(err.Error())
+	document := newIndexDocument(item.Route().Value(), index.getDocumentHash(item), item.Content, index.indexValueFunc(item))
+
+	index.unindex(document.Route)
+	index.add(document)
+
+	if index.storage == nil {
+		return
+	}
+
+	if err := index.storage.Save(document); err != nil {
+		index.logger.Error("Unable to save the %q search index entry for %q. Error: %s", index.name, document.Route, err.Error())
+	}
+}
+
+// remove removes the document with the given route from the index and the storage. The caller must hold the lock.
+func (index *FullTextIndex) remove(documentRoute string) {
+
+	if !index.unindex(documentRoute) || index.storage == nil {
 		return
 	}
 
-	defer searchIndexFile.Close()
+	if err := index.storage.Remove(documentRoute); err != nil {
+		index.logger.Error("Unable to remove the %q search index entry for %q. Error: %s", index.name, documentRoute, err.Error())
+	}
+}
+
+// unindex removes the document with the given route from the word index. The caller must hold the lock.
+func (index *FullTextIndex) unindex(documentRoute string) (removed bool) {
+
+	document, exists := index.documents[documentRoute]
+	if !exists {
+		return false
+	}
+
+	for word := range document.Words {
+		delete(index.words[word], documentRoute)
+		if len(index.words[word]) == 0 {
+			delete(index.words, word)
+		}
+	}
+
+	delete(index.documents, documentRoute)
+	return true
+}
+
+// add adds the given document to the word index. The caller must hold the lock.
+func (index *FullTextIndex) add(document indexDocument) {
+
+	index.documents[document.Route] = document
+
+	for word, count := range document.Words {
+		if index.words[word] == nil {
+			index.words[word] = make(map[string]int)
+		}
+
+		index.words[word][document.Route] = count
+	}
+}
+
+// getDocumentHash returns a hash for the given item which changes
+// if either the item itself or the indexed values have changed.
+func (index *FullTextIndex) getDocumentHash(item *model.Item) string {
+	indexValueHash := sha1.Sum(getIndexValue(index.indexValueFunc(item)))
+	return fmt.Sprintf("%s-%x", item.Hash, indexValueHash)
+}
+
+// indexDocumentVersion is the version of the index document format.
+// Stored documents with another version are re-indexed.
+const indexDocumentVersion = 1
+
+// indexDocument contains the indexed words of a single item.
+type indexDocument struct {
+	Version int `json:"version"`
+
+	Route      string         `json:"route"`
+	Hash       string         `json:"hash"`
+	StoreValue string         `json:"storeValue"`
+	Words      map[string]int `json:"words"`
+}
+
+// newIndexDocument creates a new index document from the given store value and index values.
+func newIndexDocument(documentRoute, hash, storeValue string, indexValues []string) indexDocument {
+
+	words := make(map[string]int)
+	for _, word := range getWords(string(getIndexValue(indexValues))) {
+		words[word]++
+	}
+
+	for _, word := range getWords(storeValue) {
+		words[word]++
+	}
+
+	return indexDocument{
+		Version: indexDocumentVersion,
+
+		Route:      documentRoute,
+		Hash:       hash,
+		StoreValue: storeValue,
+		Words:      words,
+	}
+}
+
+// getWords splits the given text into normalized words.
+func getWords(text string) []string {
+	var words []string
+	for _, word := range fulltext.Wordize(text) {
+		if word = fulltext.IndexizeWord(word); word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return words
 }
 
 func getIndexValue(values []string) []byte {
 	return []byte(strings.Join(values, " "))
 }
+
+// resultsByScore sorts search results by descending score and ascending route.
+type resultsByScore []Result
+
+func (results resultsByScore) Len() int {
+	return len(results)
+}
+
+func (results resultsByScore) Swap(i, j int) {
+	results[i], results[j] = results[j], results[i]
+}
+
+func (results resultsByScore) Less(i, j int) bool {
+	if results[i].Score != results[j].Score {
+		return results[i].Score > results[j].Score
+	}
+
+	return results[i].Route.Value() < results[j].Route.Value()
+}
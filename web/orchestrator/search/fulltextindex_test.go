@@ -0,0 +1,119 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package search
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/model"
+)
+
+func newTestItem(routeValue, hash, content string) *model.Item {
+	item := model.NewItem(route.NewFromRequest(routeValue), nil, 0)
+	item.Hash = hash
+	item.Content = content
+	return item
+}
+
+func Test_FullTextIndex_Search_OrderedByScore(t *testing.T) {
+	// arrange
+	items := []*model.Item{
+		newTestItem("a", "1", "golang"),
+		newTestItem("b", "1", "golang, golang and markdown"),
+		newTestItem("c", "1", "markdown"),
+	}
+	index := newIndex(console.New(loglevel.Off), items, "content", "", itemContentKeywordProvider)
+
+	// act
+	results := index.Search("Golang", 10)
+
+	// assert
+	if len(results) != 2 {
+		t.Fatalf("The search should return 2 results but returned %v.", len(results))
+	}
+
+	if results[0].Route.Value() != "b" || results[0].Number != 1 {
+		t.Errorf("The first result should be %q but was %q (%v).", "b", results[0].Route.Value(), results[0].Number)
+	}
+}
+
+func Test_FullTextIndex_StoredIndexIsUpdatedIncrementally(t *testing.T) {
+	// arrange
+	folder, err := ioutil.TempDir("", "allmark-search")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(folder)
+
+	logger := console.New(loglevel.Off)
+	newIndex(logger, []*model.Item{
+		newTestItem("a", "1", "old"),
+		newTestItem("b", "1", "deleted"),
+		newTestItem("c", "1", "unchanged"),
+	}, "content", folder, itemContentKeywordProvider)
+
+	// act
+	index := newIndex(logger, []*model.Item{
+		newTestItem("a", "2", "new"),
+		newTestItem("c", "1", "unchanged"),
+	}, "content", folder, itemContentKeywordProvider)
+
+	// assert
+	expectedResultCounts := map[string]int{
+		"old":       0,
+		"new":       1,
+		"deleted":   0,
+		"unchanged": 1,
+	}
+
+	for keyword, expected := range expectedResultCounts {
+		if results := index.Search(keyword, 10); len(results) != expected {
+			t.Errorf("The search for %q should return %v results but returned %v.", keyword, expected, len(results))
+		}
+	}
+
+	files, _ := ioutil.ReadDir(folder)
+	if len(files) != 2 {
+		t.Errorf("The index folder should contain 2 documents but contained %v.", len(files))
+	}
+}
+
+func Test_FullTextIndex_UpdateAndRemove(t *testing.T) {
+	// arrange
+	folder, err := ioutil.TempDir("", "allmark-search")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(folder)
+
+	logger := console.New(loglevel.Off)
+	index := newIndex(logger, []*model.Item{newTestItem("a", "1", "first")}, "content", folder, itemContentKeywordProvider)
+
+	// act
+	index.Update(newTestItem("b", "1", "second"))
+	index.Remove(route.NewFromRequest("a"))
+
+	// assert
+	reloadedIndex := newIndex(logger, nil, "content", folder, itemContentKeywordProvider)
+	if len(reloadedIndex.documents) != 0 {
+		t.Errorf("Stored documents of items which no longer exist should be removed but the index contained %v documents.", len(reloadedIndex.documents))
+	}
+
+	storage := newDocumentStorage(folder)
+	if documents, _ := storage.Load(); len(documents) != 0 {
+		t.Errorf("The storage should be empty but contained %v documents.", len(documents))
+	}
+
+	if results := index.Search("second", 10); len(results) != 1 {
+		t.Errorf("The search for %q should return 1 result but returned %v.", "second", len(results))
+	}
+}
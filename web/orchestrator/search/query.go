@@ -0,0 +1,479 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/model"
+)
+
+// The names of the search filters. They can be used in the query
+// (e.g. "tag:go author:"John Doe" created:2015-01..2015-06") or as URL parameters.
+const (
+	FilterNameTag      = "tag"
+	FilterNameAuthor   = "author"
+	FilterNameLanguage = "language"
+	FilterNameType     = "type"
+	FilterNamePath     = "path"
+	FilterNameCreated  = "created"
+	FilterNameModified = "modified"
+)
+
+// FilterNames contains the names of all available search filters.
+var FilterNames = []string{
+	FilterNameTag,
+	FilterNameAuthor,
+	FilterNameLanguage,
+	FilterNameType,
+	FilterNamePath,
+	FilterNameCreated,
+	FilterNameModified,
+}
+
+// filterNameAliases maps alternative filter names to the filter names.
+var filterNameAliases = map[string]string{
+	"tags":  FilterNameTag,
+	"lang":  FilterNameLanguage,
+	"route": FilterNamePath,
+}
+
+// dateRangeSeparator separates the start and the end of a date range (e.g. "2015-01-01..2015-12-31").
+const dateRangeSeparator = ".."
+
+// dateFormats contains the supported date formats ordered by decreasing precision.
+var dateFormats = []string{"2006-01-02", "2006-01", "2006"}
+
+// Query is a search query consisting of full-text keywords and meta data filters.
+type Query struct {
+	Keywords string
+	Filter   Filter
+}
+
+// ParseQuery parses the given query text (e.g. "tag:go lang:en some keywords").
+// Tokens which are not valid filters are treated as keywords.
+func ParseQuery(text string) Query {
+	query := Query{}
+
+	var keywords []string
+	for _, token := range tokenize(text) {
+
+		separatorPosition := strings.Index(token, ":")
+		if separatorPosition > 0 && query.SetFilter(token[:separatorPosition], unquote(token[separatorPosition+1:])) {
+			continue
+		}
+
+		keywords = append(keywords, token)
+	}
+
+	query.Keywords = strings.Join(keywords, " ")
+	return query
+}
+
+// SetFilter applies the filter with the given name (e.g. "tag") and value (e.g. "go") to the query.
+// It returns false if the filter name is unknown or the value is invalid.
+func (query *Query) SetFilter(name, value string) bool {
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, isAlias := filterNameAliases[name]; isAlias {
+		name = alias
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+
+	filter := &query.Filter
+	switch name {
+
+	case FilterNameTag:
+		for _, tag := range filter.Tags {
+			if strings.EqualFold(tag, value) {
+				return true
+			}
+		}
+
+		filter.Tags = append(filter.Tags, value)
+
+	case FilterNameAuthor:
+		filter.Author = value
+
+	case FilterNameLanguage:
+		filter.Language = value
+
+	case FilterNameType:
+		filter.Type = value
+
+	case FilterNamePath:
+		filter.Path = "/" + strings.Trim(value, "/")
+
+	case FilterNameCreated, FilterNameModified:
+		dateRange, err := ParseDateRange(value)
+		if err != nil {
+			return false
+		}
+
+		if name == FilterNameCreated {
+			filter.Created = dateRange
+		} else {
+			filter.Modified = dateRange
+		}
+
+	default:
+		return false
+
+	}
+
+	return true
+}
+
+// With returns a copy of the query with the given filter applied.
+func (query Query) With(name, value string) Query {
+	result := query.copy()
+	result.SetFilter(name, value)
+	return result
+}
+
+// Without returns a copy of the query without the given filter.
+// For tags only the tag with the given value is removed.
+func (query Query) Without(name, value string) Query {
+	result := query.copy()
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, isAlias := filterNameAliases[name]; isAlias {
+		name = alias
+	}
+
+	filter := &result.Filter
+	switch name {
+	case FilterNameTag:
+		filter.Tags = nil
+		for _, tag := range query.Filter.Tags {
+			if !strings.EqualFold(tag, value) {
+				filter.Tags = append(filter.Tags, tag)
+			}
+		}
+
+	case FilterNameAuthor:
+		filter.Author = ""
+
+	case FilterNameLanguage:
+		filter.Language = ""
+
+	case FilterNameType:
+		filter.Type = ""
+
+	case FilterNamePath:
+		filter.Path = ""
+
+	case FilterNameCreated:
+		filter.Created = DateRange{}
+
+	case FilterNameModified:
+		filter.Modified = DateRange{}
+	}
+
+	return result
+}
+
+// copy returns a copy of the query which does not share the tag list.
+func (query Query) copy() Query {
+	result := query
+	result.Filter.Tags = append([]string{}, query.Filter.Tags...)
+	return result
+}
+
+// IsEmpty returns true if the query has neither keywords nor filters.
+func (query Query) IsEmpty() bool {
+	return strings.TrimSpace(query.Keywords) == "" && query.Filter.IsEmpty()
+}
+
+// String returns the query text (e.g. "tag:go some keywords").
+func (query Query) String() string {
+	var tokens []string
+
+	for _, tag := range query.Filter.Tags {
+		tokens = append(tokens, formatFilter(FilterNameTag, tag))
+	}
+
+	if query.Filter.Author != "" {
+		tokens = append(tokens, formatFilter(FilterNameAuthor, query.Filter.Author))
+	}
+
+	if query.Filter.Language != "" {
+		tokens = append(tokens, formatFilter(FilterNameLanguage, query.Filter.Language))
+	}
+
+	if query.Filter.Type != "" {
+		tokens = append(tokens, formatFilter(FilterNameType, query.Filter.Type))
+	}
+
+	if query.Filter.Path != "" {
+		tokens = append(tokens, formatFilter(FilterNamePath, query.Filter.Path))
+	}
+
+	if !query.Filter.Created.IsEmpty() {
+		tokens = append(tokens, formatFilter(FilterNameCreated, query.Filter.Created.String()))
+	}
+
+	if !query.Filter.Modified.IsEmpty() {
+		tokens = append(tokens, formatFilter(FilterNameModified, query.Filter.Modified.String()))
+	}
+
+	if keywords := strings.TrimSpace(query.Keywords); keywords != "" {
+		tokens = append(tokens, keywords)
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// Filter contains the meta data criteria search results must match.
+type Filter struct {
+	Tags     []string
+	Author   string
+	Language string
+	Type     string
+	Path     string
+
+	Created  DateRange
+	Modified DateRange
+}
+
+// IsEmpty returns true if no filter criteria are set.
+func (filter Filter) IsEmpty() bool {
+	return len(filter.Tags) == 0 &&
+		filter.Author == "" &&
+		filter.Language == "" &&
+		filter.Type == "" &&
+		filter.Path == "" &&
+		filter.Created.IsEmpty() &&
+		filter.Modified.IsEmpty()
+}
+
+// Matches returns true if the given item matches all filter criteria.
+func (filter Filter) Matches(item *model.Item) bool {
+
+	if item == nil {
+		return false
+	}
+
+	// tags: all tags must be assigned to the item
+	for _, tag := range filter.Tags {
+		if !hasTag(item, tag) {
+			return false
+		}
+	}
+
+	if filter.Author != "" && !strings.EqualFold(filter.Author, item.MetaData.Author) {
+		return false
+	}
+
+	if filter.Language != "" && !strings.EqualFold(filter.Language, GetLanguage(item)) {
+		return false
+	}
+
+	if filter.Type != "" && !strings.EqualFold(filter.Type, item.Type.String()) {
+		return false
+	}
+
+	if filter.Path != "" && !isInPath(item.Route(), filter.Path) {
+		return false
+	}
+
+	if !filter.Created.Contains(item.MetaData.CreationDate) {
+		return false
+	}
+
+	if !filter.Modified.Contains(item.MetaData.LastModifiedDate) {
+		return false
+	}
+
+	return true
+}
+
+// GetLanguage returns the language of the given item or the default language if the item has none.
+func GetLanguage(item *model.Item) string {
+	if item.MetaData.Language == "" {
+		return config.DefaultLanguage
+	}
+
+	return item.MetaData.Language
+}
+
+// DateRange is a time span with an inclusive start and an exclusive end.
+// An empty start or end means the range is open on that side.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+
+	text string
+}
+
+// ParseDateRange parses date ranges such as "2015", "2015-03", "2015-01-01..2015-06-30",
+// "2015-01.." or "..2015-06". A single date covers the whole year, month or day.
+func ParseDateRange(text string) (DateRange, error) {
+
+	text = strings.TrimSpace(text)
+
+	fromText, toText := text, text
+	if separatorPosition := strings.Index(text, dateRangeSeparator); separatorPosition != -1 {
+		fromText = strings.TrimSpace(text[:separatorPosition])
+		toText = strings.TrimSpace(text[separatorPosition+len(dateRangeSeparator):])
+	}
+
+	if fromText == "" && toText == "" {
+		return DateRange{}, fmt.Errorf("The date range %q has neither a start nor an end.", text)
+	}
+
+	dateRange := DateRange{text: text}
+
+	if fromText != "" {
+		from, _, err := parseDate(fromText)
+		if err != nil {
+			return DateRange{}, err
+		}
+
+		dateRange.From = from
+	}
+
+	if toText != "" {
+		to, format, err := parseDate(toText)
+		if err != nil {
+			return DateRange{}, err
+		}
+
+		// the end of the range is the end of the given year, month or day
+		switch format {
+		case "2006":
+			dateRange.To = to.AddDate(1, 0, 0)
+		case "2006-01":
+			dateRange.To = to.AddDate(0, 1, 0)
+		default:
+			dateRange.To = to.AddDate(0, 0, 1)
+		}
+	}
+
+	return dateRange, nil
+}
+
+// IsEmpty returns true if the date range has neither a start nor an end.
+func (dateRange DateRange) IsEmpty() bool {
+	return dateRange.From.IsZero() && dateRange.To.IsZero()
+}
+
+// Contains returns true if the given date is within the date range.
+// Empty date ranges contain all dates.
+func (dateRange DateRange) Contains(date time.Time) bool {
+	if dateRange.IsEmpty() {
+		return true
+	}
+
+	if date.IsZero() {
+		return false
+	}
+
+	if !dateRange.From.IsZero() && date.Before(dateRange.From) {
+		return false
+	}
+
+	if !dateRange.To.IsZero() && !date.Before(dateRange.To) {
+		return false
+	}
+
+	return true
+}
+
+// String returns the text representation of the date range (e.g. "2015-01-01..2015-06-30").
+func (dateRange DateRange) String() string {
+	return dateRange.text
+}
+
+// parseDate parses the given date with the first matching date format.
+func parseDate(text string) (date time.Time, format string, err error) {
+	for _, format := range dateFormats {
+		if date, err := time.Parse(format, text); err == nil {
+			return date, format, nil
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("%q is not a valid date. Please use the format YYYY, YYYY-MM or YYYY-MM-DD.", text)
+}
+
+// hasTag returns true if the given tag is assigned to the specified item.
+func hasTag(item *model.Item, tagName string) bool {
+	for _, tag := range item.MetaData.Tags {
+		if strings.EqualFold(tag, tagName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isInPath returns true if the given route equals or is located below the given path (e.g. "/documents").
+func isInPath(itemRoute route.Route, path string) bool {
+	prefix := strings.ToLower(route.NewFromRequest(path).Value())
+	if prefix == "" {
+		return true
+	}
+
+	value := strings.ToLower(itemRoute.Value())
+	return value == prefix || strings.HasPrefix(value, prefix+"/")
+}
+
+// tokenize splits the given text at whitespace characters
+// but keeps double-quoted phrases (e.g. author:"John Doe") together.
+func tokenize(text string) []string {
+	var tokens []string
+
+	token := ""
+	isQuoted := false
+	for _, character := range text {
+
+		if character == '"' {
+			isQuoted = !isQuoted
+		}
+
+		if !isQuoted && (character == ' ' || character == '\t' || character == '\n' || character == '\r') {
+			if token != "" {
+				tokens = append(tokens, token)
+			}
+
+			token = ""
+			continue
+		}
+
+		token += string(character)
+	}
+
+	if token != "" {
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+// unquote removes surrounding double quotes from the given value.
+func unquote(value string) string {
+	if len(value) > 1 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// formatFilter returns the query representation of the given filter (e.g. `author:"John Doe"`).
+func formatFilter(name, value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return fmt.Sprintf("%s:%q", name, value)
+	}
+
+	return fmt.Sprintf("%s:%s", name, value)
+}
@@ -0,0 +1,114 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/model"
+)
+
+func Test_ParseQuery_FiltersAndKeywords(t *testing.T) {
+	// arrange
+	text := `tag:go author:"John Doe" lang:de type:document path:/documents/ created:2015-01..2015-06 some keywords`
+
+	// act
+	query := ParseQuery(text)
+
+	// assert
+	if query.Keywords != "some keywords" {
+		t.Errorf("The keywords should be %q but were %q.", "some keywords", query.Keywords)
+	}
+
+	if len(query.Filter.Tags) != 1 || query.Filter.Tags[0] != "go" {
+		t.Errorf("The tag filter should be %q but was %q.", []string{"go"}, query.Filter.Tags)
+	}
+
+	if query.Filter.Author != "John Doe" {
+		t.Errorf("The author filter should be %q but was %q.", "John Doe", query.Filter.Author)
+	}
+
+	if query.Filter.Language != "de" {
+		t.Errorf("The language filter should be %q but was %q.", "de", query.Filter.Language)
+	}
+
+	if query.Filter.Path != "/documents" {
+		t.Errorf("The path filter should be %q but was %q.", "/documents", query.Filter.Path)
+	}
+
+	expectedFrom := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	expectedTo := time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC)
+	if !query.Filter.Created.From.Equal(expectedFrom) || !query.Filter.Created.To.Equal(expectedTo) {
+		t.Errorf("The created filter should range from %s to %s but was %s to %s.", expectedFrom, expectedTo, query.Filter.Created.From, query.Filter.Created.To)
+	}
+}
+
+func Test_ParseQuery_InvalidFilter_IsKeyword(t *testing.T) {
+	// arrange
+	text := "created:yesterday http://example.com"
+
+	// act
+	query := ParseQuery(text)
+
+	// assert
+	if query.Keywords != text {
+		t.Errorf("The keywords should be %q but were %q.", text, query.Keywords)
+	}
+
+	if !query.Filter.IsEmpty() {
+		t.Errorf("The filter should be empty but was %#v.", query.Filter)
+	}
+}
+
+func Test_Query_String(t *testing.T) {
+	// arrange
+	query := ParseQuery(`keyword author:"John Doe" tag:go`)
+
+	// act
+	result := query.With(FilterNameModified, "2015").String()
+
+	// assert
+	expected := `tag:go author:"John Doe" modified:2015 keyword`
+	if result != expected {
+		t.Errorf("The query should be %q but was %q.", expected, result)
+	}
+}
+
+func Test_Filter_Matches(t *testing.T) {
+	// arrange
+	item := model.NewItem(route.NewFromRequest("documents/example"), nil, 0)
+	item.Type = model.TypeDocument
+	item.MetaData.Tags = []string{"Go", "Markdown"}
+	item.MetaData.CreationDate = time.Date(2015, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	inputs := map[string]bool{
+		"tag:go":                  true,
+		"tag:go tag:markdown":     true,
+		"tag:go tag:java":         false,
+		"lang:en":                 true,
+		"type:presentation":       false,
+		"path:/documents":         true,
+		"path:/doc":               false,
+		"created:2015-03":         true,
+		"created:..2015-02-28":    false,
+		"modified:2015":           false,
+		"author:\"John Doe\"":     false,
+		"created:2015-03-01..":    true,
+		"type:document path:docs": false,
+	}
+
+	for input, expected := range inputs {
+
+		// act
+		result := ParseQuery(input).Filter.Matches(item)
+
+		// assert
+		if result != expected {
+			t.Errorf("Filter %q should return %t but returned %t.", input, expected, result)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/andreaskoch/allmark/common/logger"
 	"github.com/andreaskoch/allmark/common/route"
 	"github.com/andreaskoch/allmark/model"
+	"path/filepath"
 	"strings"
 )
 
@@ -20,15 +21,31 @@ type Result struct {
 	StoreValue string
 }
 
-// NewItemSearch creates a new repository item searcher.
+// NewItemSearch creates a new repository item searcher which keeps its index in memory.
 func NewItemSearch(logger logger.Logger, items []*model.Item) *ItemSearch {
+	return NewPersistentItemSearch(logger, "", items)
+}
+
+// NewPersistentItemSearch creates a new repository item searcher which stores its index
+// in the given folder. Stored indizes are loaded and only the changed items are re-indexed.
+func NewPersistentItemSearch(logger logger.Logger, indexFolder string, items []*model.Item) *ItemSearch {
 
 	return &ItemSearch{
 		logger: logger,
 
-		routesFullTextIndex:      newIndex(logger, items, "route", itemRouteKeywordProvider),
-		itemContentFullTextIndex: newIndex(logger, items, "content", itemContentKeywordProvider),
+		routesFullTextIndex:      newIndex(logger, items, "route", getIndexFolder(indexFolder, "route"), itemRouteKeywordProvider),
+		itemContentFullTextIndex: newIndex(logger, items, "content", getIndexFolder(indexFolder, "content"), itemContentKeywordProvider),
+	}
+}
+
+// getIndexFolder returns the storage folder for the index with the given name
+// or an empty string if the index is not persisted.
+func getIndexFolder(indexFolder, name string) string {
+	if indexFolder == "" {
+		return ""
 	}
+
+	return filepath.Join(indexFolder, name)
 }
 
 // itemRouteKeywordProvider returns a list of keywords for the fulltext index
@@ -85,6 +102,18 @@ func (itemSearch *ItemSearch) Search(keywords string, maxiumNumberOfResults int)
 	return itemSearch.itemContentFullTextIndex.Search(keywords, maxiumNumberOfResults)
 }
 
+// Update adds the given item to the index or updates its index entries.
+func (itemSearch *ItemSearch) Update(item *model.Item) {
+	itemSearch.routesFullTextIndex.Update(item)
+	itemSearch.itemContentFullTextIndex.Update(item)
+}
+
+// Remove removes the item with the given route from the index.
+func (itemSearch *ItemSearch) Remove(itemRoute route.Route) {
+	itemSearch.routesFullTextIndex.Remove(itemRoute)
+	itemSearch.itemContentFullTextIndex.Remove(itemRoute)
+}
+
 // getContentFromItem returns the content from the given repository item.
 func getContentFromItem(item *model.Item) string {
 
@@ -0,0 +1,168 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package search
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/andreaskoch/fulltext"
+)
+
+const (
+	// snippetLength is the approximate maximum number of characters of a snippet.
+	snippetLength = 200
+
+	// snippetContextLength is the number of characters which are displayed before the first match.
+	snippetContextLength = 60
+
+	// snippetEllipsis marks the beginning or end of truncated snippets.
+	snippetEllipsis = "…"
+)
+
+var (
+	// words are separated by the same characters the full-text index uses
+	snippetWordPattern = regexp.MustCompile(`[^\s,.;:!?\[\]()'"]+`)
+
+	// markdown syntax which is removed from the snippet text
+	markdownImagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLinkPattern       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	markdownHTMLTagPattern    = regexp.MustCompile(`<[^>]+>`)
+	markdownLinePrefixPattern = regexp.MustCompile(`(?m)^\s*(?:#+|>+|[*+-]|\d+\.)\s+`)
+	markdownEmphasisPattern   = regexp.MustCompile("[*`~]+|={3,}|-{3,}")
+	snippetWhitespacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// GetSnippet returns an excerpt of the given markdown text around the words which match the given keywords.
+// The snippet is HTML-encoded and the matching words are wrapped in <mark> tags.
+// If none of the keywords is found an empty string is returned.
+func GetSnippet(markdown, keywords string) string {
+
+	searchWords := make(map[string]bool)
+	for _, word := range getWords(keywords) {
+		searchWords[word] = true
+	}
+
+	if len(searchWords) == 0 {
+		return ""
+	}
+
+	text := getSnippetText(markdown)
+
+	// locate the matching words
+	var matches [][]int
+	for _, position := range snippetWordPattern.FindAllStringIndex(text, -1) {
+		if searchWords[fulltext.IndexizeWord(text[position[0]:position[1]])] {
+			matches = append(matches, position)
+		}
+	}
+
+	if len(matches) == 0 {
+		return ""
+	}
+
+	start, end := getSnippetBoundaries(text, matches)
+
+	// assemble the snippet
+	snippet := ""
+	if start > 0 {
+		snippet += snippetEllipsis + " "
+	}
+
+	position := start
+	for _, match := range matches {
+		if match[0] < start || match[1] > end {
+			continue
+		}
+
+		snippet += html.EscapeString(text[position:match[0]])
+		snippet += "<mark>" + html.EscapeString(text[match[0]:match[1]]) + "</mark>"
+		position = match[1]
+	}
+
+	snippet += html.EscapeString(text[position:end])
+
+	if end < len(text) {
+		snippet += " " + snippetEllipsis
+	}
+
+	return snippet
+}
+
+// getSnippetBoundaries returns the start and end position of the part of
+// the given text which contains the most matches.
+func getSnippetBoundaries(text string, matches [][]int) (start, end int) {
+
+	// find the window with the most matches
+	bestMatch, bestMatchCount := 0, 0
+	for index, match := range matches {
+
+		windowEnd := match[0] + snippetLength - snippetContextLength
+
+		matchCount := 0
+		for _, otherMatch := range matches[index:] {
+			if otherMatch[1] > windowEnd {
+				break
+			}
+
+			matchCount++
+		}
+
+		if matchCount > bestMatchCount {
+			bestMatch, bestMatchCount = index, matchCount
+		}
+	}
+
+	firstMatch := matches[bestMatch]
+
+	// start at a word boundary before the first match
+	start = 0
+	if firstMatch[0] > snippetContextLength {
+		start = getRuneStart(text, firstMatch[0]-snippetContextLength)
+		if spacePosition := strings.Index(text[start:firstMatch[0]], " "); spacePosition != -1 {
+			start += spacePosition + 1
+		} else {
+			start = firstMatch[0]
+		}
+	}
+
+	// end at a word boundary after the last match
+	end = len(text)
+	if start+snippetLength < len(text) {
+		end = getRuneStart(text, start+snippetLength)
+		if end < firstMatch[1] {
+			end = firstMatch[1]
+		}
+
+		if spacePosition := strings.LastIndex(text[firstMatch[1]:end], " "); spacePosition != -1 {
+			end = firstMatch[1] + spacePosition
+		}
+	}
+
+	return start, end
+}
+
+// getRuneStart moves the given position back to the beginning of the
+// UTF-8 encoded character it points to, so the text is not cut inside a character.
+func getRuneStart(text string, position int) int {
+	for position > 0 && position < len(text) && !utf8.RuneStart(text[position]) {
+		position--
+	}
+
+	return position
+}
+
+// getSnippetText converts the given markdown into plain text.
+func getSnippetText(markdown string) string {
+	text := markdownImagePattern.ReplaceAllString(markdown, "$1")
+	text = markdownLinkPattern.ReplaceAllString(text, "$1")
+	text = markdownHTMLTagPattern.ReplaceAllString(text, " ")
+	text = markdownLinePrefixPattern.ReplaceAllString(text, "")
+	text = markdownEmphasisPattern.ReplaceAllString(text, "")
+	text = snippetWhitespacePattern.ReplaceAllString(text, " ")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,91 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package search
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func Test_GetSnippet_MatchesAreHighlighted(t *testing.T) {
+	// arrange
+	markdown := "## Introduction\n\nAllmark renders **Markdown** files. See the [markdown guide](http://example.com/<guide>)."
+
+	// act
+	result := GetSnippet(markdown, "markdown")
+
+	// assert
+	expected := "Introduction Allmark renders <mark>Markdown</mark> files. See the <mark>markdown</mark> guide."
+	if result != expected {
+		t.Errorf("GetSnippet should return %q but returned %q.", expected, result)
+	}
+}
+
+func Test_GetSnippet_TextIsEncoded(t *testing.T) {
+	// arrange
+	markdown := "Use a & b <script>alert(1)</script> with golang"
+
+	// act
+	result := GetSnippet(markdown, "golang")
+
+	// assert
+	if strings.Contains(result, "<script>") || !strings.Contains(result, "a &amp; b") {
+		t.Errorf("The snippet should be html-encoded but was %q.", result)
+	}
+}
+
+func Test_GetSnippet_LongText_SnippetIsTruncatedAroundTheMatch(t *testing.T) {
+	// arrange
+	filler := strings.Repeat("lorem ipsum dolor ", 30)
+	markdown := filler + "the keyword is here " + filler
+
+	// act
+	result := GetSnippet(markdown, "keyword")
+
+	// assert
+	if !strings.HasPrefix(result, snippetEllipsis+" ") || !strings.HasSuffix(result, " "+snippetEllipsis) {
+		t.Errorf("The snippet should be truncated on both sides but was %q.", result)
+	}
+
+	if !strings.Contains(result, "the <mark>keyword</mark> is here") {
+		t.Errorf("The snippet should contain the highlighted keyword but was %q.", result)
+	}
+
+	if len(result) > snippetLength+50 {
+		t.Errorf("The snippet should not be longer than %v characters but was %v characters long.", snippetLength, len(result))
+	}
+}
+
+func Test_GetSnippet_NoMatch_EmptySnippet(t *testing.T) {
+	// arrange
+	markdown := "Some text"
+
+	// act
+	result := GetSnippet(markdown, "keyword")
+
+	// assert
+	if result != "" {
+		t.Errorf("GetSnippet should return an empty string but returned %q.", result)
+	}
+}
+
+func Test_GetSnippet_LongTextWithoutSpaces_SnippetIsValidUTF8(t *testing.T) {
+	// arrange
+	filler := strings.Repeat("日本語のテキスト", 40)
+	markdown := filler + ",keyword,," + filler
+
+	// act
+	result := GetSnippet(markdown, "keyword")
+
+	// assert
+	if !utf8.ValidString(result) {
+		t.Errorf("The snippet should be valid UTF-8 but was %q.", result)
+	}
+
+	if !strings.Contains(result, "<mark>keyword</mark>") {
+		t.Errorf("The snippet should contain the highlighted keyword but was %q.", result)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package search
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// documentFileExtension is the file extension of the stored index documents.
+	documentFileExtension = ".json"
+
+	// temporaryFilePrefix is the prefix of documents which are being written.
+	temporaryFilePrefix = "document-"
+)
+
+// newDocumentStorage creates a new document storage for the given folder.
+func newDocumentStorage(folder string) *documentStorage {
+	return &documentStorage{folder}
+}
+
+// documentStorage stores every index document in a separate file so
+// that updates of a single item do not require saving the whole index.
+type documentStorage struct {
+	folder string
+}
+
+// Load reads all stored documents. Documents which cannot be read or which have
+// been stored with another format version are removed from the storage.
+func (storage *documentStorage) Load() ([]indexDocument, error) {
+
+	files, err := ioutil.ReadDir(storage.folder)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []indexDocument{}, nil
+		}
+
+		return []indexDocument{}, fmt.Errorf("Cannot read the index folder %q. Error: %s", storage.folder, err.Error())
+	}
+
+	documents := make([]indexDocument, 0, len(files))
+	for _, file := range files {
+
+		documentFilePath := filepath.Join(storage.folder, file.Name())
+
+		// remove the leftovers of interrupted writes
+		if strings.HasPrefix(file.Name(), temporaryFilePrefix) {
+			os.Remove(documentFilePath)
+			continue
+		}
+
+		if file.IsDir() || !strings.HasSuffix(file.Name(), documentFileExtension) {
+			continue
+		}
+
+		document, err := readDocument(documentFilePath)
+		if err != nil || document.Version != indexDocumentVersion || storage.getFilePath(document.Route) != documentFilePath {
+			os.Remove(documentFilePath)
+			continue
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// Save stores the given document. The document is written to a temporary
+// file first so an interrupted write does not leave a corrupted document behind.
+func (storage *documentStorage) Save(document indexDocument) error {
+
+	if err := os.MkdirAll(storage.folder, 0700); err != nil {
+		return fmt.Errorf("Cannot create the index folder %q. Error: %s", storage.folder, err.Error())
+	}
+
+	bytes, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	temporaryFile, err := ioutil.TempFile(storage.folder, temporaryFilePrefix)
+	if err != nil {
+		return err
+	}
+
+	if _, err := temporaryFile.Write(bytes); err != nil {
+		temporaryFile.Close()
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	if err := temporaryFile.Close(); err != nil {
+		os.Remove(temporaryFile.Name())
+		return err
+	}
+
+	return os.Rename(temporaryFile.Name(), storage.getFilePath(document.Route))
+}
+
+// Remove deletes the stored document with the given route.
+func (storage *documentStorage) Remove(documentRoute string) error {
+	if err := os.Remove(storage.getFilePath(documentRoute)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// getFilePath returns the path of the file for the document with the given route.
+func (storage *documentStorage) getFilePath(documentRoute string) string {
+	fileName := fmt.Sprintf("%x%s", sha1.Sum([]byte(documentRoute)), documentFileExtension)
+	return filepath.Join(storage.folder, fileName)
+}
+
+// readDocument reads the index document from the given file.
+func readDocument(documentFilePath string) (indexDocument, error) {
+
+	file, err := os.Open(documentFilePath)
+	if err != nil {
+		return indexDocument{}, err
+	}
+
+	defer file.Close()
+
+	var document indexDocument
+	err = json.NewDecoder(file).Decode(&document)
+	return document, err
+}
@@ -14,21 +14,21 @@ type TypeAheadOrchestrator struct {
 	*Orchestrator
 }
 
-func (orchestrator *TypeAheadOrchestrator) GetSuggestions(keywords string) []viewmodel.TypeAhead {
+func (orchestrator *TypeAheadOrchestrator) GetSuggestions(query search.Query) []viewmodel.TypeAhead {
 
 	// collect the search results
 	typeAheadResults := make([]viewmodel.TypeAhead, 0)
 
 	maximumNumberOfResults := 5
 
-	if strings.TrimSpace(keywords) != "" {
+	if !query.IsEmpty() {
 
 		// execute the search
-		searchResultItems := orchestrator.search(keywords, maximumNumberOfResults)
+		searchResultItems := orchestrator.find(query, maximumNumberOfResults)
 
 		// prepare the result models
 		for _, searchResult := range searchResultItems {
-			typeAheadResults = append(typeAheadResults, orchestrator.createTypeAheadResultModel(searchResult))
+			typeAheadResults = append(typeAheadResults, orchestrator.createTypeAheadResultModel(searchResult, query.Keywords))
 		}
 
 	}
@@ -36,7 +36,7 @@ func (orchestrator *TypeAheadOrchestrator) GetSuggestions(keywords string) []vie
 	return typeAheadResults
 }
 
-func (orchestrator *TypeAheadOrchestrator) createTypeAheadResultModel(searchResult search.Result) viewmodel.TypeAhead {
+func (orchestrator *TypeAheadOrchestrator) createTypeAheadResultModel(searchResult search.Result, keywords string) viewmodel.TypeAhead {
 
 	item := orchestrator.getItem(searchResult.Route)
 	if item == nil {
@@ -53,8 +53,9 @@ func (orchestrator *TypeAheadOrchestrator) createTypeAheadResultModel(searchResu
 		Description: item.Description,
 		Route:       location,
 		Path:        item.Route().OriginalValue(),
+		Snippet:     search.GetSnippet(item.Content, keywords),
 
 		Value:  item.Title,
-		Tokens: strings.Split(searchResult.StoreValue, " "),
+		Tokens: strings.Fields(searchResult.StoreValue),
 	}
 }
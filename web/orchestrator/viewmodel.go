@@ -9,6 +9,7 @@ import (
 
 	"github.com/andreaskoch/allmark/common/paths"
 	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/common/toc"
 	"github.com/andreaskoch/allmark/model"
 	"github.com/andreaskoch/allmark/web/view/viewmodel"
 )
@@ -36,6 +37,10 @@ func (orchestrator *ViewModelOrchestrator) GetFullViewModel(itemRoute route.Rout
 
 			// append the content
 			viewModel.Content = orchestrator.getHTMLFromRoute(orchestrator.relativePather(itemRoute), itemRoute)
+			viewModel.TableOfContents = getTableOfContents(viewModel.Content)
+
+			// backlinks
+			viewModel.ReferencedBy = orchestrator.getReferencingModels(itemRoute)
 
 			return viewModel, true
 		}
@@ -46,6 +51,10 @@ func (orchestrator *ViewModelOrchestrator) GetFullViewModel(itemRoute route.Rout
 	// initialize the cache
 	orchestrator.fullViewmodelsByRoute = newViewmodelCache()
 
+	// the base view models must be initialized first so they are updated
+	// before the full view models (even if the requested item does not exist)
+	orchestrator.getViewModel(itemRoute)
+
 	// updateViewModel update the viewmodel cache for the given route.
 	updateViewModel := func(route route.Route) {
 
@@ -291,6 +300,7 @@ func (orchestrator *ViewModelOrchestrator) getViewModel(itemRoute route.Route) (
 			Author:           orchestrator.getAuthorInformation(item.MetaData.Author),
 			Files:            orchestrator.fileOrchestrator.GetFiles(route),
 			Images:           orchestrator.fileOrchestrator.GetImages(route),
+			Custom:           item.MetaData.Custom,
 			IsRepositoryItem: true,
 		}
 
@@ -299,6 +309,16 @@ func (orchestrator *ViewModelOrchestrator) getViewModel(itemRoute route.Route) (
 			viewModel.DOCXURL = GetTypedItemURL(route, "docx")
 		}
 
+		// add history url if the repository provides a revision history
+		if _, isAvailable := orchestrator.historyProvider(); isAvailable {
+			viewModel.HistoryURL = GetTypedItemURL(route, "history")
+		}
+
+		// add edit url if the item can be edited in the browser
+		if itemWriter, isAvailable := orchestrator.itemWriter(); isAvailable && itemWriter.CanWrite(route) {
+			viewModel.EditURL = GetTypedItemURL(route, "edit")
+		}
+
 		orchestrator.viewmodelsByRoute.Set(route.String(), viewModel)
 	}
 
@@ -346,6 +366,24 @@ func (orchestrator *ViewModelOrchestrator) getChildModels(itemRoute route.Route)
 	return childModels
 }
 
+// getReferencingModels returns the base models of all items which link to the item with the given route.
+func (orchestrator *ViewModelOrchestrator) getReferencingModels(itemRoute route.Route) []viewmodel.Base {
+
+	rootItem := orchestrator.rootItem()
+	if rootItem == nil {
+		orchestrator.logger.Fatal("No root item found")
+	}
+
+	referencingModels := make([]viewmodel.Base, 0)
+	for _, referencingItem := range orchestrator.getReferencingItems(itemRoute) {
+		baseModel := getBaseModel(rootItem, referencingItem, orchestrator.config)
+		baseModel.Route = orchestrator.itemPather().Path(baseModel.Route)
+		referencingModels = append(referencingModels, baseModel)
+	}
+
+	return referencingModels
+}
+
 // getHTMLFromRoute returns the converted HTML code for the item with the given route.
 func (orchestrator *ViewModelOrchestrator) getHTMLFromRoute(pathProvider paths.Pather, route route.Route) string {
 	item := orchestrator.getItem(route)
@@ -362,7 +400,7 @@ func (orchestrator *ViewModelOrchestrator) getHTMLFromItem(pathProvider paths.Pa
 		return ""
 	}
 
-	convertedContent, err := orchestrator.converter.Convert(orchestrator.getItemByAlias, pathProvider, item)
+	convertedContent, err := orchestrator.converter.Convert(orchestrator.getItemByAlias, orchestrator.getItemByName, pathProvider, item)
 	if err != nil {
 		orchestrator.logger.Warn("Cannot convert content for route %q. Error: %s.", item.Route(), err.Error())
 		return "<!-- Conversion Error -->"
@@ -370,3 +408,22 @@ func (orchestrator *ViewModelOrchestrator) getHTMLFromItem(pathProvider paths.Pa
 
 	return convertedContent
 }
+
+// getTableOfContents returns the table of contents of the given HTML code.
+func getTableOfContents(html string) []viewmodel.TableOfContentsEntry {
+	return getTableOfContentsEntries(toc.Parse(html))
+}
+
+func getTableOfContentsEntries(entries []toc.Entry) []viewmodel.TableOfContentsEntry {
+	tableOfContents := make([]viewmodel.TableOfContentsEntry, 0, len(entries))
+	for _, entry := range entries {
+		tableOfContents = append(tableOfContents, viewmodel.TableOfContentsEntry{
+			Id:       entry.Id,
+			Title:    entry.Title,
+			Level:    entry.Level,
+			Children: getTableOfContentsEntries(entry.Children),
+		})
+	}
+
+	return tableOfContents
+}
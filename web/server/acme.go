@@ -0,0 +1,155 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	// the first retry for obtaining a certificate happens after this interval
+	certificateRetryInterval = time.Minute
+
+	// the retry interval doubles after every failed attempt up to this interval
+	maxCertificateRetryInterval = time.Hour
+)
+
+// newCertificateManager creates a manager which obtains the certificates for the configured
+// domains from the ACME directory, caches them in the certificates folder and renews them
+// before they expire.
+func newCertificateManager(config config.Config) (*autocert.Manager, error) {
+
+	acmeConfig := config.Server.HTTPS.ACME
+	if !acmeConfig.AcceptTermsOfService {
+		return nil, fmt.Errorf("Please accept the terms of service of the ACME certificate authority %q (AcceptTermsOfService).", acmeConfig.Directory())
+	}
+
+	domains := config.ACMEDomains()
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("No domain names configured for obtaining certificates via ACME.")
+	}
+
+	httpClient, err := getACMEHTTPClient(config.ACMECACertFilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	return &autocert.Manager{
+		Prompt:      autocert.AcceptTOS,
+		Cache:       autocert.DirCache(config.ACMECertificateDirectory()),
+		HostPolicy:  getHostPolicy(domains),
+		RenewBefore: acmeConfig.RenewBefore(),
+		Email:       acmeConfig.Email,
+		Client: &acme.Client{
+			DirectoryURL: acmeConfig.Directory(),
+			HTTPClient:   httpClient,
+			UserAgent:    "allmark",
+		},
+	}, nil
+}
+
+// getHostPolicy returns a policy which only allows certificates for the given domains.
+func getHostPolicy(domains []string) autocert.HostPolicy {
+	whitelist := autocert.HostWhitelist(domains...)
+
+	return func(ctx context.Context, host string) error {
+
+		// the HTTP-01 challenges of test CAs (e.g. Pebble) are not necessarily sent to port 80
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+
+		return whitelist(ctx, host)
+	}
+}
+
+// getACMEHTTPClient returns the HTTP client for the ACME directory which trusts the
+// root certificates of the system and the ones in the given file (if any).
+func getACMEHTTPClient(caCertFilePath string) (*http.Client, error) {
+	if caCertFilePath == "" {
+		return http.DefaultClient, nil
+	}
+
+	pemCerts, err := ioutil.ReadFile(caCertFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read the root certificates for the ACME directory. Error: %s", err)
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	if !rootCAs.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("The file %q does not contain any PEM encoded certificates.", caCertFilePath)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// obtainCertificates loads the certificates of the given domains from the cache or requests
+// them from the ACME directory so they are available before the first request and are renewed
+// in the background. Failed requests are retried until the server is stopped.
+func obtainCertificates(logger logger.Logger, manager *autocert.Manager, domains []string, quit chan struct{}) {
+
+	for _, domain := range domains {
+
+		go func(domain string) {
+
+			hello := getClientHello(domain)
+			retryInterval := certificateRetryInterval
+			for {
+				certificate, err := manager.GetCertificate(hello)
+				if err == nil {
+					logger.Info("The certificate for %q is valid until %s.", domain, certificate.Leaf.NotAfter.Format(time.RFC3339))
+					return
+				}
+
+				logger.Warn("Cannot obtain a certificate for %q. Retrying in %s. Error: %s", domain, retryInterval, err)
+
+				select {
+				case <-quit:
+					return
+
+				case <-time.After(retryInterval):
+				}
+
+				if retryInterval *= 2; retryInterval > maxCertificateRetryInterval {
+					retryInterval = maxCertificateRetryInterval
+				}
+			}
+
+		}(domain)
+
+	}
+
+}
+
+// getClientHello returns the client hello of a client which supports ECDSA certificates
+// for requesting the certificate of the given domain from the certificate manager.
+func getClientHello(domain string) *tls.ClientHelloInfo {
+	return &tls.ClientHelloInfo{
+		ServerName:        domain,
+		SignatureSchemes:  []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256},
+		SupportedCurves:   []tls.CurveID{tls.CurveP256},
+		CipherSuites:      []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		SupportedVersions: []uint16{tls.VersionTLS12},
+	}
+}
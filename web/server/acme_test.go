@@ -0,0 +1,542 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger/console"
+	"github.com/andreaskoch/allmark/common/logger/loglevel"
+	"golang.org/x/crypto/acme"
+)
+
+// the extension of the TLS-ALPN-01 challenge certificates (RFC 8737)
+var acmeIdentifierExtension = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+// testACMEServer is a minimal ACME certificate authority (RFC 8555) which validates the
+// TLS-ALPN-01 challenges with the certificates of the given function and issues the
+// certificates with its own root certificate. The signatures of the requests are not verified.
+type testACMEServer struct {
+	server *httptest.Server
+
+	// returns the certificate the domain presents to the certificate authority
+	getChallengeCertificate func(hello *tls.ClientHelloInfo) (*tls.Certificate, error)
+
+	root    *x509.Certificate
+	rootKey *ecdsa.PrivateKey
+
+	lock       sync.Mutex
+	nonce      int
+	thumbprint string
+	orders     []*testACMEOrder
+}
+
+type testACMEOrder struct {
+	domain      string
+	status      string
+	token       string
+	certificate []byte
+}
+
+func newTestACMEServer(t *testing.T) *testACMEServer {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Cannot create the key of the certificate authority. Error: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "allmark test certificate authority"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, rootKey.Public(), rootKey)
+	if err != nil {
+		t.Fatalf("Cannot create the certificate of the certificate authority. Error: %s", err)
+	}
+
+	root, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Cannot parse the certificate of the certificate authority. Error: %s", err)
+	}
+
+	acmeServer := &testACMEServer{root: root, rootKey: rootKey}
+	acmeServer.server = httptest.NewTLSServer(acmeServer)
+	t.Cleanup(acmeServer.server.Close)
+
+	return acmeServer
+}
+
+// getOrderCount returns the number of orders which have been placed.
+func (acmeServer *testACMEServer) getOrderCount() int {
+	acmeServer.lock.Lock()
+	defer acmeServer.lock.Unlock()
+
+	return len(acmeServer.orders)
+}
+
+func (acmeServer *testACMEServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	acmeServer.lock.Lock()
+	defer acmeServer.lock.Unlock()
+
+	acmeServer.nonce++
+	w.Header().Set("Replay-Nonce", "nonce"+strconv.Itoa(acmeServer.nonce))
+
+	baseURL := acmeServer.server.URL
+	if r.URL.Path == "/directory" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"newNonce":   baseURL + "/nonce",
+			"newAccount": baseURL + "/account",
+			"newOrder":   baseURL + "/order",
+			"revokeCert": baseURL + "/revoke",
+			"keyChange":  baseURL + "/key-change",
+			"meta":       map[string]string{"termsOfService": baseURL + "/terms"},
+		})
+		return
+	}
+
+	if r.URL.Path == "/nonce" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	header, payload, err := readJWS(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"type": "urn:ietf:params:acme:error:malformed", "detail": err.Error()})
+		return
+	}
+
+	if r.URL.Path == "/account" {
+		acmeServer.thumbprint = getJWKThumbprint(header.JWK)
+		w.Header().Set("Location", baseURL+"/account/1")
+		writeJSON(w, http.StatusCreated, map[string]string{"status": "valid"})
+		return
+	}
+
+	if r.URL.Path == "/order" {
+		var request struct {
+			Identifiers []struct{ Value string }
+		}
+
+		if err := json.Unmarshal(payload, &request); err != nil || len(request.Identifiers) != 1 {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"type": "urn:ietf:params:acme:error:malformed"})
+			return
+		}
+
+		acmeServer.orders = append(acmeServer.orders, &testACMEOrder{
+			domain: request.Identifiers[0].Value,
+			status: "pending",
+			token:  "token" + strconv.Itoa(len(acmeServer.orders)),
+		})
+
+		acmeServer.writeOrder(w, http.StatusCreated, len(acmeServer.orders)-1)
+		return
+	}
+
+	// /<resource>/<order index>
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	index := -1
+	if len(parts) == 2 {
+		index, _ = strconv.Atoi(parts[1])
+	}
+
+	if index < 0 || index >= len(acmeServer.orders) {
+		http.NotFound(w, r)
+		return
+	}
+
+	order := acmeServer.orders[index]
+	switch parts[0] {
+
+	case "order":
+		acmeServer.writeOrder(w, http.StatusOK, index)
+
+	case "authz":
+		acmeServer.writeAuthorization(w, index)
+
+	case "challenge":
+		order.status = "invalid"
+		if err := acmeServer.validate(order.domain, order.token+"."+acmeServer.thumbprint); err == nil {
+			order.status = "ready"
+		}
+
+		writeJSON(w, http.StatusOK, acmeServer.getChallenge(index))
+
+	case "finalize":
+		certificate, err := acmeServer.issueCertificate(payload)
+		if order.status != "ready" || err != nil {
+			writeJSON(w, http.StatusForbidden, map[string]string{"type": "urn:ietf:params:acme:error:orderNotReady", "detail": fmt.Sprint(err)})
+			return
+		}
+
+		order.status = "valid"
+		order.certificate = certificate
+		acmeServer.writeOrder(w, http.StatusOK, index)
+
+	case "cert":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		w.Write(order.certificate)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (acmeServer *testACMEServer) writeOrder(w http.ResponseWriter, statusCode, index int) {
+	order := acmeServer.orders[index]
+	baseURL := acmeServer.server.URL
+
+	response := map[string]interface{}{
+		"status":         order.status,
+		"identifiers":    []map[string]string{{"type": "dns", "value": order.domain}},
+		"authorizations": []string{fmt.Sprintf("%s/authz/%d", baseURL, index)},
+		"finalize":       fmt.Sprintf("%s/finalize/%d", baseURL, index),
+	}
+
+	if order.certificate != nil {
+		response["certificate"] = fmt.Sprintf("%s/cert/%d", baseURL, index)
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/order/%d", baseURL, index))
+	writeJSON(w, statusCode, response)
+}
+
+func (acmeServer *testACMEServer) writeAuthorization(w http.ResponseWriter, index int) {
+	order := acmeServer.orders[index]
+
+	status := "valid"
+	if order.status == "pending" || order.status == "invalid" {
+		status = order.status
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":     status,
+		"identifier": map[string]string{"type": "dns", "value": order.domain},
+		"challenges": []interface{}{acmeServer.getChallenge(index)},
+	})
+}
+
+func (acmeServer *testACMEServer) getChallenge(index int) map[string]string {
+	order := acmeServer.orders[index]
+
+	status := "valid"
+	if order.status == "pending" || order.status == "invalid" {
+		status = order.status
+	}
+
+	return map[string]string{
+		"type":   "tls-alpn-01",
+		"url":    fmt.Sprintf("%s/challenge/%d", acmeServer.server.URL, index),
+		"token":  order.token,
+		"status": status,
+	}
+}
+
+// validate checks whether the challenge certificate of the given domain contains the given key authorization.
+func (acmeServer *testACMEServer) validate(domain, keyAuthorization string) error {
+	certificate, err := acmeServer.getChallengeCertificate(&tls.ClientHelloInfo{
+		ServerName:      domain,
+		SupportedProtos: []string{acme.ALPNProto},
+	})
+	if err != nil {
+		return err
+	}
+
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return err
+	}
+
+	expected := sha256.Sum256([]byte(keyAuthorization))
+	for _, extension := range leaf.Extensions {
+		var value []byte
+		if extension.Id.Equal(acmeIdentifierExtension) {
+			if _, err := asn1.Unmarshal(extension.Value, &value); err == nil && bytes.Equal(value, expected[:]) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("The challenge certificate of %q does not contain the key authorization.", domain)
+}
+
+// issueCertificate returns the PEM encoded certificate chain for the certificate signing request in the given payload.
+func (acmeServer *testACMEServer) issueCertificate(payload []byte) ([]byte, error) {
+	var request struct {
+		CSR string
+	}
+
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return nil, err
+	}
+
+	der, err := base64.RawURLEncoding.DecodeString(request.CSR)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: csr.DNSNames[0]},
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, acmeServer.root, csr.PublicKey, acmeServer.rootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	return append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: acmeServer.root.Raw})...), nil
+}
+
+type jwsHeader struct {
+	JWK json.RawMessage `json:"jwk"`
+}
+
+// readJWS returns the protected header and the payload of the JSON web signature in the request body.
+func readJWS(r *http.Request) (header jwsHeader, payload []byte, err error) {
+	var jws struct {
+		Protected string
+		Payload   string
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return header, nil, err
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return header, nil, err
+	}
+
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return header, nil, err
+	}
+
+	payload, err = base64.RawURLEncoding.DecodeString(jws.Payload)
+	return header, payload, err
+}
+
+// getJWKThumbprint returns the thumbprint (RFC 7638) of the given elliptic curve JSON web key.
+func getJWKThumbprint(jwk json.RawMessage) string {
+	var key struct {
+		Crv string
+		X   string
+		Y   string
+	}
+
+	json.Unmarshal(jwk, &key)
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`, key.Crv, key.X, key.Y)))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(value)
+}
+
+// getTestACMEConfiguration returns the configuration for obtaining the certificate of
+// "example.com" from the given certificate authority.
+func getTestACMEConfiguration(t *testing.T, acmeServer *testACMEServer) *config.Config {
+	configuration := config.Default(t.TempDir())
+	configuration.Server.DomainName = "example.com"
+	configuration.Server.HTTPS.ACME.Enabled = true
+	configuration.Server.HTTPS.ACME.AcceptTermsOfService = true
+	configuration.Server.HTTPS.ACME.DirectoryURL = acmeServer.server.URL + "/directory"
+	configuration.Server.HTTPS.ACME.CACertFileName = "acme-ca.pem"
+
+	// trust the TLS certificate of the ACME directory
+	if err := os.MkdirAll(configuration.CertificateDirectory(), 0700); err != nil {
+		t.Fatalf("Cannot create the certificates folder. Error: %s", err)
+	}
+
+	serverCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: acmeServer.server.Certificate().Raw})
+	if err := ioutil.WriteFile(configuration.ACMECACertFilePath(), serverCertificate, 0600); err != nil {
+		t.Fatalf("Cannot save the certificate of the ACME directory. Error: %s", err)
+	}
+
+	return configuration
+}
+
+// getLeafCertificate parses the first certificate of the given PEM encoded key and certificates.
+func getLeafCertificate(t *testing.T, pemData []byte) *x509.Certificate {
+	for {
+		var block *pem.Block
+		if block, pemData = pem.Decode(pemData); block == nil {
+			t.Fatalf("The PEM data does not contain a certificate.")
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatalf("Cannot parse the certificate. Error: %s", err)
+		}
+
+		return certificate
+	}
+}
+
+func Test_getHostPolicy_ConfiguredDomainWithPort_HostIsAllowed(t *testing.T) {
+	// arrange
+	policy := getHostPolicy([]string{"example.com"})
+
+	// act
+	err := policy(context.Background(), "example.com:5002")
+
+	// assert
+	if err != nil {
+		t.Errorf("The host should be allowed but the policy returned an error: %s", err)
+	}
+}
+
+func Test_getHostPolicy_OtherDomain_HostIsRejected(t *testing.T) {
+	// arrange
+	policy := getHostPolicy([]string{"example.com"})
+
+	// act
+	err := policy(context.Background(), "example.org")
+
+	// assert
+	if err == nil {
+		t.Errorf("The host should be rejected")
+	}
+}
+
+func Test_newCertificateManager_TermsOfServiceNotAccepted_ErrorIsReturned(t *testing.T) {
+	// arrange
+	configuration := config.Default("/tmp")
+	configuration.Server.DomainName = "example.com"
+	configuration.Server.HTTPS.ACME.Enabled = true
+
+	// act
+	_, err := newCertificateManager(*configuration)
+
+	// assert
+	if err == nil {
+		t.Errorf("An error should be returned if the terms of service have not been accepted")
+	}
+}
+
+func Test_obtainCertificates_ACMEDirectory_CertificateIsIssuedAndCached(t *testing.T) {
+	// arrange
+	acmeServer := newTestACMEServer(t)
+	configuration := getTestACMEConfiguration(t, acmeServer)
+
+	manager, err := newCertificateManager(*configuration)
+	if err != nil {
+		t.Fatalf("newCertificateManager returned an error: %s", err)
+	}
+
+	acmeServer.getChallengeCertificate = manager.GetCertificate
+
+	quit := make(chan struct{})
+	defer close(quit)
+
+	// act
+	obtainCertificates(console.New(loglevel.Fatal), manager, configuration.ACMEDomains(), quit)
+
+	// assert
+	cacheFile := filepath.Join(configuration.ACMECertificateDirectory(), "example.com")
+	deadline := time.Now().Add(20 * time.Second)
+	for !fileExists(cacheFile) && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	cachedCertificate, err := ioutil.ReadFile(cacheFile)
+	if err != nil {
+		t.Fatalf("The certificate should have been saved in the certificates folder. Error: %s", err)
+	}
+
+	certificate := getLeafCertificate(t, cachedCertificate)
+	if err := certificate.CheckSignatureFrom(acmeServer.root); err != nil || certificate.VerifyHostname("example.com") != nil {
+		t.Errorf("The certificate for %q should have been issued by the certificate authority but was issued by %q for %v (Error: %v)", "example.com", certificate.Issuer.CommonName, certificate.DNSNames, err)
+	}
+}
+
+func Test_newCertificateManager_CertificateInCache_CertificateIsNotRequestedAgain(t *testing.T) {
+	// arrange
+	acmeServer := newTestACMEServer(t)
+	configuration := getTestACMEConfiguration(t, acmeServer)
+
+	manager, err := newCertificateManager(*configuration)
+	if err != nil {
+		t.Fatalf("newCertificateManager returned an error: %s", err)
+	}
+
+	acmeServer.getChallengeCertificate = manager.GetCertificate
+	issuedCertificate, err := manager.GetCertificate(getClientHello("example.com"))
+	if err != nil {
+		t.Fatalf("The certificate could not be obtained. Error: %s", err)
+	}
+
+	// the certificate authority is not available anymore
+	acmeServer.server.Close()
+
+	restartedManager, err := newCertificateManager(*configuration)
+	if err != nil {
+		t.Fatalf("newCertificateManager returned an error: %s", err)
+	}
+
+	// act
+	certificate, err := restartedManager.GetCertificate(getClientHello("example.com"))
+
+	// assert
+	if err != nil {
+		t.Fatalf("The certificate should have been loaded from the certificates folder. Error: %s", err)
+	}
+
+	if !bytes.Equal(certificate.Leaf.Raw, issuedCertificate.Leaf.Raw) || acmeServer.getOrderCount() != 1 {
+		t.Errorf("The cached certificate should have been used but %d certificates were ordered.", acmeServer.getOrderCount())
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
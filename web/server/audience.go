@@ -0,0 +1,113 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package server
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/andreaskoch/allmark/common/config"
+	"github.com/andreaskoch/allmark/common/logger"
+	"github.com/andreaskoch/allmark/common/route"
+	"github.com/andreaskoch/allmark/services/access"
+	"github.com/andreaskoch/allmark/web/handlers"
+	"github.com/andreaskoch/allmark/web/header"
+	"github.com/andreaskoch/allmark/web/orchestrator"
+	"github.com/andreaskoch/allmark/web/view/templates"
+)
+
+// newAudienceHandlers creates a provider for the request handlers of the different audiences.
+// The request handlers of an audience are created on its first request.
+func newAudienceHandlers(logger logger.Logger, config config.Config, templateProvider templates.Provider, orchestratorFactory *orchestrator.Factory, newRouter func(requestHandlers handlers.HandlerList) http.Handler) *audienceHandlers {
+	return &audienceHandlers{
+		logger:              logger,
+		config:              config,
+		templateProvider:    templateProvider,
+		orchestratorFactory: orchestratorFactory,
+		headerWriterFactory: header.NewPrivateHeaderWriterFactory(config.Indexing.IntervalInSeconds),
+		newRouter:           newRouter,
+
+		audiences: make(map[string]*audienceHandler),
+	}
+}
+
+// audienceHandlers creates and caches the request handlers of the different audiences.
+type audienceHandlers struct {
+	logger              logger.Logger
+	config              config.Config
+	templateProvider    templates.Provider
+	orchestratorFactory *orchestrator.Factory
+	headerWriterFactory header.WriterFactory
+	newRouter           func(requestHandlers handlers.HandlerList) http.Handler
+
+	audiences     map[string]*audienceHandler
+	audiencesLock sync.Mutex
+}
+
+// audienceHandler contains the request handlers of an audience.
+type audienceHandler struct {
+	requestHandlers       handlers.HandlerList
+	router                http.Handler
+	viewModelOrchestrator *orchestrator.ViewModelOrchestrator
+}
+
+// AudienceHandler returns the request router which only serves the items the given audience can see.
+func (audienceHandlers *audienceHandlers) AudienceHandler(audience access.Audience) http.Handler {
+	return audienceHandlers.get(audience).router
+}
+
+// RequestHandlers returns the request handlers which only serve the items the given audience can see.
+func (audienceHandlers *audienceHandlers) RequestHandlers(audience access.Audience) handlers.HandlerList {
+	return audienceHandlers.get(audience).requestHandlers
+}
+
+// IsHiddenFrom checks whether the given request path points to an item which exists but is hidden from the given audience.
+func (audienceHandlers *audienceHandlers) IsHiddenFrom(audience access.Audience, requestPath string) bool {
+	requestRoute := route.NewFromRequest(requestPath)
+	if !audienceHandlers.orchestratorFactory.NewViewModelOrchestrator().ItemExists(requestRoute) {
+		return false
+	}
+
+	return !audienceHandlers.get(audience).viewModelOrchestrator.ItemExists(requestRoute)
+}
+
+// get returns the request handlers of the given audience.
+func (audienceHandlers *audienceHandlers) get(audience access.Audience) *audienceHandler {
+
+	audienceHandlers.audiencesLock.Lock()
+	defer audienceHandlers.audiencesLock.Unlock()
+
+	name := audience.Name()
+	if handler, exists := audienceHandlers.audiences[name]; exists {
+		return handler
+	}
+
+	audienceHandlers.logger.Info("Creating the request handlers for the audience %q.", name)
+
+	orchestratorFactory := audienceHandlers.orchestratorFactory.NewFilteredFactory(name, audience.CanView)
+	requestHandlers := handlers.GetBaseHandlers(audienceHandlers.logger, audienceHandlers.config, audienceHandlers.templateProvider, *orchestratorFactory, audienceHandlers.headerWriterFactory)
+
+	handler := &audienceHandler{
+		requestHandlers:       requestHandlers,
+		router:                audienceHandlers.newRouter(requestHandlers),
+		viewModelOrchestrator: orchestratorFactory.NewViewModelOrchestrator(),
+	}
+
+	audienceHandlers.audiences[name] = handler
+	return handler
+}
+
+// unrestrictedAudienceHandlers serves all audiences with the same request router.
+type unrestrictedAudienceHandlers struct {
+	router http.Handler
+}
+
+func (audienceHandlers unrestrictedAudienceHandlers) AudienceHandler(audience access.Audience) http.Handler {
+	return audienceHandlers.router
+}
+
+func (audienceHandlers unrestrictedAudienceHandlers) IsHiddenFrom(audience access.Audience, requestPath string) bool {
+	return false
+}
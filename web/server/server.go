@@ -7,28 +7,35 @@
 package server
 
 import (
+	"context"
 	"github.com/andreaskoch/allmark/common/config"
 	"github.com/andreaskoch/allmark/common/logger"
 	"github.com/andreaskoch/allmark/dataaccess"
+	"github.com/andreaskoch/allmark/services/access"
 	"github.com/andreaskoch/allmark/services/converter/markdowntohtml"
 	"github.com/andreaskoch/allmark/services/converter/markdowntohtml/imageprovider"
+	"github.com/andreaskoch/allmark/services/diagram"
 	"github.com/andreaskoch/allmark/services/parser"
 	"github.com/andreaskoch/allmark/services/thumbnail"
 	"github.com/andreaskoch/allmark/web/handlers"
 	"github.com/andreaskoch/allmark/web/header"
+	"github.com/andreaskoch/allmark/web/oidc"
 	"github.com/andreaskoch/allmark/web/orchestrator"
 	"github.com/andreaskoch/allmark/web/view/templates"
 	"github.com/andreaskoch/allmark/web/webpaths"
 	"fmt"
 	"github.com/gorilla/mux"
 	"github.com/skratchdot/open-golang/open"
+	"golang.org/x/crypto/acme/autocert"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 )
 
 // New creates a new Server instance for the given repository.
-func New(logger logger.Logger, config config.Config, repository dataaccess.Repository, parser parser.Parser, thumbnailIndex *thumbnail.Index) (*Server, error) {
+// The thumbnail service is nil if no thumbnails are created.
+func New(logger logger.Logger, config config.Config, repository dataaccess.Repository, parser parser.Parser, thumbnailIndex *thumbnail.Index, thumbnailService *thumbnail.ConversionService) (*Server, error) {
 
 	patherFactory := webpaths.NewFactory(logger, repository)
 	webPathProvider := webpaths.NewWebPathProvider(patherFactory, handlers.BasePath, handlers.TagPathPrefix)
@@ -36,22 +43,88 @@ func New(logger logger.Logger, config config.Config, repository dataaccess.Repos
 	// image provider
 	imageProvider := imageprovider.NewImageProvider(webPathProvider.AbsolutePather("/"), thumbnailIndex)
 
+	// diagram renderer
+	diagramRenderer := diagram.New(logger, diagram.NewCache(logger, config.DiagramFolder()))
+
 	// converter
-	converter := markdowntohtml.New(logger, imageProvider)
+	converter := markdowntohtml.New(logger, config, imageProvider, diagramRenderer)
 
-	orchestratorFactory := orchestrator.NewFactory(logger, config, repository, parser, converter, webPathProvider)
+	orchestratorFactory := orchestrator.NewFactory(logger, config, repository, parser, converter, webPathProvider, thumbnailIndex, thumbnailService)
 	reindexInterval := config.Indexing.IntervalInSeconds
 	headerWriterFactory := header.NewHeaderWriterFactory(reindexInterval)
+	if config.OIDCIsEnabled() && config.Server.Authentication.Enabled {
+		// the pages depend on the session cookie
+		headerWriterFactory = header.NewPrivateHeaderWriterFactory(reindexInterval)
+	}
+
 	templateProvider := templates.NewProvider(config.TemplatesFolder())
 	requestHandlers := handlers.GetBaseHandlers(logger, config, templateProvider, *orchestratorFactory, headerWriterFactory)
 
-	return &Server{
+	// access rules
+	policy, err := access.New(config.Server.Access)
+	if err != nil {
+		return nil, err
+	}
+
+	// login with an OpenID Connect provider
+	var oidcClient *oidc.Client
+	if config.Server.Authentication.OIDC.Enabled && !config.OIDCIsEnabled() {
+		logger.Warn("Logging in with an OpenID Connect provider is only available over HTTPS (disable HTTP, force HTTPS or configure the trusted proxies which terminate TLS).")
+	}
+
+	if config.OIDCIsEnabled() {
+		sessionKey, err := oidc.LoadKey(config.SessionKeyFilePath())
+		if err != nil {
+			return nil, err
+		}
+
+		oidcClient, err = oidc.New(logger, config.Server.Authentication.OIDC, sessionKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if policy.IsRestricted() && !config.LoginIsAvailable() && oidcClient == nil {
+		logger.Warn("Logging in is only available over HTTPS (disable HTTP, force HTTPS or configure the trusted proxies which terminate TLS) and requires the user store %q. The restricted items are hidden from all users.", config.AuthenticationFilePath())
+	}
+
+	// reverse proxies
+	trustedNetworks, err := config.Server.Proxy.TrustedNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	// certificates via ACME
+	var certificateManager *autocert.Manager
+	if config.Server.HTTPS.Enabled && config.Server.HTTPS.ACME.Enabled {
+		manager, err := newCertificateManager(config)
+		if err != nil {
+			return nil, err
+		}
+
+		certificateManager = manager
+	}
+
+	server := &Server{
 		logger: logger,
 		config: config,
 
 		headerWriterFactory: headerWriterFactory,
 		requestHandlers:     requestHandlers,
-	}, nil
+		certificateManager:  certificateManager,
+		trustedNetworks:     trustedNetworks,
+
+		policy:     policy,
+		oidcClient: oidcClient,
+
+		quit: make(chan struct{}),
+	}
+
+	server.audienceHandlers = newAudienceHandlers(logger, config, templateProvider, orchestratorFactory, func(requestHandlers handlers.HandlerList) http.Handler {
+		return server.getRequestRouter(requestHandlers, false)
+	})
+
+	return server, nil
 
 }
 
@@ -63,12 +136,32 @@ type Server struct {
 	headerWriterFactory header.WriterFactory
 
 	requestHandlers handlers.HandlerList
+
+	policy           *access.Policy
+	audienceHandlers *audienceHandlers
+	oidcClient       *oidc.Client
+
+	certificateManager *autocert.Manager
+	trustedNetworks    []*net.IPNet
+
+	lock        sync.Mutex
+	httpServers []*http.Server
+
+	quit     chan struct{}
+	quitOnce sync.Once
 }
 
-// Start starts the current web server.
+// Start starts the current web server. The returned channel receives an error if one
+// of the endpoints fails and nil for every endpoint that has been stopped by Shutdown.
 func (server *Server) Start() chan error {
 
-	result := make(chan error)
+	result := make(chan error, len(server.config.Server.HTTP.Bindings)+len(server.config.Server.HTTPS.Bindings)+1)
+
+	// basic-authentication is only allowed over secure connections
+	if server.config.Server.Authentication.Enabled && !server.config.AuthenticationIsEnabled() {
+		result <- fmt.Errorf("Basic-Authentication over HTTP is not available. Please disable HTTP, force HTTPS or configure the trusted proxies which terminate TLS in order to use basic-authentication.")
+		return result
+	}
 
 	standardRequestRouter := server.getStandardRequestRouter()
 
@@ -94,31 +187,36 @@ func (server *Server) Start() chan error {
 			tcpAddr := tcpBinding.GetTCPAddress()
 			address := tcpAddr.String()
 
-			// start listening
-			go func() {
-				server.logger.Info("HTTP Endpoint: %s", address)
+			// Standard HTTP Request Router
+			var requestRouter http.Handler = standardRequestRouter
+			if httpEndpoint.ForceHTTPS() {
 
-				if httpEndpoint.ForceHTTPS() {
+				// Redirect HTTP → HTTPS
+				redirectTarget := httpsEndpoint.DefaultURL()
+				requestRouter = server.getRedirectRouter(redirectTarget, standardRequestRouter)
 
-					// Redirect HTTP → HTTPS
-					redirectTarget := httpsEndpoint.DefaultURL()
-					httpsRedirectRouter := server.getRedirectRouter(redirectTarget, standardRequestRouter)
+			} else if server.config.Server.Proxy.IsEnabled() && server.acceptsLogins() {
 
-					if err := http.ListenAndServe(address, httpsRedirectRouter); err != nil {
-						result <- fmt.Errorf("Server failed with error: %v", err)
-					} else {
-						result <- nil
-					}
+				// credentials are only accepted from proxies which have been reached over HTTPS
+				requestRouter = handlers.RequireHTTPS(server.logger, standardRequestRouter)
 
-				} else {
+			}
+
+			// answer the HTTP-01 challenges of the ACME certificate authority
+			if server.certificateManager != nil {
+				requestRouter = server.certificateManager.HTTPHandler(requestRouter)
+			}
 
-					// Standard HTTP Request Router
-					if err := http.ListenAndServe(address, standardRequestRouter); err != nil {
-						result <- fmt.Errorf("Server failed with error: %v", err)
-					} else {
-						result <- nil
-					}
+			httpServer := server.newHTTPServer(address, server.trustProxies(requestRouter))
 
+			// start listening
+			go func() {
+				server.logger.Info("HTTP Endpoint: %s", address)
+
+				if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					result <- fmt.Errorf("Server failed with error: %v", err)
+				} else {
+					result <- nil
 				}
 
 			}()
@@ -142,12 +240,21 @@ func (server *Server) Start() chan error {
 			tcpAddr := tcpBinding.GetTCPAddress()
 			address := tcpAddr.String()
 
+			// Standard HTTPS Request Router
+			httpServer := server.newHTTPServer(address, server.trustProxies(standardRequestRouter))
+
+			// use the certificates obtained via ACME (this includes answering TLS-ALPN-01 challenges)
+			certFilePath, keyFilePath := httpsEndpoint.CertFilePath(), httpsEndpoint.KeyFilePath()
+			if server.certificateManager != nil {
+				httpServer.TLSConfig = server.certificateManager.TLSConfig()
+				certFilePath, keyFilePath = "", ""
+			}
+
 			// start listening
 			go func() {
 				server.logger.Info("HTTPS Endpoint: %s", address)
 
-				// Standard HTTPS Request Router
-				if err := http.ListenAndServeTLS(address, httpsEndpoint.CertFilePath(), httpsEndpoint.KeyFilePath(), standardRequestRouter); err != nil {
+				if err := httpServer.ListenAndServeTLS(certFilePath, keyFilePath); err != nil && err != http.ErrServerClosed {
 					result <- fmt.Errorf("Server failed with error: %v", err)
 				} else {
 					result <- nil
@@ -160,20 +267,27 @@ func (server *Server) Start() chan error {
 			uniqueURLs[endpointURL] = endpointURL
 		}
 
+		// obtain the certificates and renew them in the background
+		if server.certificateManager != nil {
+			obtainCertificates(server.logger, server.certificateManager, server.config.ACMEDomains(), server.quit)
+		}
+
 	}
 
 	// docx conversion endpoint (unencrypted, no authentication)
 	if server.config.Conversion.DOCX.IsEnabled() {
 
+		conversionEndpointTCPAddress := server.config.Conversion.EndpointBinding().GetTCPAddress()
+		conversionEndpointAddress := conversionEndpointTCPAddress.String()
+
+		// Local Request Router
+		httpServer := server.newHTTPServer(conversionEndpointAddress, server.getLocalRequestRouter())
+
 		// start listening
 		go func() {
-			conversionEndpointTCPAddress := server.config.Conversion.EndpointBinding().GetTCPAddress()
-			conversionEndpointAddress := conversionEndpointTCPAddress.String()
-
 			server.logger.Info("Docx Conversion Endpoint: %s", conversionEndpointAddress)
 
-			// Standard HTTPS Request Router
-			if err := http.ListenAndServe(conversionEndpointAddress, server.getLocalRequestRouter()); err != nil {
+			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				result <- fmt.Errorf("Docx Conversion endpoint failed with error: %v", err)
 			} else {
 				result <- nil
@@ -192,6 +306,75 @@ func (server *Server) Start() chan error {
 	return result
 }
 
+// Shutdown stops all endpoints of the current web server gracefully. The listeners are closed
+// immediately; the open requests are completed until the given context expires.
+func (server *Server) Shutdown(ctx context.Context) error {
+
+	server.quitOnce.Do(func() {
+		close(server.quit)
+	})
+
+	server.lock.Lock()
+	httpServers := server.httpServers
+	server.lock.Unlock()
+
+	var wg sync.WaitGroup
+	errors := make(chan error, len(httpServers))
+
+	for _, httpServer := range httpServers {
+		wg.Add(1)
+
+		go func(httpServer *http.Server) {
+			defer wg.Done()
+
+			if err := httpServer.Shutdown(ctx); err != nil {
+				// drop the remaining connections
+				httpServer.Close()
+				errors <- fmt.Errorf("Cannot stop the endpoint %s gracefully. Error: %s", httpServer.Addr, err)
+			}
+		}(httpServer)
+	}
+
+	wg.Wait()
+	close(errors)
+
+	return <-errors
+}
+
+// newHTTPServer creates a server for the given address and handler with the configured timeouts.
+func (server *Server) newHTTPServer(address string, handler http.Handler) *http.Server {
+
+	timeouts := server.config.Server.Timeouts
+	httpServer := &http.Server{
+		Addr:         address,
+		Handler:      handler,
+		ReadTimeout:  timeouts.Read(),
+		WriteTimeout: timeouts.Write(),
+		IdleTimeout:  timeouts.Idle(),
+	}
+
+	server.lock.Lock()
+	server.httpServers = append(server.httpServers, httpServer)
+	server.lock.Unlock()
+
+	return httpServer
+}
+
+// trustProxies applies the X-Forwarded-* headers of the requests from the trusted proxies (if there are any).
+func (server *Server) trustProxies(handler http.Handler) http.Handler {
+	if len(server.trustedNetworks) == 0 {
+		return handler
+	}
+
+	return handlers.TrustProxies(server.logger, server.trustedNetworks, handler)
+}
+
+// acceptsLogins get a flag indicating if users can log in (with the credentials of the user store
+// or with an OpenID Connect provider) or if basic-authentication is required.
+func (server *Server) acceptsLogins() bool {
+	return server.config.LoginIsAvailable() || server.config.OIDCIsEnabled() || server.config.AuthenticationIsEnabled()
+}
+
 // getRedirectRouter returns a router which redirects all requests to the url with the given base.
 func (server *Server) getRedirectRouter(baseURITarget string, baseHandler http.Handler) *mux.Router {
 	redirectRouter := mux.NewRouter()
@@ -207,12 +390,56 @@ func (server *Server) getRedirectRouter(baseURITarget string, baseHandler http.H
 }
 
 // Get an instance of the standard request router for all repository related routes.
-func (server *Server) getStandardRequestRouter() *mux.Router {
+// If there are access rules or users log in with an OpenID Connect provider, the requests
+// are served by the router of the user's audience.
+func (server *Server) getStandardRequestRouter() http.Handler {
+
+	loginIsRequired := server.config.AuthenticationIsEnabled()
+
+	if !server.policy.IsRestricted() && server.oidcClient == nil {
+		return server.getRequestRouter(server.requestHandlers, loginIsRequired)
+	}
+
+	var audienceHandlers handlers.AudienceHandlerProvider = server.audienceHandlers
+	if !server.policy.IsRestricted() {
+		audienceHandlers = unrestrictedAudienceHandlers{server.getRequestRouter(server.requestHandlers, false)}
+	}
+
+	// login with an OpenID Connect provider
+	if server.oidcClient != nil {
+		redirectURL := server.config.Server.Authentication.OIDC.RedirectURL
+		authenticator := handlers.NewOIDCAuthenticator(server.logger, server.oidcClient, redirectURL)
+
+		requestRouter := mux.NewRouter()
+		requestRouter.Handle(handlers.LoginCallbackHandlerRoute, handlers.LogRequests(handlers.OIDCCallback(server.logger, server.oidcClient, redirectURL)))
+		requestRouter.Handle(handlers.LogoutHandlerRoute, handlers.LogRequests(handlers.OIDCLogout(server.logger, server.oidcClient)))
+		requestRouter.PathPrefix(handlers.BasePath).Handler(handlers.RequireAccess(server.logger, server.policy, authenticator, loginIsRequired, audienceHandlers))
+
+		return requestRouter
+	}
+
+	// login with the credentials of the user store
+	var authenticator handlers.Authenticator
+	userStore := server.config.GetLoginUserStore()
+	if loginIsRequired {
+		userStore = server.config.GetAuthenticationUserStore()
+	}
+
+	if userStore != nil {
+		authenticator = handlers.NewBasicAuthenticator(userStore)
+	}
+
+	return handlers.RequireAccess(server.logger, server.policy, authenticator, loginIsRequired, audienceHandlers)
+}
+
+// getRequestRouter returns a request router for the given request handlers with logging,
+// compression and, if requested, authentication.
+func (server *Server) getRequestRouter(requestHandlers handlers.HandlerList, requireAuthentication bool) *mux.Router {
 
 	// register requst routers
 	requestRouter := mux.NewRouter()
 
-	for _, requestHandler := range server.requestHandlers {
+	for _, requestHandler := range requestHandlers {
 		requestRoute := requestHandler.Route
 		requestHandler := requestHandler.Handler
 
@@ -223,7 +450,7 @@ func (server *Server) getStandardRequestRouter() *mux.Router {
 		requestHandler = handlers.CompressResponses(requestHandler)
 
 		// add authentication
-		if _, httpsEnabled := server.httpsEndpoint(); httpsEnabled && server.config.AuthenticationIsEnabled() {
+		if requireAuthentication {
 			secretProvider := server.config.GetAuthenticationUserStore()
 			if secretProvider == nil {
 				panic("Authentication is enabled but the supplied secret provider is nil.")
@@ -238,13 +465,39 @@ func (server *Server) getStandardRequestRouter() *mux.Router {
 	return requestRouter
 }
 
+// Handler returns a request router for all repository related routes without logging,
+// compression and authentication (e.g. for rendering the repository into static files).
+// If there are access rules, only the items which are visible to anonymous users are served.
+func (server *Server) Handler() http.Handler {
+
+	requestHandlers := server.requestHandlers
+	if server.policy.IsRestricted() {
+		requestHandlers = server.audienceHandlers.RequestHandlers(server.policy.Anonymous())
+	}
+
+	requestRouter := mux.NewRouter()
+
+	for _, requestHandler := range requestHandlers {
+		requestRouter.Handle(requestHandler.Route, requestHandler.Handler)
+	}
+
+	return requestRouter
+}
+
 // getLocalRequestRouter returns a local request router without compression and without authentication.
+// The local router is bound to the loopback interface, but any local process can use it, so if there
+// are access rules, only the items which are visible to anonymous users are served.
 func (server *Server) getLocalRequestRouter() *mux.Router {
 
+	requestHandlers := server.requestHandlers
+	if server.policy.IsRestricted() {
+		requestHandlers = server.audienceHandlers.RequestHandlers(server.policy.Anonymous())
+	}
+
 	// register requst routers
 	requestRouter := mux.NewRouter()
 
-	for _, requestHandler := range server.requestHandlers {
+	for _, requestHandler := range requestHandlers {
 		requestRoute := requestHandler.Route
 		requestHandler := requestHandler.Handler
 
@@ -285,7 +538,11 @@ func (server *Server) httpsEndpoint() (httpsEndpoint HTTPSEndpoint, enabled bool
 		tcpBindings: server.config.Server.HTTPS.Bindings,
 	}
 
-	certFilePath, keyFilePath, _ := server.config.CertificateFilePaths()
+	// the certificates obtained via ACME don't have any files
+	certFilePath, keyFilePath := "", ""
+	if !server.config.Server.HTTPS.ACME.Enabled {
+		certFilePath, keyFilePath, _ = server.config.CertificateFilePaths()
+	}
 
 	httpsEndpoint = HTTPSEndpoint{
 		HTTPEndpoint: httpEndpoint,
@@ -0,0 +1,53 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package defaulttheme
+
+import (
+	"github.com/andreaskoch/allmark/web/view/templates/templatenames"
+)
+
+func init() {
+	templates[templatenames.Edit] = editTemplate
+}
+
+const editTemplate = `
+<header>
+<h1 class="title">
+{{.Title}}
+</h1>
+</header>
+
+<section class="description">
+{{.Description}}
+</section>
+
+<section class="content">
+
+{{ if .Conflict }}
+<p class="conflict">
+	Someone else has changed this item while you were editing it.
+	Compare your changes with the <a href="{{.BaseURL}}" target="_blank">current version</a> and save again to overwrite it.
+</p>
+{{ end }}
+
+<form class="editor" action="{{.EditURL}}" method="POST">
+	<input type="hidden" name="hash" value="{{.LastHash | html}}">
+
+	<div class="panes">
+		<textarea name="markdown" spellcheck="true" autofocus>{{.Markdown | html}}</textarea>
+		<div class="preview">{{.Preview}}</div>
+	</div>
+
+	<input type="submit" value="Save">
+	<a href="{{.BaseURL}}">Cancel</a>
+</form>
+
+<form class="upload" action="{{.UploadURL}}" method="POST" enctype="multipart/form-data">
+	<label>Add files: <input type="file" name="files" multiple></label>
+	<input type="submit" value="Upload">
+</form>
+
+</section>
+`
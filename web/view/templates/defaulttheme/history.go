@@ -0,0 +1,100 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package defaulttheme
+
+import (
+	"github.com/andreaskoch/allmark/web/view/templates/templatenames"
+)
+
+func init() {
+	templates[templatenames.History] = historyTemplate
+}
+
+const historyTemplate = `
+<header>
+<h1 class="title">
+{{.Title}}
+</h1>
+</header>
+
+<section class="description">
+{{.Description}}
+</section>
+
+<section class="content">
+
+{{ with .Diff }}
+<section class="diff">
+	<header>
+		Changes between
+		<span class="revision" title="{{.From.Subject | html}}">{{.From.ShortId}}</span> ({{.From.Date}})
+		and
+		<span class="revision" title="{{.To.Subject | html}}">{{.To.ShortId}}</span> ({{.To.Date}}):
+	</header>
+
+	{{ range .Files }}
+	<section class="diff-file">
+		<h2>{{.Name | html}}</h2>
+		{{ if .IsBinary }}
+		<p class="binary">Binary file changed.</p>
+		{{ else }}
+		<table>
+			{{ range .Lines }}
+			<tr class="{{.Type}}">
+			{{ if eq .Type "hunk" }}
+				<td colspan="3">{{.Text | html}}</td>
+			{{ else }}
+				<td class="number">{{if .OldNumber}}{{.OldNumber}}{{end}}</td>
+				<td class="number">{{if .NewNumber}}{{.NewNumber}}{{end}}</td>
+				<td class="text"><pre>{{.Text | html}}</pre></td>
+			{{ end }}
+			</tr>
+			{{ end }}
+		</table>
+		{{ end }}
+	</section>
+	{{ else }}
+	<p>There are no changes between these revisions.</p>
+	{{ end }}
+</section>
+{{ end }}
+
+{{ if .Revisions }}
+<form class="revisions" action="{{.HistoryURL}}" method="GET">
+	<table>
+		<thead>
+			<tr>
+				<th title="Compare from this revision">From</th>
+				<th title="Compare to this revision">To</th>
+				<th>Revision</th>
+				<th>Date</th>
+				<th>Author</th>
+				<th>Message</th>
+			</tr>
+		</thead>
+		<tbody>
+		{{ range $index, $revision := .Revisions }}
+			<tr>
+				<td><input type="radio" name="from" value="{{.Id}}"{{if eq $index 1}} checked{{end}}></td>
+				<td><input type="radio" name="to" value="{{.Id}}"{{if eq $index 0}} checked{{end}}></td>
+				<td class="revision">{{if .DiffURL}}<a href="{{.DiffURL | html}}" title="Show the changes of this revision">{{.ShortId}}</a>{{else}}{{.ShortId}}{{end}}</td>
+				<td class="date">{{.Date}}</td>
+				<td class="author">{{.Author | html}}</td>
+				<td class="message" title="{{.Message | html}}">{{.Subject | html}}</td>
+			</tr>
+		{{ end }}
+		</tbody>
+	</table>
+
+	{{ if gt (len .Revisions) 1 }}
+	<input type="submit" value="Compare">
+	{{ end }}
+</form>
+{{ else }}
+<p>This item has not been committed yet.</p>
+{{ end }}
+
+</section>
+`
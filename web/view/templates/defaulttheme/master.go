@@ -14,6 +14,8 @@ func init() {
 		breadcrumbNavigationSnippet +
 		itemNavigationSnippet +
 		childrenSnippet +
+		tableOfContentsSnippet +
+		referencedBySnippet +
 		tagcloudSnippet +
 		tagsSnippet +
 		publisherSnippet +
@@ -23,6 +25,8 @@ func init() {
 	templates[templatenames.BreadcrumbNavigation] = breadcrumbNavigationSnippet
 	templates[templatenames.ItemNavigation] = itemNavigationSnippet
 	templates[templatenames.Children] = childrenSnippet
+	templates[templatenames.TableOfContents] = tableOfContentsSnippet
+	templates[templatenames.ReferencedBy] = referencedBySnippet
 	templates[templatenames.TagCloud] = tagcloudSnippet
 	templates[templatenames.Tags] = tagsSnippet
 	templates[templatenames.Publisher] = publisherSnippet
@@ -98,21 +102,27 @@ const masterTemplate = `<!DOCTYPE HTML>
 
 	{{template "itemnavigation-snippet" .}}
 
+	{{template "toc-snippet" .}}
+
 	{{template "children-snippet" .}}
 
+	{{template "referencedby-snippet" .}}
+
 	{{template "tagcloud-snippet" .}}
 
 </aside>
 
 <div class="cleaner"></div>
 
-{{if or .PrintURL .JSONURL .MarkdownURL .DOCXURL}}
+{{if or .PrintURL .JSONURL .MarkdownURL .DOCXURL .HistoryURL .EditURL}}
 <aside class="export">
 <ul>
 	{{if .PrintURL}}<li><a href="{{.PrintURL}}">Print</a></li>{{end}}
 	{{if .JSONURL}}<li><a href="{{.JSONURL}}">JSON</a></li>{{end}}
 	{{if .MarkdownURL}}<li><a href="{{.MarkdownURL}}">Markdown</a></li>{{end}}
 	{{if .DOCXURL}}<li><a href="{{.DOCXURL}}">DOCX</a></li>{{end}}
+	{{if .HistoryURL}}<li><a href="{{.HistoryURL}}">History</a></li>{{end}}
+	{{if .EditURL}}<li><a href="{{.EditURL}}" rel="nofollow">Edit</a></li>{{end}}
 </ul>
 </aside>
 {{end}}
@@ -144,13 +154,10 @@ const masterTemplate = `<!DOCTYPE HTML>
 {{ if .LiveReloadEnabled }}<script src="/theme/autoupdate.js"></script>{{ end }}
 <script src="/theme/presentation.js"></script>
 <script src="/theme/latest.js"></script>
-<script src="/theme/codehighlighting/highlight.js"></script>
 <script type="text/javascript">
 $(function() {
-	// code highligting
-	$('pre code').each(function(i, block) {
-		hljs.highlightBlock(block);
-	});
+	// diagrams
+	renderDiagrams();
 
 	// deep linking
 	addDeepLinksToElements('section.content > h1, h2, h3, h4, h5, h6');
@@ -158,11 +165,9 @@ $(function() {
 	// register a on change listener
 	if (typeof(autoupdate) === 'object' && typeof(autoupdate.onchange) === 'function') {
 		autoupdate.onchange(
-			"Code Highlighting",
+			"Diagrams",
 			function() {
-				$('pre code').each(function(i, block) {
-					hljs.highlightBlock(block);
-				});
+				renderDiagrams();
 			}
 		);
 	}
@@ -170,6 +175,8 @@ $(function() {
 </script>
 {{ end }}
 
+{{ if eq .Type "editor" }}<script src="/theme/editor.js"></script>{{ end }}
+
 {{if .Analytics.Enabled}}
 {{if .Analytics.GoogleAnalytics.Enabled}}
 <script>
@@ -260,6 +267,44 @@ const childrenSnippet = `{{define "children-snippet"}}
 {{end}}
 `
 
+const referencedBySnippet = `{{define "referencedby-snippet"}}
+<section class="referenced-by">
+{{ if .ReferencedBy }}
+<h1>Referenced by</h1>
+
+<ol class="list">
+{{range .ReferencedBy}}
+<li class="reference">
+	<a href="{{.Route}}" class="reference-title reference-link">{{.Title}}</a>
+</li>
+{{end}}
+</ol>
+{{end}}
+</section>
+{{end}}
+`
+
+const tableOfContentsSnippet = `{{define "toc-snippet"}}
+<section class="toc">
+{{ if .TableOfContents }}
+<h1>Contents</h1>
+{{template "toc-entries" .TableOfContents}}
+{{end}}
+</section>
+{{end}}
+
+{{define "toc-entries"}}
+<ol>
+{{range .}}
+<li>
+	<a href="#{{.Id}}">{{.Title | html}}</a>
+	{{ if .Children }}{{template "toc-entries" .Children}}{{end}}
+</li>
+{{end}}
+</ol>
+{{end}}
+`
+
 const tagcloudSnippet = `{{define "tagcloud-snippet"}}
 <section class="tagcloud">
 {{if .TagCloud}}
@@ -34,6 +34,24 @@ const searchTemplate = `
 	</form>
 </nav>
 
+{{if .Facets}}
+<aside class="facets">
+	{{ range .Facets }}
+	<section class="facet facet-{{.Name}}">
+		<h2>{{.Title}}</h2>
+		<ul>
+			{{ range .Values }}
+			<li{{if .IsActive}} class="active"{{end}}>
+				<a href="/search?q={{.Query | urlquery}}">{{.Value}}</a>
+				<span class="count">{{.Count}}</span>
+			</li>
+			{{ end }}
+		</ul>
+	</section>
+	{{ end }}
+</aside>
+{{end}}
+
 {{if .ResultCount}}
 <header>
 	Displaying {{.ResultCount}} of {{.TotalResultCount}} search results for "{{.Query}}":
@@ -44,6 +62,7 @@ const searchTemplate = `
 	<li data-index="{{.Index}}">
 			<a class="title" href="{{.Route}}">{{.Title}}</a>
 			<p class="description">{{.Description}}</p>
+			{{if .Snippet}}<p class="snippet">{{.Snippet}}</p>{{end}}
 			<span class="path">{{.Path}}</span>
 	</li>
 	{{ end }}
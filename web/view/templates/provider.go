@@ -66,6 +66,16 @@ func (provider *Provider) GetSearchTemplate(hostname string) (*template.Template
 	return provider.getWrappedTemplate(templatenames.Search, hostname)
 }
 
+// GetHistoryTemplate returns the template for the revision history of items.
+func (provider *Provider) GetHistoryTemplate(hostname string) (*template.Template, error) {
+	return provider.getWrappedTemplate(templatenames.History, hostname)
+}
+
+// GetEditTemplate returns the template of the markdown editor.
+func (provider *Provider) GetEditTemplate(hostname string) (*template.Template, error) {
+	return provider.getWrappedTemplate(templatenames.Edit, hostname)
+}
+
 // GetItemTemplate returns the item template for the given item type (e.g. document, presentation).
 func (provider *Provider) GetItemTemplate(itemType, hostname string) (*template.Template, error) {
 	return provider.getWrappedTemplate(itemType, hostname)
@@ -22,6 +22,8 @@ const (
 	TagMap     = "tagmap"
 	AliasIndex = "aliasindex"
 	Search     = "search"
+	History    = "history"
+	Edit       = "edit"
 	Conversion = "converter"
 	RobotsTxt  = "robotstxt"
 
@@ -32,5 +34,7 @@ const (
 	BreadcrumbNavigation = "breadcrumbnavigation-snippet"
 	ItemNavigation       = "itemnavigation-snippet"
 	Children               = "children-snippet"
+	TableOfContents        = "toc-snippet"
+	ReferencedBy           = "referencedby-snippet"
 	TagCloud             = "tagcloud-snippet"
 )
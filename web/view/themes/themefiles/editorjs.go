@@ -0,0 +1,108 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package themefiles
+
+// EditorJs updates the preview of the markdown editor while the user is typing
+// and inserts links to uploaded or pasted files into the markdown.
+const EditorJs = `
+$(function() {
+
+	var form = $('form.editor');
+	if (form.length === 0) {
+		return;
+	}
+
+	var textarea = form.find('textarea[name=markdown]');
+	var preview = form.find('.preview');
+
+	var timer;
+	var pendingRequest;
+
+	var updatePreview = function() {
+		if (pendingRequest) {
+			pendingRequest.abort();
+		}
+
+		pendingRequest = $.ajax({
+			url: form.attr('action'),
+			type: 'POST',
+			data: { action: 'preview', markdown: textarea.val() },
+			dataType: 'html',
+			success: function(html) {
+				preview.html(html);
+				renderDiagrams();
+			}
+		});
+	};
+
+	textarea.on('input', function() {
+		clearTimeout(timer);
+		timer = setTimeout(updatePreview, 300);
+	});
+
+	// insert the given text at the cursor position
+	var insert = function(text) {
+		var element = textarea.get(0);
+		var start = element.selectionStart;
+		var end = element.selectionEnd;
+		var markdown = textarea.val();
+
+		textarea.val(markdown.substring(0, start) + text + markdown.substring(end));
+		element.selectionStart = element.selectionEnd = start + text.length;
+		updatePreview();
+	};
+
+	var uploadForm = $('form.upload');
+
+	var upload = function(files) {
+		if (files.length === 0) {
+			return;
+		}
+
+		var data = new FormData();
+		$.each(files, function(i, file) {
+			data.append('files', file, file.name);
+		});
+
+		$.ajax({
+			url: uploadForm.attr('action'),
+			type: 'POST',
+			data: data,
+			processData: false,
+			contentType: false,
+			dataType: 'json',
+			headers: { Accept: 'application/json' },
+			success: function(uploads) {
+				insert($.map(uploads, function(upload) {
+					return upload.markdown;
+				}).join('\n'));
+			},
+			error: function(request) {
+				alert('The files could not be uploaded: ' + request.statusText);
+			}
+		});
+	};
+
+	// upload the selected files without leaving the editor
+	uploadForm.on('submit', function(event) {
+		event.preventDefault();
+
+		var input = uploadForm.find('input[type=file]');
+		upload(input.get(0).files);
+		input.val('');
+	});
+
+	// upload pasted screenshots
+	textarea.on('paste', function(event) {
+		var clipboard = event.originalEvent.clipboardData;
+		if (clipboard && clipboard.files && clipboard.files.length > 0) {
+			event.preventDefault();
+			upload(clipboard.files);
+		}
+	});
+
+	highlight();
+});
+`
@@ -1,997 +0,0 @@
-// Copyright 2014 Andreas Koch. All rights reserved.
-// Use of this source code is governed by a BSD-style
-// license that can be found in the LICENSE file.
-
-package themefiles
-
-const HighlightJs = `LyogSGlnaGxpZ2h0LmpzIHYuOC4zICovCnZhciBobGpzPW5ldyBmdW5jdGlvbigpe2Z1bmN0aW9u
-IGUoZSl7cmV0dXJuIGUucmVwbGFjZSgvJi9nbSwiJmFtcDsiKS5yZXBsYWNlKC88L2dtLCImbHQ7
-IikucmVwbGFjZSgvPi9nbSwiJmd0OyIpfWZ1bmN0aW9uIHQoZSl7cmV0dXJuIGUubm9kZU5hbWUu
-dG9Mb3dlckNhc2UoKX1mdW5jdGlvbiBuKGUsdCl7dmFyIG49ZSYmZS5leGVjKHQpO3JldHVybiBu
-JiYwPT1uLmluZGV4fWZ1bmN0aW9uIHIoZSl7dmFyIHQ9KGUuY2xhc3NOYW1lKyIgIisoZS5wYXJl
-bnROb2RlP2UucGFyZW50Tm9kZS5jbGFzc05hbWU6IiIpKS5zcGxpdCgvXHMrLyk7cmV0dXJuIHQ9
-dC5tYXAoZnVuY3Rpb24oZSl7cmV0dXJuIGUucmVwbGFjZSgvXmxhbmcodWFnZSk/LS8sIiIpfSks
-dC5maWx0ZXIoZnVuY3Rpb24oZSl7cmV0dXJuIG0oZSl8fC9ubygtPyloaWdobGlnaHQvLnRlc3Qo
-ZSl9KVswXX1mdW5jdGlvbiBpKGUsdCl7dmFyIG49e307Zm9yKHZhciByIGluIGUpbltyXT1lW3Jd
-O2lmKHQpZm9yKHZhciByIGluIHQpbltyXT10W3JdO3JldHVybiBufWZ1bmN0aW9uIGEoZSl7dmFy
-IG49W107cmV0dXJuIGZ1bmN0aW9uIHIoZSxpKXtmb3IodmFyIGE9ZS5maXJzdENoaWxkO2E7YT1h
-Lm5leHRTaWJsaW5nKTM9PWEubm9kZVR5cGU/aSs9YS5ub2RlVmFsdWUubGVuZ3RoOjE9PWEubm9k
-ZVR5cGUmJihuLnB1c2goe2V2ZW50OiJzdGFydCIsb2Zmc2V0Omksbm9kZTphfSksaT1yKGEsaSks
-dChhKS5tYXRjaCgvYnJ8aHJ8aW1nfGlucHV0Lyl8fG4ucHVzaCh7ZXZlbnQ6InN0b3AiLG9mZnNl
-dDppLG5vZGU6YX0pKTtyZXR1cm4gaX0oZSwwKSxufWZ1bmN0aW9uIHMobixyLGkpe2Z1bmN0aW9u
-IGEoKXtyZXR1cm4gbi5sZW5ndGgmJnIubGVuZ3RoP25bMF0ub2Zmc2V0IT1yWzBdLm9mZnNldD9u
-WzBdLm9mZnNldDxyWzBdLm9mZnNldD9uOnI6InN0YXJ0Ij09clswXS5ldmVudD9uOnI6bi5sZW5n
-dGg/bjpyfWZ1bmN0aW9uIHMobil7ZnVuY3Rpb24gcih0KXtyZXR1cm4iICIrdC5ub2RlTmFtZSsn
-PSInK2UodC52YWx1ZSkrJyInfWwrPSI8Iit0KG4pK0FycmF5LnByb3RvdHlwZS5tYXAuY2FsbChu
-LmF0dHJpYnV0ZXMscikuam9pbigiIikrIj4ifWZ1bmN0aW9uIG8oZSl7bCs9IjwvIit0KGUpKyI+
-In1mdW5jdGlvbiBjKGUpeygic3RhcnQiPT1lLmV2ZW50P3M6bykoZS5ub2RlKX1mb3IodmFyIHU9
-MCxsPSIiLGY9W107bi5sZW5ndGh8fHIubGVuZ3RoOyl7dmFyIGg9YSgpO2lmKGwrPWUoaS5zdWJz
-dHIodSxoWzBdLm9mZnNldC11KSksdT1oWzBdLm9mZnNldCxoPT1uKXtmLnJldmVyc2UoKS5mb3JF
-YWNoKG8pO2RvIGMoaC5zcGxpY2UoMCwxKVswXSksaD1hKCk7d2hpbGUoaD09biYmaC5sZW5ndGgm
-JmhbMF0ub2Zmc2V0PT11KTtmLnJldmVyc2UoKS5mb3JFYWNoKHMpfWVsc2Uic3RhcnQiPT1oWzBd
-LmV2ZW50P2YucHVzaChoWzBdLm5vZGUpOmYucG9wKCksYyhoLnNwbGljZSgwLDEpWzBdKX1yZXR1
-cm4gbCtlKGkuc3Vic3RyKHUpKX1mdW5jdGlvbiBvKGUpe2Z1bmN0aW9uIHQoZSl7cmV0dXJuIGUm
-JmUuc291cmNlfHxlfWZ1bmN0aW9uIG4obixyKXtyZXR1cm4gUmVnRXhwKHQobiksIm0iKyhlLmNJ
-PyJpIjoiIikrKHI/ImciOiIiKSl9ZnVuY3Rpb24gcihhLHMpe2lmKCFhLmNvbXBpbGVkKXtpZihh
-LmNvbXBpbGVkPSEwLGEuaz1hLmt8fGEuYkssYS5rKXt2YXIgbz17fSxjPWZ1bmN0aW9uKHQsbil7
-ZS5jSSYmKG49bi50b0xvd2VyQ2FzZSgpKSxuLnNwbGl0KCIgIikuZm9yRWFjaChmdW5jdGlvbihl
-KXt2YXIgbj1lLnNwbGl0KCJ8Iik7b1tuWzBdXT1bdCxuWzFdP051bWJlcihuWzFdKToxXX0pfTsi
-c3RyaW5nIj09dHlwZW9mIGEuaz9jKCJrZXl3b3JkIixhLmspOk9iamVjdC5rZXlzKGEuaykuZm9y
-RWFjaChmdW5jdGlvbihlKXtjKGUsYS5rW2VdKX0pLGEuaz1vfWEubFI9bihhLmx8fC9cYltBLVph
-LXowLTlfXStcYi8sITApLHMmJihhLmJLJiYoYS5iPSJcXGIoIithLmJLLnNwbGl0KCIgIikuam9p
-bigifCIpKyIpXFxiIiksYS5ifHwoYS5iPS9cQnxcYi8pLGEuYlI9bihhLmIpLGEuZXx8YS5lV3x8
-KGEuZT0vXEJ8XGIvKSxhLmUmJihhLmVSPW4oYS5lKSksYS50RT10KGEuZSl8fCIiLGEuZVcmJnMu
-dEUmJihhLnRFKz0oYS5lPyJ8IjoiIikrcy50RSkpLGEuaSYmKGEuaVI9bihhLmkpKSx2b2lkIDA9
-PT1hLnImJihhLnI9MSksYS5jfHwoYS5jPVtdKTt2YXIgdT1bXTthLmMuZm9yRWFjaChmdW5jdGlv
-bihlKXtlLnY/ZS52LmZvckVhY2goZnVuY3Rpb24odCl7dS5wdXNoKGkoZSx0KSl9KTp1LnB1c2go
-InNlbGYiPT1lP2E6ZSl9KSxhLmM9dSxhLmMuZm9yRWFjaChmdW5jdGlvbihlKXtyKGUsYSl9KSxh
-LnN0YXJ0cyYmcihhLnN0YXJ0cyxzKTt2YXIgbD1hLmMubWFwKGZ1bmN0aW9uKGUpe3JldHVybiBl
-LmJLPyJcXC4/KCIrZS5iKyIpXFwuPyI6ZS5ifSkuY29uY2F0KFthLnRFLGEuaV0pLm1hcCh0KS5m
-aWx0ZXIoQm9vbGVhbik7YS50PWwubGVuZ3RoP24obC5qb2luKCJ8IiksITApOntleGVjOmZ1bmN0
-aW9uKCl7cmV0dXJuIG51bGx9fX19cihlKX1mdW5jdGlvbiBjKHQscixpLGEpe2Z1bmN0aW9uIHMo
-ZSx0KXtmb3IodmFyIHI9MDtyPHQuYy5sZW5ndGg7cisrKWlmKG4odC5jW3JdLmJSLGUpKXJldHVy
-biB0LmNbcl19ZnVuY3Rpb24gbChlLHQpe3JldHVybiBuKGUuZVIsdCk/ZTplLmVXP2woZS5wYXJl
-bnQsdCk6dm9pZCAwfWZ1bmN0aW9uIGYoZSx0KXtyZXR1cm4haSYmbih0LmlSLGUpfWZ1bmN0aW9u
-IGgoZSx0KXt2YXIgbj14LmNJP3RbMF0udG9Mb3dlckNhc2UoKTp0WzBdO3JldHVybiBlLmsuaGFz
-T3duUHJvcGVydHkobikmJmUua1tuXX1mdW5jdGlvbiBnKGUsdCxuLHIpe3ZhciBpPXI/IiI6Ti5j
-bGFzc1ByZWZpeCxhPSc8c3BhbiBjbGFzcz0iJytpLHM9bj8iIjoiPC9zcGFuPiI7cmV0dXJuIGEr
-PWUrJyI+JyxhK3Qrc31mdW5jdGlvbiBwKCl7aWYoIXcuaylyZXR1cm4gZShCKTt2YXIgdD0iIixu
-PTA7dy5sUi5sYXN0SW5kZXg9MDtmb3IodmFyIHI9dy5sUi5leGVjKEIpO3I7KXt0Kz1lKEIuc3Vi
-c3RyKG4sci5pbmRleC1uKSk7dmFyIGk9aCh3LHIpO2k/KHkrPWlbMV0sdCs9ZyhpWzBdLGUoclsw
-XSkpKTp0Kz1lKHJbMF0pLG49dy5sUi5sYXN0SW5kZXgscj13LmxSLmV4ZWMoQil9cmV0dXJuIHQr
-ZShCLnN1YnN0cihuKSl9ZnVuY3Rpb24gdigpe2lmKHcuc0wmJiFFW3cuc0xdKXJldHVybiBlKEIp
-O3ZhciB0PXcuc0w/Yyh3LnNMLEIsITAsTFt3LnNMXSk6dShCKTtyZXR1cm4gdy5yPjAmJih5Kz10
-LnIpLCJjb250aW51b3VzIj09dy5zdWJMYW5ndWFnZU1vZGUmJihMW3cuc0xdPXQudG9wKSxnKHQu
-bGFuZ3VhZ2UsdC52YWx1ZSwhMSwhMCl9ZnVuY3Rpb24gYigpe3JldHVybiB2b2lkIDAhPT13LnNM
-P3YoKTpwKCl9ZnVuY3Rpb24gZCh0LG4pe3ZhciByPXQuY04/Zyh0LmNOLCIiLCEwKToiIjt0LnJC
-PyhNKz1yLEI9IiIpOnQuZUI/KE0rPWUobikrcixCPSIiKTooTSs9cixCPW4pLHc9T2JqZWN0LmNy
-ZWF0ZSh0LHtwYXJlbnQ6e3ZhbHVlOnd9fSl9ZnVuY3Rpb24gUih0LG4pe2lmKEIrPXQsdm9pZCAw
-PT09bilyZXR1cm4gTSs9YigpLDA7dmFyIHI9cyhuLHcpO2lmKHIpcmV0dXJuIE0rPWIoKSxkKHIs
-biksci5yQj8wOm4ubGVuZ3RoO3ZhciBpPWwodyxuKTtpZihpKXt2YXIgYT13O2EuckV8fGEuZUV8
-fChCKz1uKSxNKz1iKCk7ZG8gdy5jTiYmKE0rPSI8L3NwYW4+IikseSs9dy5yLHc9dy5wYXJlbnQ7
-d2hpbGUodyE9aS5wYXJlbnQpO3JldHVybiBhLmVFJiYoTSs9ZShuKSksQj0iIixpLnN0YXJ0cyYm
-ZChpLnN0YXJ0cywiIiksYS5yRT8wOm4ubGVuZ3RofWlmKGYobix3KSl0aHJvdyBuZXcgRXJyb3Io
-J0lsbGVnYWwgbGV4ZW1lICInK24rJyIgZm9yIG1vZGUgIicrKHcuY058fCI8dW5uYW1lZD4iKSsn
-IicpO3JldHVybiBCKz1uLG4ubGVuZ3RofHwxfXZhciB4PW0odCk7aWYoIXgpdGhyb3cgbmV3IEVy
-cm9yKCdVbmtub3duIGxhbmd1YWdlOiAiJyt0KyciJyk7byh4KTtmb3IodmFyIHc9YXx8eCxMPXt9
-LE09IiIsaz13O2shPXg7az1rLnBhcmVudClrLmNOJiYoTT1nKGsuY04sIiIsITApK00pO3ZhciBC
-PSIiLHk9MDt0cnl7Zm9yKHZhciBDLEksaj0wOzspe2lmKHcudC5sYXN0SW5kZXg9aixDPXcudC5l
-eGVjKHIpLCFDKWJyZWFrO0k9UihyLnN1YnN0cihqLEMuaW5kZXgtaiksQ1swXSksaj1DLmluZGV4
-K0l9UihyLnN1YnN0cihqKSk7Zm9yKHZhciBrPXc7ay5wYXJlbnQ7az1rLnBhcmVudClrLmNOJiYo
-TSs9Ijwvc3Bhbj4iKTtyZXR1cm57cjp5LHZhbHVlOk0sbGFuZ3VhZ2U6dCx0b3A6d319Y2F0Y2go
-QSl7aWYoLTEhPUEubWVzc2FnZS5pbmRleE9mKCJJbGxlZ2FsIikpcmV0dXJue3I6MCx2YWx1ZTpl
-KHIpfTt0aHJvdyBBfX1mdW5jdGlvbiB1KHQsbil7bj1ufHxOLmxhbmd1YWdlc3x8T2JqZWN0Lmtl
-eXMoRSk7dmFyIHI9e3I6MCx2YWx1ZTplKHQpfSxpPXI7cmV0dXJuIG4uZm9yRWFjaChmdW5jdGlv
-bihlKXtpZihtKGUpKXt2YXIgbj1jKGUsdCwhMSk7bi5sYW5ndWFnZT1lLG4ucj5pLnImJihpPW4p
-LG4ucj5yLnImJihpPXIscj1uKX19KSxpLmxhbmd1YWdlJiYoci5zZWNvbmRfYmVzdD1pKSxyfWZ1
-bmN0aW9uIGwoZSl7cmV0dXJuIE4udGFiUmVwbGFjZSYmKGU9ZS5yZXBsYWNlKC9eKCg8W14+XSs+
-fFx0KSspL2dtLGZ1bmN0aW9uKGUsdCl7cmV0dXJuIHQucmVwbGFjZSgvXHQvZyxOLnRhYlJlcGxh
-Y2UpfSkpLE4udXNlQlImJihlPWUucmVwbGFjZSgvXG4vZywiPGJyPiIpKSxlfWZ1bmN0aW9uIGYo
-ZSx0LG4pe3ZhciByPXQ/Ult0XTpuLGk9W2UudHJpbSgpXTtyZXR1cm4gZS5tYXRjaCgvKFxzfF4p
-aGxqcyhcc3wkKS8pfHxpLnB1c2goImhsanMiKSxyJiZpLnB1c2gociksaS5qb2luKCIgIikudHJp
-bSgpfWZ1bmN0aW9uIGgoZSl7dmFyIHQ9cihlKTtpZighL25vKC0/KWhpZ2hsaWdodC8udGVzdCh0
-KSl7dmFyIG47Ti51c2VCUj8obj1kb2N1bWVudC5jcmVhdGVFbGVtZW50TlMoImh0dHA6Ly93d3cu
-dzMub3JnLzE5OTkveGh0bWwiLCJkaXYiKSxuLmlubmVySFRNTD1lLmlubmVySFRNTC5yZXBsYWNl
-KC9cbi9nLCIiKS5yZXBsYWNlKC88YnJbIFwvXSo+L2csIlxuIikpOm49ZTt2YXIgaT1uLnRleHRD
-b250ZW50LG89dD9jKHQsaSwhMCk6dShpKSxoPWEobik7aWYoaC5sZW5ndGgpe3ZhciBnPWRvY3Vt
-ZW50LmNyZWF0ZUVsZW1lbnROUygiaHR0cDovL3d3dy53My5vcmcvMTk5OS94aHRtbCIsImRpdiIp
-O2cuaW5uZXJIVE1MPW8udmFsdWUsby52YWx1ZT1zKGgsYShnKSxpKX1vLnZhbHVlPWwoby52YWx1
-ZSksZS5pbm5lckhUTUw9by52YWx1ZSxlLmNsYXNzTmFtZT1mKGUuY2xhc3NOYW1lLHQsby5sYW5n
-dWFnZSksZS5yZXN1bHQ9e2xhbmd1YWdlOm8ubGFuZ3VhZ2UscmU6by5yfSxvLnNlY29uZF9iZXN0
-JiYoZS5zZWNvbmRfYmVzdD17bGFuZ3VhZ2U6by5zZWNvbmRfYmVzdC5sYW5ndWFnZSxyZTpvLnNl
-Y29uZF9iZXN0LnJ9KX19ZnVuY3Rpb24gZyhlKXtOPWkoTixlKX1mdW5jdGlvbiBwKCl7aWYoIXAu
-Y2FsbGVkKXtwLmNhbGxlZD0hMDt2YXIgZT1kb2N1bWVudC5xdWVyeVNlbGVjdG9yQWxsKCJwcmUg
-Y29kZSIpO0FycmF5LnByb3RvdHlwZS5mb3JFYWNoLmNhbGwoZSxoKX19ZnVuY3Rpb24gdigpe2Fk
-ZEV2ZW50TGlzdGVuZXIoIkRPTUNvbnRlbnRMb2FkZWQiLHAsITEpLGFkZEV2ZW50TGlzdGVuZXIo
-ImxvYWQiLHAsITEpfWZ1bmN0aW9uIGIoZSx0KXt2YXIgbj1FW2VdPXQodGhpcyk7bi5hbGlhc2Vz
-JiZuLmFsaWFzZXMuZm9yRWFjaChmdW5jdGlvbih0KXtSW3RdPWV9KX1mdW5jdGlvbiBkKCl7cmV0
-dXJuIE9iamVjdC5rZXlzKEUpfWZ1bmN0aW9uIG0oZSl7cmV0dXJuIEVbZV18fEVbUltlXV19dmFy
-IE49e2NsYXNzUHJlZml4OiJobGpzLSIsdGFiUmVwbGFjZTpudWxsLHVzZUJSOiExLGxhbmd1YWdl
-czp2b2lkIDB9LEU9e30sUj17fTt0aGlzLmhpZ2hsaWdodD1jLHRoaXMuaGlnaGxpZ2h0QXV0bz11
-LHRoaXMuZml4TWFya3VwPWwsdGhpcy5oaWdobGlnaHRCbG9jaz1oLHRoaXMuY29uZmlndXJlPWcs
-dGhpcy5pbml0SGlnaGxpZ2h0aW5nPXAsdGhpcy5pbml0SGlnaGxpZ2h0aW5nT25Mb2FkPXYsdGhp
-cy5yZWdpc3Rlckxhbmd1YWdlPWIsdGhpcy5saXN0TGFuZ3VhZ2VzPWQsdGhpcy5nZXRMYW5ndWFn
-ZT1tLHRoaXMuaW5oZXJpdD1pLHRoaXMuSVI9IlthLXpBLVpdW2EtekEtWjAtOV9dKiIsdGhpcy5V
-SVI9IlthLXpBLVpfXVthLXpBLVowLTlfXSoiLHRoaXMuTlI9IlxcYlxcZCsoXFwuXFxkKyk/Iix0
-aGlzLkNOUj0iKFxcYjBbeFhdW2EtZkEtRjAtOV0rfChcXGJcXGQrKFxcLlxcZCopP3xcXC5cXGQr
-KShbZUVdWy0rXT9cXGQrKT8pIix0aGlzLkJOUj0iXFxiKDBiWzAxXSspIix0aGlzLlJTUj0iIXwh
-PXwhPT18JXwlPXwmfCYmfCY9fFxcKnxcXCo9fFxcK3xcXCs9fCx8LXwtPXwvPXwvfDp8O3w8PHw8
-PD18PD18PHw9PT18PT18PXw+Pj49fD4+PXw+PXw+Pj58Pj58PnxcXD98XFxbfFxce3xcXCh8XFxe
-fFxcXj18XFx8fFxcfD18XFx8XFx8fH4iLHRoaXMuQkU9e2I6IlxcXFxbXFxzXFxTXSIscjowfSx0
-aGlzLkFTTT17Y046InN0cmluZyIsYjoiJyIsZToiJyIsaToiXFxuIixjOlt0aGlzLkJFXX0sdGhp
-cy5RU009e2NOOiJzdHJpbmciLGI6JyInLGU6JyInLGk6IlxcbiIsYzpbdGhpcy5CRV19LHRoaXMu
-UFdNPXtiOi9cYihhfGFufHRoZXxhcmV8SXxJJ218aXNuJ3R8ZG9uJ3R8ZG9lc24ndHx3b24ndHxi
-dXR8anVzdHxzaG91bGR8cHJldHR5fHNpbXBseXxlbm91Z2h8Z29ubmF8Z29pbmd8d3RmfHNvfHN1
-Y2gpXGIvfSx0aGlzLkNMQ009e2NOOiJjb21tZW50IixiOiIvLyIsZToiJCIsYzpbdGhpcy5QV01d
-fSx0aGlzLkNCQ009e2NOOiJjb21tZW50IixiOiIvXFwqIixlOiJcXCovIixjOlt0aGlzLlBXTV19
-LHRoaXMuSENNPXtjTjoiY29tbWVudCIsYjoiIyIsZToiJCIsYzpbdGhpcy5QV01dfSx0aGlzLk5N
-PXtjTjoibnVtYmVyIixiOnRoaXMuTlIscjowfSx0aGlzLkNOTT17Y046Im51bWJlciIsYjp0aGlz
-LkNOUixyOjB9LHRoaXMuQk5NPXtjTjoibnVtYmVyIixiOnRoaXMuQk5SLHI6MH0sdGhpcy5DU1NO
-TT17Y046Im51bWJlciIsYjp0aGlzLk5SKyIoJXxlbXxleHxjaHxyZW18dnd8dmh8dm1pbnx2bWF4
-fGNtfG1tfGlufHB0fHBjfHB4fGRlZ3xncmFkfHJhZHx0dXJufHN8bXN8SHp8a0h6fGRwaXxkcGNt
-fGRwcHgpPyIscjowfSx0aGlzLlJNPXtjTjoicmVnZXhwIixiOi9cLy8sZTovXC9bZ2ltdXldKi8s
-aTovXG4vLGM6W3RoaXMuQkUse2I6L1xbLyxlOi9cXS8scjowLGM6W3RoaXMuQkVdfV19LHRoaXMu
-VE09e2NOOiJ0aXRsZSIsYjp0aGlzLklSLHI6MH0sdGhpcy5VVE09e2NOOiJ0aXRsZSIsYjp0aGlz
-LlVJUixyOjB9fTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoImphdmFzY3JpcHQiLGZ1bmN0aW9uKHIp
-e3JldHVybnthbGlhc2VzOlsianMiXSxrOntrZXl3b3JkOiJpbiBpZiBmb3Igd2hpbGUgZmluYWxs
-eSB2YXIgbmV3IGZ1bmN0aW9uIGRvIHJldHVybiB2b2lkIGVsc2UgYnJlYWsgY2F0Y2ggaW5zdGFu
-Y2VvZiB3aXRoIHRocm93IGNhc2UgZGVmYXVsdCB0cnkgdGhpcyBzd2l0Y2ggY29udGludWUgdHlw
-ZW9mIGRlbGV0ZSBsZXQgeWllbGQgY29uc3QgY2xhc3MiLGxpdGVyYWw6InRydWUgZmFsc2UgbnVs
-bCB1bmRlZmluZWQgTmFOIEluZmluaXR5IixidWlsdF9pbjoiZXZhbCBpc0Zpbml0ZSBpc05hTiBw
-YXJzZUZsb2F0IHBhcnNlSW50IGRlY29kZVVSSSBkZWNvZGVVUklDb21wb25lbnQgZW5jb2RlVVJJ
-IGVuY29kZVVSSUNvbXBvbmVudCBlc2NhcGUgdW5lc2NhcGUgT2JqZWN0IEZ1bmN0aW9uIEJvb2xl
-YW4gRXJyb3IgRXZhbEVycm9yIEludGVybmFsRXJyb3IgUmFuZ2VFcnJvciBSZWZlcmVuY2VFcnJv
-ciBTdG9wSXRlcmF0aW9uIFN5bnRheEVycm9yIFR5cGVFcnJvciBVUklFcnJvciBOdW1iZXIgTWF0
-aCBEYXRlIFN0cmluZyBSZWdFeHAgQXJyYXkgRmxvYXQzMkFycmF5IEZsb2F0NjRBcnJheSBJbnQx
-NkFycmF5IEludDMyQXJyYXkgSW50OEFycmF5IFVpbnQxNkFycmF5IFVpbnQzMkFycmF5IFVpbnQ4
-QXJyYXkgVWludDhDbGFtcGVkQXJyYXkgQXJyYXlCdWZmZXIgRGF0YVZpZXcgSlNPTiBJbnRsIGFy
-Z3VtZW50cyByZXF1aXJlIG1vZHVsZSBjb25zb2xlIHdpbmRvdyBkb2N1bWVudCJ9LGM6W3tjTjoi
-cGkiLGI6L15ccyooJ3wiKXVzZSBzdHJpY3QoJ3wiKS8scjoxMH0sci5BU00sci5RU00sci5DTENN
-LHIuQ0JDTSxyLkNOTSx7YjoiKCIrci5SU1IrInxcXGIoY2FzZXxyZXR1cm58dGhyb3cpXFxiKVxc
-cyoiLGs6InJldHVybiB0aHJvdyBjYXNlIixjOltyLkNMQ00sci5DQkNNLHIuUk0se2I6LzwvLGU6
-Lz47LyxyOjAsc0w6InhtbCJ9XSxyOjB9LHtjTjoiZnVuY3Rpb24iLGJLOiJmdW5jdGlvbiIsZTov
-XHsvLGVFOiEwLGM6W3IuaW5oZXJpdChyLlRNLHtiOi9bQS1aYS16JF9dWzAtOUEtWmEteiRfXSov
-fSkse2NOOiJwYXJhbXMiLGI6L1woLyxlOi9cKS8sYzpbci5DTENNLHIuQ0JDTV0saTovWyInXChd
-L31dLGk6L1xbfCUvfSx7YjovXCRbKC5dL30se2I6IlxcLiIrci5JUixyOjB9XX19KTtobGpzLnJl
-Z2lzdGVyTGFuZ3VhZ2UoInNxbCIsZnVuY3Rpb24oZSl7dmFyIHQ9e2NOOiJjb21tZW50IixiOiIt
-LSIsZToiJCJ9O3JldHVybntjSTohMCxpOi9bPD5dLyxjOlt7Y046Im9wZXJhdG9yIixiSzoiYmVn
-aW4gZW5kIHN0YXJ0IGNvbW1pdCByb2xsYmFjayBzYXZlcG9pbnQgbG9jayBhbHRlciBjcmVhdGUg
-ZHJvcCByZW5hbWUgY2FsbCBkZWxldGUgZG8gaGFuZGxlciBpbnNlcnQgbG9hZCByZXBsYWNlIHNl
-bGVjdCB0cnVuY2F0ZSB1cGRhdGUgc2V0IHNob3cgcHJhZ21hIGdyYW50IG1lcmdlIGRlc2NyaWJl
-IHVzZSBleHBsYWluIGhlbHAgZGVjbGFyZSBwcmVwYXJlIGV4ZWN1dGUgZGVhbGxvY2F0ZSBzYXZl
-cG9pbnQgcmVsZWFzZSB1bmxvY2sgcHVyZ2UgcmVzZXQgY2hhbmdlIHN0b3AgYW5hbHl6ZSBjYWNo
-ZSBmbHVzaCBvcHRpbWl6ZSByZXBhaXIga2lsbCBpbnN0YWxsIHVuaW5zdGFsbCBjaGVja3N1bSBy
-ZXN0b3JlIGNoZWNrIGJhY2t1cCIsZTovOy8sZVc6ITAsazp7a2V5d29yZDoiYWJzIGFic29sdXRl
-IGFjb3MgYWN0aW9uIGFkZCBhZGRkYXRlIGFkZHRpbWUgYWVzX2RlY3J5cHQgYWVzX2VuY3J5cHQg
-YWZ0ZXIgYWdncmVnYXRlIGFsbCBhbGxvY2F0ZSBhbHRlciBhbmFseXplIGFuZCBhbnkgYXJlIGFz
-IGFzYyBhc2NpaSBhc2luIGFzc2VydGlvbiBhdCBhdGFuIGF0YW4yIGF0bjIgYXV0aG9yaXphdGlv
-biBhdXRob3JzIGF2ZyBiYWNrdXAgYmVmb3JlIGJlZ2luIGJlbmNobWFyayBiZXR3ZWVuIGJpbiBi
-aW5sb2cgYml0X2FuZCBiaXRfY291bnQgYml0X2xlbmd0aCBiaXRfb3IgYml0X3hvciBib3RoIGJ5
-IGNhY2hlIGNhbGwgY2FzY2FkZSBjYXNjYWRlZCBjYXNlIGNhc3QgY2F0YWxvZyBjZWlsIGNlaWxp
-bmcgY2hhaW4gY2hhbmdlIGNoYW5nZWQgY2hhcl9sZW5ndGggY2hhcmFjdGVyX2xlbmd0aCBjaGFy
-aW5kZXggY2hhcnNldCBjaGVjayBjaGVja3N1bSBjaGVja3N1bV9hZ2cgY2hvb3NlIGNsb3NlIGNv
-YWxlc2NlIGNvZXJjaWJpbGl0eSBjb2xsYXRlIGNvbGxhdGlvbiBjb2xsYXRpb25wcm9wZXJ0eSBj
-b2x1bW4gY29sdW1ucyBjb2x1bW5zX3VwZGF0ZWQgY29tbWl0IGNvbXByZXNzIGNvbmNhdCBjb25j
-YXRfd3MgY29uY3VycmVudCBjb25uZWN0IGNvbm5lY3Rpb24gY29ubmVjdGlvbl9pZCBjb25zaXN0
-ZW50IGNvbnN0cmFpbnQgY29uc3RyYWludHMgY29udGludWUgY29udHJpYnV0b3JzIGNvbnYgY29u
-dmVydCBjb252ZXJ0X3R6IGNvcnJlc3BvbmRpbmcgY29zIGNvdCBjb3VudCBjb3VudF9iaWcgY3Jj
-MzIgY3JlYXRlIGNyb3NzIGN1bWVfZGlzdCBjdXJkYXRlIGN1cnJlbnQgY3VycmVudF9kYXRlIGN1
-cnJlbnRfdGltZSBjdXJyZW50X3RpbWVzdGFtcCBjdXJyZW50X3VzZXIgY3Vyc29yIGN1cnRpbWUg
-ZGF0YSBkYXRhYmFzZSBkYXRhYmFzZXMgZGF0YWxlbmd0aCBkYXRlX2FkZCBkYXRlX2Zvcm1hdCBk
-YXRlX3N1YiBkYXRlYWRkIGRhdGVkaWZmIGRhdGVmcm9tcGFydHMgZGF0ZW5hbWUgZGF0ZXBhcnQg
-ZGF0ZXRpbWUyZnJvbXBhcnRzIGRhdGV0aW1lb2Zmc2V0ZnJvbXBhcnRzIGRheSBkYXluYW1lIGRh
-eW9mbW9udGggZGF5b2Z3ZWVrIGRheW9meWVhciBkZWFsbG9jYXRlIGRlY2xhcmUgZGVjb2RlIGRl
-ZmF1bHQgZGVmZXJyYWJsZSBkZWZlcnJlZCBkZWdyZWVzIGRlbGF5ZWQgZGVsZXRlIGRlc19kZWNy
-eXB0IGRlc19lbmNyeXB0IGRlc19rZXlfZmlsZSBkZXNjIGRlc2NyaWJlIGRlc2NyaXB0b3IgZGlh
-Z25vc3RpY3MgZGlmZmVyZW5jZSBkaXNjb25uZWN0IGRpc3RpbmN0IGRpc3RpbmN0cm93IGRpdiBk
-byBkb21haW4gZG91YmxlIGRyb3AgZHVtcGZpbGUgZWFjaCBlbHNlIGVsdCBlbmNsb3NlZCBlbmNv
-ZGUgZW5jcnlwdCBlbmQgZW5kLWV4ZWMgZW5naW5lIGVuZ2luZXMgZW9tb250aCBlcnJvcnMgZXNj
-YXBlIGVzY2FwZWQgZXZlbnQgZXZlbnRkYXRhIGV2ZW50cyBleGNlcHQgZXhjZXB0aW9uIGV4ZWMg
-ZXhlY3V0ZSBleGlzdHMgZXhwIGV4cGxhaW4gZXhwb3J0X3NldCBleHRlbmRlZCBleHRlcm5hbCBl
-eHRyYWN0IGZhc3QgZmV0Y2ggZmllbGQgZmllbGRzIGZpbmRfaW5fc2V0IGZpcnN0IGZpcnN0X3Zh
-bHVlIGZsb29yIGZsdXNoIGZvciBmb3JjZSBmb3JlaWduIGZvcm1hdCBmb3VuZCBmb3VuZF9yb3dz
-IGZyb20gZnJvbV9iYXNlNjQgZnJvbV9kYXlzIGZyb21fdW5peHRpbWUgZnVsbCBmdW5jdGlvbiBn
-ZXQgZ2V0X2Zvcm1hdCBnZXRfbG9jayBnZXRkYXRlIGdldHV0Y2RhdGUgZ2xvYmFsIGdvIGdvdG8g
-Z3JhbnQgZ3JhbnRzIGdyZWF0ZXN0IGdyb3VwIGdyb3VwX2NvbmNhdCBncm91cGluZyBncm91cGlu
-Z19pZCBndGlkX3N1YnNldCBndGlkX3N1YnRyYWN0IGhhbmRsZXIgaGF2aW5nIGhlbHAgaGV4IGhp
-Z2hfcHJpb3JpdHkgaG9zdHMgaG91ciBpZGVudF9jdXJyZW50IGlkZW50X2luY3IgaWRlbnRfc2Vl
-ZCBpZGVudGlmaWVkIGlkZW50aXR5IGlmIGlmbnVsbCBpZ25vcmUgaWlmIGlsaWtlIGltbWVkaWF0
-ZSBpbiBpbmRleCBpbmRpY2F0b3IgaW5ldDZfYXRvbiBpbmV0Nl9udG9hIGluZXRfYXRvbiBpbmV0
-X250b2EgaW5maWxlIGluaXRpYWxseSBpbm5lciBpbm5vZGIgaW5wdXQgaW5zZXJ0IGluc3RhbGwg
-aW5zdHIgaW50ZXJzZWN0IGludG8gaXMgaXNfZnJlZV9sb2NrIGlzX2lwdjQgaXNfaXB2NF9jb21w
-YXQgaXNfaXB2NF9tYXBwZWQgaXNfbm90IGlzX25vdF9udWxsIGlzX3VzZWRfbG9jayBpc2RhdGUg
-aXNudWxsIGlzb2xhdGlvbiBqb2luIGtleSBraWxsIGxhbmd1YWdlIGxhc3QgbGFzdF9kYXkgbGFz
-dF9pbnNlcnRfaWQgbGFzdF92YWx1ZSBsY2FzZSBsZWFkIGxlYWRpbmcgbGVhc3QgbGVhdmVzIGxl
-ZnQgbGVuIGxlbmdodCBsZXZlbCBsaWtlIGxpbWl0IGxpbmVzIGxuIGxvYWQgbG9hZF9maWxlIGxv
-Y2FsIGxvY2FsdGltZSBsb2NhbHRpbWVzdGFtcCBsb2NhdGUgbG9jayBsb2cgbG9nMTAgbG9nMiBs
-b2dmaWxlIGxvZ3MgbG93X3ByaW9yaXR5IGxvd2VyIGxwYWQgbHRyaW0gbWFrZV9zZXQgbWFrZWRh
-dGUgbWFrZXRpbWUgbWFzdGVyIG1hc3Rlcl9wb3Nfd2FpdCBtYXRjaCBtYXRjaGVkIG1heCBtZDUg
-bWVkaXVtIG1lcmdlIG1pY3Jvc2Vjb25kIG1pZCBtaW4gbWludXRlIG1vZCBtb2RlIG1vZHVsZSBt
-b250aCBtb250aG5hbWUgbXV0ZXggbmFtZV9jb25zdCBuYW1lcyBuYXRpb25hbCBuYXR1cmFsIG5j
-aGFyIG5leHQgbm8gbm9fd3JpdGVfdG9fYmlubG9nIG5vdCBub3cgbnVsbGlmIG52YXJjaGFyIG9j
-dCBvY3RldF9sZW5ndGggb2Ygb2xkX3Bhc3N3b3JkIG9uIG9ubHkgb3BlbiBvcHRpbWl6ZSBvcHRp
-b24gb3B0aW9uYWxseSBvciBvcmQgb3JkZXIgb3V0ZXIgb3V0ZmlsZSBvdXRwdXQgcGFkIHBhcnNl
-IHBhcnRpYWwgcGFydGl0aW9uIHBhc3N3b3JkIHBhdGluZGV4IHBlcmNlbnRfcmFuayBwZXJjZW50
-aWxlX2NvbnQgcGVyY2VudGlsZV9kaXNjIHBlcmlvZF9hZGQgcGVyaW9kX2RpZmYgcGkgcGx1Z2lu
-IHBvc2l0aW9uIHBvdyBwb3dlciBwcmFnbWEgcHJlY2lzaW9uIHByZXBhcmUgcHJlc2VydmUgcHJp
-bWFyeSBwcmlvciBwcml2aWxlZ2VzIHByb2NlZHVyZSBwcm9jZWR1cmVfYW5hbHl6ZSBwcm9jZXNz
-bGlzdCBwcm9maWxlIHByb2ZpbGVzIHB1YmxpYyBwdWJsaXNoaW5nc2VydmVybmFtZSBwdXJnZSBx
-dWFydGVyIHF1ZXJ5IHF1aWNrIHF1b3RlIHF1b3RlbmFtZSByYWRpYW5zIHJhbmQgcmVhZCByZWZl
-cmVuY2VzIHJlZ2V4cCByZWxhdGl2ZSByZWxheWxvZyByZWxlYXNlIHJlbGVhc2VfbG9jayByZW5h
-bWUgcmVwYWlyIHJlcGVhdCByZXBsYWNlIHJlcGxpY2F0ZSByZXNldCByZXN0b3JlIHJlc3RyaWN0
-IHJldHVybiByZXR1cm5zIHJldmVyc2UgcmV2b2tlIHJpZ2h0IHJsaWtlIHJvbGxiYWNrIHJvbGx1
-cCByb3VuZCByb3cgcm93X2NvdW50IHJvd3MgcnBhZCBydHJpbSBzYXZlcG9pbnQgc2NoZW1hIHNj
-cm9sbCBzZWNfdG9fdGltZSBzZWNvbmQgc2VjdGlvbiBzZWxlY3Qgc2VyaWFsaXphYmxlIHNlcnZl
-ciBzZXNzaW9uIHNlc3Npb25fdXNlciBzZXQgc2hhIHNoYTEgc2hhMiBzaGFyZSBzaG93IHNpZ24g
-c2luIHNpemUgc2xhdmUgc2xlZXAgc21hbGxkYXRldGltZWZyb21wYXJ0cyBzbmFwc2hvdCBzb21l
-IHNvbmFtZSBzb3VuZGV4IHNvdW5kc19saWtlIHNwYWNlIHNxbCBzcWxfYmlnX3Jlc3VsdCBzcWxf
-YnVmZmVyX3Jlc3VsdCBzcWxfY2FjaGUgc3FsX2NhbGNfZm91bmRfcm93cyBzcWxfbm9fY2FjaGUg
-c3FsX3NtYWxsX3Jlc3VsdCBzcWxfdmFyaWFudF9wcm9wZXJ0eSBzcWxzdGF0ZSBzcXJ0IHNxdWFy
-ZSBzdGFydCBzdGFydGluZyBzdGF0dXMgc3RkIHN0ZGRldiBzdGRkZXZfcG9wIHN0ZGRldl9zYW1w
-IHN0ZGV2IHN0ZGV2cCBzdG9wIHN0ciBzdHJfdG9fZGF0ZSBzdHJhaWdodF9qb2luIHN0cmNtcCBz
-dHJpbmcgc3R1ZmYgc3ViZGF0ZSBzdWJzdHIgc3Vic3RyaW5nIHN1YnRpbWUgc3VidHJpbmdfaW5k
-ZXggc3VtIHN3aXRjaG9mZnNldCBzeXNkYXRlIHN5c2RhdGV0aW1lIHN5c2RhdGV0aW1lb2Zmc2V0
-IHN5c3RlbV91c2VyIHN5c3V0Y2RhdGV0aW1lIHRhYmxlIHRhYmxlcyB0YWJsZXNwYWNlIHRhbiB0
-ZW1wb3JhcnkgdGVybWluYXRlZCB0ZXJ0aWFyeV93ZWlnaHRzIHRoZW4gdGltZSB0aW1lX2Zvcm1h
-dCB0aW1lX3RvX3NlYyB0aW1lZGlmZiB0aW1lZnJvbXBhcnRzIHRpbWVzdGFtcCB0aW1lc3RhbXBh
-ZGQgdGltZXN0YW1wZGlmZiB0aW1lem9uZV9ob3VyIHRpbWV6b25lX21pbnV0ZSB0byB0b19iYXNl
-NjQgdG9fZGF5cyB0b19zZWNvbmRzIHRvZGF0ZXRpbWVvZmZzZXQgdHJhaWxpbmcgdHJhbnNhY3Rp
-b24gdHJhbnNsYXRpb24gdHJpZ2dlciB0cmlnZ2VyX25lc3RsZXZlbCB0cmlnZ2VycyB0cmltIHRy
-dW5jYXRlIHRyeV9jYXN0IHRyeV9jb252ZXJ0IHRyeV9wYXJzZSB1Y2FzZSB1bmNvbXByZXNzIHVu
-Y29tcHJlc3NlZF9sZW5ndGggdW5oZXggdW5pY29kZSB1bmluc3RhbGwgdW5pb24gdW5pcXVlIHVu
-aXhfdGltZXN0YW1wIHVua25vd24gdW5sb2NrIHVwZGF0ZSB1cGdyYWRlIHVwcGVkIHVwcGVyIHVz
-YWdlIHVzZSB1c2VyIHVzZXJfcmVzb3VyY2VzIHVzaW5nIHV0Y19kYXRlIHV0Y190aW1lIHV0Y190
-aW1lc3RhbXAgdXVpZCB1dWlkX3Nob3J0IHZhbGlkYXRlX3Bhc3N3b3JkX3N0cmVuZ3RoIHZhbHVl
-IHZhbHVlcyB2YXIgdmFyX3BvcCB2YXJfc2FtcCB2YXJpYWJsZXMgdmFyaWFuY2UgdmFycCB2ZXJz
-aW9uIHZpZXcgd2FybmluZ3Mgd2VlayB3ZWVrZGF5IHdlZWtvZnllYXIgd2VpZ2h0X3N0cmluZyB3
-aGVuIHdoZW5ldmVyIHdoZXJlIHdpdGggd29yayB3cml0ZSB4bWwgeG9yIHllYXIgeWVhcndlZWsg
-em9uIixsaXRlcmFsOiJ0cnVlIGZhbHNlIG51bGwiLGJ1aWx0X2luOiJhcnJheSBiaWdpbnQgYmlu
-YXJ5IGJpdCBibG9iIGJvb2xlYW4gY2hhciBjaGFyYWN0ZXIgZGF0ZSBkZWMgZGVjaW1hbCBmbG9h
-dCBpbnQgaW50ZWdlciBpbnRlcnZhbCBudW1iZXIgbnVtZXJpYyByZWFsIHNlcmlhbCBzbWFsbGlu
-dCB2YXJjaGFyIHZhcnlpbmcgaW50OCBzZXJpYWw4IHRleHQifSxjOlt7Y046InN0cmluZyIsYjoi
-JyIsZToiJyIsYzpbZS5CRSx7YjoiJycifV19LHtjTjoic3RyaW5nIixiOiciJyxlOiciJyxjOltl
-LkJFLHtiOiciIid9XX0se2NOOiJzdHJpbmciLGI6ImAiLGU6ImAiLGM6W2UuQkVdfSxlLkNOTSxl
-LkNCQ00sdF19LGUuQ0JDTSx0XX19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoInB5dGhvbiIsZnVu
-Y3Rpb24oZSl7dmFyIHI9e2NOOiJwcm9tcHQiLGI6L14oPj4+fFwuXC5cLikgL30sYj17Y046InN0
-cmluZyIsYzpbZS5CRV0sdjpbe2I6Lyh1fGIpP3I/JycnLyxlOi8nJycvLGM6W3JdLHI6MTB9LHti
-Oi8odXxiKT9yPyIiIi8sZTovIiIiLyxjOltyXSxyOjEwfSx7YjovKHV8cnx1ciknLyxlOi8nLyxy
-OjEwfSx7YjovKHV8cnx1cikiLyxlOi8iLyxyOjEwfSx7YjovKGJ8YnIpJy8sZTovJy99LHtiOi8o
-YnxicikiLyxlOi8iL30sZS5BU00sZS5RU01dfSxpPXtjTjoibnVtYmVyIixyOjAsdjpbe2I6ZS5C
-TlIrIltsTGpKXT8ifSx7YjoiXFxiKDBvWzAtN10rKVtsTGpKXT8ifSx7YjplLkNOUisiW2xMakpd
-PyJ9XX0sbD17Y046InBhcmFtcyIsYjovXCgvLGU6L1wpLyxjOlsic2VsZiIscixpLGJdfSxuPXtl
-Oi86LyxpOi9bJHs9O1xuXS8sYzpbZS5VVE0sbF19O3JldHVybnthbGlhc2VzOlsicHkiLCJneXAi
-XSxrOntrZXl3b3JkOiJhbmQgZWxpZiBpcyBnbG9iYWwgYXMgaW4gaWYgZnJvbSByYWlzZSBmb3Ig
-ZXhjZXB0IGZpbmFsbHkgcHJpbnQgaW1wb3J0IHBhc3MgcmV0dXJuIGV4ZWMgZWxzZSBicmVhayBu
-b3Qgd2l0aCBjbGFzcyBhc3NlcnQgeWllbGQgdHJ5IHdoaWxlIGNvbnRpbnVlIGRlbCBvciBkZWYg
-bGFtYmRhIG5vbmxvY2FsfDEwIE5vbmUgVHJ1ZSBGYWxzZSIsYnVpbHRfaW46IkVsbGlwc2lzIE5v
-dEltcGxlbWVudGVkIn0saTovKDxcL3wtPnxcPykvLGM6W3IsaSxiLGUuSENNLGUuaW5oZXJpdChu
-LHtjTjoiZnVuY3Rpb24iLGJLOiJkZWYiLHI6MTB9KSxlLmluaGVyaXQobix7Y046ImNsYXNzIixi
-SzoiY2xhc3MifSkse2NOOiJkZWNvcmF0b3IiLGI6L0AvLGU6LyQvfSx7YjovXGIocHJpbnR8ZXhl
-YylcKC99XX19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoInBlcmwiLGZ1bmN0aW9uKGUpe3ZhciB0
-PSJnZXRwd2VudCBnZXRzZXJ2ZW50IHF1b3RlbWV0YSBtc2dyY3Ygc2NhbGFyIGtpbGwgZGJtY2xv
-c2UgdW5kZWYgbGMgbWEgc3lzd3JpdGUgdHIgc2VuZCB1bWFzayBzeXNvcGVuIHNobXdyaXRlIHZl
-YyBxeCB1dGltZSBsb2NhbCBvY3Qgc2VtY3RsIGxvY2FsdGltZSByZWFkcGlwZSBkbyByZXR1cm4g
-Zm9ybWF0IHJlYWQgc3ByaW50ZiBkYm1vcGVuIHBvcCBnZXRwZ3JwIG5vdCBnZXRwd25hbSByZXdp
-bmRkaXIgcXFmaWxlbm8gcXcgZW5kcHJvdG9lbnQgd2FpdCBzZXRob3N0ZW50IGJsZXNzIHN8MCBv
-cGVuZGlyIGNvbnRpbnVlIGVhY2ggc2xlZXAgZW5kZ3JlbnQgc2h1dGRvd24gZHVtcCBjaG9tcCBj
-b25uZWN0IGdldHNvY2tuYW1lIGRpZSBzb2NrZXRwYWlyIGNsb3NlIGZsb2NrIGV4aXN0cyBpbmRl
-eCBzaG1nZXRzdWIgZm9yIGVuZHB3ZW50IHJlZG8gbHN0YXQgbXNnY3RsIHNldHBncnAgYWJzIGV4
-aXQgc2VsZWN0IHByaW50IHJlZiBnZXRob3N0YnlhZGRyIHVuc2hpZnQgZmNudGwgc3lzY2FsbCBn
-b3RvIGdldG5ldGJ5YWRkciBqb2luIGdtdGltZSBzeW1saW5rIHNlbWdldCBzcGxpY2UgeHwwIGdl
-dHBlZXJuYW1lIHJlY3YgbG9nIHNldHNvY2tvcHQgY29zIGxhc3QgcmV2ZXJzZSBnZXRob3N0Ynlu
-YW1lIGdldGdybmFtIHN0dWR5IGZvcm1saW5lIGVuZGhvc3RlbnQgdGltZXMgY2hvcCBsZW5ndGgg
-Z2V0aG9zdGVudCBnZXRuZXRlbnQgcGFjayBnZXRwcm90b2VudCBnZXRzZXJ2YnluYW1lIHJhbmQg
-bWtkaXIgcG9zIGNobW9kIHl8MCBzdWJzdHIgZW5kbmV0ZW50IHByaW50ZiBuZXh0IG9wZW4gbXNn
-c25kIHJlYWRkaXIgdXNlIHVubGluayBnZXRzb2Nrb3B0IGdldHByaW9yaXR5IHJpbmRleCB3YW50
-YXJyYXkgaGV4IHN5c3RlbSBnZXRzZXJ2Ynlwb3J0IGVuZHNlcnZlbnQgaW50IGNociB1bnRpZSBy
-bWRpciBwcm90b3R5cGUgdGVsbCBsaXN0ZW4gZm9yayBzaG1yZWFkIHVjZmlyc3Qgc2V0cHJvdG9l
-bnQgZWxzZSBzeXNzZWVrIGxpbmsgZ2V0Z3JnaWQgc2htY3RsIHdhaXRwaWQgdW5wYWNrIGdldG5l
-dGJ5bmFtZSByZXNldCBjaGRpciBncmVwIHNwbGl0IHJlcXVpcmUgY2FsbGVyIGxjZmlyc3QgdW50
-aWwgd2FybiB3aGlsZSB2YWx1ZXMgc2hpZnQgdGVsbGRpciBnZXRwd3VpZCBteSBnZXRwcm90b2J5
-bnVtYmVyIGRlbGV0ZSBhbmQgc29ydCB1YyBkZWZpbmVkIHNyYW5kIGFjY2VwdCBwYWNrYWdlIHNl
-ZWtkaXIgZ2V0cHJvdG9ieW5hbWUgc2Vtb3Agb3VyIHJlbmFtZSBzZWVrIGlmIHF8MCBjaHJvb3Qg
-c3lzcmVhZCBzZXRwd2VudCBubyBjcnlwdCBnZXRjIGNob3duIHNxcnQgd3JpdGUgc2V0bmV0ZW50
-IHNldHByaW9yaXR5IGZvcmVhY2ggdGllIHNpbiBtc2dnZXQgbWFwIHN0YXQgZ2V0bG9naW4gdW5s
-ZXNzIGVsc2lmIHRydW5jYXRlIGV4ZWMga2V5cyBnbG9iIHRpZWQgY2xvc2VkaXJpb2N0bCBzb2Nr
-ZXQgcmVhZGxpbmsgZXZhbCB4b3IgcmVhZGxpbmUgYmlubW9kZSBzZXRzZXJ2ZW50IGVvZiBvcmQg
-YmluZCBhbGFybSBwaXBlIGF0YW4yIGdldGdyZW50IGV4cCB0aW1lIHB1c2ggc2V0Z3JlbnQgZ3Qg
-bHQgb3IgbmUgbXwwIGJyZWFrIGdpdmVuIHNheSBzdGF0ZSB3aGVuIixyPXtjTjoic3Vic3QiLGI6
-IlskQF1cXHsiLGU6IlxcfSIsazp0fSxzPXtiOiItPnsiLGU6In0ifSxuPXtjTjoidmFyaWFibGUi
-LHY6W3tiOi9cJFxkL30se2I6L1tcJFwlXEBdKFxeXHdcYnwjXHcrKFw6XDpcdyspKnx7XHcrfXxc
-dysoXDpcOlx3KikqKS99LHtiOi9bXCRcJVxAXVteXHNcd3tdLyxyOjB9XX0sbz17Y046ImNvbW1l
-bnQiLGI6Il4oX19FTkRfX3xfX0RBVEFfXykiLGU6IlxcbiQiLHI6NX0saT1bZS5CRSxyLG5dLGM9
-W24sZS5IQ00sbyx7Y046ImNvbW1lbnQiLGI6Il5cXD1cXHciLGU6IlxcPWN1dCIsZVc6ITB9LHMs
-e2NOOiJzdHJpbmciLGM6aSx2Olt7YjoicVtxd3hyXT9cXHMqXFwoIixlOiJcXCkiLHI6NX0se2I6
-InFbcXd4cl0/XFxzKlxcWyIsZToiXFxdIixyOjV9LHtiOiJxW3F3eHJdP1xccypcXHsiLGU6Ilxc
-fSIscjo1fSx7YjoicVtxd3hyXT9cXHMqXFx8IixlOiJcXHwiLHI6NX0se2I6InFbcXd4cl0/XFxz
-KlxcPCIsZToiXFw+IixyOjV9LHtiOiJxd1xccytxIixlOiJxIixyOjV9LHtiOiInIixlOiInIixj
-OltlLkJFXX0se2I6JyInLGU6JyInfSx7YjoiYCIsZToiYCIsYzpbZS5CRV19LHtiOiJ7XFx3K30i
-LGM6W10scjowfSx7YjoiLT9cXHcrXFxzKlxcPVxcPiIsYzpbXSxyOjB9XX0se2NOOiJudW1iZXIi
-LGI6IihcXGIwWzAtN19dKyl8KFxcYjB4WzAtOWEtZkEtRl9dKyl8KFxcYlsxLTldWzAtOV9dKihc
-XC5bMC05X10rKT8pfFswX11cXGIiLHI6MH0se2I6IihcXC9cXC98IitlLlJTUisifFxcYihzcGxp
-dHxyZXR1cm58cHJpbnR8cmV2ZXJzZXxncmVwKVxcYilcXHMqIixrOiJzcGxpdCByZXR1cm4gcHJp
-bnQgcmV2ZXJzZSBncmVwIixyOjAsYzpbZS5IQ00sbyx7Y046InJlZ2V4cCIsYjoiKHN8dHJ8eSkv
-KFxcXFwufFteL10pKi8oXFxcXC58W14vXSkqL1thLXpdKiIscjoxMH0se2NOOiJyZWdleHAiLGI6
-IihtfHFyKT8vIixlOiIvW2Etel0qIixjOltlLkJFXSxyOjB9XX0se2NOOiJzdWIiLGJLOiJzdWIi
-LGU6IihcXHMqXFwoLio/XFwpKT9bO3tdIixyOjV9LHtjTjoib3BlcmF0b3IiLGI6Ii1cXHdcXGIi
-LHI6MH1dO3JldHVybiByLmM9YyxzLmM9Yyx7YWxpYXNlczpbInBsIl0sazp0LGM6Y319KTtobGpz
-LnJlZ2lzdGVyTGFuZ3VhZ2UoImFwYWNoZSIsZnVuY3Rpb24oZSl7dmFyIHI9e2NOOiJudW1iZXIi
-LGI6IltcXCQlXVxcZCsifTtyZXR1cm57YWxpYXNlczpbImFwYWNoZWNvbmYiXSxjSTohMCxjOltl
-LkhDTSx7Y046InRhZyIsYjoiPC8/IixlOiI+In0se2NOOiJrZXl3b3JkIixiOi9cdysvLHI6MCxr
-Ontjb21tb246Im9yZGVyIGRlbnkgYWxsb3cgc2V0ZW52IHJld3JpdGVydWxlIHJld3JpdGVlbmdp
-bmUgcmV3cml0ZWNvbmQgZG9jdW1lbnRyb290IHNldGhhbmRsZXIgZXJyb3Jkb2N1bWVudCBsb2Fk
-bW9kdWxlIG9wdGlvbnMgaGVhZGVyIGxpc3RlbiBzZXJ2ZXJyb290IHNlcnZlcm5hbWUifSxzdGFy
-dHM6e2U6LyQvLHI6MCxrOntsaXRlcmFsOiJvbiBvZmYgYWxsIn0sYzpbe2NOOiJzcWJyYWNrZXQi
-LGI6Ilxcc1xcWyIsZToiXFxdJCJ9LHtjTjoiY2JyYWNrZXQiLGI6IltcXCQlXVxceyIsZToiXFx9
-IixjOlsic2VsZiIscl19LHIsZS5RU01dfX1dLGk6L1xTL319KTtobGpzLnJlZ2lzdGVyTGFuZ3Vh
-Z2UoInhtbCIsZnVuY3Rpb24oKXt2YXIgdD0iW0EtWmEtejAtOVxcLl86LV0rIixlPXtiOi88XD8o
-cGhwKT8oPyFcdykvLGU6L1w/Pi8sc0w6InBocCIsc3ViTGFuZ3VhZ2VNb2RlOiJjb250aW51b3Vz
-In0sYz17ZVc6ITAsaTovPC8scjowLGM6W2Use2NOOiJhdHRyaWJ1dGUiLGI6dCxyOjB9LHtiOiI9
-IixyOjAsYzpbe2NOOiJ2YWx1ZSIsYzpbZV0sdjpbe2I6LyIvLGU6LyIvfSx7YjovJy8sZTovJy99
-LHtiOi9bXlxzXC8+XSsvfV19XX1dfTtyZXR1cm57YWxpYXNlczpbImh0bWwiLCJ4aHRtbCIsInJz
-cyIsImF0b20iLCJ4c2wiLCJwbGlzdCJdLGNJOiEwLGM6W3tjTjoiZG9jdHlwZSIsYjoiPCFET0NU
-WVBFIixlOiI+IixyOjEwLGM6W3tiOiJcXFsiLGU6IlxcXSJ9XX0se2NOOiJjb21tZW50IixiOiI8
-IS0tIixlOiItLT4iLHI6MTB9LHtjTjoiY2RhdGEiLGI6IjxcXCFcXFtDREFUQVxcWyIsZToiXFxd
-XFxdPiIscjoxMH0se2NOOiJ0YWciLGI6IjxzdHlsZSg/PVxcc3w+fCQpIixlOiI+IixrOnt0aXRs
-ZToic3R5bGUifSxjOltjXSxzdGFydHM6e2U6Ijwvc3R5bGU+IixyRTohMCxzTDoiY3NzIn19LHtj
-TjoidGFnIixiOiI8c2NyaXB0KD89XFxzfD58JCkiLGU6Ij4iLGs6e3RpdGxlOiJzY3JpcHQifSxj
-OltjXSxzdGFydHM6e2U6Ijwvc2NyaXB0PiIsckU6ITAsc0w6ImphdmFzY3JpcHQifX0sZSx7Y046
-InBpIixiOi88XD9cdysvLGU6L1w/Pi8scjoxMH0se2NOOiJ0YWciLGI6IjwvPyIsZToiLz8+Iixj
-Olt7Y046InRpdGxlIixiOi9bXiBcLz48XG5cdF0rLyxyOjB9LGNdfV19fSk7aGxqcy5yZWdpc3Rl
-ckxhbmd1YWdlKCJjc3MiLGZ1bmN0aW9uKGUpe3ZhciBjPSJbYS16QS1aLV1bYS16QS1aMC05Xy1d
-KiIsYT17Y046ImZ1bmN0aW9uIixiOmMrIlxcKCIsckI6ITAsZUU6ITAsZToiXFwoIn07cmV0dXJu
-e2NJOiEwLGk6Ils9L3wnXSIsYzpbZS5DQkNNLHtjTjoiaWQiLGI6IlxcI1tBLVphLXowLTlfLV0r
-In0se2NOOiJjbGFzcyIsYjoiXFwuW0EtWmEtejAtOV8tXSsiLHI6MH0se2NOOiJhdHRyX3NlbGVj
-dG9yIixiOiJcXFsiLGU6IlxcXSIsaToiJCJ9LHtjTjoicHNldWRvIixiOiI6KDopP1thLXpBLVow
-LTlcXF9cXC1cXCtcXChcXClcXFwiXFwnXSsifSx7Y046ImF0X3J1bGUiLGI6IkAoZm9udC1mYWNl
-fHBhZ2UpIixsOiJbYS16LV0rIixrOiJmb250LWZhY2UgcGFnZSJ9LHtjTjoiYXRfcnVsZSIsYjoi
-QCIsZToiW3s7XSIsYzpbe2NOOiJrZXl3b3JkIixiOi9cUysvfSx7YjovXHMvLGVXOiEwLGVFOiEw
-LHI6MCxjOlthLGUuQVNNLGUuUVNNLGUuQ1NTTk1dfV19LHtjTjoidGFnIixiOmMscjowfSx7Y046
-InJ1bGVzIixiOiJ7IixlOiJ9IixpOiJbXlxcc10iLHI6MCxjOltlLkNCQ00se2NOOiJydWxlIixi
-OiJbXlxcc10iLHJCOiEwLGU6IjsiLGVXOiEwLGM6W3tjTjoiYXR0cmlidXRlIixiOiJbQS1aXFxf
-XFwuXFwtXSsiLGU6IjoiLGVFOiEwLGk6IlteXFxzXSIsc3RhcnRzOntjTjoidmFsdWUiLGVXOiEw
-LGVFOiEwLGM6W2EsZS5DU1NOTSxlLlFTTSxlLkFTTSxlLkNCQ00se2NOOiJoZXhjb2xvciIsYjoi
-I1swLTlBLUZhLWZdKyJ9LHtjTjoiaW1wb3J0YW50IixiOiIhaW1wb3J0YW50In1dfX1dfV19XX19
-KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoImNwcCIsZnVuY3Rpb24odCl7dmFyIGU9e2tleXdvcmQ6
-ImZhbHNlIGludCBmbG9hdCB3aGlsZSBwcml2YXRlIGNoYXIgY2F0Y2ggZXhwb3J0IHZpcnR1YWwg
-b3BlcmF0b3Igc2l6ZW9mIGR5bmFtaWNfY2FzdHwxMCB0eXBlZGVmIGNvbnN0X2Nhc3R8MTAgY29u
-c3Qgc3RydWN0IGZvciBzdGF0aWNfY2FzdHwxMCB1bmlvbiBuYW1lc3BhY2UgdW5zaWduZWQgbG9u
-ZyB0aHJvdyB2b2xhdGlsZSBzdGF0aWMgcHJvdGVjdGVkIGJvb2wgdGVtcGxhdGUgbXV0YWJsZSBp
-ZiBwdWJsaWMgZnJpZW5kIGRvIHJldHVybiBnb3RvIGF1dG8gdm9pZCBlbnVtIGVsc2UgYnJlYWsg
-bmV3IGV4dGVybiB1c2luZyB0cnVlIGNsYXNzIGFzbSBjYXNlIHR5cGVpZCBzaG9ydCByZWludGVy
-cHJldF9jYXN0fDEwIGRlZmF1bHQgZG91YmxlIHJlZ2lzdGVyIGV4cGxpY2l0IHNpZ25lZCB0eXBl
-bmFtZSB0cnkgdGhpcyBzd2l0Y2ggY29udGludWUgd2NoYXJfdCBpbmxpbmUgZGVsZXRlIGFsaWdu
-b2YgY2hhcjE2X3QgY2hhcjMyX3QgY29uc3RleHByIGRlY2x0eXBlIG5vZXhjZXB0IG51bGxwdHIg
-c3RhdGljX2Fzc2VydCB0aHJlYWRfbG9jYWwgcmVzdHJpY3QgX0Jvb2wgY29tcGxleCBfQ29tcGxl
-eCBfSW1hZ2luYXJ5IixidWlsdF9pbjoic3RkIHN0cmluZyBjaW4gY291dCBjZXJyIGNsb2cgc3Ry
-aW5nc3RyZWFtIGlzdHJpbmdzdHJlYW0gb3N0cmluZ3N0cmVhbSBhdXRvX3B0ciBkZXF1ZSBsaXN0
-IHF1ZXVlIHN0YWNrIHZlY3RvciBtYXAgc2V0IGJpdHNldCBtdWx0aXNldCBtdWx0aW1hcCB1bm9y
-ZGVyZWRfc2V0IHVub3JkZXJlZF9tYXAgdW5vcmRlcmVkX211bHRpc2V0IHVub3JkZXJlZF9tdWx0
-aW1hcCBhcnJheSBzaGFyZWRfcHRyIGFib3J0IGFicyBhY29zIGFzaW4gYXRhbjIgYXRhbiBjYWxs
-b2MgY2VpbCBjb3NoIGNvcyBleGl0IGV4cCBmYWJzIGZsb29yIGZtb2QgZnByaW50ZiBmcHV0cyBm
-cmVlIGZyZXhwIGZzY2FuZiBpc2FsbnVtIGlzYWxwaGEgaXNjbnRybCBpc2RpZ2l0IGlzZ3JhcGgg
-aXNsb3dlciBpc3ByaW50IGlzcHVuY3QgaXNzcGFjZSBpc3VwcGVyIGlzeGRpZ2l0IHRvbG93ZXIg
-dG91cHBlciBsYWJzIGxkZXhwIGxvZzEwIGxvZyBtYWxsb2MgbWVtY2hyIG1lbWNtcCBtZW1jcHkg
-bWVtc2V0IG1vZGYgcG93IHByaW50ZiBwdXRjaGFyIHB1dHMgc2NhbmYgc2luaCBzaW4gc25wcmlu
-dGYgc3ByaW50ZiBzcXJ0IHNzY2FuZiBzdHJjYXQgc3RyY2hyIHN0cmNtcCBzdHJjcHkgc3RyY3Nw
-biBzdHJsZW4gc3RybmNhdCBzdHJuY21wIHN0cm5jcHkgc3RycGJyayBzdHJyY2hyIHN0cnNwbiBz
-dHJzdHIgdGFuaCB0YW4gdmZwcmludGYgdnByaW50ZiB2c3ByaW50ZiJ9O3JldHVybnthbGlhc2Vz
-OlsiYyIsImgiLCJjKysiLCJoKysiXSxrOmUsaToiPC8iLGM6W3QuQ0xDTSx0LkNCQ00sdC5RU00s
-e2NOOiJzdHJpbmciLGI6IidcXFxcPy4iLGU6IiciLGk6Ii4ifSx7Y046Im51bWJlciIsYjoiXFxi
-KFxcZCsoXFwuXFxkKik/fFxcLlxcZCspKHV8VXxsfEx8dWx8VUx8ZnxGKSJ9LHQuQ05NLHtjTjoi
-cHJlcHJvY2Vzc29yIixiOiIjIixlOiIkIixrOiJpZiBlbHNlIGVsaWYgZW5kaWYgZGVmaW5lIHVu
-ZGVmIHdhcm5pbmcgZXJyb3IgbGluZSBwcmFnbWEiLGM6W3tiOidpbmNsdWRlXFxzKls8Il0nLGU6
-J1s+Il0nLGs6ImluY2x1ZGUiLGk6IlxcbiJ9LHQuQ0xDTV19LHtjTjoic3RsX2NvbnRhaW5lciIs
-YjoiXFxiKGRlcXVlfGxpc3R8cXVldWV8c3RhY2t8dmVjdG9yfG1hcHxzZXR8Yml0c2V0fG11bHRp
-c2V0fG11bHRpbWFwfHVub3JkZXJlZF9tYXB8dW5vcmRlcmVkX3NldHx1bm9yZGVyZWRfbXVsdGlz
-ZXR8dW5vcmRlcmVkX211bHRpbWFwfGFycmF5KVxccyo8IixlOiI+IixrOmUsYzpbInNlbGYiXX0s
-e2I6dC5JUisiOjoifV19fSk7aGxqcy5yZWdpc3Rlckxhbmd1YWdlKCJ2aW0iLGZ1bmN0aW9uKGUp
-e3JldHVybntsOi9bISNAXHddKy8sazp7a2V5d29yZDoiTnwwIFB8MCBYfDAgYXwwIGFiIGFiYyBh
-Ym8gYWwgYW0gYW58MCBhciBhcmdhIGFyZ2QgYXJnZSBhcmdkbyBhcmdnIGFyZ2wgYXJndSBhcyBh
-dSBhdWcgYXVuIGJ8MCBiTiBiYSBiYWQgYmQgYmUgYmVsIGJmIGJsIGJtIGJuIGJvIGJwIGJyIGJy
-ZWEgYnJlYWthIGJyZWFrZCBicmVha2wgYnJvIGJ1ZmRvIGJ1ZmZlcnMgYnVuIGJ3IGN8MCBjTiBj
-TmYgY2EgY2FiYyBjYWRkYiBjYWQgY2FkZGYgY2FsIGNhdCBjYiBjYyBjY2wgY2QgY2UgY2V4IGNm
-IGNmaXIgY2dldGIgY2dldGUgY2cgY2hhbmdlcyBjaGQgY2hlIGNoZWNrdCBjbCBjbGEgY2xvIGNt
-IGNtYXBjIGNtZSBjbiBjbmV3IGNuZiBjbm8gY25vcmVhIGNub3JlbWUgY28gY29sIGNvbG8gY29t
-IGNvbWMgY29tcCBjb24gY29uZiBjb3BlIGNwIGNwZiBjcSBjciBjcyBjc3QgY3UgY3VuYSBjdW5t
-ZSBjdyBkfDAgZGVsbSBkZWIgZGVidWdnIGRlbGMgZGVsZiBkaWYgZGlmZmcgZGlmZm8gZGlmZnAg
-ZGlmZnB1IGRpZmZzIGRpZmZ0aGlzIGRpZyBkaSBkbCBkZWxsIGRqIGRsaSBkbyBkb2F1dG9hIGRw
-IGRyIGRzIGRzcCBlfDAgZWEgZWMgZWNob2UgZWNob2ggZWNob20gZWNob24gZWwgZWxzZWkgZW0g
-ZW4gZW5kZm8gZW5kZiBlbmR0IGVuZHcgZW5lIGV4IGV4ZSBleGkgZXh1IGZ8MCBmaWxlcyBmaWxl
-dCBmaW4gZmluYSBmaW5pIGZpciBmaXggZm8gZm9sZGMgZm9sZGQgZm9sZGRvYyBmb2xkbyBmb3Ig
-ZnUgZ3wwIGdvIGdyIGdyZXBhIGd1IGd2IGhhIGh8MCBoZWxwZiBoZWxwZyBoZWxwdCBoaSBoaWQg
-aGlzIGl8MCBpYSBpYWJjIGlmIGlqIGlsIGltIGltYXBjIGltZSBpbm8gaW5vcmVhIGlub3JlbWUg
-aW50IGlzIGlzcCBpdSBpdW5hIGl1bm1lIGp8MCBqdSBrfDAga2VlcGEga2VlIGtlZXBqIGxOIGxO
-ZiBsfDAgbGFkIGxhZGRiIGxhZGRmIGxhIGxhbiBsYXQgbGIgbGMgbGNoIGxjbCBsY3MgbGUgbGVm
-dGEgbGV0IGxleCBsZiBsZmlyIGxnZXRiIGxnZXRlIGxnIGxnciBsZ3JlcGEgbGggbGwgbGxhIGxs
-aSBsbWFrIGxtIGxtYXBjIGxuZSBsbmV3IGxuZiBsbiBsb2FkayBsbyBsb2MgbG9ja3YgbG9sIGxv
-cGUgbHAgbHBmIGxyIGxzIGx0IGx1IGx1YSBsdWFkIGx1YWYgbHYgbHZpbWdyZXBhIGx3IG18MCBt
-YSBtYWsgbWFwIG1hcGMgbWFya3MgbWF0IG1lIG1lbnV0IG1lcyBtayBta3MgbWtzcCBta3YgbWt2
-aWUgbW9kIG16IG16ZiBuYmMgbmIgbmJzIG58MCBuZXcgbm0gbm1hcGMgbm1lIG5uIG5ub3JlbWUg
-bm9hIG5vIG5vaCBub3JlYSBub3JlbWUgbm9ybSBudSBudW4gbnVubWUgb2wgb3wwIG9tIG9tYXBj
-IG9tZSBvbiBvbm8gb25vcmVtZSBvcHQgb3Ugb3VubWUgb3cgcHwwIHByb2ZkIHByb2YgcHJvIHBy
-b21wdHIgcGMgcGVkIHBlIHBlcmxkIHBvIHBvcHUgcHAgcHJlIHByZXYgcHMgcHQgcHROIHB0ZiBw
-dGogcHRsIHB0biBwdHAgcHRyIHB0cyBwdSBwdyBweTMgcHl0aG9uMyBweTNkIHB5M2YgcHkgcHlk
-IHB5ZiBxfDAgcXVpdGEgcWEgcnwwIHJlYyByZWQgcmVkaSByZWRyIHJlZHJhd3MgcmVnIHJlcyBy
-ZXQgcmV0dSByZXcgcmkgcmlnaHRiIHJ1YiBydWJ5ZCBydWJ5ZiBydW5kIHJ1IHJ2IHN8MCBzTiBz
-YW4gc2Egc2FsIHNhdiBzYiBzYk4gc2JhIHNiZiBzYmwgc2JtIHNibiBzYnAgc2JyIHNjcmlwIHNj
-cmlwdGUgc2NzIHNlIHNldGYgc2V0ZyBzZXRsIHNmIHNmaXIgc2ggc2ltIHNpZyBzaWwgc2wgc2xh
-IHNtIHNtYXAgc21hcGMgc21lIHNuIHNuaSBzbm8gc25vciBzbm9yZW1lIHNvciBzbyBzcGVsbGQg
-c3BlIHNwZWxsaSBzcGVsbHIgc3BlbGx1IHNwZWxsdyBzcCBzcHIgc3JlIHN0IHN0YSBzdGFydGcg
-c3RhcnRyIHN0YXIgc3RvcGkgc3RqIHN0cyBzdW4gc3VubSBzdW5tZSBzdXMgc3Ygc3cgc3kgc3lu
-dGkgc3luYyB0fDAgdE4gdGFiTiB0YWJjIHRhYmRvIHRhYmUgdGFiZiB0YWJmaXIgdGFibCB0YWJt
-IHRhYm5ldyB0YWJuIHRhYm8gdGFicCB0YWJyIHRhYnMgdGFiIHRhIHRhZ3MgdGMgdGNsZCB0Y2xm
-IHRlIHRmIHRoIHRqIHRsIHRtIHRuIHRvIHRwIHRyIHRyeSB0cyB0dSB1fDAgdW5kb2ogdW5kb2wg
-dW5hIHVuaCB1bmwgdW5sbyB1bm0gdW5tZSB1bnMgdXAgdnwwIHZlIHZlcmIgdmVydCB2aW0gdmlt
-Z3JlcGEgdmkgdml1IHZpZSB2bSB2bWFwYyB2bWUgdm5lIHZuIHZub3JlbWUgdnMgdnUgdnVubWUg
-d2luZG8gd3wwIHdOIHdhIHdoIHdpIHdpbmMgd2lucCB3biB3cCB3cSB3cWEgd3Mgd3Ugd3YgeHww
-IHhhIHhtYXBjIHhtIHhtZSB4biB4bm9yZW1lIHh1IHh1bm1lIHl8MCB6fDAgfiBOZXh0IFByaW50
-IGFwcGVuZCBhYmJyZXZpYXRlIGFiY2xlYXIgYWJvdmVsZWZ0IGFsbCBhbWVudSBhbm9yZW1lbnUg
-YXJncyBhcmdhZGQgYXJnZGVsZXRlIGFyZ2VkaXQgYXJnZ2xvYmFsIGFyZ2xvY2FsIGFyZ3VtZW50
-IGFzY2lpIGF1dG9jbWQgYXVncm91cCBhdW5tZW51IGJ1ZmZlciBiTmV4dCBiYWxsIGJhZGQgYmRl
-bGV0ZSBiZWhhdmUgYmVsb3dyaWdodCBiZmlyc3QgYmxhc3QgYm1vZGlmaWVkIGJuZXh0IGJvdHJp
-Z2h0IGJwcmV2aW91cyBicmV3aW5kIGJyZWFrIGJyZWFrYWRkIGJyZWFrZGVsIGJyZWFrbGlzdCBi
-cm93c2UgYnVubG9hZCBid2lwZW91dCBjaGFuZ2UgY05leHQgY05maWxlIGNhYmJyZXYgY2FiY2xl
-YXIgY2FkZGJ1ZmZlciBjYWRkZXhwciBjYWRkZmlsZSBjYWxsIGNhdGNoIGNidWZmZXIgY2Nsb3Nl
-IGNlbnRlciBjZXhwciBjZmlsZSBjZmlyc3QgY2dldGJ1ZmZlciBjZ2V0ZXhwciBjZ2V0ZmlsZSBj
-aGRpciBjaGVja3BhdGggY2hlY2t0aW1lIGNsaXN0IGNsYXN0IGNsb3NlIGNtYXAgY21hcGNsZWFy
-IGNtZW51IGNuZXh0IGNuZXdlciBjbmZpbGUgY25vcmVtYXAgY25vcmVhYmJyZXYgY25vcmVtZW51
-IGNvcHkgY29sZGVyIGNvbG9yc2NoZW1lIGNvbW1hbmQgY29tY2xlYXIgY29tcGlsZXIgY29udGlu
-dWUgY29uZmlybSBjb3BlbiBjcHJldmlvdXMgY3BmaWxlIGNxdWl0IGNyZXdpbmQgY3Njb3BlIGNz
-dGFnIGN1bm1hcCBjdW5hYmJyZXYgY3VubWVudSBjd2luZG93IGRlbGV0ZSBkZWxtYXJrcyBkZWJ1
-ZyBkZWJ1Z2dyZWVkeSBkZWxjb21tYW5kIGRlbGZ1bmN0aW9uIGRpZmZ1cGRhdGUgZGlmZmdldCBk
-aWZmb2ZmIGRpZmZwYXRjaCBkaWZmcHV0IGRpZmZzcGxpdCBkaWdyYXBocyBkaXNwbGF5IGRlbGV0
-ZWwgZGp1bXAgZGxpc3QgZG9hdXRvY21kIGRvYXV0b2FsbCBkZWxldGVwIGRyb3AgZHNlYXJjaCBk
-c3BsaXQgZWRpdCBlYXJsaWVyIGVjaG8gZWNob2VyciBlY2hvaGwgZWNob21zZyBlbHNlIGVsc2Vp
-ZiBlbWVudSBlbmRpZiBlbmRmb3IgZW5kZnVuY3Rpb24gZW5kdHJ5IGVuZHdoaWxlIGVuZXcgZXhl
-Y3V0ZSBleGl0IGV4dXNhZ2UgZmlsZSBmaWxldHlwZSBmaW5kIGZpbmFsbHkgZmluaXNoIGZpcnN0
-IGZpeGRlbCBmb2xkIGZvbGRjbG9zZSBmb2xkZG9vcGVuIGZvbGRkb2Nsb3NlZCBmb2xkb3BlbiBm
-dW5jdGlvbiBnbG9iYWwgZ290byBncmVwIGdyZXBhZGQgZ3VpIGd2aW0gaGFyZGNvcHkgaGVscCBo
-ZWxwZmluZCBoZWxwZ3JlcCBoZWxwdGFncyBoaWdobGlnaHQgaGlkZSBoaXN0b3J5IGluc2VydCBp
-YWJicmV2IGlhYmNsZWFyIGlqdW1wIGlsaXN0IGltYXAgaW1hcGNsZWFyIGltZW51IGlub3JlbWFw
-IGlub3JlYWJicmV2IGlub3JlbWVudSBpbnRybyBpc2VhcmNoIGlzcGxpdCBpdW5tYXAgaXVuYWJi
-cmV2IGl1bm1lbnUgam9pbiBqdW1wcyBrZWVwYWx0IGtlZXBtYXJrcyBrZWVwanVtcHMgbE5leHQg
-bE5maWxlIGxpc3QgbGFkZGV4cHIgbGFkZGJ1ZmZlciBsYWRkZmlsZSBsYXN0IGxhbmd1YWdlIGxh
-dGVyIGxidWZmZXIgbGNkIGxjaGRpciBsY2xvc2UgbGNzY29wZSBsZWZ0IGxlZnRhYm92ZSBsZXhw
-ciBsZmlsZSBsZmlyc3QgbGdldGJ1ZmZlciBsZ2V0ZXhwciBsZ2V0ZmlsZSBsZ3JlcCBsZ3JlcGFk
-ZCBsaGVscGdyZXAgbGxhc3QgbGxpc3QgbG1ha2UgbG1hcCBsbWFwY2xlYXIgbG5leHQgbG5ld2Vy
-IGxuZmlsZSBsbm9yZW1hcCBsb2Fka2V5bWFwIGxvYWR2aWV3IGxvY2ttYXJrcyBsb2NrdmFyIGxv
-bGRlciBsb3BlbiBscHJldmlvdXMgbHBmaWxlIGxyZXdpbmQgbHRhZyBsdW5tYXAgbHVhZG8gbHVh
-ZmlsZSBsdmltZ3JlcCBsdmltZ3JlcGFkZCBsd2luZG93IG1vdmUgbWFyayBtYWtlIG1hcGNsZWFy
-IG1hdGNoIG1lbnUgbWVudXRyYW5zbGF0ZSBtZXNzYWdlcyBta2V4cmMgbWtzZXNzaW9uIG1rc3Bl
-bGwgbWt2aW1yYyBta3ZpZXcgbW9kZSBtenNjaGVtZSBtemZpbGUgbmJjbG9zZSBuYmtleSBuYnNh
-cnQgbmV4dCBubWFwIG5tYXBjbGVhciBubWVudSBubm9yZW1hcCBubm9yZW1lbnUgbm9hdXRvY21k
-IG5vcmVtYXAgbm9obHNlYXJjaCBub3JlYWJicmV2IG5vcmVtZW51IG5vcm1hbCBudW1iZXIgbnVu
-bWFwIG51bm1lbnUgb2xkZmlsZXMgb3BlbiBvbWFwIG9tYXBjbGVhciBvbWVudSBvbmx5IG9ub3Jl
-bWFwIG9ub3JlbWVudSBvcHRpb25zIG91bm1hcCBvdW5tZW51IG93bnN5bnRheCBwcmludCBwcm9m
-ZGVsIHByb2ZpbGUgcHJvbXB0ZmluZCBwcm9tcHRyZXBsIHBjbG9zZSBwZWRpdCBwZXJsIHBlcmxk
-byBwb3AgcG9wdXAgcHBvcCBwcmVzZXJ2ZSBwcmV2aW91cyBwc2VhcmNoIHB0YWcgcHROZXh0IHB0
-Zmlyc3QgcHRqdW1wIHB0bGFzdCBwdG5leHQgcHRwcmV2aW91cyBwdHJld2luZCBwdHNlbGVjdCBw
-dXQgcHdkIHB5M2RvIHB5M2ZpbGUgcHl0aG9uIHB5ZG8gcHlmaWxlIHF1aXQgcXVpdGFsbCBxYWxs
-IHJlYWQgcmVjb3ZlciByZWRvIHJlZGlyIHJlZHJhdyByZWRyYXdzdGF0dXMgcmVnaXN0ZXJzIHJl
-c2l6ZSByZXRhYiByZXR1cm4gcmV3aW5kIHJpZ2h0IHJpZ2h0YmVsb3cgcnVieSBydWJ5ZG8gcnVi
-eWZpbGUgcnVuZG8gcnVudGltZSBydmltaW5mbyBzdWJzdGl0dXRlIHNOZXh0IHNhbmRib3ggc2Fy
-Z3VtZW50IHNhbGwgc2F2ZWFzIHNidWZmZXIgc2JOZXh0IHNiYWxsIHNiZmlyc3Qgc2JsYXN0IHNi
-bW9kaWZpZWQgc2JuZXh0IHNicHJldmlvdXMgc2JyZXdpbmQgc2NyaXB0bmFtZXMgc2NyaXB0ZW5j
-b2Rpbmcgc2NzY29wZSBzZXQgc2V0ZmlsZXR5cGUgc2V0Z2xvYmFsIHNldGxvY2FsIHNmaW5kIHNm
-aXJzdCBzaGVsbCBzaW1hbHQgc2lnbiBzaWxlbnQgc2xlZXAgc2xhc3Qgc21hZ2ljIHNtYXBjbGVh
-ciBzbWVudSBzbmV4dCBzbmlmZiBzbm9tYWdpYyBzbm9yZW1hcCBzbm9yZW1lbnUgc29ydCBzb3Vy
-Y2Ugc3BlbGxkdW1wIHNwZWxsZ29vZCBzcGVsbGluZm8gc3BlbGxyZXBhbGwgc3BlbGx1bmRvIHNw
-ZWxsd3Jvbmcgc3BsaXQgc3ByZXZpb3VzIHNyZXdpbmQgc3RvcCBzdGFnIHN0YXJ0Z3JlcGxhY2Ug
-c3RhcnRyZXBsYWNlIHN0YXJ0aW5zZXJ0IHN0b3BpbnNlcnQgc3RqdW1wIHN0c2VsZWN0IHN1bmhp
-ZGUgc3VubWFwIHN1bm1lbnUgc3VzcGVuZCBzdmlldyBzd2FwbmFtZSBzeW50YXggc3ludGltZSBz
-eW5jYmluZCB0TmV4dCB0YWJOZXh0IHRhYmNsb3NlIHRhYmVkaXQgdGFiZmluZCB0YWJmaXJzdCB0
-YWJsYXN0IHRhYm1vdmUgdGFibmV4dCB0YWJvbmx5IHRhYnByZXZpb3VzIHRhYnJld2luZCB0YWcg
-dGNsIHRjbGRvIHRjbGZpbGUgdGVhcm9mZiB0Zmlyc3QgdGhyb3cgdGp1bXAgdGxhc3QgdG1lbnUg
-dG5leHQgdG9wbGVmdCB0cHJldmlvdXMgdHJld2luZCB0c2VsZWN0IHR1bm1lbnUgdW5kbyB1bmRv
-am9pbiB1bmRvbGlzdCB1bmFiYnJldmlhdGUgdW5oaWRlIHVubGV0IHVubG9ja3ZhciB1bm1hcCB1
-bm1lbnUgdW5zaWxlbnQgdXBkYXRlIHZnbG9iYWwgdmVyc2lvbiB2ZXJib3NlIHZlcnRpY2FsIHZp
-bWdyZXAgdmltZ3JlcGFkZCB2aXN1YWwgdml1c2FnZSB2aWV3IHZtYXAgdm1hcGNsZWFyIHZtZW51
-IHZuZXcgdm5vcmVtYXAgdm5vcmVtZW51IHZzcGxpdCB2dW5tYXAgdnVubWVudSB3cml0ZSB3TmV4
-dCB3YWxsIHdoaWxlIHdpbnNpemUgd2luY21kIHdpbnBvcyB3bmV4dCB3cHJldmlvdXMgd3FhbGwg
-d3N2ZXJiIHd1bmRvIHd2aW1pbmZvIHhpdCB4YWxsIHhtYXBjbGVhciB4bWFwIHhtZW51IHhub3Jl
-bWFwIHhub3JlbWVudSB4dW5tYXAgeHVubWVudSB5YW5rIixidWlsdF9pbjoiYWJzIGFjb3MgYWRk
-IGFuZCBhcHBlbmQgYXJnYyBhcmdpZHggYXJndiBhc2luIGF0YW4gYXRhbjIgYnJvd3NlIGJyb3dz
-ZWRpciBidWZleGlzdHMgYnVmbGlzdGVkIGJ1ZmxvYWRlZCBidWZuYW1lIGJ1Zm5yIGJ1Zndpbm5y
-IGJ5dGUybGluZSBieXRlaWR4IGNhbGwgY2VpbCBjaGFuZ2VuciBjaGFyMm5yIGNpbmRlbnQgY2xl
-YXJtYXRjaGVzIGNvbCBjb21wbGV0ZSBjb21wbGV0ZV9hZGQgY29tcGxldGVfY2hlY2sgY29uZmly
-bSBjb3B5IGNvcyBjb3NoIGNvdW50IGNzY29wZV9jb25uZWN0aW9uIGN1cnNvciBkZWVwY29weSBk
-ZWxldGUgZGlkX2ZpbGV0eXBlIGRpZmZfZmlsbGVyIGRpZmZfaGxJRCBlbXB0eSBlc2NhcGUgZXZh
-bCBldmVudGhhbmRsZXIgZXhlY3V0YWJsZSBleGlzdHMgZXhwIGV4cGFuZCBleHRlbmQgZmVlZGtl
-eXMgZmlsZXJlYWRhYmxlIGZpbGV3cml0YWJsZSBmaWx0ZXIgZmluZGRpciBmaW5kZmlsZSBmbG9h
-dDJuciBmbG9vciBmbW9kIGZuYW1lZXNjYXBlIGZuYW1lbW9kaWZ5IGZvbGRjbG9zZWQgZm9sZGNs
-b3NlZGVuZCBmb2xkbGV2ZWwgZm9sZHRleHQgZm9sZHRleHRyZXN1bHQgZm9yZWdyb3VuZCBmdW5j
-dGlvbiBnYXJiYWdlY29sbGVjdCBnZXQgZ2V0YnVmbGluZSBnZXRidWZ2YXIgZ2V0Y2hhciBnZXRj
-aGFybW9kIGdldGNtZGxpbmUgZ2V0Y21kcG9zIGdldGNtZHR5cGUgZ2V0Y3dkIGdldGZvbnRuYW1l
-IGdldGZwZXJtIGdldGZzaXplIGdldGZ0aW1lIGdldGZ0eXBlIGdldGxpbmUgZ2V0bG9jbGlzdCBn
-ZXRtYXRjaGVzIGdldHBpZCBnZXRwb3MgZ2V0cWZsaXN0IGdldHJlZyBnZXRyZWd0eXBlIGdldHRh
-YnZhciBnZXR0YWJ3aW52YXIgZ2V0d2lucG9zeCBnZXR3aW5wb3N5IGdldHdpbnZhciBnbG9iIGds
-b2JwYXRoIGhhcyBoYXNfa2V5IGhhc2xvY2FsZGlyIGhhc21hcHRvIGhpc3RhZGQgaGlzdGRlbCBo
-aXN0Z2V0IGhpc3RuciBobGV4aXN0cyBobElEIGhvc3RuYW1lIGljb252IGluZGVudCBpbmRleCBp
-bnB1dCBpbnB1dGRpYWxvZyBpbnB1dGxpc3QgaW5wdXRyZXN0b3JlIGlucHV0c2F2ZSBpbnB1dHNl
-Y3JldCBpbnNlcnQgaW52ZXJ0IGlzZGlyZWN0b3J5IGlzbG9ja2VkIGl0ZW1zIGpvaW4ga2V5cyBs
-ZW4gbGliY2FsbCBsaWJjYWxsbnIgbGluZSBsaW5lMmJ5dGUgbGlzcGluZGVudCBsb2NhbHRpbWUg
-bG9nIGxvZzEwIGx1YWV2YWwgbWFwIG1hcGFyZyBtYXBjaGVjayBtYXRjaCBtYXRjaGFkZCBtYXRj
-aGFyZyBtYXRjaGRlbGV0ZSBtYXRjaGVuZCBtYXRjaGxpc3QgbWF0Y2hzdHIgbWF4IG1pbiBta2Rp
-ciBtb2RlIG16ZXZhbCBuZXh0bm9uYmxhbmsgbnIyY2hhciBvciBwYXRoc2hvcnRlbiBwb3cgcHJl
-dm5vbmJsYW5rIHByaW50ZiBwdW12aXNpYmxlIHB5M2V2YWwgcHlldmFsIHJhbmdlIHJlYWRmaWxl
-IHJlbHRpbWUgcmVsdGltZXN0ciByZW1vdGVfZXhwciByZW1vdGVfZm9yZWdyb3VuZCByZW1vdGVf
-cGVlayByZW1vdGVfcmVhZCByZW1vdGVfc2VuZCByZW1vdmUgcmVuYW1lIHJlcGVhdCByZXNvbHZl
-IHJldmVyc2Ugcm91bmQgc2NyZWVuYXR0ciBzY3JlZW5jaGFyIHNjcmVlbmNvbCBzY3JlZW5yb3cg
-c2VhcmNoIHNlYXJjaGRlY2wgc2VhcmNocGFpciBzZWFyY2hwYWlycG9zIHNlYXJjaHBvcyBzZXJ2
-ZXIyY2xpZW50IHNlcnZlcmxpc3Qgc2V0YnVmdmFyIHNldGNtZHBvcyBzZXRsaW5lIHNldGxvY2xp
-c3Qgc2V0bWF0Y2hlcyBzZXRwb3Mgc2V0cWZsaXN0IHNldHJlZyBzZXR0YWJ2YXIgc2V0dGFid2lu
-dmFyIHNldHdpbnZhciBzaGEyNTYgc2hlbGxlc2NhcGUgc2hpZnR3aWR0aCBzaW1wbGlmeSBzaW4g
-c2luaCBzb3J0IHNvdW5kZm9sZCBzcGVsbGJhZHdvcmQgc3BlbGxzdWdnZXN0IHNwbGl0IHNxcnQg
-c3RyMmZsb2F0IHN0cjJuciBzdHJjaGFycyBzdHJkaXNwbGF5d2lkdGggc3RyZnRpbWUgc3RyaWR4
-IHN0cmluZyBzdHJsZW4gc3RycGFydCBzdHJyaWR4IHN0cnRyYW5zIHN0cndpZHRoIHN1Ym1hdGNo
-IHN1YnN0aXR1dGUgc3luY29uY2VhbGVkIHN5bklEIHN5bklEYXR0ciBzeW5JRHRyYW5zIHN5bnN0
-YWNrIHN5c3RlbSB0YWJwYWdlYnVmbGlzdCB0YWJwYWdlbnIgdGFicGFnZXdpbm5yIHRhZ2ZpbGVz
-IHRhZ2xpc3QgdGFuIHRhbmggdGVtcG5hbWUgdG9sb3dlciB0b3VwcGVyIHRyIHRydW5jIHR5cGUg
-dW5kb2ZpbGUgdW5kb3RyZWUgdmFsdWVzIHZpcnRjb2wgdmlzdWFsbW9kZSB3aWxkbWVudW1vZGUg
-d2luYnVmbnIgd2luY29sIHdpbmhlaWdodCB3aW5saW5lIHdpbm5yIHdpbnJlc3RjbWQgd2lucmVz
-dHZpZXcgd2luc2F2ZXZpZXcgd2lud2lkdGggd3JpdGVmaWxlIHhvciJ9LGk6L1t7Ol0vLGM6W2Uu
-Tk0sZS5BU00se2NOOiJzdHJpbmciLGI6LyIoKFxcIil8W14iXG5dKSooInxcbikvfSx7Y046InZh
-cmlhYmxlIixiOi9bYnd0Z2xzYXZdOltcd1xkX10qL30se2NOOiJmdW5jdGlvbiIsYks6ImZ1bmN0
-aW9uIGZ1bmN0aW9uISIsZToiJCIscjowLGM6W2UuVE0se2NOOiJwYXJhbXMiLGI6IlxcKCIsZToi
-XFwpIn1dfV19fSk7aGxqcy5yZWdpc3Rlckxhbmd1YWdlKCJqc29uIixmdW5jdGlvbihlKXt2YXIg
-dD17bGl0ZXJhbDoidHJ1ZSBmYWxzZSBudWxsIn0saT1bZS5RU00sZS5DTk1dLGw9e2NOOiJ2YWx1
-ZSIsZToiLCIsZVc6ITAsZUU6ITAsYzppLGs6dH0sYz17YjoieyIsZToifSIsYzpbe2NOOiJhdHRy
-aWJ1dGUiLGI6J1xccyoiJyxlOiciXFxzKjpcXHMqJyxlQjohMCxlRTohMCxjOltlLkJFXSxpOiJc
-XG4iLHN0YXJ0czpsfV0saToiXFxTIn0sbj17YjoiXFxbIixlOiJcXF0iLGM6W2UuaW5oZXJpdChs
-LHtjTjpudWxsfSldLGk6IlxcUyJ9O3JldHVybiBpLnNwbGljZShpLmxlbmd0aCwwLGMsbikse2M6
-aSxrOnQsaToiXFxTIn19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoImNvZmZlZXNjcmlwdCIsZnVu
-Y3Rpb24oZSl7dmFyIGM9e2tleXdvcmQ6ImluIGlmIGZvciB3aGlsZSBmaW5hbGx5IG5ldyBkbyBy
-ZXR1cm4gZWxzZSBicmVhayBjYXRjaCBpbnN0YW5jZW9mIHRocm93IHRyeSB0aGlzIHN3aXRjaCBj
-b250aW51ZSB0eXBlb2YgZGVsZXRlIGRlYnVnZ2VyIHN1cGVyIHRoZW4gdW5sZXNzIHVudGlsIGxv
-b3Agb2YgYnkgd2hlbiBhbmQgb3IgaXMgaXNudCBub3QiLGxpdGVyYWw6InRydWUgZmFsc2UgbnVs
-bCB1bmRlZmluZWQgeWVzIG5vIG9uIG9mZiIscmVzZXJ2ZWQ6ImNhc2UgZGVmYXVsdCBmdW5jdGlv
-biB2YXIgdm9pZCB3aXRoIGNvbnN0IGxldCBlbnVtIGV4cG9ydCBpbXBvcnQgbmF0aXZlIF9faGFz
-UHJvcCBfX2V4dGVuZHMgX19zbGljZSBfX2JpbmQgX19pbmRleE9mIixidWlsdF9pbjoibnBtIHJl
-cXVpcmUgY29uc29sZSBwcmludCBtb2R1bGUgZ2xvYmFsIHdpbmRvdyBkb2N1bWVudCJ9LG49IltB
-LVphLXokX11bMC05QS1aYS16JF9dKiIsdD17Y046InN1YnN0IixiOi8jXHsvLGU6L30vLGs6Y30s
-cj1bZS5CTk0sZS5pbmhlcml0KGUuQ05NLHtzdGFydHM6e2U6IihcXHMqLyk/IixyOjB9fSkse2NO
-OiJzdHJpbmciLHY6W3tiOi8nJycvLGU6LycnJy8sYzpbZS5CRV19LHtiOi8nLyxlOi8nLyxjOltl
-LkJFXX0se2I6LyIiIi8sZTovIiIiLyxjOltlLkJFLHRdfSx7YjovIi8sZTovIi8sYzpbZS5CRSx0
-XX1dfSx7Y046InJlZ2V4cCIsdjpbe2I6Ii8vLyIsZToiLy8vIixjOlt0LGUuSENNXX0se2I6Ii8v
-W2dpbV0qIixyOjB9LHtiOi9cLyg/IVsgKl0pKFxcXC98LikqP1wvW2dpbV0qKD89XFd8JCkvfV19
-LHtjTjoicHJvcGVydHkiLGI6IkAiK259LHtiOiJgIixlOiJgIixlQjohMCxlRTohMCxzTDoiamF2
-YXNjcmlwdCJ9XTt0LmM9cjt2YXIgaT1lLmluaGVyaXQoZS5UTSx7YjpufSkscz0iKFxcKC4qXFwp
-KT9cXHMqXFxCWy09XT4iLG89e2NOOiJwYXJhbXMiLGI6IlxcKFteXFwoXSIsckI6ITAsYzpbe2I6
-L1woLyxlOi9cKS8sazpjLGM6WyJzZWxmIl0uY29uY2F0KHIpfV19O3JldHVybnthbGlhc2VzOlsi
-Y29mZmVlIiwiY3NvbiIsImljZWQiXSxrOmMsaTovXC9cKi8sYzpyLmNvbmNhdChbe2NOOiJjb21t
-ZW50IixiOiIjIyMiLGU6IiMjIyIsYzpbZS5QV01dfSxlLkhDTSx7Y046ImZ1bmN0aW9uIixiOiJe
-XFxzKiIrbisiXFxzKj1cXHMqIitzLGU6IlstPV0+IixyQjohMCxjOltpLG9dfSx7YjovWzpcKCw9
-XVxzKi8scjowLGM6W3tjTjoiZnVuY3Rpb24iLGI6cyxlOiJbLT1dPiIsckI6ITAsYzpbb119XX0s
-e2NOOiJjbGFzcyIsYks6ImNsYXNzIixlOiIkIixpOi9bOj0iXFtcXV0vLGM6W3tiSzoiZXh0ZW5k
-cyIsZVc6ITAsaTovWzo9IlxbXF1dLyxjOltpXX0saV19LHtjTjoiYXR0cmlidXRlIixiOm4rIjoi
-LGU6IjoiLHJCOiEwLHJFOiEwLHI6MH1dKX19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoInBocCIs
-ZnVuY3Rpb24oZSl7dmFyIGM9e2NOOiJ2YXJpYWJsZSIsYjoiXFwkK1thLXpBLVpffy3Dv11bYS16
-QS1aMC05X38tw79dKiJ9LGk9e2NOOiJwcmVwcm9jZXNzb3IiLGI6LzxcPyhwaHApP3xcPz4vfSxh
-PXtjTjoic3RyaW5nIixjOltlLkJFLGldLHY6W3tiOidiIicsZTonIid9LHtiOiJiJyIsZToiJyJ9
-LGUuaW5oZXJpdChlLkFTTSx7aTpudWxsfSksZS5pbmhlcml0KGUuUVNNLHtpOm51bGx9KV19LG49
-e3Y6W2UuQk5NLGUuQ05NXX07cmV0dXJue2FsaWFzZXM6WyJwaHAzIiwicGhwNCIsInBocDUiLCJw
-aHA2Il0sY0k6ITAsazoiYW5kIGluY2x1ZGVfb25jZSBsaXN0IGFic3RyYWN0IGdsb2JhbCBwcml2
-YXRlIGVjaG8gaW50ZXJmYWNlIGFzIHN0YXRpYyBlbmRzd2l0Y2ggYXJyYXkgbnVsbCBpZiBlbmR3
-aGlsZSBvciBjb25zdCBmb3IgZW5kZm9yZWFjaCBzZWxmIHZhciB3aGlsZSBpc3NldCBwdWJsaWMg
-cHJvdGVjdGVkIGV4aXQgZm9yZWFjaCB0aHJvdyBlbHNlaWYgaW5jbHVkZSBfX0ZJTEVfXyBlbXB0
-eSByZXF1aXJlX29uY2UgZG8geG9yIHJldHVybiBwYXJlbnQgY2xvbmUgdXNlIF9fQ0xBU1NfXyBf
-X0xJTkVfXyBlbHNlIGJyZWFrIHByaW50IGV2YWwgbmV3IGNhdGNoIF9fTUVUSE9EX18gY2FzZSBl
-eGNlcHRpb24gZGVmYXVsdCBkaWUgcmVxdWlyZSBfX0ZVTkNUSU9OX18gZW5kZGVjbGFyZSBmaW5h
-bCB0cnkgc3dpdGNoIGNvbnRpbnVlIGVuZGZvciBlbmRpZiBkZWNsYXJlIHVuc2V0IHRydWUgZmFs
-c2UgdHJhaXQgZ290byBpbnN0YW5jZW9mIGluc3RlYWRvZiBfX0RJUl9fIF9fTkFNRVNQQUNFX18g
-eWllbGQgZmluYWxseSIsYzpbZS5DTENNLGUuSENNLHtjTjoiY29tbWVudCIsYjoiL1xcKiIsZToi
-XFwqLyIsYzpbe2NOOiJwaHBkb2MiLGI6Ilxcc0BbQS1aYS16XSsifSxpXX0se2NOOiJjb21tZW50
-IixiOiJfX2hhbHRfY29tcGlsZXIuKz87IixlVzohMCxrOiJfX2hhbHRfY29tcGlsZXIiLGw6ZS5V
-SVJ9LHtjTjoic3RyaW5nIixiOiI8PDxbJ1wiXT9cXHcrWydcIl0/JCIsZToiXlxcdys7IixjOltl
-LkJFXX0saSxjLHtiOi8tPitbYS16QS1aX1x4N2YtXHhmZl1bYS16QS1aMC05X1x4N2YtXHhmZl0q
-L30se2NOOiJmdW5jdGlvbiIsYks6ImZ1bmN0aW9uIixlOi9bO3tdLyxlRTohMCxpOiJcXCR8XFxb
-fCUiLGM6W2UuVVRNLHtjTjoicGFyYW1zIixiOiJcXCgiLGU6IlxcKSIsYzpbInNlbGYiLGMsZS5D
-QkNNLGEsbl19XX0se2NOOiJjbGFzcyIsYks6ImNsYXNzIGludGVyZmFjZSIsZToieyIsZUU6ITAs
-aTovWzpcKFwkIl0vLGM6W3tiSzoiZXh0ZW5kcyBpbXBsZW1lbnRzIn0sZS5VVE1dfSx7Yks6Im5h
-bWVzcGFjZSIsZToiOyIsaTovW1wuJ10vLGM6W2UuVVRNXX0se2JLOiJ1c2UiLGU6IjsiLGM6W2Uu
-VVRNXX0se2I6Ij0+In0sYSxuXX19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoInBvd2Vyc2hlbGwi
-LGZ1bmN0aW9uKGUpe3ZhciB0PXtiOiJgW1xcc1xcU10iLHI6MH0scj17Y046InZhcmlhYmxlIix2
-Olt7YjovXCRbXHdcZF1bXHdcZF86XSovfV19LG89e2NOOiJzdHJpbmciLGI6LyIvLGU6LyIvLGM6
-W3Qscix7Y046InZhcmlhYmxlIixiOi9cJFtBLXpdLyxlOi9bXkEtel0vfV19LGE9e2NOOiJzdHJp
-bmciLGI6LycvLGU6LycvfTtyZXR1cm57YWxpYXNlczpbInBzIl0sbDovLT9bQS16XC5cLV0rLyxj
-STohMCxrOntrZXl3b3JkOiJpZiBlbHNlIGZvcmVhY2ggcmV0dXJuIGZ1bmN0aW9uIGRvIHdoaWxl
-IHVudGlsIGVsc2VpZiBiZWdpbiBmb3IgdHJhcCBkYXRhIGR5bmFtaWNwYXJhbSBlbmQgYnJlYWsg
-dGhyb3cgcGFyYW0gY29udGludWUgZmluYWxseSBpbiBzd2l0Y2ggZXhpdCBmaWx0ZXIgdHJ5IHBy
-b2Nlc3MgY2F0Y2giLGxpdGVyYWw6IiRudWxsICR0cnVlICRmYWxzZSIsYnVpbHRfaW46IkFkZC1D
-b250ZW50IEFkZC1IaXN0b3J5IEFkZC1NZW1iZXIgQWRkLVBTU25hcGluIENsZWFyLUNvbnRlbnQg
-Q2xlYXItSXRlbSBDbGVhci1JdGVtIFByb3BlcnR5IENsZWFyLVZhcmlhYmxlIENvbXBhcmUtT2Jq
-ZWN0IENvbnZlcnRGcm9tLVNlY3VyZVN0cmluZyBDb252ZXJ0LVBhdGggQ29udmVydFRvLUh0bWwg
-Q29udmVydFRvLVNlY3VyZVN0cmluZyBDb3B5LUl0ZW0gQ29weS1JdGVtUHJvcGVydHkgRXhwb3J0
-LUFsaWFzIEV4cG9ydC1DbGl4bWwgRXhwb3J0LUNvbnNvbGUgRXhwb3J0LUNzdiBGb3JFYWNoLU9i
-amVjdCBGb3JtYXQtQ3VzdG9tIEZvcm1hdC1MaXN0IEZvcm1hdC1UYWJsZSBGb3JtYXQtV2lkZSBH
-ZXQtQWNsIEdldC1BbGlhcyBHZXQtQXV0aGVudGljb2RlU2lnbmF0dXJlIEdldC1DaGlsZEl0ZW0g
-R2V0LUNvbW1hbmQgR2V0LUNvbnRlbnQgR2V0LUNyZWRlbnRpYWwgR2V0LUN1bHR1cmUgR2V0LURh
-dGUgR2V0LUV2ZW50TG9nIEdldC1FeGVjdXRpb25Qb2xpY3kgR2V0LUhlbHAgR2V0LUhpc3Rvcnkg
-R2V0LUhvc3QgR2V0LUl0ZW0gR2V0LUl0ZW1Qcm9wZXJ0eSBHZXQtTG9jYXRpb24gR2V0LU1lbWJl
-ciBHZXQtUGZ4Q2VydGlmaWNhdGUgR2V0LVByb2Nlc3MgR2V0LVBTRHJpdmUgR2V0LVBTUHJvdmlk
-ZXIgR2V0LVBTU25hcGluIEdldC1TZXJ2aWNlIEdldC1UcmFjZVNvdXJjZSBHZXQtVUlDdWx0dXJl
-IEdldC1VbmlxdWUgR2V0LVZhcmlhYmxlIEdldC1XbWlPYmplY3QgR3JvdXAtT2JqZWN0IEltcG9y
-dC1BbGlhcyBJbXBvcnQtQ2xpeG1sIEltcG9ydC1Dc3YgSW52b2tlLUV4cHJlc3Npb24gSW52b2tl
-LUhpc3RvcnkgSW52b2tlLUl0ZW0gSm9pbi1QYXRoIE1lYXN1cmUtQ29tbWFuZCBNZWFzdXJlLU9i
-amVjdCBNb3ZlLUl0ZW0gTW92ZS1JdGVtUHJvcGVydHkgTmV3LUFsaWFzIE5ldy1JdGVtIE5ldy1J
-dGVtUHJvcGVydHkgTmV3LU9iamVjdCBOZXctUFNEcml2ZSBOZXctU2VydmljZSBOZXctVGltZVNw
-YW4gTmV3LVZhcmlhYmxlIE91dC1EZWZhdWx0IE91dC1GaWxlIE91dC1Ib3N0IE91dC1OdWxsIE91
-dC1QcmludGVyIE91dC1TdHJpbmcgUG9wLUxvY2F0aW9uIFB1c2gtTG9jYXRpb24gUmVhZC1Ib3N0
-IFJlbW92ZS1JdGVtIFJlbW92ZS1JdGVtUHJvcGVydHkgUmVtb3ZlLVBTRHJpdmUgUmVtb3ZlLVBT
-U25hcGluIFJlbW92ZS1WYXJpYWJsZSBSZW5hbWUtSXRlbSBSZW5hbWUtSXRlbVByb3BlcnR5IFJl
-c29sdmUtUGF0aCBSZXN0YXJ0LVNlcnZpY2UgUmVzdW1lLVNlcnZpY2UgU2VsZWN0LU9iamVjdCBT
-ZWxlY3QtU3RyaW5nIFNldC1BY2wgU2V0LUFsaWFzIFNldC1BdXRoZW50aWNvZGVTaWduYXR1cmUg
-U2V0LUNvbnRlbnQgU2V0LURhdGUgU2V0LUV4ZWN1dGlvblBvbGljeSBTZXQtSXRlbSBTZXQtSXRl
-bVByb3BlcnR5IFNldC1Mb2NhdGlvbiBTZXQtUFNEZWJ1ZyBTZXQtU2VydmljZSBTZXQtVHJhY2VT
-b3VyY2UgU2V0LVZhcmlhYmxlIFNvcnQtT2JqZWN0IFNwbGl0LVBhdGggU3RhcnQtU2VydmljZSBT
-dGFydC1TbGVlcCBTdGFydC1UcmFuc2NyaXB0IFN0b3AtUHJvY2VzcyBTdG9wLVNlcnZpY2UgU3Rv
-cC1UcmFuc2NyaXB0IFN1c3BlbmQtU2VydmljZSBUZWUtT2JqZWN0IFRlc3QtUGF0aCBUcmFjZS1D
-b21tYW5kIFVwZGF0ZS1Gb3JtYXREYXRhIFVwZGF0ZS1UeXBlRGF0YSBXaGVyZS1PYmplY3QgV3Jp
-dGUtRGVidWcgV3JpdGUtRXJyb3IgV3JpdGUtSG9zdCBXcml0ZS1PdXRwdXQgV3JpdGUtUHJvZ3Jl
-c3MgV3JpdGUtVmVyYm9zZSBXcml0ZS1XYXJuaW5nIixvcGVyYXRvcjoiLW5lIC1lcSAtbHQgLWd0
-IC1nZSAtbGUgLW5vdCAtbGlrZSAtbm90bGlrZSAtbWF0Y2ggLW5vdG1hdGNoIC1jb250YWlucyAt
-bm90Y29udGFpbnMgLWluIC1ub3RpbiAtcmVwbGFjZSJ9LGM6W2UuSENNLGUuTk0sbyxhLHJdfX0p
-O2hsanMucmVnaXN0ZXJMYW5ndWFnZSgibmdpbngiLGZ1bmN0aW9uKGUpe3ZhciByPXtjTjoidmFy
-aWFibGUiLHY6W3tiOi9cJFxkKy99LHtiOi9cJFx7LyxlOi99L30se2I6IltcXCRcXEBdIitlLlVJ
-Un1dfSxiPXtlVzohMCxsOiJbYS16L19dKyIsazp7YnVpbHRfaW46Im9uIG9mZiB5ZXMgbm8gdHJ1
-ZSBmYWxzZSBub25lIGJsb2NrZWQgZGVidWcgaW5mbyBub3RpY2Ugd2FybiBlcnJvciBjcml0IHNl
-bGVjdCBicmVhayBsYXN0IHBlcm1hbmVudCByZWRpcmVjdCBrcXVldWUgcnRzaWcgZXBvbGwgcG9s
-bCAvZGV2L3BvbGwifSxyOjAsaToiPT4iLGM6W2UuSENNLHtjTjoic3RyaW5nIixjOltlLkJFLHJd
-LHY6W3tiOi8iLyxlOi8iL30se2I6LycvLGU6LycvfV19LHtjTjoidXJsIixiOiIoW2Etel0rKTov
-IixlOiJcXHMiLGVXOiEwLGVFOiEwLGM6W3JdfSx7Y046InJlZ2V4cCIsYzpbZS5CRSxyXSx2Olt7
-YjoiXFxzXFxeIixlOiJcXHN8e3w7IixyRTohMH0se2I6In5cXCo/XFxzKyIsZToiXFxzfHt8OyIs
-ckU6ITB9LHtiOiJcXCooXFwuW2EtelxcLV0rKSsifSx7YjoiKFthLXpcXC1dK1xcLikrXFwqIn1d
-fSx7Y046Im51bWJlciIsYjoiXFxiXFxkezEsM31cXC5cXGR7MSwzfVxcLlxcZHsxLDN9XFwuXFxk
-ezEsM30oOlxcZHsxLDV9KT9cXGIifSx7Y046Im51bWJlciIsYjoiXFxiXFxkK1trS21NZ0dkc2hk
-d3ldKlxcYiIscjowfSxyXX07cmV0dXJue2FsaWFzZXM6WyJuZ2lueGNvbmYiXSxjOltlLkhDTSx7
-YjplLlVJUisiXFxzIixlOiI7fHsiLHJCOiEwLGM6W3tjTjoidGl0bGUiLGI6ZS5VSVIsc3RhcnRz
-OmJ9XSxyOjB9XSxpOiJbXlxcc1xcfV0ifX0pO2hsanMucmVnaXN0ZXJMYW5ndWFnZSgiZGlmZiIs
-ZnVuY3Rpb24oKXtyZXR1cm57YWxpYXNlczpbInBhdGNoIl0sYzpbe2NOOiJjaHVuayIscjoxMCx2
-Olt7YjovXlxAXEAgK1wtXGQrLFxkKyArXCtcZCssXGQrICtcQFxAJC99LHtiOi9eXCpcKlwqICtc
-ZCssXGQrICtcKlwqXCpcKiQvfSx7YjovXlwtXC1cLSArXGQrLFxkKyArXC1cLVwtXC0kL31dfSx7
-Y046ImhlYWRlciIsdjpbe2I6L0luZGV4OiAvLGU6LyQvfSx7YjovPT09PT0vLGU6Lz09PT09JC99
-LHtiOi9eXC1cLVwtLyxlOi8kL30se2I6L15cKnszfSAvLGU6LyQvfSx7YjovXlwrXCtcKy8sZTov
-JC99LHtiOi9cKns1fS8sZTovXCp7NX0kL31dfSx7Y046ImFkZGl0aW9uIixiOiJeXFwrIixlOiIk
-In0se2NOOiJkZWxldGlvbiIsYjoiXlxcLSIsZToiJCJ9LHtjTjoiY2hhbmdlIixiOiJeXFwhIixl
-OiIkIn1dfX0pO2hsanMucmVnaXN0ZXJMYW5ndWFnZSgiZ28iLGZ1bmN0aW9uKGUpe3ZhciB0PXtr
-ZXl3b3JkOiJicmVhayBkZWZhdWx0IGZ1bmMgaW50ZXJmYWNlIHNlbGVjdCBjYXNlIG1hcCBzdHJ1
-Y3QgY2hhbiBlbHNlIGdvdG8gcGFja2FnZSBzd2l0Y2ggY29uc3QgZmFsbHRocm91Z2ggaWYgcmFu
-Z2UgdHlwZSBjb250aW51ZSBmb3IgaW1wb3J0IHJldHVybiB2YXIgZ28gZGVmZXIiLGNvbnN0YW50
-OiJ0cnVlIGZhbHNlIGlvdGEgbmlsIix0eXBlbmFtZToiYm9vbCBieXRlIGNvbXBsZXg2NCBjb21w
-bGV4MTI4IGZsb2F0MzIgZmxvYXQ2NCBpbnQ4IGludDE2IGludDMyIGludDY0IHN0cmluZyB1aW50
-OCB1aW50MTYgdWludDMyIHVpbnQ2NCBpbnQgdWludCB1aW50cHRyIHJ1bmUiLGJ1aWx0X2luOiJh
-cHBlbmQgY2FwIGNsb3NlIGNvbXBsZXggY29weSBpbWFnIGxlbiBtYWtlIG5ldyBwYW5pYyBwcmlu
-dCBwcmludGxuIHJlYWwgcmVjb3ZlciBkZWxldGUifTtyZXR1cm57YWxpYXNlczpbImdvbGFuZyJd
-LGs6dCxpOiI8LyIsYzpbZS5DTENNLGUuQ0JDTSxlLlFTTSx7Y046InN0cmluZyIsYjoiJyIsZToi
-W15cXFxcXScifSx7Y046InN0cmluZyIsYjoiYCIsZToiYCJ9LHtjTjoibnVtYmVyIixiOiJbXmEt
-ekEtWl8wLTldKFxcLXxcXCspP1xcZCsoXFwuXFxkK3xcXC9cXGQrKT8oKGR8ZXxmfGx8cykoXFwr
-fFxcLSk/XFxkKyk/IixyOjB9LGUuQ05NXX19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoIm1ha2Vm
-aWxlIixmdW5jdGlvbihlKXt2YXIgYT17Y046InZhcmlhYmxlIixiOi9cJFwoLyxlOi9cKS8sYzpb
-ZS5CRV19O3JldHVybnthbGlhc2VzOlsibWsiLCJtYWsiXSxjOltlLkhDTSx7YjovXlx3K1xzKlxX
-Kj0vLHJCOiEwLHI6MCxzdGFydHM6e2NOOiJjb25zdGFudCIsZTovXHMqXFcqPS8sZUU6ITAsc3Rh
-cnRzOntlOi8kLyxyOjAsYzpbYV19fX0se2NOOiJ0aXRsZSIsYjovXltcd10rOlxzKiQvfSx7Y046
-InBob255IixiOi9eXC5QSE9OWTovLGU6LyQvLGs6Ii5QSE9OWSIsbDovW1wuXHddKy99LHtiOi9e
-XHQrLyxlOi8kLyxyOjAsYzpbZS5RU00sYV19XX19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoInJ1
-YnkiLGZ1bmN0aW9uKGUpe3ZhciBiPSJbYS16QS1aX11cXHcqWyE/PV0/fFstK35dXFxAfDw8fD4+
-fD1+fD09PT98PD0+fFs8Pl09P3xcXCpcXCp8Wy0vKyVeJip+YHxdfFxcW1xcXT0/IixyPSJhbmQg
-ZmFsc2UgdGhlbiBkZWZpbmVkIG1vZHVsZSBpbiByZXR1cm4gcmVkbyBpZiBCRUdJTiByZXRyeSBl
-bmQgZm9yIHRydWUgc2VsZiB3aGVuIG5leHQgdW50aWwgZG8gYmVnaW4gdW5sZXNzIEVORCByZXNj
-dWUgbmlsIGVsc2UgYnJlYWsgdW5kZWYgbm90IHN1cGVyIGNsYXNzIGNhc2UgcmVxdWlyZSB5aWVs
-ZCBhbGlhcyB3aGlsZSBlbnN1cmUgZWxzaWYgb3IgaW5jbHVkZSBhdHRyX3JlYWRlciBhdHRyX3dy
-aXRlciBhdHRyX2FjY2Vzc29yIixjPXtjTjoieWFyZG9jdGFnIixiOiJAW0EtWmEtel0rIn0sYT17
-Y046InZhbHVlIixiOiIjPCIsZToiPiJ9LHM9e2NOOiJjb21tZW50Iix2Olt7YjoiIyIsZToiJCIs
-YzpbY119LHtiOiJeXFw9YmVnaW4iLGU6Il5cXD1lbmQiLGM6W2NdLHI6MTB9LHtiOiJeX19FTkRf
-XyIsZToiXFxuJCJ9XX0sbj17Y046InN1YnN0IixiOiIjXFx7IixlOiJ9IixrOnJ9LHQ9e2NOOiJz
-dHJpbmciLGM6W2UuQkUsbl0sdjpbe2I6LycvLGU6LycvfSx7YjovIi8sZTovIi99LHtiOi9gLyxl
-Oi9gL30se2I6IiVbcVF3V3hdP1xcKCIsZToiXFwpIn0se2I6IiVbcVF3V3hdP1xcWyIsZToiXFxd
-In0se2I6IiVbcVF3V3hdP3siLGU6In0ifSx7YjoiJVtxUXdXeF0/PCIsZToiPiJ9LHtiOiIlW3FR
-d1d4XT8vIixlOiIvIn0se2I6IiVbcVF3V3hdPyUiLGU6IiUifSx7YjoiJVtxUXdXeF0/LSIsZToi
-LSJ9LHtiOiIlW3FRd1d4XT9cXHwiLGU6IlxcfCJ9LHtiOi9cQlw/KFxcXGR7MSwzfXxcXHhbQS1G
-YS1mMC05XXsxLDJ9fFxcdVtBLUZhLWYwLTldezR9fFxcP1xTKVxiL31dfSxpPXtjTjoicGFyYW1z
-IixiOiJcXCgiLGU6IlxcKSIsazpyfSxsPVt0LGEscyx7Y046ImNsYXNzIixiSzoiY2xhc3MgbW9k
-dWxlIixlOiIkfDsiLGk6Lz0vLGM6W2UuaW5oZXJpdChlLlRNLHtiOiJbQS1aYS16X11cXHcqKDo6
-XFx3KykqKFxcP3xcXCEpPyJ9KSx7Y046ImluaGVyaXRhbmNlIixiOiI8XFxzKiIsYzpbe2NOOiJw
-YXJlbnQiLGI6IigiK2UuSVIrIjo6KT8iK2UuSVJ9XX0sc119LHtjTjoiZnVuY3Rpb24iLGJLOiJk
-ZWYiLGU6IiB8JHw7IixyOjAsYzpbZS5pbmhlcml0KGUuVE0se2I6Yn0pLGksc119LHtjTjoiY29u
-c3RhbnQiLGI6Iig6Oik/KFxcYltBLVpdXFx3Kig6Oik/KSsiLHI6MH0se2NOOiJzeW1ib2wiLGI6
-ZS5VSVIrIihcXCF8XFw/KT86IixyOjB9LHtjTjoic3ltYm9sIixiOiI6IixjOlt0LHtiOmJ9XSxy
-OjB9LHtjTjoibnVtYmVyIixiOiIoXFxiMFswLTdfXSspfChcXGIweFswLTlhLWZBLUZfXSspfChc
-XGJbMS05XVswLTlfXSooXFwuWzAtOV9dKyk/KXxbMF9dXFxiIixyOjB9LHtjTjoidmFyaWFibGUi
-LGI6IihcXCRcXFcpfCgoXFwkfFxcQFxcQD8pKFxcdyspKSJ9LHtiOiIoIitlLlJTUisiKVxccyoi
-LGM6W2Escyx7Y046InJlZ2V4cCIsYzpbZS5CRSxuXSxpOi9cbi8sdjpbe2I6Ii8iLGU6Ii9bYS16
-XSoifSx7YjoiJXJ7IixlOiJ9W2Etel0qIn0se2I6IiVyXFwoIixlOiJcXClbYS16XSoifSx7Yjoi
-JXIhIixlOiIhW2Etel0qIn0se2I6IiVyXFxbIixlOiJcXF1bYS16XSoifV19XSxyOjB9XTtuLmM9
-bCxpLmM9bDt2YXIgdT1be2I6L15ccyo9Pi8sY046InN0YXR1cyIsc3RhcnRzOntlOiIkIixjOmx9
-fSx7Y046InByb21wdCIsYjovXlxTW149PlxuXSo+Ky8sc3RhcnRzOntlOiIkIixjOmx9fV07cmV0
-dXJue2FsaWFzZXM6WyJyYiIsImdlbXNwZWMiLCJwb2RzcGVjIiwidGhvciIsImlyYiJdLGs6cixj
-OltzXS5jb25jYXQodSkuY29uY2F0KGwpfX0pO2hsanMucmVnaXN0ZXJMYW5ndWFnZSgidHdpZyIs
-ZnVuY3Rpb24oKXt2YXIgZT17Y046InBhcmFtcyIsYjoiXFwoIixlOiJcXCkifSx0PSJhdHRyaWJ1
-dGUgYmxvY2sgY29uc3RhbnQgY3ljbGUgZGF0ZSBkdW1wIGluY2x1ZGUgbWF4IG1pbiBwYXJlbnQg
-cmFuZG9tIHJhbmdlIHNvdXJjZSB0ZW1wbGF0ZV9mcm9tX3N0cmluZyIsYT17Y046ImZ1bmN0aW9u
-IixiSzp0LHI6MCxjOltlXX0scj17Y046ImZpbHRlciIsYjovXHxbQS1aYS16XStcOj8vLGs6ImFi
-cyBiYXRjaCBjYXBpdGFsaXplIGNvbnZlcnRfZW5jb2RpbmcgZGF0ZSBkYXRlX21vZGlmeSBkZWZh
-dWx0IGVzY2FwZSBmaXJzdCBmb3JtYXQgam9pbiBqc29uX2VuY29kZSBrZXlzIGxhc3QgbGVuZ3Ro
-IGxvd2VyIG1lcmdlIG5sMmJyIG51bWJlcl9mb3JtYXQgcmF3IHJlcGxhY2UgcmV2ZXJzZSByb3Vu
-ZCBzbGljZSBzb3J0IHNwbGl0IHN0cmlwdGFncyB0aXRsZSB0cmltIHVwcGVyIHVybF9lbmNvZGUi
-LGM6W2FdfTtyZXR1cm57YWxpYXNlczpbImNyYWZ0Y21zIl0sY0k6ITAsc0w6InhtbCIsc3ViTGFu
-Z3VhZ2VNb2RlOiJjb250aW51b3VzIixjOlt7Y046InRlbXBsYXRlX2NvbW1lbnQiLGI6L1x7Iy8s
-ZTovI30vfSx7Y046InRlbXBsYXRlX3RhZyIsYjovXHslLyxlOi8lfS8sazoiYXV0b2VzY2FwZSBi
-bG9jayBkbyBlbWJlZCBleHRlbmRzIGZpbHRlciBmbHVzaCBmb3IgaWYgaW1wb3J0IGluY2x1ZGUg
-bWFybyBzYW5kYm94IHNldCBzcGFjZWxlc3MgdXNlIHZlcmJhdGltIixjOltyLGFdfSx7Y046InZh
-cmlhYmxlIixiOi9ce1x7LyxlOi99fS8sYzpbcixhXX1dfX0pO2hsanMucmVnaXN0ZXJMYW5ndWFn
-ZSgiYmFzaCIsZnVuY3Rpb24oZSl7dmFyIHI9e2NOOiJ2YXJpYWJsZSIsdjpbe2I6L1wkW1x3XGQj
-QF1bXHdcZF9dKi99LHtiOi9cJFx7KC4qPylcfS99XX0scz17Y046InN0cmluZyIsYjovIi8sZTov
-Ii8sYzpbZS5CRSxyLHtjTjoidmFyaWFibGUiLGI6L1wkXCgvLGU6L1wpLyxjOltlLkJFXX1dfSx0
-PXtjTjoic3RyaW5nIixiOi8nLyxlOi8nL307cmV0dXJue2FsaWFzZXM6WyJzaCIsInpzaCJdLGw6
-Ly0/W2EtelwuXSsvLGs6e2tleXdvcmQ6ImlmIHRoZW4gZWxzZSBlbGlmIGZpIGZvciBicmVhayBj
-b250aW51ZSB3aGlsZSBpbiBkbyBkb25lIGV4aXQgcmV0dXJuIHNldCBkZWNsYXJlIGNhc2UgZXNh
-YyBleHBvcnQgZXhlYyBmdW5jdGlvbiIsbGl0ZXJhbDoidHJ1ZSBmYWxzZSIsYnVpbHRfaW46InBy
-aW50ZiBlY2hvIHJlYWQgY2QgcHdkIHB1c2hkIHBvcGQgZGlycyBsZXQgZXZhbCB1bnNldCB0eXBl
-c2V0IHJlYWRvbmx5IGdldG9wdHMgc291cmNlIHNob3B0IGNhbGxlciB0eXBlIGhhc2ggYmluZCBo
-ZWxwIHN1ZG8iLG9wZXJhdG9yOiItbmUgLWVxIC1sdCAtZ3QgLWYgLWQgLWUgLXMgLWwgLWEifSxj
-Olt7Y046InNoZWJhbmciLGI6L14jIVteXG5dK3NoXHMqJC8scjoxMH0se2NOOiJmdW5jdGlvbiIs
-YjovXHdbXHdcZF9dKlxzKlwoXHMqXClccypcey8sckI6ITAsYzpbZS5pbmhlcml0KGUuVE0se2I6
-L1x3W1x3XGRfXSovfSldLHI6MH0sZS5IQ00sZS5OTSxzLHQscl19fSk7aGxqcy5yZWdpc3Rlckxh
-bmd1YWdlKCJjcyIsZnVuY3Rpb24oZSl7dmFyIHI9ImFic3RyYWN0IGFzIGJhc2UgYm9vbCBicmVh
-ayBieXRlIGNhc2UgY2F0Y2ggY2hhciBjaGVja2VkIGNvbnN0IGNvbnRpbnVlIGRlY2ltYWwgZGVm
-YXVsdCBkZWxlZ2F0ZSBkbyBkb3VibGUgZWxzZSBlbnVtIGV2ZW50IGV4cGxpY2l0IGV4dGVybiBm
-YWxzZSBmaW5hbGx5IGZpeGVkIGZsb2F0IGZvciBmb3JlYWNoIGdvdG8gaWYgaW1wbGljaXQgaW4g
-aW50IGludGVyZmFjZSBpbnRlcm5hbCBpcyBsb2NrIGxvbmcgbmV3IG51bGwgb2JqZWN0IG9wZXJh
-dG9yIG91dCBvdmVycmlkZSBwYXJhbXMgcHJpdmF0ZSBwcm90ZWN0ZWQgcHVibGljIHJlYWRvbmx5
-IHJlZiByZXR1cm4gc2J5dGUgc2VhbGVkIHNob3J0IHNpemVvZiBzdGFja2FsbG9jIHN0YXRpYyBz
-dHJpbmcgc3RydWN0IHN3aXRjaCB0aGlzIHRocm93IHRydWUgdHJ5IHR5cGVvZiB1aW50IHVsb25n
-IHVuY2hlY2tlZCB1bnNhZmUgdXNob3J0IHVzaW5nIHZpcnR1YWwgdm9sYXRpbGUgdm9pZCB3aGls
-ZSBhc3luYyBhd2FpdCBwcm90ZWN0ZWQgcHVibGljIHByaXZhdGUgaW50ZXJuYWwgYXNjZW5kaW5n
-IGRlc2NlbmRpbmcgZnJvbSBnZXQgZ3JvdXAgaW50byBqb2luIGxldCBvcmRlcmJ5IHBhcnRpYWwg
-c2VsZWN0IHNldCB2YWx1ZSB2YXIgd2hlcmUgeWllbGQiLHQ9ZS5JUisiKDwiK2UuSVIrIj4pPyI7
-cmV0dXJue2FsaWFzZXM6WyJjc2hhcnAiXSxrOnIsaTovOjovLGM6W3tjTjoiY29tbWVudCIsYjoi
-Ly8vIixlOiIkIixyQjohMCxjOlt7Y046InhtbERvY1RhZyIsdjpbe2I6Ii8vLyIscjowfSx7Yjoi
-PCEtLXwtLT4ifSx7YjoiPC8/IixlOiI+In1dfV19LGUuQ0xDTSxlLkNCQ00se2NOOiJwcmVwcm9j
-ZXNzb3IiLGI6IiMiLGU6IiQiLGs6ImlmIGVsc2UgZWxpZiBlbmRpZiBkZWZpbmUgdW5kZWYgd2Fy
-bmluZyBlcnJvciBsaW5lIHJlZ2lvbiBlbmRyZWdpb24gcHJhZ21hIGNoZWNrc3VtIn0se2NOOiJz
-dHJpbmciLGI6J0AiJyxlOiciJyxjOlt7YjonIiInfV19LGUuQVNNLGUuUVNNLGUuQ05NLHtiSzoi
-Y2xhc3MgbmFtZXNwYWNlIGludGVyZmFjZSIsZTovW3s7PV0vLGk6L1teXHM6XS8sYzpbZS5UTSxl
-LkNMQ00sZS5DQkNNXX0se2JLOiJuZXciLGU6L1xzLyxyOjB9LHtjTjoiZnVuY3Rpb24iLGI6Iigi
-K3QrIlxccyspKyIrZS5JUisiXFxzKlxcKCIsckI6ITAsZTovW3s7PV0vLGVFOiEwLGs6cixjOlt7
-YjplLklSKyJcXHMqXFwoIixyQjohMCxjOltlLlRNXX0se2NOOiJwYXJhbXMiLGI6L1woLyxlOi9c
-KS8sazpyLGM6W2UuQVNNLGUuUVNNLGUuQ05NLGUuQ0JDTV19LGUuQ0xDTSxlLkNCQ01dfV19fSk7
-aGxqcy5yZWdpc3Rlckxhbmd1YWdlKCJtYXJrZG93biIsZnVuY3Rpb24oKXtyZXR1cm57YWxpYXNl
-czpbIm1kIiwibWtkb3duIiwibWtkIl0sYzpbe2NOOiJoZWFkZXIiLHY6W3tiOiJeI3sxLDZ9Iixl
-OiIkIn0se2I6Il4uKz9cXG5bPS1dezIsfSQifV19LHtiOiI8IixlOiI+IixzTDoieG1sIixyOjB9
-LHtjTjoiYnVsbGV0IixiOiJeKFsqKy1dfChcXGQrXFwuKSlcXHMrIn0se2NOOiJzdHJvbmciLGI6
-IlsqX117Mn0uKz9bKl9dezJ9In0se2NOOiJlbXBoYXNpcyIsdjpbe2I6IlxcKi4rP1xcKiJ9LHti
-OiJfLis/XyIscjowfV19LHtjTjoiYmxvY2txdW90ZSIsYjoiXj5cXHMrIixlOiIkIn0se2NOOiJj
-b2RlIix2Olt7YjoiYC4rP2AifSx7YjoiXiggezR9fAkpIixlOiIkIixyOjB9XX0se2NOOiJob3Jp
-em9udGFsX3J1bGUiLGI6Il5bLVxcKl17Myx9IixlOiIkIn0se2I6IlxcWy4rP1xcXVtcXChcXFtd
-Lio/W1xcKVxcXV0iLHJCOiEwLGM6W3tjTjoibGlua19sYWJlbCIsYjoiXFxbIixlOiJcXF0iLGVC
-OiEwLHJFOiEwLHI6MH0se2NOOiJsaW5rX3VybCIsYjoiXFxdXFwoIixlOiJcXCkiLGVCOiEwLGVF
-OiEwfSx7Y046ImxpbmtfcmVmZXJlbmNlIixiOiJcXF1cXFsiLGU6IlxcXSIsZUI6ITAsZUU6ITB9
-XSxyOjEwfSx7YjoiXlxcWy4rXFxdOiIsckI6ITAsYzpbe2NOOiJsaW5rX3JlZmVyZW5jZSIsYjoi
-XFxbIixlOiJcXF06IixlQjohMCxlRTohMCxzdGFydHM6e2NOOiJsaW5rX3VybCIsZToiJCJ9fV19
-XX19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoImRhcnQiLGZ1bmN0aW9uKGUpe3ZhciB0PXtjTjoi
-c3Vic3QiLGI6IlxcJFxceyIsZToifSIsazoidHJ1ZSBmYWxzZSBudWxsIHRoaXMgaXMgbmV3IHN1
-cGVyIn0scj17Y046InN0cmluZyIsdjpbe2I6InInJyciLGU6IicnJyJ9LHtiOidyIiIiJyxlOici
-IiInfSx7YjoiciciLGU6IiciLGk6IlxcbiJ9LHtiOidyIicsZTonIicsaToiXFxuIn0se2I6Iicn
-JyIsZToiJycnIixjOltlLkJFLHRdfSx7YjonIiIiJyxlOiciIiInLGM6W2UuQkUsdF19LHtiOiIn
-IixlOiInIixpOiJcXG4iLGM6W2UuQkUsdF19LHtiOiciJyxlOiciJyxpOiJcXG4iLGM6W2UuQkUs
-dF19XX07dC5jPVtlLkNOTSxyXTt2YXIgbj17a2V5d29yZDoiYXNzZXJ0IGJyZWFrIGNhc2UgY2F0
-Y2ggY2xhc3MgY29uc3QgY29udGludWUgZGVmYXVsdCBkbyBlbHNlIGVudW0gZXh0ZW5kcyBmYWxz
-ZSBmaW5hbCBmaW5hbGx5IGZvciBpZiBpbiBpcyBuZXcgbnVsbCByZXRocm93IHJldHVybiBzdXBl
-ciBzd2l0Y2ggdGhpcyB0aHJvdyB0cnVlIHRyeSB2YXIgdm9pZCB3aGlsZSB3aXRoIixsaXRlcmFs
-OiJhYnN0cmFjdCBhcyBkeW5hbWljIGV4cG9ydCBleHRlcm5hbCBmYWN0b3J5IGdldCBpbXBsZW1l
-bnRzIGltcG9ydCBsaWJyYXJ5IG9wZXJhdG9yIHBhcnQgc2V0IHN0YXRpYyB0eXBlZGVmIixidWls
-dF9pbjoicHJpbnQgQ29tcGFyYWJsZSBEYXRlVGltZSBEdXJhdGlvbiBGdW5jdGlvbiBJdGVyYWJs
-ZSBJdGVyYXRvciBMaXN0IE1hcCBNYXRjaCBOdWxsIE9iamVjdCBQYXR0ZXJuIFJlZ0V4cCBTZXQg
-U3RvcHdhdGNoIFN0cmluZyBTdHJpbmdCdWZmZXIgU3RyaW5nU2luayBTeW1ib2wgVHlwZSBVcmkg
-Ym9vbCBkb3VibGUgaW50IG51bSBkb2N1bWVudCB3aW5kb3cgcXVlcnlTZWxlY3RvciBxdWVyeVNl
-bGVjdG9yQWxsIEVsZW1lbnQgRWxlbWVudExpc3QifTtyZXR1cm57azpuLGM6W3Ise2NOOiJkYXJ0
-ZG9jIixiOiIvXFwqXFwqIixlOiJcXCovIixzTDoibWFya2Rvd24iLHN1Ykxhbmd1YWdlTW9kZToi
-Y29udGludW91cyJ9LHtjTjoiZGFydGRvYyIsYjoiLy8vIixlOiIkIixzTDoibWFya2Rvd24iLHN1
-Ykxhbmd1YWdlTW9kZToiY29udGludW91cyJ9LGUuQ0xDTSxlLkNCQ00se2NOOiJjbGFzcyIsYks6
-ImNsYXNzIGludGVyZmFjZSIsZToieyIsZUU6ITAsYzpbe2JLOiJleHRlbmRzIGltcGxlbWVudHMi
-fSxlLlVUTV19LGUuQ05NLHtjTjoiYW5ub3RhdGlvbiIsYjoiQFtBLVphLXpdKyJ9LHtiOiI9PiJ9
-XX19KTtobGpzLnJlZ2lzdGVyTGFuZ3VhZ2UoImluaSIsZnVuY3Rpb24oZSl7cmV0dXJue2NJOiEw
-LGk6L1xTLyxjOlt7Y046ImNvbW1lbnQiLGI6IjsiLGU6IiQifSx7Y046InRpdGxlIixiOiJeXFxb
-IixlOiJcXF0ifSx7Y046InNldHRpbmciLGI6Il5bYS16MC05XFxbXFxdXy1dK1sgXFx0XSo9WyBc
-XHRdKiIsZToiJCIsYzpbe2NOOiJ2YWx1ZSIsZVc6ITAsazoib24gb2ZmIHRydWUgZmFsc2UgeWVz
-IG5vIixjOltlLlFTTSxlLk5NXSxyOjB9XX1dfX0pO2hsanMucmVnaXN0ZXJMYW5ndWFnZSgiaHR0
-cCIsZnVuY3Rpb24oKXtyZXR1cm57aToiXFxTIixjOlt7Y046InN0YXR1cyIsYjoiXkhUVFAvWzAt
-OVxcLl0rIixlOiIkIixjOlt7Y046Im51bWJlciIsYjoiXFxiXFxkezN9XFxiIn1dfSx7Y046InJl
-cXVlc3QiLGI6Il5bQS1aXSsgKC4qPykgSFRUUC9bMC05XFwuXSskIixyQjohMCxlOiIkIixjOlt7
-Y046InN0cmluZyIsYjoiICIsZToiICIsZUI6ITAsZUU6ITB9XX0se2NOOiJhdHRyaWJ1dGUiLGI6
-Il5cXHciLGU6IjogIixlRTohMCxpOiJcXG58XFxzfD0iLHN0YXJ0czp7Y046InN0cmluZyIsZToi
-JCJ9fSx7YjoiXFxuXFxuIixzdGFydHM6e3NMOiIiLGVXOiEwfX1dfX0pO2hsanMucmVnaXN0ZXJM
-YW5ndWFnZSgiamF2YSIsZnVuY3Rpb24oZSl7dmFyIHQ9ZS5VSVIrIig8IitlLlVJUisiPik/Iixh
-PSJmYWxzZSBzeW5jaHJvbml6ZWQgaW50IGFic3RyYWN0IGZsb2F0IHByaXZhdGUgY2hhciBib29s
-ZWFuIHN0YXRpYyBudWxsIGlmIGNvbnN0IGZvciB0cnVlIHdoaWxlIGxvbmcgdGhyb3cgc3RyaWN0
-ZnAgZmluYWxseSBwcm90ZWN0ZWQgaW1wb3J0IG5hdGl2ZSBmaW5hbCByZXR1cm4gdm9pZCBlbnVt
-IGVsc2UgYnJlYWsgdHJhbnNpZW50IG5ldyBjYXRjaCBpbnN0YW5jZW9mIGJ5dGUgc3VwZXIgdm9s
-YXRpbGUgY2FzZSBhc3NlcnQgc2hvcnQgcGFja2FnZSBkZWZhdWx0IGRvdWJsZSBwdWJsaWMgdHJ5
-IHRoaXMgc3dpdGNoIGNvbnRpbnVlIHRocm93cyBwcm90ZWN0ZWQgcHVibGljIHByaXZhdGUiO3Jl
-dHVybnthbGlhc2VzOlsianNwIl0sazphLGk6LzxcLy8sYzpbe2NOOiJqYXZhZG9jIixiOiIvXFwq
-XFwqIixlOiJcXCovIixyOjAsYzpbe2NOOiJqYXZhZG9jdGFnIixiOiIoXnxcXHMpQFtBLVphLXpd
-KyJ9XX0sZS5DTENNLGUuQ0JDTSxlLkFTTSxlLlFTTSx7Y046ImNsYXNzIixiSzoiY2xhc3MgaW50
-ZXJmYWNlIixlOi9bezs9XS8sZUU6ITAsazoiY2xhc3MgaW50ZXJmYWNlIixpOi9bOiJcW1xdXS8s
-Yzpbe2JLOiJleHRlbmRzIGltcGxlbWVudHMifSxlLlVUTV19LHtiSzoibmV3IHRocm93IixlOi9c
-cy8scjowfSx7Y046ImZ1bmN0aW9uIixiOiIoIit0KyJcXHMrKSsiK2UuVUlSKyJcXHMqXFwoIixy
-QjohMCxlOi9bezs9XS8sZUU6ITAsazphLGM6W3tiOmUuVUlSKyJcXHMqXFwoIixyQjohMCxjOltl
-LlVUTV19LHtjTjoicGFyYW1zIixiOi9cKC8sZTovXCkvLGs6YSxjOltlLkFTTSxlLlFTTSxlLkNO
-TSxlLkNCQ01dfSxlLkNMQ00sZS5DQkNNXX0sZS5DTk0se2NOOiJhbm5vdGF0aW9uIixiOiJAW0Et
-WmEtel0rIn1dfX0pO2hsanMucmVnaXN0ZXJMYW5ndWFnZSgidHlwZXNjcmlwdCIsZnVuY3Rpb24o
-ZSl7cmV0dXJue2FsaWFzZXM6WyJ0cyJdLGs6e2tleXdvcmQ6ImluIGlmIGZvciB3aGlsZSBmaW5h
-bGx5IHZhciBuZXcgZnVuY3Rpb258MCBkbyByZXR1cm4gdm9pZCBlbHNlIGJyZWFrIGNhdGNoIGlu
-c3RhbmNlb2Ygd2l0aCB0aHJvdyBjYXNlIGRlZmF1bHQgdHJ5IHRoaXMgc3dpdGNoIGNvbnRpbnVl
-IHR5cGVvZiBkZWxldGUgbGV0IHlpZWxkIGNvbnN0IGNsYXNzIHB1YmxpYyBwcml2YXRlIGdldCBz
-ZXQgc3VwZXIgaW50ZXJmYWNlIGV4dGVuZHNzdGF0aWMgY29uc3RydWN0b3IgaW1wbGVtZW50cyBl
-bnVtIGV4cG9ydCBpbXBvcnQgZGVjbGFyZSIsbGl0ZXJhbDoidHJ1ZSBmYWxzZSBudWxsIHVuZGVm
-aW5lZCBOYU4gSW5maW5pdHkiLGJ1aWx0X2luOiJldmFsIGlzRmluaXRlIGlzTmFOIHBhcnNlRmxv
-YXQgcGFyc2VJbnQgZGVjb2RlVVJJIGRlY29kZVVSSUNvbXBvbmVudCBlbmNvZGVVUkkgZW5jb2Rl
-VVJJQ29tcG9uZW50IGVzY2FwZSB1bmVzY2FwZSBPYmplY3QgRnVuY3Rpb24gQm9vbGVhbiBFcnJv
-ciBFdmFsRXJyb3IgSW50ZXJuYWxFcnJvciBSYW5nZUVycm9yIFJlZmVyZW5jZUVycm9yIFN0b3BJ
-dGVyYXRpb24gU3ludGF4RXJyb3IgVHlwZUVycm9yIFVSSUVycm9yIE51bWJlciBNYXRoIERhdGUg
-U3RyaW5nIFJlZ0V4cCBBcnJheSBGbG9hdDMyQXJyYXkgRmxvYXQ2NEFycmF5IEludDE2QXJyYXkg
-SW50MzJBcnJheSBJbnQ4QXJyYXkgVWludDE2QXJyYXkgVWludDMyQXJyYXkgVWludDhBcnJheSBV
-aW50OENsYW1wZWRBcnJheSBBcnJheUJ1ZmZlciBEYXRhVmlldyBKU09OIEludGwgYXJndW1lbnRz
-IHJlcXVpcmUgbW9kdWxlIGNvbnNvbGUgd2luZG93IGRvY3VtZW50IGFueSBudW1iZXIgYm9vbGVh
-biBzdHJpbmcgdm9pZCJ9LGM6W3tjTjoicGkiLGI6L15ccyooJ3wiKXVzZSBzdHJpY3QoJ3wiKS8s
-cjowfSxlLkFTTSxlLlFTTSxlLkNMQ00sZS5DQkNNLGUuQ05NLHtiOiIoIitlLlJTUisifFxcYihj
-YXNlfHJldHVybnx0aHJvdylcXGIpXFxzKiIsazoicmV0dXJuIHRocm93IGNhc2UiLGM6W2UuQ0xD
-TSxlLkNCQ00sZS5STSx7YjovPC8sZTovPjsvLHI6MCxzTDoieG1sIn1dLHI6MH0se2NOOiJmdW5j
-dGlvbiIsYks6ImZ1bmN0aW9uIixlOi9cey8sZUU6ITAsYzpbZS5pbmhlcml0KGUuVE0se2I6L1tB
-LVphLXokX11bMC05QS1aYS16JF9dKi99KSx7Y046InBhcmFtcyIsYjovXCgvLGU6L1wpLyxjOltl
-LkNMQ00sZS5DQkNNXSxpOi9bIidcKF0vfV0saTovXFt8JS8scjowfSx7Y046ImNvbnN0cnVjdG9y
-IixiSzoiY29uc3RydWN0b3IiLGU6L1x7LyxlRTohMCxyOjEwfSx7Y046Im1vZHVsZSIsYks6Im1v
-ZHVsZSIsZTovXHsvLGVFOiEwfSx7Y046ImludGVyZmFjZSIsYks6ImludGVyZmFjZSIsZTovXHsv
-LGVFOiEwfSx7YjovXCRbKC5dL30se2I6IlxcLiIrZS5JUixyOjB9XX19KTs=`
-
-const HighlightCss = `/* Original style from softwaremaniacs.org (c) Ivan Sagalaev <Maniac@SoftwareManiacs.Org> v.8.3 */
-
-.hljs {
-  display: block;
-  overflow-x: auto;
-  padding: 0.5em;
-  background: #f0f0f0;
-  -webkit-text-size-adjust: none;
-}
-
-.hljs,
-.hljs-subst,
-.hljs-tag .hljs-title,
-.nginx .hljs-title {
-  color: black;
-}
-
-.hljs-string,
-.hljs-title,
-.hljs-constant,
-.hljs-parent,
-.hljs-tag .hljs-value,
-.hljs-rules .hljs-value,
-.hljs-preprocessor,
-.hljs-pragma,
-.haml .hljs-symbol,
-.ruby .hljs-symbol,
-.ruby .hljs-symbol .hljs-string,
-.hljs-template_tag,
-.django .hljs-variable,
-.smalltalk .hljs-class,
-.hljs-addition,
-.hljs-flow,
-.hljs-stream,
-.bash .hljs-variable,
-.apache .hljs-tag,
-.apache .hljs-cbracket,
-.tex .hljs-command,
-.tex .hljs-special,
-.erlang_repl .hljs-function_or_atom,
-.asciidoc .hljs-header,
-.markdown .hljs-header,
-.coffeescript .hljs-attribute {
-  color: #800;
-}
-
-.smartquote,
-.hljs-comment,
-.hljs-annotation,
-.hljs-template_comment,
-.diff .hljs-header,
-.hljs-chunk,
-.asciidoc .hljs-blockquote,
-.markdown .hljs-blockquote {
-  color: #888;
-}
-
-.hljs-number,
-.hljs-date,
-.hljs-regexp,
-.hljs-literal,
-.hljs-hexcolor,
-.smalltalk .hljs-symbol,
-.smalltalk .hljs-char,
-.go .hljs-constant,
-.hljs-change,
-.lasso .hljs-variable,
-.makefile .hljs-variable,
-.asciidoc .hljs-bullet,
-.markdown .hljs-bullet,
-.asciidoc .hljs-link_url,
-.markdown .hljs-link_url {
-  color: #080;
-}
-
-.hljs-label,
-.hljs-javadoc,
-.ruby .hljs-string,
-.hljs-decorator,
-.hljs-filter .hljs-argument,
-.hljs-localvars,
-.hljs-array,
-.hljs-attr_selector,
-.hljs-important,
-.hljs-pseudo,
-.hljs-pi,
-.haml .hljs-bullet,
-.hljs-doctype,
-.hljs-deletion,
-.hljs-envvar,
-.hljs-shebang,
-.apache .hljs-sqbracket,
-.nginx .hljs-built_in,
-.tex .hljs-formula,
-.erlang_repl .hljs-reserved,
-.hljs-prompt,
-.asciidoc .hljs-link_label,
-.markdown .hljs-link_label,
-.vhdl .hljs-attribute,
-.clojure .hljs-attribute,
-.asciidoc .hljs-attribute,
-.lasso .hljs-attribute,
-.coffeescript .hljs-property,
-.hljs-phony {
-  color: #88f;
-}
-
-.hljs-keyword,
-.hljs-id,
-.hljs-title,
-.hljs-built_in,
-.css .hljs-tag,
-.hljs-javadoctag,
-.hljs-phpdoc,
-.hljs-dartdoc,
-.hljs-yardoctag,
-.smalltalk .hljs-class,
-.hljs-winutils,
-.bash .hljs-variable,
-.apache .hljs-tag,
-.hljs-type,
-.hljs-typename,
-.tex .hljs-command,
-.asciidoc .hljs-strong,
-.markdown .hljs-strong,
-.hljs-request,
-.hljs-status {
-  font-weight: bold;
-}
-
-.asciidoc .hljs-emphasis,
-.markdown .hljs-emphasis {
-  font-style: italic;
-}
-
-.nginx .hljs-built_in {
-  font-weight: normal;
-}
-
-.coffeescript .javascript,
-.javascript .xml,
-.lasso .markup,
-.tex .hljs-formula,
-.xml .javascript,
-.xml .vbscript,
-.xml .css,
-.xml .hljs-cdata {
-  opacity: 0.5;
-}
-`
@@ -55,7 +55,7 @@ pre, blockquote {
     white-space: pre-wrap;
 }
 
-ol, ul, tr, img {
+ol, ul, tr, img, math, figure.diagram {
     page-break-inside: avoid;
 }
 
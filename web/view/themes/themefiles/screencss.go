@@ -405,6 +405,16 @@ body>nav.search .typeahead {
   margin: 0;
 }
 
+.tt-suggestion p.snippet {
+  font-size: 0.7em;
+  line-height: 1.3em;
+  color: #666;
+}
+
+.tt-suggestion.tt-cursor p.snippet {
+  color: #fff;
+}
+
 body>nav.toplevel>ul {
     display: inline;
     list-style: none;
@@ -575,6 +585,42 @@ aside.sidebar>.children>.list>.child:nth-child(even) {
     background-color:transparent;
 }
 
+aside.sidebar>.toc {
+    margin: 0 0 15px 0;
+}
+
+aside.sidebar>.toc>h1 {
+    font-size: 1.5em;
+}
+
+aside.sidebar>.toc ol {
+    list-style: none;
+    padding: 0 0 0 1em;
+    margin: 0;
+}
+
+aside.sidebar>.toc>ol {
+    padding: 0;
+}
+
+aside.sidebar>.referenced-by {
+    margin: 0 0 15px 0;
+}
+
+aside.sidebar>.referenced-by>h1 {
+    font-size: 1.5em;
+}
+
+aside.sidebar>.referenced-by>.list {
+    list-style: none;
+    padding: 0;
+    margin: 0;
+}
+
+aside.sidebar>.referenced-by>.list>.reference {
+    margin: 0 0 0.5em 0;
+}
+
 aside.sidebar>.tagcloud {
 }
 
@@ -818,11 +864,218 @@ article.presentation .slide {
     font-size: 1.0em;
 }
 
+.search>.content>ol>li>.snippet {
+    margin: 0px;
+    font-size: 0.9em;
+    color: #666;
+}
+
+.search>.content>ol>li>.snippet>mark {
+    background-color: #fff3a8;
+    color: #000;
+}
+
 .search>.content>ol>li>.path {
     margin: 0px;
     font-size: 0.8em;
 }
 
+.search>.content>.facets {
+    float: right;
+    width: 12em;
+    margin: 10px 0 10px 20px;
+    font-size: 0.9em;
+}
+
+.search>.content>.facets>.facet>h2 {
+    font-size: 1.0em;
+    margin: 10px 0 5px 0;
+}
+
+.search>.content>.facets>.facet>ul {
+    list-style: none;
+    margin: 0px;
+    padding: 0px;
+}
+
+.search>.content>.facets>.facet>ul>li.active>a {
+    font-weight: bold;
+}
+
+.search>.content>.facets>.facet>ul>li>.count {
+    color: #999;
+}
+
+.search>.content>.facets>.facet>ul>li>.count:before {
+    content: "(";
+}
+
+.search>.content>.facets>.facet>ul>li>.count:after {
+    content: ")";
+}
+
+.history>.content table {
+    width: 100%;
+    border-collapse: collapse;
+    font-size: 0.9em;
+}
+
+.history>.content>.revisions th,
+.history>.content>.revisions td {
+    padding: 3px 5px;
+    text-align: left;
+    vertical-align: top;
+    border-bottom: 1px solid #eee;
+}
+
+.history>.content>.revisions .revision,
+.history>.content>.diff .revision {
+    font-family: monospace;
+}
+
+.history>.content>.revisions input[type=submit] {
+    margin: 10px 0;
+}
+
+.history>.content>.diff {
+    margin-bottom: 20px;
+}
+
+.history>.content>.diff>.diff-file>h2 {
+    font-size: 1.0em;
+    font-family: monospace;
+    margin: 15px 0 5px 0;
+}
+
+.history>.content>.diff>.diff-file td {
+    padding: 0 5px;
+    font-family: monospace;
+    vertical-align: top;
+}
+
+.history>.content>.diff>.diff-file td.number {
+    width: 3em;
+    text-align: right;
+    color: #999;
+}
+
+.history>.content>.diff>.diff-file td>pre {
+    margin: 0;
+    padding: 0;
+    border: none;
+    background: none;
+    white-space: pre-wrap;
+}
+
+.history>.content>.diff>.diff-file tr.hunk {
+    background-color: #f0f4fa;
+    color: #666;
+}
+
+.history>.content>.diff>.diff-file tr.added {
+    background-color: #e6ffec;
+}
+
+.history>.content>.diff>.diff-file tr.removed {
+    background-color: #ffebe9;
+}
+
+.editor>.content>.conflict {
+    padding: 10px;
+    border: 1px solid #e0b4b4;
+    background: #fff6f6;
+}
+
+.editor>.content>form>.panes {
+    display: flex;
+    margin-bottom: 10px;
+}
+
+.editor>.content>form>.panes>textarea,
+.editor>.content>form>.panes>.preview {
+    box-sizing: border-box;
+    width: 50%;
+    height: 70vh;
+    overflow: auto;
+}
+
+.editor>.content>form>.panes>textarea {
+    padding: 5px;
+    font-family: monospace;
+    font-size: 0.9em;
+    resize: vertical;
+}
+
+.editor>.content>form>.panes>.preview {
+    padding: 0 10px;
+    border: 1px solid #eee;
+}
+
+.editor>.content>.upload {
+    margin-top: 15px;
+}
+
+.wikilink.missing {
+    color: #c00;
+    border-bottom: 1px dashed #c00;
+    cursor: help;
+}
+
+nav.toc {
+    margin: 1em 0;
+    padding: 0.5em 1em;
+    border-left: 3px solid #eee;
+}
+
+nav.toc ol {
+    margin: 0;
+    padding: 0 0 0 1.5em;
+}
+
+math[display="block"] {
+    margin: 1em 0;
+    overflow-x: auto;
+    overflow-y: hidden;
+}
+
+figure.diagram {
+    margin: 1em 0;
+    overflow-x: auto;
+    text-align: center;
+}
+
+figure.diagram svg {
+    max-width: 100%;
+    height: auto;
+}
+
+pre.mermaid {
+    background: none;
+    border: none;
+    text-align: center;
+}
+
+li>input[type="checkbox"] {
+    margin: 0 0.4em 0 0;
+}
+
+dl dt {
+    font-weight: bold;
+}
+
+dl dd {
+    margin: 0 0 0.5em 1.5em;
+}
+
+.footnotes {
+    font-size: 0.9em;
+}
+
+.footnotes>hr {
+    border: none;
+    border-top: 1px solid #eee;
+}
+
 .ribbon {
   display: none;
 }
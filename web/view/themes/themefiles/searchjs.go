@@ -45,7 +45,16 @@ $('.typeahead').typeahead(
 		displayKey: 'value',
 		source: searchDataSource.ttAdapter(),
 		templates: {
-			header: '<h3>Search Results</h3>'
+			header: '<h3>Search Results</h3>',
+			suggestion: function(datum) {
+				var suggestion = $('<p>').text(datum.value).prop('outerHTML');
+				if (datum.snippet) {
+					// the snippet is already html-encoded
+					suggestion += '<p class="snippet">' + datum.snippet + '</p>';
+				}
+
+				return suggestion;
+			}
 		}
 	}
 ).on('typeahead:selected', function(event, datum) {
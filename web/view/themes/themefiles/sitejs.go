@@ -39,10 +39,43 @@ function addDeepLinksToElements(cssSelector) {
 		var tagName = this.tagName;
 		var headlineLevel = tagName.replace(/[^\d]/g, "");
 
-		var anchorText = headlineLevel + "-" + getAnchorNameFromText(headlineText);
+		// use the id of the headline (see table of contents) if it has one
+		var anchorText = this.id || headlineLevel + "-" + getAnchorNameFromText(headlineText);
+
+		if (!this.id) {
+			$(this).before('<a class="deeplink" name="' + anchorText + '">' + headlineText + '</a>');
+		}
 
-		$(this).before('<a class="deeplink" name="' + anchorText + '">' + headlineText + '</a>');
 		$(this).wrap('<a href="#' +anchorText + '"></a>')
 	});
 }
+
+/**
+ * renderDiagrams renders the mermaid diagrams of the page in the browser.
+ * The mermaid library is only loaded if the page contains mermaid diagrams.
+ */
+function renderDiagrams() {
+	var diagrams = $('pre.mermaid:not([data-processed])');
+	if (diagrams.length === 0) {
+		return;
+	}
+
+	var render = function() {
+		mermaid.initialize({ startOnLoad: false });
+		mermaid.run({ nodes: diagrams.get() });
+	};
+
+	if (typeof(mermaid) === 'object') {
+		render();
+		return;
+	}
+
+	// unlike $.getScript the library is loaded from the browser cache
+	$.ajax({
+		url: '/theme/mermaid.js',
+		dataType: 'script',
+		cache: true,
+		success: render
+	});
+}
 `
@@ -0,0 +1,169 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package themefiles
+
+// StaticSearchJs replaces the search.js of static builds. Instead of querying
+// the server it searches the prebuilt index in "search-index.js".
+const StaticSearchJs = `
+var staticSearch = (function() {
+
+	// determine the root folder of the static build from the location of this script
+	var rootPath = (function() {
+		var scripts = document.getElementsByTagName('script');
+		for (var i = 0; i < scripts.length; i++) {
+			var source = scripts[i].getAttribute('src') || '';
+			var position = source.indexOf('theme/search.js');
+			if (position >= 0) {
+				return source.substring(0, position);
+			}
+		}
+
+		return '';
+	})();
+
+	var normalize = function(text) {
+		return (text || '').toLowerCase();
+	};
+
+	var loadIndex = function(callback) {
+		if (typeof(allmarkSearchIndex) === 'object') {
+			callback(allmarkSearchIndex);
+			return;
+		}
+
+		var script = document.createElement('script');
+		script.src = rootPath + 'search-index.js';
+		script.onload = function() {
+			callback(allmarkSearchIndex);
+		};
+
+		document.body.appendChild(script);
+	};
+
+	var search = function(index, query) {
+		var terms = $.grep(normalize(query).split(/\s+/), function(term) {
+			return term.length > 0;
+		});
+
+		if (terms.length === 0) {
+			return [];
+		}
+
+		var matches = [];
+		$.each(index, function(i, entry) {
+			var title = normalize(entry.title);
+			var description = normalize(entry.description);
+			var tags = normalize((entry.tags || []).join(' '));
+			var text = normalize(entry.text);
+
+			var score = 0;
+			for (var t = 0; t < terms.length; t++) {
+				var term = terms[t];
+				var termScore = 0;
+
+				if (title.indexOf(term) >= 0) { termScore += 10; }
+				if (tags.indexOf(term) >= 0) { termScore += 5; }
+				if (description.indexOf(term) >= 0) { termScore += 3; }
+				if (text.indexOf(term) >= 0) { termScore += 1; }
+
+				// all terms must match
+				if (termScore === 0) {
+					return;
+				}
+
+				score += termScore;
+			}
+
+			matches.push({ score: score, entry: entry });
+		});
+
+		matches.sort(function(a, b) {
+			return b.score - a.score;
+		});
+
+		return $.map(matches, function(match) {
+			return match.entry;
+		});
+	};
+
+	var getQuery = function() {
+		var parameters = window.location.search.substring(1).split('&');
+		for (var i = 0; i < parameters.length; i++) {
+			var parameter = parameters[i].split('=');
+			if (parameter[0] === 'q' && parameter.length > 1) {
+				return decodeURIComponent(parameter[1].replace(/\+/g, ' '));
+			}
+		}
+
+		return '';
+	};
+
+	return {
+		rootPath: rootPath,
+		loadIndex: loadIndex,
+		search: search,
+		getQuery: getQuery
+	};
+
+})();
+
+// auto-suggest
+$('.typeahead').typeahead(
+	{
+		minLength: 1,
+		items: 10,
+		highlight: true,
+	},
+	{
+		name: 'searchresults',
+		displayKey: 'value',
+		source: function(query, callback) {
+			staticSearch.loadIndex(function(index) {
+				var results = staticSearch.search(index, query).slice(0, 10);
+				callback($.map(results, function(entry) {
+					return { value: entry.title, route: staticSearch.rootPath + entry.route };
+				}));
+			});
+		},
+		templates: {
+			header: '<h3>Search Results</h3>'
+		}
+	}
+).on('typeahead:selected', function(event, datum) {
+	window.location = datum.route;
+});
+
+// search page
+$(function() {
+	var container = $('article.search section.content');
+	var query = staticSearch.getQuery();
+	if (container.length === 0 || query === '') {
+		return;
+	}
+
+	container.find('input[name=q]').val(query);
+
+	staticSearch.loadIndex(function(index) {
+		var results = staticSearch.search(index, query);
+		if (results.length === 0) {
+			container.append($('<p>').text('No results found for "' + query + '".'));
+			return;
+		}
+
+		container.append($('<header>').text('Displaying ' + results.length + ' search results for "' + query + '":'));
+
+		var list = $('<ol>');
+		$.each(results, function(i, entry) {
+			var listItem = $('<li>').attr('data-index', i + 1);
+			listItem.append($('<a class="title">').attr('href', staticSearch.rootPath + entry.route).text(entry.title));
+			listItem.append($('<p class="description">').text(entry.description));
+			listItem.append($('<span class="path">').text(entry.path));
+			list.append(listItem);
+		});
+
+		container.append(list);
+	});
+});
+`
@@ -47,13 +47,18 @@ func init() {
 			newFileFromText("search.js", themefiles.SearchJs),
 
 			// code highlighting
-			newFileFromBase64("codehighlighting/highlight.js", themefiles.HighlightJs),
 			newFileFromText("codehighlighting/highlight.css", themefiles.HighlightCss),
 
 			// latest/preview
 			newFileFromText("latest.js", themefiles.LatestJs),
 			newFileFromText("jquery.tmpl.js", themefiles.JqueryTempl),
 
+			// markdown editor
+			newFileFromText("editor.js", themefiles.EditorJs),
+
+			// diagrams
+			newFileFromBase64("mermaid.js", themefiles.MermaidJs),
+
 			// lazy-loading
 			newFileFromText("lazysizes.js", themefiles.LazySizesJs),
 
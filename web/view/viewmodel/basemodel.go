@@ -25,6 +25,8 @@ type Base struct {
 	JSONURL     string `json:"jsonURL"`
 	MarkdownURL string `json:"markdownURL"`
 	DOCXURL     string `json:"docxURL"`
+	HistoryURL  string `json:"historyURL"`
+	EditURL     string `json:"editURL"`
 
 	PageTitle   string `json:"pageTitle"`
 	Title       string `json:"title"`
@@ -0,0 +1,33 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package viewmodel
+
+// Editor contains the markdown of an item that is edited in the browser.
+type Editor struct {
+	Model
+
+	// LastHash identifies the version of the item the markdown is based on.
+	LastHash string `json:"lastHash"`
+
+	// Preview contains the HTML of the markdown.
+	Preview string `json:"preview"`
+
+	// UploadURL is the address files of the item can be uploaded to.
+	UploadURL string `json:"uploadURL"`
+
+	// Conflict indicates that the item has been changed by someone else while it was edited.
+	Conflict bool `json:"conflict"`
+}
+
+// Upload is a file that has been added to an item.
+type Upload struct {
+	Name string `json:"name"`
+
+	// Path is the path of the file relative to the item (e.g. "files/image.png").
+	Path string `json:"path"`
+
+	// Markdown is the markdown code which references the file.
+	Markdown string `json:"markdown"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package viewmodel
+
+// History contains the revision history of an item and optionally the diff between two of its revisions.
+type History struct {
+	Model
+
+	Revisions []Revision `json:"revisions"`
+	Diff      *Diff      `json:"diff"`
+}
+
+// Revision is a commit which changed an item.
+type Revision struct {
+	Id      string `json:"id"`
+	ShortId string `json:"shortId"`
+
+	Author      string `json:"author"`
+	AuthorEmail string `json:"authorEmail"`
+	Date        string `json:"date"`
+	Subject     string `json:"subject"`
+	Message     string `json:"message"`
+
+	// DiffURL points to the changes of this revision compared to the previous revision of the item.
+	DiffURL string `json:"diffURL"`
+}
+
+// Diff contains the changes between two revisions.
+type Diff struct {
+	From  Revision   `json:"from"`
+	To    Revision   `json:"to"`
+	Files []DiffFile `json:"files"`
+}
+
+// DiffFile contains the changes of a single file.
+type DiffFile struct {
+	Name     string     `json:"name"`
+	IsBinary bool       `json:"isBinary"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// DiffLine is a single line of a diff. The type is one of "hunk", "context", "added" or "removed".
+type DiffLine struct {
+	Type      string `json:"type"`
+	OldNumber int    `json:"oldNumber"`
+	NewNumber int    `json:"newNumber"`
+	Text      string `json:"text"`
+}
@@ -0,0 +1,23 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package viewmodel
+
+// LinkGraph contains all items of a repository and the links between them.
+type LinkGraph struct {
+	Nodes []LinkGraphNode `json:"nodes"`
+	Links []LinkGraphLink `json:"links"`
+}
+
+// LinkGraphNode is an item of the link graph.
+type LinkGraphNode struct {
+	Route string `json:"route"`
+	Title string `json:"title"`
+}
+
+// LinkGraphLink is a link from the item with the source route to the item with the target route.
+type LinkGraphLink struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
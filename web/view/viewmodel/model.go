@@ -14,11 +14,15 @@ type Model struct {
 	Content  string `json:"content"`
 	Markdown string `json:"markdown"`
 
+	TableOfContents []TableOfContentsEntry `json:"toc"`
+
 	Publisher Publisher `json:"publisher"`
 	Author    Author    `json:"author"`
 
 	Children []Base `json:"children"`
 
+	ReferencedBy []Base `json:"referencedBy"`
+
 	ToplevelNavigation   ToplevelNavigation   `json:"toplevelNavigation"`
 	BreadcrumbNavigation BreadcrumbNavigation `json:"breadcrumbNavigation"`
 	ItemNavigation       ItemNavigation       `json:"itemNavigation"`
@@ -31,6 +35,8 @@ type Model struct {
 
 	GeoLocation GeoLocation `json:"geoLocation"`
 
+	Custom map[string][]string `json:"custom"`
+
 	Analytics Analytics `json:"-"`
 
 	Hash string `json:"hash"`
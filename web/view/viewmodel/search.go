@@ -19,6 +19,8 @@ type SearchResults struct {
 	StartIndex       int `json:"startIndex"`
 	ResultCount      int `json:"resultCount"`
 	TotalResultCount int `json:"totalResultCount"`
+
+	Facets []SearchFacet `json:"facets"`
 }
 
 type SearchResult struct {
@@ -28,4 +30,18 @@ type SearchResult struct {
 	Description string `json:"description"`
 	Route       string `json:"route"`
 	Path        string `json:"path"`
+	Snippet     string `json:"snippet"`
+}
+
+type SearchFacet struct {
+	Name   string             `json:"name"`
+	Title  string             `json:"title"`
+	Values []SearchFacetValue `json:"values"`
+}
+
+type SearchFacetValue struct {
+	Value    string `json:"value"`
+	Count    int    `json:"count"`
+	Query    string `json:"query"`
+	IsActive bool   `json:"isActive"`
 }
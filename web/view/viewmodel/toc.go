@@ -0,0 +1,13 @@
+// Copyright 2015 Andreas Koch. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package viewmodel
+
+// TableOfContentsEntry is a heading of a document with the headings below it.
+type TableOfContentsEntry struct {
+	Id       string                 `json:"id"`
+	Title    string                 `json:"title"`
+	Level    int                    `json:"level"`
+	Children []TableOfContentsEntry `json:"children"`
+}
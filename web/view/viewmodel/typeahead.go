@@ -11,6 +11,7 @@ type TypeAhead struct {
 	Description string `json:"description"`
 	Route       string `json:"route"`
 	Path        string `json:"path"`
+	Snippet     string `json:"snippet"`
 
 	Value  string   `json:"value"`
 	Tokens []string `json:"tokens"`
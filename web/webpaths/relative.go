@@ -34,8 +34,12 @@ func (webPathProvider *RelativeWebPathProvider) Path(itemPath string) string {
 
 	for _, route := range webPathProvider.routesProvider.Routes() {
 
-		// ignore all routes which are not a child of the base route
+		// routes which are not a child of the base route can only be reached with an absolute path
 		if !route.IsChildOf(webPathProvider.baseRoute) {
+			if route.Value() == itemPath && !route.Equals(webPathProvider.baseRoute) {
+				return "/" + route.Value()
+			}
+
 			continue
 		}
 
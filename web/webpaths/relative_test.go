@@ -50,6 +50,36 @@ func Test_RelativeWebPathProvider_RootAsBasePath_Path_ParameterIsAbsolute_Return
 	}
 }
 
+func Test_RelativeWebPathProvider_Path_RouteIsNotAChild_ReturnsAbsolutePath(t *testing.T) {
+	// arrange
+	baseRoute := route.NewFromRequest("notes/c")
+	routes := getRoutesFromStrings([]string{
+		"",
+		"notes",
+		"notes/a",
+		"notes/c",
+		"notes/c/child",
+	})
+	routesProvider := dummyRoutesProvider{routes}
+	pathProvider := newRelativeWebPathProvider(routesProvider, baseRoute)
+	inputs := map[string]string{
+		"notes/a":        "/notes/a",
+		"notes/c/child":  "child",
+		"files/data.csv": "files/data.csv",
+	}
+
+	for inputPath, expected := range inputs {
+
+		// act
+		result := pathProvider.Path(inputPath)
+
+		// assert
+		if result != expected {
+			t.Errorf("The result for pathProvider.Path(%q) should be %q but was %q.", inputPath, expected, result)
+		}
+	}
+}
+
 // Get an array of route.Route objects from a string array of URIs.
 func getRoutesFromStrings(uris []string) []route.Route {
 